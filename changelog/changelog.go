@@ -0,0 +1,179 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package changelog
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/project-cdim/configuration-manager/common"
+)
+
+// Key of the transaction-level advisory lock serializing the writers of the change log.
+// Because the lock is held until the commit, the sequence numbers become visible in ascending order,
+// and a reader that has seen a sequence number never misses a smaller one committed later.
+const changeLogLockKey int64 = 0x636d6368616e6765
+
+const sqlLockChangeLog string = `SELECT pg_advisory_xact_lock($1)`
+
+// The entries are inserted in one statement from the arrays of their fields, numbered in the order of the arrays.
+const sqlInsertChanges string = `
+INSERT INTO public.configuration_manager_changes (id, type, subject, data)
+SELECT entry.id, entry.type, entry.subject, entry.data::jsonb
+FROM unnest($1::text[], $2::text[], $3::text[], $4::text[]) WITH ORDINALITY AS entry(id, type, subject, data, ord)
+ORDER BY entry.ord
+`
+
+const sqlSelectChanges string = `
+SELECT seq, id, type, subject, data, created_at
+FROM public.configuration_manager_changes
+WHERE seq > $1
+ORDER BY seq
+LIMIT $2
+`
+
+const sqlSelectLatestSeq string = `
+SELECT COALESCE(MAX(seq), 0) FROM public.configuration_manager_changes
+`
+
+// Executor is a database handle on which the change log is read or written, satisfied by both *sql.DB and *sql.Tx.
+type Executor interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+// Entry is a change to be recorded in the change log.
+// ID is the ID of the event describing the change, so that the change log and the published events can be correlated.
+type Entry struct {
+	ID      string
+	Type    string
+	Subject string
+	Data    map[string]any
+}
+
+// Change is a change recorded in the change log, numbered by Seq in the order of the commits.
+type Change struct {
+	Seq       int64
+	ID        string
+	Type      string
+	Subject   string
+	Data      json.RawMessage
+	Timestamp string
+}
+
+// ToObject converts the change into a map for the response.
+func (c *Change) ToObject() map[string]any {
+	return map[string]any{
+		"seq":       c.Seq,
+		"id":        c.ID,
+		"type":      c.Type,
+		"subject":   c.Subject,
+		"timestamp": c.Timestamp,
+		"data":      c.Data,
+	}
+}
+
+// Append records the entries in the change log in the transaction tx of the change they describe.
+// It should be called just before the commit, because it serializes the transactions writing the change log until they end.
+func Append(tx *sql.Tx, entries ...Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	if _, err := tx.Exec(sqlLockChangeLog, changeLogLockKey); err != nil {
+		common.Log.Error(err.Error())
+		return err
+	}
+
+	ids := make([]string, 0, len(entries))
+	types := make([]string, 0, len(entries))
+	subjects := make([]string, 0, len(entries))
+	data := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		entryData, err := json.Marshal(entry.Data)
+		if err != nil {
+			common.Log.Error(fmt.Sprintf("change log data marshal error [id : %s] : %s", entry.ID, err.Error()))
+			return err
+		}
+		ids = append(ids, entry.ID)
+		types = append(types, entry.Type)
+		subjects = append(subjects, entry.Subject)
+		data = append(data, string(entryData))
+	}
+
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %v, param2: %v, param3: %v", sqlInsertChanges, ids, types, subjects))
+	if _, err := tx.Exec(sqlInsertChanges, pq.Array(ids), pq.Array(types), pq.Array(subjects), pq.Array(data)); err != nil {
+		common.Log.Error(err.Error())
+		return err
+	}
+	return nil
+}
+
+// List returns up to limit changes whose sequence numbers are greater than since, in ascending order of the sequence numbers.
+func List(executor Executor, since int64, limit int) ([]Change, error) {
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %d, param2: %d", sqlSelectChanges, since, limit))
+	rows, err := executor.Query(sqlSelectChanges, since, limit)
+	if err != nil {
+		common.Log.Error(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := []Change{}
+	for rows.Next() {
+		var change Change
+		var data []byte
+		var createdAt time.Time
+		if err := rows.Scan(&change.Seq, &change.ID, &change.Type, &change.Subject, &data, &createdAt); err != nil {
+			common.Log.Error(err.Error())
+			return nil, err
+		}
+		change.Data = json.RawMessage(data)
+		change.Timestamp = createdAt.UTC().Format("2006-01-02T15:04:05Z07:00")
+		changes = append(changes, change)
+	}
+	if err := rows.Err(); err != nil {
+		common.Log.Error(err.Error())
+		return nil, err
+	}
+	return changes, nil
+}
+
+// LatestSeq returns the sequence number of the latest change, or 0 if no change has been recorded.
+func LatestSeq(executor Executor) (int64, error) {
+	common.Log.Debug(fmt.Sprintf("query: %s", sqlSelectLatestSeq))
+	rows, err := executor.Query(sqlSelectLatestSeq)
+	if err != nil {
+		common.Log.Error(err.Error())
+		return 0, err
+	}
+	defer rows.Close()
+
+	latest := int64(0)
+	if rows.Next() {
+		if err := rows.Scan(&latest); err != nil {
+			common.Log.Error(err.Error())
+			return 0, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		common.Log.Error(err.Error())
+		return 0, err
+	}
+	return latest, nil
+}
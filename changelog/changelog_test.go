@@ -0,0 +1,55 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package changelog
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestChange_ToObject(t *testing.T) {
+	change := Change{
+		Seq:       10,
+		ID:        "event001",
+		Type:      "resource.created",
+		Subject:   "res101",
+		Data:      json.RawMessage(`{"deviceID":"res101"}`),
+		Timestamp: "2025-01-01T00:00:00Z",
+	}
+	want := map[string]any{
+		"seq":       int64(10),
+		"id":        "event001",
+		"type":      "resource.created",
+		"subject":   "res101",
+		"timestamp": "2025-01-01T00:00:00Z",
+		"data":      json.RawMessage(`{"deviceID":"res101"}`),
+	}
+	if got := change.ToObject(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ToObject() = %v, want %v", got, want)
+	}
+}
+
+func TestAppend(t *testing.T) {
+	t.Skip("not test")
+}
+
+func TestList(t *testing.T) {
+	t.Skip("not test")
+}
+
+func TestLatestSeq(t *testing.T) {
+	t.Skip("not test")
+}
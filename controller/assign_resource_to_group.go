@@ -15,6 +15,7 @@
 package controller
 
 import (
+	"database/sql"
 	"fmt"
 	"net/http"
 
@@ -121,7 +122,14 @@ func AssignResourceToGroup(c *gin.Context) {
 	}
 
 	repository := cmapi_repository_resource.NewAssignResourceToGroupRepository(id, dbDeciceType, targetGroups)
-	groupIDs, err := cmapi_repository.RelaySet(&repository, nil)
+	// The event is written to the outbox in the same transaction as the assignment
+	groupIDs, err := cmapi_repository.RelaySetWithHook(&repository, nil, func(tx *sql.Tx, res map[string]any) error {
+		return enqueueDomainEvents(tx, []domainEvent{newDomainEvent(domainEventResourceGroupChanged, id, map[string]any{
+			"deviceID": id,
+			"before":   resource["resourceGroupIDs"],
+			"after":    res["resourceGroupIDs"],
+		})})
+	})
 	if err != nil {
 		errorDatial := "RelaySet error"
 		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
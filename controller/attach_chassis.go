@@ -0,0 +1,118 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/project-cdim/configuration-manager/common"
+	cmapi_filter "github.com/project-cdim/configuration-manager/filter"
+	cmapi_model_chassis "github.com/project-cdim/configuration-manager/model/chassis"
+	cmapi_repository "github.com/project-cdim/configuration-manager/repository"
+	cmapi_repository_chassis "github.com/project-cdim/configuration-manager/repository/chassis"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AttachChassis is a handler function to attach a chassis to a rack.
+// The request body specifies "rackID" and "unitPosition" (the lowest rack unit occupied by the chassis).
+// If the chassis is already attached to a rack, it is moved to the specified position.
+//
+// Parameters:
+//   - c: gin.Context - Request context
+//
+// Response:
+//   - On success: 200 status code with the attachment
+//   - On validation error, if the rack does not exist: 400 status code
+//   - If the chassis does not exist: 404 status code
+//   - If the chassis does not fit in the rack at the position: 409 status code
+//   - On server error: 500 status code
+func AttachChassis(c *gin.Context) {
+	common.Log.Info(fmt.Sprintf("%s[%s] start.", c.Request.URL.Path, c.Request.Method))
+	funcName := "AttachChassis"
+
+	id := c.Param("id")
+	properties, err := unmarshalRequestBodyForMap(c)
+	if err != nil {
+		errorDatial := "unmarshalRequestBodyForMap error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
+	// Validation of the requestBody
+	if !cmapi_model_chassis.ValidateAttachmentProperty(properties) {
+		errorDatial := "Validation error"
+		common.Log.Error(fmt.Sprintf("%s %s", funcName, errorDatial), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
+	if !existsChassis(c, funcName, id) {
+		return
+	}
+
+	attachment := cmapi_model_chassis.NewAttachment(id, properties)
+	repository := cmapi_repository_chassis.NewAttachChassisRepository()
+	res, err := cmapi_repository.RelaySet(&repository, &attachment)
+	if errors.Is(err, cmapi_repository.ErrNotFound) {
+		errorDatial := "The specified rack did not exist"
+		common.Log.Warn(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+	if errors.Is(err, cmapi_repository_chassis.ErrUnitConflict) {
+		errorDatial := "The chassis does not fit in the rack at the specified unit position"
+		common.Log.Warn(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusConflict, convertErrorResponse(http.StatusConflict, errorDatial))
+		return
+	}
+	if err != nil {
+		errorDatial := "RelaySet error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	logResponseBody(res)
+	common.Log.Info(fmt.Sprintf("%s[%s] completed successfully.", c.Request.URL.Path, c.Request.Method))
+
+	c.JSON(http.StatusOK, res)
+}
+
+// existsChassis reports whether the chassis exists.
+// If it does not exist or cannot be retrieved, the error response is written and false is returned.
+func existsChassis(c *gin.Context, funcName string, id string) bool {
+	filter := cmapi_filter.NewNoFilter()
+	getRepository := cmapi_repository_chassis.NewChassisRepository(id, false)
+	chassis, err := cmapi_repository.RelayFind(&getRepository, filter)
+	if err != nil {
+		errorDatial := "RelayFind error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return false
+	}
+
+	if chassis == nil {
+		errorDatial := "The target chassis did not exist"
+		common.Log.Warn(fmt.Sprintf("%s %s [id : %v]", funcName, errorDatial, id), false)
+		c.JSON(http.StatusNotFound, convertErrorResponse(http.StatusNotFound, errorDatial))
+		return false
+	}
+
+	return true
+}
@@ -19,84 +19,54 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/project-cdim/configuration-manager/common"
 	cmapi_filter "github.com/project-cdim/configuration-manager/filter"
+	"github.com/project-cdim/configuration-manager/resourcetype"
 
 	"github.com/gin-gonic/gin"
 )
 
-// Configuration item names for hardware control features
+// Configuration item names for hardware control features.
+// These are the built-in resource types. The resource types are looked up in the registry (see resourcetype.Registered).
 const (
-	CPU               = "CPU"
-	Accelerator       = "Accelerator"
-	DSP               = "DSP"
-	FPGA              = "FPGA"
-	GPU               = "GPU"
-	UnknownProcessor  = "UnknownProcessor"
-	Memory            = "memory"
-	Storage           = "storage"
-	NetworkInterface  = "networkInterface"
-	GraphicController = "graphicController"
-	VirtualMedia      = "virtualMedia"
-)
-
-// Label names for Vertex
-const (
-	DB_CPU               = "CPU"
-	DB_Accelerator       = "Accelerator"
-	DB_DSP               = "DSP"
-	DB_FPGA              = "FPGA"
-	DB_GPU               = "GPU"
-	DB_UnknownProcessor  = "UnknownProcessor"
-	DB_Memory            = "Memory"
-	DB_Storage           = "Storage"
-	DB_NetworkInterface  = "NetworkInterface"
-	DB_GraphicController = "GraphicController"
-	DB_VirtualMedia      = "VirtualMedia"
+	CPU               = resourcetype.CPU
+	Accelerator       = resourcetype.Accelerator
+	DSP               = resourcetype.DSP
+	FPGA              = resourcetype.FPGA
+	GPU               = resourcetype.GPU
+	UnknownProcessor  = resourcetype.UnknownProcessor
+	Memory            = resourcetype.Memory
+	Storage           = resourcetype.Storage
+	NetworkInterface  = resourcetype.NetworkInterface
+	GraphicController = resourcetype.GraphicController
+	VirtualMedia      = resourcetype.VirtualMedia
 )
 
 // StatusToResponse is a map that maps the status code to the response body.
 var StatusToResponse = map[int]gin.H{
-	http.StatusInternalServerError: {"code": "internalServerError", "message": "Internal Server Error. Contact the administrator."},
-	http.StatusBadRequest:          {"code": "badRequest", "message": "Bad Request. Check the request parameters."},
-	http.StatusNotFound:            {"code": "notFound", "message": "Not Found. Check the request URL."},
+	http.StatusInternalServerError:   {"code": "internalServerError", "message": "Internal Server Error. Contact the administrator."},
+	http.StatusBadRequest:            {"code": "badRequest", "message": "Bad Request. Check the request parameters."},
+	http.StatusNotFound:              {"code": "notFound", "message": "Not Found. Check the request URL."},
+	http.StatusConflict:              {"code": "conflict", "message": "Conflict. Check the current state of the target."},
+	http.StatusRequestEntityTooLarge: {"code": "requestEntityTooLarge", "message": "Request Entity Too Large. Reduce the size of the request body."},
 }
 
 // hwResourceType defines a string type for representing various hardware resource categories.
 type hwResourceType string
 
 // convertToDBLabel converts a JSON label to its corresponding database label.
-// It takes a hwResourceType and returns the matching database label string and nil error for known types.
+// It takes a hwResourceType and returns the label of the type in the registry and nil error for registered types.
 // For unknown types, it returns an empty string and an error indicating an unexpected type.
 func (rt hwResourceType) convertToDBLabel() (string, error) {
-	switch rt {
-	case CPU:
-		return DB_CPU, nil
-	case Accelerator:
-		return DB_Accelerator, nil
-	case DSP:
-		return DB_DSP, nil
-	case FPGA:
-		return DB_FPGA, nil
-	case GPU:
-		return DB_GPU, nil
-	case UnknownProcessor:
-		return DB_UnknownProcessor, nil
-	case Memory:
-		return DB_Memory, nil
-	case Storage:
-		return DB_Storage, nil
-	case NetworkInterface:
-		return DB_NetworkInterface, nil
-	case GraphicController:
-		return DB_GraphicController, nil
-	case VirtualMedia:
-		return DB_VirtualMedia, nil
-	default:
-		return "", fmt.Errorf("unexpected type in JSON. type(%v)", rt)
+	resourceType, ok := resourcetype.Registered().Lookup(string(rt))
+	if !ok {
+		return "", invalidDevicef("unexpected type in JSON. type(%v)", rt)
 	}
+	return resourceType.Label, nil
 }
 
 // unmarshalRequestBodyForMap reads the JSON from the request body, unmarshals it into a map[string]any, and returns the map.
@@ -118,10 +88,11 @@ func unmarshalRequestBodyForMap(c *gin.Context) (map[string]any, error) {
 	return res, nil
 }
 
-// unmarshalRequestBodyForSlice reads the JSON from the request body, unmarshals it into a slice of map[string]any, and returns the slice.
-// It returns an error if reading the request body fails, or if the JSON is not in the correct format.
-func unmarshalRequestBodyForSlice(c *gin.Context) ([]map[string]any, error) {
-	body, err := io.ReadAll(c.Request.Body)
+// unmarshalBodyForSlice reads the JSON from the body of a request, unmarshals it into a slice of map[string]any, and returns the slice.
+// It returns an error if reading the body fails, or if the JSON is not in the correct format.
+// The body is either the body of the request being handled or the body stored in a sync job.
+func unmarshalBodyForSlice(reader io.Reader) ([]map[string]any, error) {
+	body, err := io.ReadAll(reader)
 	if err != nil {
 		common.Log.Error(err.Error())
 		return nil, err
@@ -160,6 +131,54 @@ func getBoolQueryParam(c *gin.Context, name string) (bool, error) {
 	return false, fmt.Errorf("query parameter value error. name(%v) value(%v)", name, v)
 }
 
+// getIntQueryParam retrieves an integer query parameter from the given gin.Context.
+// If the query parameter is not specified, defaultValue is returned.
+// If the value is not a decimal integer, it returns an error.
+//
+// Parameters:
+//   - c: *gin.Context - The gin context from which to retrieve the query parameter.
+//   - name: string - The name of the query parameter to retrieve.
+//   - defaultValue: int64 - The value returned when the query parameter is not specified.
+//
+// Returns:
+//   - int64: The integer value of the query parameter.
+//   - error: An error if the query parameter value is not an integer.
+func getIntQueryParam(c *gin.Context, name string, defaultValue int64) (int64, error) {
+	v, ok := c.GetQuery(name)
+	if !ok {
+		return defaultValue, nil
+	}
+
+	value, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("query parameter value error. name(%v) value(%v)", name, v)
+	}
+	return value, nil
+}
+
+// getTimeQueryParam retrieves a time query parameter in ISO 8601 (RFC 3339) from the given gin.Context.
+// If the query parameter is not specified, the zero time is returned.
+//
+// Parameters:
+//   - c: *gin.Context - The gin context from which to retrieve the query parameter.
+//   - name: string - The name of the query parameter to retrieve.
+//
+// Returns:
+//   - time.Time: The time of the query parameter.
+//   - error: An error if the query parameter value is not a time in ISO 8601.
+func getTimeQueryParam(c *gin.Context, name string) (time.Time, error) {
+	v, ok := c.GetQuery(name)
+	if !ok {
+		return time.Time{}, nil
+	}
+
+	value, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("query parameter value error. name(%v) value(%v)", name, v)
+	}
+	return value, nil
+}
+
 // convertErrorResponse converts an error response containing the specified status and details.
 // It retrieves the response map corresponding to the status and adds the details to the map if available.
 // It returns the converted response map.
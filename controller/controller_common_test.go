@@ -18,7 +18,10 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -121,8 +124,29 @@ func Test_unmarshalRequestBodyForMap(t *testing.T) {
 	t.Skip("not test")
 }
 
-func Test_unmarshalRequestBodyForSlice(t *testing.T) {
-	t.Skip("not test")
+func Test_unmarshalBodyForSlice(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    []map[string]any
+		wantErr bool
+	}{
+		{name: "Normal case: An array of objects", body: `[{"deviceID":"dev01"},{"deviceID":"dev02"}]`, want: []map[string]any{{"deviceID": "dev01"}, {"deviceID": "dev02"}}},
+		{name: "Error case: Not an array", body: `{"deviceID":"dev01"}`, wantErr: true},
+		{name: "Error case: Invalid JSON", body: `[{"deviceID":`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := unmarshalBodyForSlice(strings.NewReader(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("unmarshalBodyForSlice() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("unmarshalBodyForSlice() = %v, want %v", got, tt.want)
+			}
+		})
+	}
 }
 
 func Test_getBoolQueryParam(t *testing.T) {
@@ -205,6 +229,108 @@ func Test_getBoolQueryParam(t *testing.T) {
 	}
 }
 
+func Test_getIntQueryParam(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       *gin.Context
+		param   string
+		want    int64
+		wantErr bool
+	}{
+		{
+			name:    "Normal case: The value is returned",
+			c:       setupTestGinContext("since=10&limit=-1&invalid=1a"),
+			param:   "since",
+			want:    10,
+			wantErr: false,
+		},
+		{
+			name:    "Normal case: A negative value is returned",
+			c:       setupTestGinContext("since=10&limit=-1&invalid=1a"),
+			param:   "limit",
+			want:    -1,
+			wantErr: false,
+		},
+		{
+			name:    "Normal case: The default value is returned if the parameter is not specified",
+			c:       setupTestGinContext("since=10&limit=-1&invalid=1a"),
+			param:   "notExists",
+			want:    100,
+			wantErr: false,
+		},
+		{
+			name:    "Error case: The value is not an integer",
+			c:       setupTestGinContext("since=10&limit=-1&invalid=1a"),
+			param:   "invalid",
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name:    "Error case: The value is empty",
+			c:       setupTestGinContext("since="),
+			param:   "since",
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getIntQueryParam(tt.c, tt.param, 100)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getIntQueryParam() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("getIntQueryParam() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getTimeQueryParam(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       *gin.Context
+		param   string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:    "Normal case: The time is returned",
+			c:       setupTestGinContext("after=2025-06-01T09:00:00%2B09:00"),
+			param:   "after",
+			want:    time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+			wantErr: false,
+		},
+		{
+			name:    "Normal case: The zero time is returned if the parameter is not specified",
+			c:       setupTestGinContext(""),
+			param:   "after",
+			want:    time.Time{},
+			wantErr: false,
+		},
+		{
+			name:    "Error case: The value is not a time in ISO 8601",
+			c:       setupTestGinContext("after=2025-06-01"),
+			param:   "after",
+			want:    time.Time{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getTimeQueryParam(tt.c, tt.param)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getTimeQueryParam() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("getTimeQueryParam() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_convertErrorResponse(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -248,6 +374,12 @@ func Test_convertErrorResponse(t *testing.T) {
 			details: []string{"Resource not found"},
 			want:    gin.H{"code": "notFound", "message": "Not Found. Check the request URL.", "details": "Resource not found"},
 		},
+		{
+			name:    "Conflict with details",
+			status:  http.StatusConflict,
+			details: []string{"Already exists"},
+			want:    gin.H{"code": "conflict", "message": "Conflict. Check the current state of the target.", "details": "Already exists"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
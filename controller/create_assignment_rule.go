@@ -0,0 +1,102 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/project-cdim/configuration-manager/common"
+	cmapi_filter "github.com/project-cdim/configuration-manager/filter"
+	cmapi_model_rule "github.com/project-cdim/configuration-manager/model/rule"
+	cmapi_repository "github.com/project-cdim/configuration-manager/repository"
+	cmapi_repository_group "github.com/project-cdim/configuration-manager/repository/group"
+	cmapi_repository_rule "github.com/project-cdim/configuration-manager/repository/rule"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateAssignmentRule is a handler function to create a new assignment rule.
+// It converts the request body to a map, performs validation, checks that the target resource group exists,
+// and saves the rule to the database. On success, it returns the created rule object.
+//
+// Parameters:
+//   - c: gin.Context - Request context
+//
+// Response:
+//   - On success: HTTP status 201 (Created) and the created rule object
+//   - On validation error or if the target resource group does not exist: HTTP status 400 (Bad Request)
+//   - On server error: HTTP status 500 (Internal Server Error)
+func CreateAssignmentRule(c *gin.Context) {
+	common.Log.Info(fmt.Sprintf("%s[%s] start.", c.Request.URL.Path, c.Request.Method))
+	funcName := "CreateAssignmentRule"
+
+	properties, err := unmarshalRequestBodyForMap(c)
+	if err != nil {
+		errorDatial := "unmarshalRequestBodyForMap error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
+	// Validation of the requestBody
+	if !cmapi_model_rule.ValidateProperty(properties) {
+		errorDatial := "Validation error"
+		common.Log.Error(fmt.Sprintf("%s %s", funcName, errorDatial), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
+	exists, err := existsResourceGroup(properties["resourceGroupID"].(string))
+	if err != nil {
+		errorDatial := "RelayFind error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+	if !exists {
+		errorDatial := "The target group of the rule did not exist"
+		common.Log.Warn(fmt.Sprintf("%s %s [id : %v]", funcName, errorDatial, properties["resourceGroupID"]))
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
+	rule := cmapi_model_rule.NewAssignmentRuleWithCreateTimeStampsNow(properties)
+	repository := cmapi_repository_rule.NewCreateRuleRepository()
+	res, err := cmapi_repository.RelaySet(&repository, &rule)
+	if err != nil {
+		errorDatial := "RelaySet error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	logResponseBody(res)
+	common.Log.Info(fmt.Sprintf("%s[%s] completed successfully.", c.Request.URL.Path, c.Request.Method))
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// existsResourceGroup reports whether the resource group with the specified ID exists.
+func existsResourceGroup(groupID string) (bool, error) {
+	filter := cmapi_filter.NewNoFilter()
+	groupRepository := cmapi_repository_group.NewGroupRepository(groupID, false)
+	group, err := cmapi_repository.RelayFind(&groupRepository, filter)
+	if err != nil {
+		return false, err
+	}
+
+	return group != nil, nil
+}
@@ -15,6 +15,7 @@
 package controller
 
 import (
+	"database/sql"
 	"fmt"
 	"net/http"
 
@@ -60,7 +61,10 @@ func CreateGroup(c *gin.Context) {
 
 	group := cmapi_model_group.NewGroupWithCreateTimeStampsNow(properties)
 	repository := cmapi_repository_group.NewCreateGroupRepository()
-	res, err := cmapi_repository.RelaySet(&repository, &group)
+	// The event is written to the outbox in the same transaction as the creation
+	res, err := cmapi_repository.RelaySetWithHook(&repository, &group, func(tx *sql.Tx, res map[string]any) error {
+		return enqueueDomainEvents(tx, []domainEvent{newDomainEvent(domainEventResourceGroupCreated, res["id"].(string), res)})
+	})
 	if err != nil {
 		errorDatial := "RelaySet error"
 		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
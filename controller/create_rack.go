@@ -0,0 +1,81 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/project-cdim/configuration-manager/common"
+	cmapi_model_rack "github.com/project-cdim/configuration-manager/model/rack"
+	cmapi_repository "github.com/project-cdim/configuration-manager/repository"
+	cmapi_repository_rack "github.com/project-cdim/configuration-manager/repository/rack"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateRack is a handler function to register a new rack.
+// The ID of the rack can be specified in the request body; otherwise it is generated.
+//
+// Parameters:
+//   - c: gin.Context - Request context
+//
+// Response:
+//   - On success: HTTP status 201 (Created) and the created rack object
+//   - On validation error: HTTP status 400 (Bad Request)
+//   - If a rack with the specified ID already exists: HTTP status 409 (Conflict)
+//   - On server error: HTTP status 500 (Internal Server Error)
+func CreateRack(c *gin.Context) {
+	common.Log.Info(fmt.Sprintf("%s[%s] start.", c.Request.URL.Path, c.Request.Method))
+	funcName := "CreateRack"
+
+	properties, err := unmarshalRequestBodyForMap(c)
+	if err != nil {
+		errorDatial := "unmarshalRequestBodyForMap error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
+	// Validation of the requestBody
+	if !cmapi_model_rack.ValidateProperty(properties) {
+		errorDatial := "Validation error"
+		common.Log.Error(fmt.Sprintf("%s %s", funcName, errorDatial), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
+	rack := cmapi_model_rack.NewRackVertexWithCreateTimeStampsNow(properties)
+	repository := cmapi_repository_rack.NewCreateRackRepository()
+	res, err := cmapi_repository.RelaySet(&repository, &rack)
+	if errors.Is(err, cmapi_repository.ErrAlreadyExists) {
+		errorDatial := "The specified rack already exists"
+		common.Log.Warn(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusConflict, convertErrorResponse(http.StatusConflict, errorDatial))
+		return
+	}
+	if err != nil {
+		errorDatial := "RelaySet error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	logResponseBody(res)
+	common.Log.Info(fmt.Sprintf("%s[%s] completed successfully.", c.Request.URL.Path, c.Request.Method))
+
+	c.JSON(http.StatusCreated, res)
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+	"github.com/project-cdim/configuration-manager/webhook"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateWebhook is a handler function to register a webhook receiving the events of the service by HTTP callbacks.
+// The request body has the callback 'url', the optional 'eventTypes' to receive, e.g. ["resource.created", "node.*"],
+// and the optional 'secret' to sign the deliveries with HMAC-SHA256. All the events are delivered if 'eventTypes' is empty or omitted,
+// and a random secret is generated if 'secret' is omitted.
+//
+// Parameters:
+//   - c: gin.Context - Request context
+//
+// Response:
+//   - On success: HTTP status 201 (Created) and the registered webhook. The secret is included only in this response.
+//   - On validation error: HTTP status 400 (Bad Request)
+//   - On server error: HTTP status 500 (Internal Server Error)
+func CreateWebhook(c *gin.Context) {
+	common.Log.Info(fmt.Sprintf("%s[%s] start.", c.Request.URL.Path, c.Request.Method))
+	funcName := "CreateWebhook"
+
+	properties, err := unmarshalRequestBodyForMap(c)
+	if err != nil {
+		errorDatial := "unmarshalRequestBodyForMap error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
+	// Validation of the requestBody
+	hook, err := webhook.ValidateProperty(properties)
+	if err != nil {
+		errorDatial := "Validation error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
+	cmdb := database.NewCmDb()
+	err = cmdb.CmDbConnection()
+	if err != nil {
+		errorDatial := "CmDbConnection error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+	defer cmdb.CmDbDisconnection()
+
+	hook, err = webhook.Create(cmdb.Db, hook)
+	if err != nil {
+		errorDatial := "webhook.Create error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	// The secret is not logged
+	logResponseBody(hook.ToObject())
+	common.Log.Info(fmt.Sprintf("%s[%s] completed successfully.", c.Request.URL.Path, c.Request.Method))
+
+	c.JSON(http.StatusCreated, hook.ToObjectWithSecret())
+}
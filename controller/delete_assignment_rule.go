@@ -0,0 +1,73 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/project-cdim/configuration-manager/common"
+	cmapi_filter "github.com/project-cdim/configuration-manager/filter"
+	cmapi_repository "github.com/project-cdim/configuration-manager/repository"
+	cmapi_repository_rule "github.com/project-cdim/configuration-manager/repository/rule"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeleteAssignmentRule is a handler that deletes the specified assignment rule.
+// Resources that have already been assigned by the rule remain in their resource groups.
+//
+// Parameters:
+// - c: gin.Context, the request context
+//
+// Response:
+// - On success: 204 status code
+// - If the rule does not exist: 404 status code
+// - On server error: 500 status code
+func DeleteAssignmentRule(c *gin.Context) {
+	common.Log.Info(fmt.Sprintf("%s[%s] start.", c.Request.URL.Path, c.Request.Method))
+	funcName := "DeleteAssignmentRule"
+
+	id := c.Param("id")
+	filter := cmapi_filter.NewNoFilter()
+	getRepository := cmapi_repository_rule.NewRuleRepository(id)
+	rule, err := cmapi_repository.RelayFind(&getRepository, filter)
+	if err != nil {
+		errorDatial := "RelayFind error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	if rule == nil {
+		errorDatial := "The target rule for delete did not exist"
+		common.Log.Warn(fmt.Sprintf("%s %s [id : %v]", funcName, errorDatial, id))
+		c.JSON(http.StatusNotFound, convertErrorResponse(http.StatusNotFound, errorDatial))
+		return
+	}
+
+	repository := cmapi_repository_rule.NewDeleteRuleRepository(id)
+	err = cmapi_repository.RelayDelete(&repository)
+	if err != nil {
+		errorDatial := "RelayDelete error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	common.Log.Info(fmt.Sprintf("%s[%s] completed successfully.", c.Request.URL.Path, c.Request.Method))
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/project-cdim/configuration-manager/common"
+	cmapi_filter "github.com/project-cdim/configuration-manager/filter"
+	cmapi_repository "github.com/project-cdim/configuration-manager/repository"
+	cmapi_repository_chassis "github.com/project-cdim/configuration-manager/repository/chassis"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeleteChassis is a handler that deletes the specified chassis.
+// If there are devices mounted in the chassis, the chassis cannot be deleted.
+// The chassis is detached from the rack to which it is attached.
+//
+// Parameters:
+// - c: gin.Context, the request context
+//
+// Response:
+// - On success: 204 status code
+// - If devices are mounted in the chassis: 400 status code
+// - If the chassis does not exist: 404 status code
+// - On server error: 500 status code
+func DeleteChassis(c *gin.Context) {
+	common.Log.Info(fmt.Sprintf("%s[%s] start.", c.Request.URL.Path, c.Request.Method))
+	funcName := "DeleteChassis"
+
+	id := c.Param("id")
+	filter := cmapi_filter.NewNoFilter()
+	getRepository := cmapi_repository_chassis.NewChassisRepository(id, false)
+	chassis, err := cmapi_repository.RelayFind(&getRepository, filter)
+	if err != nil {
+		errorDatial := "RelayFind error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	if chassis == nil {
+		errorDatial := "The target chassis for delete did not exist"
+		common.Log.Warn(fmt.Sprintf("%s %s [id : %v]", funcName, errorDatial, id))
+		c.JSON(http.StatusNotFound, convertErrorResponse(http.StatusNotFound, errorDatial))
+		return
+	}
+
+	// If there are devices mounted in the chassis, the chassis cannot be deleted.
+	if resources, ok := chassis["resources"].([]any); ok && len(resources) > 0 {
+		errorDatial := "Chassis has mounted devices error"
+		common.Log.Warn(fmt.Sprintf("%s %s", funcName, errorDatial), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
+	repository := cmapi_repository_chassis.NewDeleteChassisRepository(id)
+	err = cmapi_repository.RelayDelete(&repository)
+	if err != nil {
+		errorDatial := "RelayDelete error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	common.Log.Info(fmt.Sprintf("%s[%s] completed successfully.", c.Request.URL.Path, c.Request.Method))
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,306 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+	cmapi_model "github.com/project-cdim/configuration-manager/model"
+
+	"github.com/apache/age/drivers/golang/age"
+	"github.com/gin-gonic/gin"
+)
+
+// cypher query to delete the annotation vertex of the resource
+const cypherDeleteResourceAnnotation = `
+	MATCH (:%s {deviceID: '%s'})-[:Have]->(van:Annotation)
+	DETACH DELETE van
+`
+
+// cypher query to delete the resource vertex with all its edges
+const cypherDeleteResource = `
+	MATCH (vrs:%s {deviceID: '%s'})
+	DETACH DELETE vrs
+`
+
+// cypher query to delete the annotation vertex of the unit whose device is the resource
+const cypherDeleteUnitAnnotation = `
+	MATCH (:Unit {deviceID: '%s'})-[:Have]->(van:Annotation)
+	DETACH DELETE van
+`
+
+// cypher query to delete the unit whose device is the resource
+const cypherDeleteUnit = `
+	MATCH (vut:Unit {deviceID: '%s'})
+	DETACH DELETE vut
+`
+
+// cypher query to fetch the units containing the resource
+const cypherSelectContainingUnitList = `
+	MATCH (vut:Unit)-[:Contain]->(:%s {deviceID: '%s'})
+	RETURN vut
+`
+const selectContainingUnitListColumnCount = 1
+
+// cypher query to delete the annotation vertices of the specified units if they do not contain any resource
+const cypherBulkDeleteUnitAnnotationWithoutContain = `
+	UNWIND %s AS unitID
+	MATCH (vut:Unit {deviceID: unitID})-[:Have]->(van:Annotation)
+	OPTIONAL MATCH (vut)-[ect:Contain]->() WITH van, count(ect) AS edges
+	WHERE edges = 0
+	DETACH DELETE van
+`
+
+// cypher query to delete the specified units if they do not contain any resource
+const cypherBulkDeleteUnitWithoutContain = `
+	UNWIND %s AS unitID
+	MATCH (vut:Unit {deviceID: unitID})
+	OPTIONAL MATCH (vut)-[ect:Contain]->() WITH vut, count(ect) AS edges
+	WHERE edges = 0
+	DETACH DELETE vut
+`
+
+// DeleteDevice removes a single device outside the hardware sync.
+// By default the device is put in the NotDetected state as if it were not reported by the hardware sync, and it is registered again
+// when it is reported. When the 'purge' query parameter is true, the device is physically deleted with its annotation,
+// its unit and all its edges, and a node left without resources is deleted as well.
+// The domain events of the changes are written to the outbox in the same transaction.
+//
+// Parameters:
+// - c: gin.Context, the request context
+//
+// Response:
+// - On success: 204 status code
+// - If the query parameter is invalid: 400 status code
+// - If the device does not exist: 404 status code
+// - On server error: 500 status code
+func DeleteDevice(c *gin.Context) {
+	common.Log.Info(fmt.Sprintf("%s[%s] start.", c.Request.URL.Path, c.Request.Method))
+	funcName := "DeleteDevice"
+
+	// Retrieve query parameter: purge
+	purge, err := getBoolQueryParam(c, "purge")
+	if err != nil {
+		errorDatial := "getBoolQueryParam error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
+	id := c.Param("id")
+	cmdb := database.NewCmDb()
+	err = cmdb.CmDbBeginTransaction()
+	if err != nil {
+		errorDatial := "CmDbBeginTransaction error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+	defer cmdb.CmDbDisconnection()
+
+	existsResources, err := getDeviceIDList(cmdb.Tx)
+	if err != nil {
+		errorDatial := "getDeviceIDList error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	existing, ok := existsResources[id]
+	if !ok {
+		errorDatial := "The target device for delete did not exist"
+		common.Log.Warn(fmt.Sprintf("%s %s [id : %v]", funcName, errorDatial, id))
+		c.JSON(http.StatusNotFound, convertErrorResponse(http.StatusNotFound, errorDatial))
+		return
+	}
+
+	var events []domainEvent
+	if purge {
+		existsNodes, err := getNodeList(cmdb.Tx)
+		if err != nil {
+			errorDatial := "getNodeList error"
+			common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+			c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+			return
+		}
+
+		removedNodeIDs, err := purgeResource(cmdb.Tx, id, existing.resourceType, existsNodes)
+		if err != nil {
+			errorDatial := "purgeResource error"
+			common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+			c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+			return
+		}
+		events = newPurgeDeviceEvents(id, removedNodeIDs)
+	} else if !existing.wasNotDetected {
+		// A removal is not a hardware sync, so no missed hardware sync is counted
+		err = markNotDetectedResource(cmdb.Tx, id, existing.resourceType, cmapi_model.CurrentTimeISO8601(), 0)
+		if err != nil {
+			errorDatial := "markNotDetectedResource error"
+			common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+			c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+			return
+		}
+		events = []domainEvent{newDomainEvent(domainEventResourceNotDetected, id, map[string]any{"deviceID": id})}
+	}
+
+	err = enqueueDomainEvents(cmdb.Tx, events)
+	if err != nil {
+		errorDatial := "enqueueDomainEvents error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	err = cmdb.CmDbCommit()
+	if err != nil {
+		errorDatial := "CmDbCommit error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	common.Log.Info(fmt.Sprintf("%s[%s] completed successfully.", c.Request.URL.Path, c.Request.Method))
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// purgeResource physically deletes the resource with its annotation, its unit and all its edges,
+// and deletes the units and the nodes composed of the resource that are left without resources.
+// It returns the IDs of the deleted nodes in ascending order.
+func purgeResource(tx *sql.Tx, deviceID string, resourceType hwResourceType, dbExistsNodes map[string]existingNodeSwitch) ([]string, error) {
+	unitIDs, removedNodeIDs, err := deletePurgedResource(tx, deviceID, resourceType, dbExistsNodes)
+	if err != nil {
+		return nil, err
+	}
+	if err := deleteUnitsWithoutContain(tx, unitIDs); err != nil {
+		return nil, err
+	}
+	return removedNodeIDs, nil
+}
+
+// deletePurgedResource physically deletes the resource with its annotation, its unit and all its edges,
+// and deletes the nodes composed of the resource that are left without resources.
+// It returns the IDs of the other units that contained the resource, which may be left without resources,
+// and the IDs of the deleted nodes, both in ascending order.
+func deletePurgedResource(tx *sql.Tx, deviceID string, resourceType hwResourceType, dbExistsNodes map[string]existingNodeSwitch) ([]string, []string, error) {
+	label, err := resourceType.convertToDBLabel()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The units are collected before the Contain edges to the resource are deleted with it
+	unitIDs, err := getContainingUnitIDs(tx, label, deviceID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, query := range []struct {
+		cypher string
+		params []any
+	}{
+		{cypherDeleteResourceAnnotation, []any{label, deviceID}},
+		{cypherDeleteResource, []any{label, deviceID}},
+		{cypherDeleteUnitAnnotation, []any{deviceID}},
+		{cypherDeleteUnit, []any{deviceID}},
+	} {
+		common.Log.Debug(fmt.Sprintf("query: %s, params: %v", query.cypher, query.params))
+		_, err := execCypher(tx, database.GRAPH_NAME, deleteColumnCount, query.cypher, query.params...)
+		if err != nil {
+			common.Log.Error(err.Error())
+			return nil, nil, err
+		}
+	}
+
+	nodeIDs, removedNodeIDs := getComposingNodeIDs(deviceID, dbExistsNodes)
+	for _, nodeID := range nodeIDs {
+		common.Log.Debug(fmt.Sprintf("query: %s, param1: %s", cypherDeleteNodeWithoutEdgesByID, nodeID))
+		_, err := execCypher(tx, database.GRAPH_NAME, deleteColumnCount, cypherDeleteNodeWithoutEdgesByID, nodeID)
+		if err != nil {
+			common.Log.Error(err.Error())
+			return nil, nil, err
+		}
+	}
+	return unitIDs, removedNodeIDs, nil
+}
+
+// getContainingUnitIDs retrieves the IDs of the units containing the resource of the label, other than its own unit, in ascending order.
+func getContainingUnitIDs(tx *sql.Tx, label string, deviceID string) ([]string, error) {
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s", cypherSelectContainingUnitList, label, deviceID))
+	cypherCursor, err := execCypher(tx, database.GRAPH_NAME, selectContainingUnitListColumnCount, cypherSelectContainingUnitList, label, deviceID)
+	if err != nil {
+		common.Log.Error(err.Error())
+		return nil, err
+	}
+	defer cypherCursor.Close()
+
+	unitIDs := map[string]bool{}
+	for cypherCursor.Next() {
+		row, err := cypherCursor.GetRow()
+		if err != nil {
+			common.Log.Error(err.Error())
+			return nil, err
+		}
+		unitID, _ := row[0].(*age.Vertex).Props()["deviceID"].(string)
+		// The own unit of the resource is deleted with it
+		if unitID != deviceID {
+			unitIDs[unitID] = true
+		}
+	}
+	return sortedKeys(unitIDs), nil
+}
+
+// deleteUnitsWithoutContain deletes the units of unitIDs that are left without resources, with their annotations.
+// Only the specified units are examined, so nothing is executed if unitIDs is empty.
+func deleteUnitsWithoutContain(tx *sql.Tx, unitIDs []string) error {
+	ids := make([]any, 0, len(unitIDs))
+	for _, unitID := range unitIDs {
+		ids = append(ids, unitID)
+	}
+	if err := execBatches(tx, cypherBulkDeleteUnitAnnotationWithoutContain, ids); err != nil {
+		return err
+	}
+	return execBatches(tx, cypherBulkDeleteUnitWithoutContain, ids)
+}
+
+// getComposingNodeIDs returns the IDs of the nodes composed of the resource, and those of them composed of no other resources, in ascending order.
+func getComposingNodeIDs(deviceID string, dbExistsNodes map[string]existingNodeSwitch) (nodeIDs []string, removedNodeIDs []string) {
+	nodeIDs = []string{}
+	removedNodeIDs = []string{}
+	for _, nodeID := range sortedKeys(dbExistsNodes) {
+		devices := dbExistsNodes[nodeID].deviceDictionary
+		if _, ok := devices[deviceID]; !ok {
+			continue
+		}
+		nodeIDs = append(nodeIDs, nodeID)
+		if len(devices) == 1 {
+			removedNodeIDs = append(removedNodeIDs, nodeID)
+		}
+	}
+	return
+}
+
+// newPurgeDeviceEvents creates the domain events of the physical deletion of the resource and the nodes left without resources.
+func newPurgeDeviceEvents(deviceID string, removedNodeIDs []string) []domainEvent {
+	events := []domainEvent{newDomainEvent(domainEventResourceDeleted, deviceID, map[string]any{"deviceID": deviceID})}
+	for _, nodeID := range removedNodeIDs {
+		events = append(events, newDomainEvent(domainEventNodeDecomposed, nodeID, map[string]any{"nodeID": nodeID}))
+	}
+	return events
+}
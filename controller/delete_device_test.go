@@ -0,0 +1,95 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDeleteDevice(t *testing.T) {
+	t.Skip("not test")
+}
+
+func Test_purgeResource(t *testing.T) {
+	t.Skip("not test")
+}
+
+func Test_deletePurgedResource(t *testing.T) {
+	t.Skip("not test")
+}
+
+func Test_getContainingUnitIDs(t *testing.T) {
+	t.Skip("not test")
+}
+
+func Test_deleteUnitsWithoutContain(t *testing.T) {
+	t.Run("Normal case: Only the specified units are examined", func(t *testing.T) {
+		statements := recordStatements(t)
+		if err := deleteUnitsWithoutContain(nil, []string{"unit001", "unit002"}); err != nil {
+			t.Fatalf("deleteUnitsWithoutContain() error = %v", err)
+		}
+		want := []string{cypherBulkDeleteUnitAnnotationWithoutContain, cypherBulkDeleteUnitWithoutContain}
+		if got := queriesOf(*statements); !reflect.DeepEqual(got, want) {
+			t.Errorf("deleteUnitsWithoutContain() queries = %v, want %v", got, want)
+		}
+		for _, statement := range *statements {
+			if list := statement.params[0].(string); !strings.Contains(list, "unit001") || !strings.Contains(list, "unit002") {
+				t.Errorf("deleteUnitsWithoutContain() units = %v, want unit001 and unit002", list)
+			}
+		}
+	})
+
+	t.Run("Normal case: No unit is swept without the units", func(t *testing.T) {
+		statements := recordStatements(t)
+		if err := deleteUnitsWithoutContain(nil, []string{}); err != nil {
+			t.Fatalf("deleteUnitsWithoutContain() error = %v", err)
+		}
+		if len(*statements) != 0 {
+			t.Errorf("deleteUnitsWithoutContain() queries = %v, want none", queriesOf(*statements))
+		}
+	})
+}
+
+func Test_getComposingNodeIDs(t *testing.T) {
+	dbExistsNodes := map[string]existingNodeSwitch{
+		"node001": {deviceDictionary: map[string]hwResourceType{"res101": CPU, "res102": Memory}},
+		"node002": {deviceDictionary: map[string]hwResourceType{"res102": Memory}},
+		"node003": {deviceDictionary: map[string]hwResourceType{"res301": CPU}},
+	}
+	gotNodeIDs, gotRemovedNodeIDs := getComposingNodeIDs("res102", dbExistsNodes)
+	if want := []string{"node001", "node002"}; !reflect.DeepEqual(gotNodeIDs, want) {
+		t.Errorf("getComposingNodeIDs() nodeIDs = %v, want %v", gotNodeIDs, want)
+	}
+	if want := []string{"node002"}; !reflect.DeepEqual(gotRemovedNodeIDs, want) {
+		t.Errorf("getComposingNodeIDs() removedNodeIDs = %v, want %v", gotRemovedNodeIDs, want)
+	}
+
+	gotNodeIDs, gotRemovedNodeIDs = getComposingNodeIDs("res999", dbExistsNodes)
+	if len(gotNodeIDs) != 0 || len(gotRemovedNodeIDs) != 0 {
+		t.Errorf("getComposingNodeIDs() = %v, %v, want empty", gotNodeIDs, gotRemovedNodeIDs)
+	}
+}
+
+func Test_newPurgeDeviceEvents(t *testing.T) {
+	want := []domainEvent{
+		newDomainEvent(domainEventResourceDeleted, "res101", map[string]any{"deviceID": "res101"}),
+		newDomainEvent(domainEventNodeDecomposed, "res101", map[string]any{"nodeID": "res101"}),
+	}
+	if got := newPurgeDeviceEvents("res101", []string{"res101"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("newPurgeDeviceEvents() = %v, want %v", got, want)
+	}
+}
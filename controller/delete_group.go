@@ -15,6 +15,7 @@
 package controller
 
 import (
+	"database/sql"
 	"fmt"
 	"net/http"
 
@@ -81,7 +82,10 @@ func DeleteGroup(c *gin.Context) {
 	}
 
 	repository := cmapi_repository_group.NewDeleteGroupRepository(id)
-	err = cmapi_repository.RelayDelete(&repository)
+	// The event is written to the outbox in the same transaction as the deletion
+	err = cmapi_repository.RelayDeleteWithHook(&repository, func(tx *sql.Tx) error {
+		return enqueueDomainEvents(tx, []domainEvent{newDomainEvent(domainEventResourceGroupDeleted, id, map[string]any{"id": id})})
+	})
 	if err != nil {
 		errorDatial := "RelayDelete error"
 		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
@@ -0,0 +1,106 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeleteResource physically deletes a resource immediately, regardless of its detection state and of the retention policy.
+// The annotation of the resource, its unit and all its edges such as Include and Contain are deleted with it,
+// and the units and the nodes left without resources are deleted as well.
+// The domain events of the deletion are written to the outbox in the same transaction.
+// If the resource is reported by a later hardware sync, it is registered again as a new resource.
+//
+// Parameters:
+// - c: gin.Context, the request context
+//
+// Response:
+// - On success: 204 status code
+// - If the resource does not exist: 404 status code
+// - On server error: 500 status code
+func DeleteResource(c *gin.Context) {
+	common.Log.Info(fmt.Sprintf("%s[%s] start.", c.Request.URL.Path, c.Request.Method))
+	funcName := "DeleteResource"
+
+	id := c.Param("id")
+	cmdb := database.NewCmDb()
+	err := cmdb.CmDbBeginTransaction()
+	if err != nil {
+		errorDatial := "CmDbBeginTransaction error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+	defer cmdb.CmDbDisconnection()
+
+	existsResources, err := getDeviceIDList(cmdb.Tx)
+	if err != nil {
+		errorDatial := "getDeviceIDList error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	existing, ok := existsResources[id]
+	if !ok {
+		errorDatial := "The target resource for delete did not exist"
+		common.Log.Warn(fmt.Sprintf("%s %s [id : %v]", funcName, errorDatial, id))
+		c.JSON(http.StatusNotFound, convertErrorResponse(http.StatusNotFound, errorDatial))
+		return
+	}
+
+	existsNodes, err := getNodeList(cmdb.Tx)
+	if err != nil {
+		errorDatial := "getNodeList error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	removedNodeIDs, err := purgeResource(cmdb.Tx, id, existing.resourceType, existsNodes)
+	if err != nil {
+		errorDatial := "purgeResource error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	err = enqueueDomainEvents(cmdb.Tx, newPurgeDeviceEvents(id, removedNodeIDs))
+	if err != nil {
+		errorDatial := "enqueueDomainEvents error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	err = cmdb.CmDbCommit()
+	if err != nil {
+		errorDatial := "CmDbCommit error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	common.Log.Info(fmt.Sprintf("%s[%s] completed successfully.", c.Request.URL.Path, c.Request.Method))
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+	"github.com/project-cdim/configuration-manager/webhook"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeleteWebhook is a handler that deletes the specified webhook.
+// Its deliveries, including those waiting for a retry and those in the dead-letter list, are deleted together.
+//
+// Parameters:
+// - c: gin.Context, the request context
+//
+// Response:
+// - On success: 204 status code
+// - If the webhook does not exist: 404 status code
+// - On server error: 500 status code
+func DeleteWebhook(c *gin.Context) {
+	common.Log.Info(fmt.Sprintf("%s[%s] start.", c.Request.URL.Path, c.Request.Method))
+	funcName := "DeleteWebhook"
+
+	id := c.Param("id")
+	cmdb := database.NewCmDb()
+	err := cmdb.CmDbConnection()
+	if err != nil {
+		errorDatial := "CmDbConnection error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+	defer cmdb.CmDbDisconnection()
+
+	deleted, err := webhook.Delete(cmdb.Db, id)
+	if err != nil {
+		errorDatial := "webhook.Delete error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	if !deleted {
+		errorDatial := "The target webhook for delete did not exist"
+		common.Log.Warn(fmt.Sprintf("%s %s [id : %v]", funcName, errorDatial, id))
+		c.JSON(http.StatusNotFound, convertErrorResponse(http.StatusNotFound, errorDatial))
+		return
+	}
+
+	common.Log.Info(fmt.Sprintf("%s[%s] completed successfully.", c.Request.URL.Path, c.Request.Method))
+
+	c.JSON(http.StatusNoContent, nil)
+}
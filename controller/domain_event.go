@@ -0,0 +1,234 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"database/sql"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/project-cdim/configuration-manager/changelog"
+	cmapi_model "github.com/project-cdim/configuration-manager/model"
+	"github.com/project-cdim/configuration-manager/outbox"
+
+	"github.com/google/uuid"
+)
+
+// Version of the payload of the domain events.
+// Increase the major version when making a change that is incompatible with the existing subscribers.
+const domainEventVersion = "1.0"
+
+// Category of the domain events. The topic to publish to and whether to publish are configured per category.
+type domainEventCategory string
+
+const (
+	domainEventCategoryResource      domainEventCategory = "resource"
+	domainEventCategoryResourceGroup domainEventCategory = "resourceGroup"
+	domainEventCategoryNode          domainEventCategory = "node"
+	domainEventCategoryCxlSwitch     domainEventCategory = "cxlswitch"
+)
+
+// Type of the domain events, in the form of "<category>.<event>"
+type domainEventType string
+
+const (
+	domainEventResourceCreated           domainEventType = "resource.created"
+	domainEventResourceUpdated           domainEventType = "resource.updated"
+	domainEventResourceNotDetected       domainEventType = "resource.notDetected"
+	domainEventResourceDeleted           domainEventType = "resource.deleted"
+	domainEventResourceQuarantined       domainEventType = "resource.quarantined"
+	domainEventResourceAnnotationChanged domainEventType = "resource.annotationChanged"
+	domainEventResourceGroupChanged      domainEventType = "resource.groupChanged"
+	domainEventResourceGroupCreated      domainEventType = "resourceGroup.created"
+	domainEventResourceGroupUpdated      domainEventType = "resourceGroup.updated"
+	domainEventResourceGroupDeleted      domainEventType = "resourceGroup.deleted"
+	domainEventNodeComposed              domainEventType = "node.composed"
+	domainEventNodeDecomposed            domainEventType = "node.decomposed"
+	domainEventCxlSwitchConnected        domainEventType = "cxlswitch.connected"
+)
+
+// category returns the category of the domain event type.
+func (t domainEventType) category() domainEventCategory {
+	category, _, _ := strings.Cut(string(t), ".")
+	return domainEventCategory(category)
+}
+
+// Environment variables to configure the domain events
+const (
+	// Name of the Dapr pub/sub component to publish the domain events to
+	envDomainEventPubsubName = "CM_EVENT_PUBSUB_NAME"
+	// Prefix of the environment variables to override the topic of a category, e.g. CM_EVENT_TOPIC_RESOURCEGROUP
+	envDomainEventTopicPrefix = "CM_EVENT_TOPIC_"
+	// Comma-separated list of the categories not to publish, e.g. "node,cxlswitch"
+	envDomainEventDisabledCategories = "CM_EVENT_DISABLED_CATEGORIES"
+)
+
+// Default name of the Dapr pub/sub component of the domain events
+const defaultDomainEventPubsubName = "configuration_manager_events"
+
+// Structure for storing the configuration of the domain events
+type domainEventConfig struct {
+	pubsubName         string
+	topics             map[domainEventCategory]string
+	disabledCategories []domainEventCategory
+}
+
+// Configuration of the domain events, loaded from the environment variables at startup
+var domainEventSetting = loadDomainEventConfig(os.Getenv)
+
+// loadDomainEventConfig loads the configuration of the domain events using getenv.
+// The topic of each category defaults to "configuration_manager.<category>", and all categories are enabled by default.
+func loadDomainEventConfig(getenv func(string) string) domainEventConfig {
+	config := domainEventConfig{
+		pubsubName:         defaultDomainEventPubsubName,
+		topics:             map[domainEventCategory]string{},
+		disabledCategories: []domainEventCategory{},
+	}
+	if pubsubName := strings.TrimSpace(getenv(envDomainEventPubsubName)); len(pubsubName) > 0 {
+		config.pubsubName = pubsubName
+	}
+
+	for _, category := range []domainEventCategory{
+		domainEventCategoryResource,
+		domainEventCategoryResourceGroup,
+		domainEventCategoryNode,
+		domainEventCategoryCxlSwitch,
+	} {
+		config.topics[category] = "configuration_manager." + string(category)
+		if topic := strings.TrimSpace(getenv(envDomainEventTopicPrefix + strings.ToUpper(string(category)))); len(topic) > 0 {
+			config.topics[category] = topic
+		}
+	}
+
+	for _, category := range strings.Split(getenv(envDomainEventDisabledCategories), ",") {
+		if category = strings.TrimSpace(category); len(category) > 0 {
+			config.disabledCategories = append(config.disabledCategories, domainEventCategory(category))
+		}
+	}
+
+	return config
+}
+
+// enabled reports whether the domain events of the category are published.
+func (dec *domainEventConfig) enabled(category domainEventCategory) bool {
+	return !slices.Contains(dec.disabledCategories, category)
+}
+
+// Structure for storing a domain event to publish
+type domainEvent struct {
+	eventType domainEventType
+	subject   string // ID of the entity the event is about
+	data      map[string]any
+}
+
+// newDomainEvent creates a domain event of eventType about the entity identified by subject.
+func newDomainEvent(eventType domainEventType, subject string, data map[string]any) domainEvent {
+	if data == nil {
+		data = map[string]any{}
+	}
+	return domainEvent{
+		eventType: eventType,
+		subject:   subject,
+		data:      data,
+	}
+}
+
+// toObject converts the domain event into the payload to publish.
+func (de *domainEvent) toObject(eventID string, timestamp string) map[string]any {
+	return map[string]any{
+		"version":   domainEventVersion,
+		"id":        eventID,
+		"type":      string(de.eventType),
+		"subject":   de.subject,
+		"timestamp": timestamp,
+		"data":      de.data,
+	}
+}
+
+// toOutboxEvent converts the domain event into an outbox event published to the topic of its category.
+func (de *domainEvent) toOutboxEvent(config *domainEventConfig, eventID string, timestamp string) outbox.Event {
+	return outbox.Event{
+		ID:         eventID,
+		PubsubName: config.pubsubName,
+		Topic:      config.topics[de.eventType.category()],
+		Type:       string(de.eventType),
+		Payload:    de.toObject(eventID, timestamp),
+	}
+}
+
+// toChangeLogEntry converts the domain event into an entry of the change log.
+func (de *domainEvent) toChangeLogEntry(eventID string) changelog.Entry {
+	return changelog.Entry{
+		ID:      eventID,
+		Type:    string(de.eventType),
+		Subject: de.subject,
+		Data:    de.data,
+	}
+}
+
+// enqueueDomainEvents records the domain events in the change log and writes them to the outbox in the transaction of the change they describe.
+// The events are published by the outbox relay after the transaction is committed.
+// All events are recorded in the change log, whereas the events of the disabled categories are not published.
+func enqueueDomainEvents(tx *sql.Tx, events []domainEvent) error {
+	timestamp := cmapi_model.CurrentTimeISO8601()
+	changes := []changelog.Entry{}
+	outboxEvents := []outbox.Event{}
+	for _, event := range events {
+		eventID, _ := uuid.NewV7()
+		changes = append(changes, event.toChangeLogEntry(eventID.String()))
+		if !domainEventSetting.enabled(event.eventType.category()) {
+			continue
+		}
+		outboxEvents = append(outboxEvents, event.toOutboxEvent(&domainEventSetting, eventID.String(), timestamp))
+	}
+
+	err := changelog.Append(tx, changes...)
+	if err != nil {
+		return err
+	}
+	return outbox.Enqueue(tx, outboxEvents...)
+}
+
+// newAnnotationChangedEvents creates the resource.annotationChanged events of the resources whose annotations were updated.
+// unitID is the ID of the unit containing the resources, and is empty if the resource is not contained in a unit.
+func newAnnotationChangedEvents(deviceIDs []string, unitID string, annotation map[string]any) []domainEvent {
+	events := []domainEvent{}
+	for _, deviceID := range deviceIDs {
+		data := map[string]any{
+			"deviceID":   deviceID,
+			"annotation": annotation,
+		}
+		if len(unitID) > 0 {
+			data["unitID"] = unitID
+		}
+		events = append(events, newDomainEvent(domainEventResourceAnnotationChanged, deviceID, data))
+	}
+	return events
+}
+
+// getUnitMemberIDs returns the device IDs of the resources contained in the unit object fetched by the unit repository.
+func getUnitMemberIDs(unit map[string]any) []string {
+	deviceIDs := []string{}
+	resources, _ := unit["resources"].([]any)
+	for _, resource := range resources {
+		resourceMap, _ := resource.(map[string]any)
+		device, _ := resourceMap["device"].(map[string]any)
+		if deviceID, ok := device["deviceID"].(string); ok {
+			deviceIDs = append(deviceIDs, deviceID)
+		}
+	}
+	return deviceIDs
+}
@@ -0,0 +1,227 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/project-cdim/configuration-manager/changelog"
+	"github.com/project-cdim/configuration-manager/outbox"
+)
+
+func Test_domainEventType_category(t *testing.T) {
+	tests := []struct {
+		name      string
+		eventType domainEventType
+		want      domainEventCategory
+	}{
+		{name: "Normal case: resource", eventType: domainEventResourceAnnotationChanged, want: domainEventCategoryResource},
+		{name: "Normal case: resourceGroup", eventType: domainEventResourceGroupDeleted, want: domainEventCategoryResourceGroup},
+		{name: "Normal case: node", eventType: domainEventNodeDecomposed, want: domainEventCategoryNode},
+		{name: "Normal case: cxlswitch", eventType: domainEventCxlSwitchConnected, want: domainEventCategoryCxlSwitch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.eventType.category(); got != tt.want {
+				t.Errorf("category() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_loadDomainEventConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want domainEventConfig
+	}{
+		{
+			name: "Normal case: Default configuration",
+			env:  map[string]string{},
+			want: domainEventConfig{
+				pubsubName: "configuration_manager_events",
+				topics: map[domainEventCategory]string{
+					domainEventCategoryResource:      "configuration_manager.resource",
+					domainEventCategoryResourceGroup: "configuration_manager.resourceGroup",
+					domainEventCategoryNode:          "configuration_manager.node",
+					domainEventCategoryCxlSwitch:     "configuration_manager.cxlswitch",
+				},
+				disabledCategories: []domainEventCategory{},
+			},
+		},
+		{
+			name: "Normal case: The pub/sub, the topics and the disabled categories are configured",
+			env: map[string]string{
+				"CM_EVENT_PUBSUB_NAME":           "pubsub",
+				"CM_EVENT_TOPIC_RESOURCEGROUP":   "groups",
+				"CM_EVENT_TOPIC_CXLSWITCH":       " switches ",
+				"CM_EVENT_DISABLED_CATEGORIES":   "node, cxlswitch,",
+				"CM_EVENT_TOPIC_UNKNOWNCATEGORY": "unknown",
+			},
+			want: domainEventConfig{
+				pubsubName: "pubsub",
+				topics: map[domainEventCategory]string{
+					domainEventCategoryResource:      "configuration_manager.resource",
+					domainEventCategoryResourceGroup: "groups",
+					domainEventCategoryNode:          "configuration_manager.node",
+					domainEventCategoryCxlSwitch:     "switches",
+				},
+				disabledCategories: []domainEventCategory{domainEventCategoryNode, domainEventCategoryCxlSwitch},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := loadDomainEventConfig(func(key string) string { return tt.env[key] })
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadDomainEventConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_domainEventConfig_enabled(t *testing.T) {
+	config := domainEventConfig{disabledCategories: []domainEventCategory{domainEventCategoryNode}}
+	if !config.enabled(domainEventCategoryResource) {
+		t.Errorf("enabled(resource) = false, want true")
+	}
+	if config.enabled(domainEventCategoryNode) {
+		t.Errorf("enabled(node) = true, want false")
+	}
+}
+
+func Test_domainEvent_toObject(t *testing.T) {
+	event := newDomainEvent(domainEventResourceGroupDeleted, "group001", nil)
+	want := map[string]any{
+		"version":   "1.0",
+		"id":        "event001",
+		"type":      "resourceGroup.deleted",
+		"subject":   "group001",
+		"timestamp": "2025-01-01T00:00:00Z",
+		"data":      map[string]any{},
+	}
+	if got := event.toObject("event001", "2025-01-01T00:00:00Z"); !reflect.DeepEqual(got, want) {
+		t.Errorf("toObject() = %v, want %v", got, want)
+	}
+}
+
+func Test_domainEvent_toOutboxEvent(t *testing.T) {
+	config := loadDomainEventConfig(func(key string) string {
+		return map[string]string{"CM_EVENT_TOPIC_RESOURCEGROUP": "groups"}[key]
+	})
+	event := newDomainEvent(domainEventResourceGroupCreated, "group001", map[string]any{"id": "group001"})
+	want := outbox.Event{
+		ID:         "event001",
+		PubsubName: "configuration_manager_events",
+		Topic:      "groups",
+		Type:       "resourceGroup.created",
+		Payload: map[string]any{
+			"version":   "1.0",
+			"id":        "event001",
+			"type":      "resourceGroup.created",
+			"subject":   "group001",
+			"timestamp": "2025-01-01T00:00:00Z",
+			"data":      map[string]any{"id": "group001"},
+		},
+	}
+	if got := event.toOutboxEvent(&config, "event001", "2025-01-01T00:00:00Z"); !reflect.DeepEqual(got, want) {
+		t.Errorf("toOutboxEvent() = %v, want %v", got, want)
+	}
+}
+
+func Test_domainEvent_toChangeLogEntry(t *testing.T) {
+	event := newDomainEvent(domainEventResourceCreated, "res101", map[string]any{"deviceID": "res101"})
+	want := changelog.Entry{
+		ID:      "event001",
+		Type:    "resource.created",
+		Subject: "res101",
+		Data:    map[string]any{"deviceID": "res101"},
+	}
+	if got := event.toChangeLogEntry("event001"); !reflect.DeepEqual(got, want) {
+		t.Errorf("toChangeLogEntry() = %v, want %v", got, want)
+	}
+}
+
+func Test_enqueueDomainEvents(t *testing.T) {
+	t.Skip("not test")
+}
+
+func Test_newAnnotationChangedEvents(t *testing.T) {
+	annotation := map[string]any{"available": true}
+	tests := []struct {
+		name      string
+		deviceIDs []string
+		unitID    string
+		want      []domainEvent
+	}{
+		{
+			name:      "Normal case: A resource that is not contained in a unit",
+			deviceIDs: []string{"res101"},
+			unitID:    "",
+			want: []domainEvent{
+				newDomainEvent(domainEventResourceAnnotationChanged, "res101", map[string]any{"deviceID": "res101", "annotation": annotation}),
+			},
+		},
+		{
+			name:      "Normal case: Resources contained in a unit",
+			deviceIDs: []string{"res101", "res102"},
+			unitID:    "unit001",
+			want: []domainEvent{
+				newDomainEvent(domainEventResourceAnnotationChanged, "res101", map[string]any{"deviceID": "res101", "annotation": annotation, "unitID": "unit001"}),
+				newDomainEvent(domainEventResourceAnnotationChanged, "res102", map[string]any{"deviceID": "res102", "annotation": annotation, "unitID": "unit001"}),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newAnnotationChangedEvents(tt.deviceIDs, tt.unitID, annotation); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newAnnotationChangedEvents() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getUnitMemberIDs(t *testing.T) {
+	tests := []struct {
+		name string
+		unit map[string]any
+		want []string
+	}{
+		{
+			name: "Normal case: The device IDs of the resources are returned",
+			unit: map[string]any{
+				"id": "unit001",
+				"resources": []any{
+					map[string]any{"device": map[string]any{"deviceID": "res101"}},
+					map[string]any{"device": map[string]any{"deviceID": "res102"}},
+				},
+			},
+			want: []string{"res101", "res102"},
+		},
+		{
+			name: "Normal case: A unit without resources",
+			unit: nil,
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getUnitMemberIDs(tt.unit); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getUnitMemberIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/project-cdim/configuration-manager/common"
+	cmapi_filter "github.com/project-cdim/configuration-manager/filter"
+	cmapi_repository "github.com/project-cdim/configuration-manager/repository"
+	cmapi_repository_rule "github.com/project-cdim/configuration-manager/repository/rule"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EvaluateAssignmentRules is a handler that evaluates the assignment rules against a sample device
+// and returns the resource group to which the device would be assigned if it were newly discovered.
+// The request body is a single device in the same format as an element of the device registration request.
+// Nothing is registered in the database.
+//
+// Parameters:
+// - c: gin.Context, the request context
+//
+// Response:
+// - On success: 200 status code with resourceGroupID, matchedRule and default
+// - If the request body is invalid: 400 status code
+// - On server error: 500 status code
+func EvaluateAssignmentRules(c *gin.Context) {
+	common.Log.Info(fmt.Sprintf("%s[%s] start.", c.Request.URL.Path, c.Request.Method))
+	funcName := "EvaluateAssignmentRules"
+
+	device, err := unmarshalRequestBodyForMap(c)
+	if err != nil {
+		errorDatial := "unmarshalRequestBodyForMap error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
+	// The sample device is checked in the same way as the device registration
+	if _, err := validateRegisterData([]map[string]any{device}); err != nil {
+		errorDatial := "validateRegisterData error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusBadRequest, convertValidationErrorResponse(err, errorDatial))
+		return
+	}
+
+	filter := cmapi_filter.NewNoFilter()
+	repository := cmapi_repository_rule.NewResolveRuleRepository(newDeviceContext(device))
+	res, err := cmapi_repository.RelayFind(&repository, filter)
+	if err != nil {
+		errorDatial := "RelayFind error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	logResponseBody(res)
+	common.Log.Info(fmt.Sprintf("%s[%s] completed successfully.", c.Request.URL.Path, c.Request.Method))
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/project-cdim/configuration-manager/common"
+	cmapi_filter "github.com/project-cdim/configuration-manager/filter"
+	cmapi_repository "github.com/project-cdim/configuration-manager/repository"
+	cmapi_repository_rule "github.com/project-cdim/configuration-manager/repository/rule"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetAssignmentRule retrieves the assignment rule for the specified ID and returns it in JSON format.
+//
+// Parameters:
+//   - c: gin.Context. Represents the request context.
+//
+// Response:
+//   - On success: HTTP status 200 and the rule object
+//   - If the rule does not exist: HTTP status 404
+//   - On server error: HTTP status 500
+func GetAssignmentRule(c *gin.Context) {
+	common.Log.Info(fmt.Sprintf("%s[%s] start.", c.Request.URL.Path, c.Request.Method))
+	funcName := "GetAssignmentRule"
+
+	id := c.Param("id")
+	filter := cmapi_filter.NewNoFilter()
+	repository := cmapi_repository_rule.NewRuleRepository(id)
+	res, err := cmapi_repository.RelayFind(&repository, filter)
+	if err != nil {
+		errorDatial := "RelayFind error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	if res == nil {
+		errorDatial := "No search results"
+		common.Log.Warn(fmt.Sprintf("%s %s [id : %v]", funcName, errorDatial, id))
+		c.JSON(http.StatusNotFound, convertErrorResponse(http.StatusNotFound, errorDatial))
+		return
+	}
+
+	logResponseBody(res)
+	common.Log.Info(fmt.Sprintf("%s[%s] completed successfully.", c.Request.URL.Path, c.Request.Method))
+
+	c.JSON(http.StatusOK, res)
+}
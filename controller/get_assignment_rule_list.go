@@ -0,0 +1,60 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/project-cdim/configuration-manager/common"
+	cmapi_filter "github.com/project-cdim/configuration-manager/filter"
+	cmapi_repository "github.com/project-cdim/configuration-manager/repository"
+	cmapi_repository_rule "github.com/project-cdim/configuration-manager/repository/rule"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetAssignmentRuleList is a handler that retrieves the list of assignment rules in evaluation order
+// and returns a JSON response.
+//
+// Parameters:
+// - c: gin.Context. The context of the HTTP request and response.
+//
+// Error handling:
+// - If the retrieval of the rule list fails, returns 500 Internal Server Error.
+func GetAssignmentRuleList(c *gin.Context) {
+	common.Log.Info(fmt.Sprintf("%s[%s] start.", c.Request.URL.Path, c.Request.Method))
+	funcName := "GetAssignmentRuleList"
+
+	filter := cmapi_filter.NewNoFilter()
+	repository := cmapi_repository_rule.NewRuleListRepository()
+	rules, err := cmapi_repository.RelayFindList(&repository, filter)
+	if err != nil {
+		errorDatial := "RelayFindList error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	res := gin.H{
+		"count":           len(rules),
+		"assignmentRules": rules,
+	}
+
+	logResponseBody(res)
+	common.Log.Info(fmt.Sprintf("%s[%s] completed successfully.", c.Request.URL.Path, c.Request.Method))
+
+	c.JSON(http.StatusOK, res)
+}
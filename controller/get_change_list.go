@@ -0,0 +1,106 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/project-cdim/configuration-manager/changelog"
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultChangeListLimit = 100
+	maxChangeListLimit     = 1000
+)
+
+// GetChangeList handles the request to fetch the changes recorded in the change log after a sequence number.
+//
+// Every committed change of the configuration is recorded in the change log with a monotonically increasing
+// sequence number. A consumer catches up by passing the lastSeq of the previous response as 'since' until
+// the count becomes 0, and can then switch to the change stream.
+//
+// Query Parameters:
+//   - since: The changes whose sequence numbers are greater than this are returned. The default is 0.
+//   - limit: The maximum number of changes to return, from 1 to 1000. The default is 100.
+//
+// Responses:
+//   - 200 OK: The count of the changes, the sequence number to pass as 'since' next time, and the changes in ascending order.
+//   - 400 Bad Request: A query parameter is invalid.
+//   - 500 Internal Server Error: An error occurred while fetching the changes from the database.
+func GetChangeList(c *gin.Context) {
+	common.Log.Info(fmt.Sprintf("%s[%s] start.", c.Request.URL.Path, c.Request.Method))
+	funcName := "GetChangeList"
+
+	since, err := getIntQueryParam(c, "since", 0)
+	if err != nil || since < 0 {
+		errorDatial := "since query parameter error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, c.Query("since")), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
+	limit, err := getIntQueryParam(c, "limit", defaultChangeListLimit)
+	if err != nil || limit < 1 || limit > maxChangeListLimit {
+		errorDatial := "limit query parameter error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, c.Query("limit")), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
+	cmdb := database.NewCmDb()
+	err = cmdb.CmDbConnection()
+	if err != nil {
+		errorDatial := "CmDbConnection error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+	defer cmdb.CmDbDisconnection()
+
+	changes, err := changelog.List(cmdb.Db, since, int(limit))
+	if err != nil {
+		errorDatial := "changelog.List error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	res := newChangeListResponse(since, changes)
+	logResponseBody(res)
+	common.Log.Info(fmt.Sprintf("%s[%s] completed successfully.", c.Request.URL.Path, c.Request.Method))
+
+	c.JSON(http.StatusOK, res)
+}
+
+// newChangeListResponse creates the response of GetChangeList.
+// lastSeq is the sequence number of the last change, or since if there is no change.
+func newChangeListResponse(since int64, changes []changelog.Change) gin.H {
+	lastSeq := since
+	objects := []map[string]any{}
+	for _, change := range changes {
+		objects = append(objects, change.ToObject())
+		lastSeq = change.Seq
+	}
+	return gin.H{
+		"count":   len(objects),
+		"lastSeq": lastSeq,
+		"changes": objects,
+	}
+}
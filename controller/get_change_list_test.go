@@ -0,0 +1,59 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/project-cdim/configuration-manager/changelog"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGetChangeList(t *testing.T) {
+	t.Skip("not test")
+}
+
+func Test_newChangeListResponse(t *testing.T) {
+	change := changelog.Change{Seq: 12, ID: "event001", Type: "resource.created", Subject: "res101", Data: json.RawMessage(`{}`), Timestamp: "2025-01-01T00:00:00Z"}
+	tests := []struct {
+		name    string
+		since   int64
+		changes []changelog.Change
+		want    gin.H
+	}{
+		{
+			name:    "Normal case: lastSeq is the sequence number of the last change",
+			since:   10,
+			changes: []changelog.Change{change},
+			want:    gin.H{"count": 1, "lastSeq": int64(12), "changes": []map[string]any{change.ToObject()}},
+		},
+		{
+			name:    "Normal case: lastSeq is since if there is no change",
+			since:   10,
+			changes: []changelog.Change{},
+			want:    gin.H{"count": 0, "lastSeq": int64(10), "changes": []map[string]any{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newChangeListResponse(tt.since, tt.changes); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newChangeListResponse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/project-cdim/configuration-manager/common"
+	cmapi_filter "github.com/project-cdim/configuration-manager/filter"
+	cmapi_repository "github.com/project-cdim/configuration-manager/repository"
+	cmapi_repository_chassis "github.com/project-cdim/configuration-manager/repository/chassis"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetChassisList retrieves the list of all chassis with the devices (switches, resources) mounted in each chassis
+// and the ID of the rack to which each chassis is attached. The 'detail' query parameter determines the level of
+// detail of the resources in the same way as GetRack. On success, it returns the list with a 200 OK status.
+func GetChassisList(c *gin.Context) {
+	common.Log.Info(fmt.Sprintf("%s[%s] start.", c.Request.URL.Path, c.Request.Method))
+	funcName := "GetChassisList"
+
+	// Retrieve query parameter: detail
+	detail, err := getBoolQueryParam(c, "detail")
+	if err != nil {
+		errorDatial := "getBoolQueryParam error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
+	filter := cmapi_filter.NewNoFilter()
+	repository := cmapi_repository_chassis.NewChassisListRepository(detail)
+	chassis, err := cmapi_repository.RelayFindList(&repository, filter)
+	if err != nil {
+		// Outputs JSON containing the error code and error message to the ResponseBody and terminates
+		errorDatial := "RelayFindList error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	res := gin.H{
+		"count":   len(chassis),
+		"chassis": chassis,
+	}
+
+	logResponseBody(res)
+	common.Log.Info(fmt.Sprintf("%s[%s] completed successfully.", c.Request.URL.Path, c.Request.Method))
+
+	c.JSON(http.StatusOK, res)
+}
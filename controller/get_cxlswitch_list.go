@@ -29,10 +29,19 @@ import (
 // GetCxlSwitchList retrieves a list of all CXL switches and the list of resources associated with each node.
 // It logs the start and end of the request, handles any errors by logging them and returning an appropriate JSON response.
 // On success, it marshals the list of CXL switches into JSON and returns it in the response body.
+// The query parameters to filter and sort the resources by the times are rejected with 400 Bad Request.
 func GetCxlSwitchList(c *gin.Context) {
 	common.Log.Info(fmt.Sprintf("%s[%s] start.", c.Request.URL.Path, c.Request.Method))
 	funcName := "GetCxlSwitchList"
 
+	// The CXL switches are not filtered or sorted by the times when their resources were seen
+	if err := rejectResourceSeenParams(c); err != nil {
+		errorDatial := "rejectResourceSeenParams error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
 	filter := cmapi_filter.NewNoFilter()
 	repository := cmapi_repository_cxlswitch.NewCXLSwitchListRepository()
 	cxlswitches, err := cmapi_repository.RelayFindList(&repository, filter)
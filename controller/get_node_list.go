@@ -30,10 +30,19 @@ import (
 // It logs the start of the request, attempts to retrieve the node list using a no-filter approach,
 // and handles errors by logging and returning an error response. On success, it marshals the response
 // into JSON and sends it back to the client with an HTTP status code of 200 OK.
+// The query parameters to filter and sort the resources by the times are rejected with 400 Bad Request.
 func GetNodeList(c *gin.Context) {
 	common.Log.Info(fmt.Sprintf("%s[%s] start.", c.Request.URL.Path, c.Request.Method))
 	funcName := "GetNodeList"
 
+	// The nodes are not filtered or sorted by the times when their resources were seen
+	if err := rejectResourceSeenParams(c); err != nil {
+		errorDatial := "rejectResourceSeenParams error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
 	filter := cmapi_filter.NewNoFilter()
 	repository := cmapi_repository_node.NewNodeListRepository()
 	nodes, err := cmapi_repository.RelayFindList(&repository, filter)
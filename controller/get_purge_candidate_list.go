@@ -0,0 +1,96 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetPurgeCandidateList handles the request to report the resources in the NotDetected state that the retention policy
+// would purge at the next hardware sync. Nothing is deleted by this request.
+//
+// Query Parameters:
+//   - notDetectedDays: Overrides the number of days of the policy to evaluate. 0 disables the criterion.
+//   - notDetectedSyncs: Overrides the number of hardware syncs of the policy to evaluate. 0 disables the criterion.
+//
+// Responses:
+//   - 200 OK: The evaluated policy, the count of the candidates, and the candidates in ascending order of the device ID.
+//   - 400 Bad Request: A query parameter is invalid.
+//   - 500 Internal Server Error: An error occurred while fetching the resources from the database.
+func GetPurgeCandidateList(c *gin.Context) {
+	common.Log.Info(fmt.Sprintf("%s[%s] start.", c.Request.URL.Path, c.Request.Method))
+	funcName := "GetPurgeCandidateList"
+
+	policy := retentionPolicySetting
+	notDetectedDays, err := getIntQueryParam(c, "notDetectedDays", policy.notDetectedDays)
+	if err != nil || notDetectedDays < 0 {
+		errorDatial := "notDetectedDays query parameter error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, c.Query("notDetectedDays")), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+	notDetectedSyncs, err := getIntQueryParam(c, "notDetectedSyncs", policy.notDetectedSyncs)
+	if err != nil || notDetectedSyncs < 0 {
+		errorDatial := "notDetectedSyncs query parameter error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, c.Query("notDetectedSyncs")), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+	policy = retentionPolicy{notDetectedDays: notDetectedDays, notDetectedSyncs: notDetectedSyncs}
+
+	cmdb := database.NewCmDb()
+	err = cmdb.CmDbBeginTransaction()
+	if err != nil {
+		errorDatial := "CmDbBeginTransaction error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+	defer cmdb.CmDbDisconnection()
+
+	resources, err := getNotDetectedResourceList(cmdb.Tx)
+	if err != nil {
+		errorDatial := "getNotDetectedResourceList error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	res := newPurgeCandidateListResponse(policy, resources, time.Now().UTC())
+	logResponseBody(res)
+	common.Log.Info(fmt.Sprintf("%s[%s] completed successfully.", c.Request.URL.Path, c.Request.Method))
+
+	c.JSON(http.StatusOK, res)
+}
+
+// newPurgeCandidateListResponse creates the response of GetPurgeCandidateList from the resources in the NotDetected state.
+func newPurgeCandidateListResponse(policy retentionPolicy, resources []notDetectedResource, now time.Time) gin.H {
+	candidates := []map[string]any{}
+	for _, candidate := range getPurgeCandidates(resources, policy, now) {
+		candidates = append(candidates, candidate.toObject(now))
+	}
+	return gin.H{
+		"policy":     policy.toObject(),
+		"count":      len(candidates),
+		"candidates": candidates,
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGetPurgeCandidateList(t *testing.T) {
+	t.Skip("not test")
+}
+
+func Test_newPurgeCandidateListResponse(t *testing.T) {
+	now := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+	resources := []notDetectedResource{
+		{deviceID: "res101", resourceType: CPU, since: "2025-06-01T00:00:00Z", missedSyncs: 3},
+		{deviceID: "res102", resourceType: Memory, since: "2025-06-29T00:00:00Z", missedSyncs: 1},
+	}
+	want := gin.H{
+		"policy": map[string]any{"notDetectedDays": int64(7), "notDetectedSyncs": int64(0)},
+		"count":  1,
+		"candidates": []map[string]any{
+			{"deviceID": "res101", "type": "CPU", "notDetectedSince": "2025-06-01T00:00:00Z", "notDetectedDays": int64(29), "missedSyncs": int64(3)},
+		},
+	}
+	got := newPurgeCandidateListResponse(retentionPolicy{notDetectedDays: 7}, resources, now)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("newPurgeCandidateListResponse() = %v, want %v", got, want)
+	}
+
+	want = gin.H{
+		"policy":     map[string]any{"notDetectedDays": int64(0), "notDetectedSyncs": int64(0)},
+		"count":      0,
+		"candidates": []map[string]any{},
+	}
+	got = newPurgeCandidateListResponse(retentionPolicy{}, resources, now)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("newPurgeCandidateListResponse() = %v, want %v", got, want)
+	}
+}
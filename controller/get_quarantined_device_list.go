@@ -0,0 +1,72 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetQuarantinedDeviceList handles the request to list the devices of unsupported resource types quarantined by the hardware sync.
+// The quarantined devices are not resources, so they are excluded from the resource APIs including the available resources.
+//
+// Responses:
+//   - 200 OK: The count of the quarantined devices, and the devices with their payloads in ascending order of the device ID.
+//   - 500 Internal Server Error: An error occurred while fetching the devices from the database.
+func GetQuarantinedDeviceList(c *gin.Context) {
+	common.Log.Info(fmt.Sprintf("%s[%s] start.", c.Request.URL.Path, c.Request.Method))
+	funcName := "GetQuarantinedDeviceList"
+
+	cmdb := database.NewCmDb()
+	err := cmdb.CmDbBeginTransaction()
+	if err != nil {
+		errorDatial := "CmDbBeginTransaction error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+	defer cmdb.CmDbDisconnection()
+
+	devices, err := getQuarantinedDeviceList(cmdb.Tx)
+	if err != nil {
+		errorDatial := "getQuarantinedDeviceList error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	res := newQuarantinedDeviceListResponse(devices)
+	logResponseBody(res)
+	common.Log.Info(fmt.Sprintf("%s[%s] completed successfully.", c.Request.URL.Path, c.Request.Method))
+
+	c.JSON(http.StatusOK, res)
+}
+
+// newQuarantinedDeviceListResponse creates the response of GetQuarantinedDeviceList from the quarantined devices.
+func newQuarantinedDeviceListResponse(devices []quarantinedDevice) gin.H {
+	deviceObjects := []map[string]any{}
+	for _, device := range devices {
+		deviceObjects = append(deviceObjects, device.toObject())
+	}
+	return gin.H{
+		"count":   len(deviceObjects),
+		"devices": deviceObjects,
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGetQuarantinedDeviceList(t *testing.T) {
+	t.Skip("not test")
+}
+
+func Test_newQuarantinedDeviceListResponse(t *testing.T) {
+	devices := []quarantinedDevice{
+		{deviceID: "dpu01", resourceType: "DPU", payload: map[string]any{"deviceID": "dpu01", "type": "DPU"}, firstSeenAt: "2025-06-01T00:00:00Z", lastSeenAt: "2025-06-02T00:00:00Z"},
+	}
+	want := gin.H{
+		"count": 1,
+		"devices": []map[string]any{
+			{"deviceID": "dpu01", "type": "DPU", "payload": map[string]any{"deviceID": "dpu01", "type": "DPU"}, "firstSeenAt": "2025-06-01T00:00:00Z", "lastSeenAt": "2025-06-02T00:00:00Z"},
+		},
+	}
+	if got := newQuarantinedDeviceListResponse(devices); !reflect.DeepEqual(got, want) {
+		t.Errorf("newQuarantinedDeviceListResponse() = %v, want %v", got, want)
+	}
+
+	want = gin.H{"count": 0, "devices": []map[string]any{}}
+	if got := newQuarantinedDeviceListResponse(nil); !reflect.DeepEqual(got, want) {
+		t.Errorf("newQuarantinedDeviceListResponse() = %v, want %v", got, want)
+	}
+}
@@ -17,10 +17,11 @@ package controller
 import (
 	"fmt"
 	"net/http"
+	"slices"
 
 	"github.com/project-cdim/configuration-manager/common"
 
-	cmapi_filter "github.com/project-cdim/configuration-manager/filter"
+	cmapi_filter_resource "github.com/project-cdim/configuration-manager/filter/resource"
 	cmapi_repository "github.com/project-cdim/configuration-manager/repository"
 	cmapi_repository_resource "github.com/project-cdim/configuration-manager/repository/resource"
 
@@ -37,11 +38,32 @@ import (
 // If marshaling fails, the error is logged and an error response is returned. Otherwise, the marshaled JSON is logged
 // for debugging purposes. Finally, the function logs the successful completion of the request and returns the marshaled
 // JSON as a response with a 200 OK status.
+//
+// The resources can be filtered by the times when they were first seen, last seen and not detected since with the query
+// parameters 'firstSeenAfter', 'firstSeenBefore', 'lastSeenAfter', 'lastSeenBefore', 'notDetectedSinceAfter' and
+// 'notDetectedSinceBefore' in ISO 8601, where "after" is inclusive and "before" is exclusive. The list is sorted by the
+// query parameter 'sort' (deviceID, firstSeenAt, lastSeenAt or notDetectedSince) in the 'order' (asc or desc).
 func GetResourceList(c *gin.Context) {
 	common.Log.Info(fmt.Sprintf("%s[%s] start.", c.Request.URL.Path, c.Request.Method))
 	funcName := "GetResourceList"
 
-	filter := cmapi_filter.NewNoFilter()
+	// Retrieve query parameters: firstSeenAfter, firstSeenBefore, lastSeenAfter, lastSeenBefore, notDetectedSinceAfter, notDetectedSinceBefore
+	filter, err := getResourceSeenFilter(c)
+	if err != nil {
+		errorDatial := "getResourceSeenFilter error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
+	// Retrieve query parameters: sort, order
+	sortKey, descending, err := getResourceSortParams(c)
+	if err != nil {
+		errorDatial := "getResourceSortParams error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
 
 	// Retrieve query parameter: detail
 	detail, err := getBoolQueryParam(c, "detail")
@@ -53,6 +75,8 @@ func GetResourceList(c *gin.Context) {
 	}
 
 	repository := cmapi_repository_resource.NewResourceListRepository(detail)
+	repository.SortKey = sortKey
+	repository.Descending = descending
 	resources, err := cmapi_repository.RelayFindList(&repository, filter)
 	if err != nil {
 		// In case of an error during the retrieval or array storage process,
@@ -74,3 +98,57 @@ func GetResourceList(c *gin.Context) {
 	// Sets the return value
 	c.JSON(http.StatusOK, res)
 }
+
+// resourceSeenParamPrefixes is the prefixes of the query parameters of the times to filter the resources by.
+var resourceSeenParamPrefixes = []string{"firstSeen", "lastSeen", "notDetectedSince"}
+
+// getResourceSeenFilter creates the filter of the times when the resources were first seen, last seen and not detected since
+// from the query parameters. A query parameter that is not specified does not restrict the time.
+func getResourceSeenFilter(c *gin.Context) (cmapi_filter_resource.ResourceSeenFilter, error) {
+	ranges := [3]cmapi_filter_resource.TimeRange{}
+	for i, prefix := range resourceSeenParamPrefixes {
+		after, err := getTimeQueryParam(c, prefix+"After")
+		if err != nil {
+			return cmapi_filter_resource.ResourceSeenFilter{}, err
+		}
+		before, err := getTimeQueryParam(c, prefix+"Before")
+		if err != nil {
+			return cmapi_filter_resource.ResourceSeenFilter{}, err
+		}
+		ranges[i] = cmapi_filter_resource.TimeRange{After: after, Before: before}
+	}
+	return cmapi_filter_resource.NewResourceSeenFilter(ranges[0], ranges[1], ranges[2]), nil
+}
+
+// getResourceSortParams retrieves the sort key and whether the order is descending from the query parameters 'sort' and 'order'.
+// The default is the ascending order of deviceID.
+func getResourceSortParams(c *gin.Context) (string, bool, error) {
+	sortKey := c.DefaultQuery("sort", cmapi_repository_resource.SortKeyDeviceID)
+	if !slices.Contains(cmapi_repository_resource.SortKeyList, sortKey) {
+		return "", false, fmt.Errorf("query parameter value error. name(sort) value(%v)", sortKey)
+	}
+
+	switch order := c.DefaultQuery("order", "asc"); order {
+	case "asc":
+		return sortKey, false, nil
+	case "desc":
+		return sortKey, true, nil
+	default:
+		return "", false, fmt.Errorf("query parameter value error. name(order) value(%v)", order)
+	}
+}
+
+// rejectResourceSeenParams returns an error if the query parameters to filter and sort the resources by the times are specified.
+// It is used by the lists that do not support them, so that the parameters are not silently ignored.
+func rejectResourceSeenParams(c *gin.Context) error {
+	names := []string{"sort", "order"}
+	for _, prefix := range resourceSeenParamPrefixes {
+		names = append(names, prefix+"After", prefix+"Before")
+	}
+	for _, name := range names {
+		if _, ok := c.GetQuery(name); ok {
+			return fmt.Errorf("query parameter not supported. name(%s)", name)
+		}
+	}
+	return nil
+}
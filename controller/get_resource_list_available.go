@@ -35,6 +35,9 @@ import (
 // and the list of resources themselves. It attempts to marshal this response object into JSON. If marshaling fails, it logs the error and returns an error response.
 // Otherwise, it logs the marshaled JSON for debugging purposes. Finally, it logs the successful completion of the request and returns the marshaled JSON as a response
 // with a 200 OK status.
+//
+// The resources can be filtered by the times and sorted with the same query parameters as GetResourceList.
+// Without the parameter 'sort', the list is sorted by resourceType and deviceID.
 func GetAvailableResourceList(c *gin.Context) {
 	common.Log.Info(fmt.Sprintf("%s[%s] start.", c.Request.URL.Path, c.Request.Method))
 	funcName := "GetAvailableResourceList"
@@ -42,8 +45,29 @@ func GetAvailableResourceList(c *gin.Context) {
 	query := c.Request.URL.Query()
 	resourceGroupIDs := query["resourceGroupID"]
 
+	// Retrieve query parameters: firstSeenAfter, firstSeenBefore, lastSeenAfter, lastSeenBefore, notDetectedSinceAfter, notDetectedSinceBefore
+	seenFilter, err := getResourceSeenFilter(c)
+	if err != nil {
+		errorDatial := "getResourceSeenFilter error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
+	// Retrieve query parameters: sort, order
+	sortKey, descending, err := getResourceSortParams(c)
+	if err != nil {
+		errorDatial := "getResourceSortParams error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
 	filter := cmapi_filter_resource.NewResourceAvailableFilter(resourceGroupIDs)
+	filter.Seen = seenFilter
 	repository := cmapi_repository_resource.NewResourceListRepository(true)
+	repository.SortKey = sortKey
+	repository.Descending = descending
 
 	resources, err := cmapi_repository.RelayFindList(&repository, filter)
 	if err != nil {
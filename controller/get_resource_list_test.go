@@ -15,9 +15,76 @@
 package controller
 
 import (
+	"reflect"
 	"testing"
+	"time"
+
+	cmapi_filter_resource "github.com/project-cdim/configuration-manager/filter/resource"
 )
 
 func TestGetResourceList(t *testing.T) {
 	t.Skip("not test")
 }
+
+func Test_getResourceSeenFilter(t *testing.T) {
+	c := setupTestGinContext("firstSeenAfter=2025-06-01T00:00:00Z&notDetectedSinceBefore=2025-06-10T00:00:00Z")
+	want := cmapi_filter_resource.ResourceSeenFilter{
+		FirstSeenAt:      cmapi_filter_resource.TimeRange{After: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+		NotDetectedSince: cmapi_filter_resource.TimeRange{Before: time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)},
+	}
+	got, err := getResourceSeenFilter(c)
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("getResourceSeenFilter() = %v, %v, want %v", got, err, want)
+	}
+
+	if _, err := getResourceSeenFilter(setupTestGinContext("lastSeenBefore=yesterday")); err == nil {
+		t.Errorf("getResourceSeenFilter() error = nil, want error")
+	}
+}
+
+func Test_getResourceSortParams(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		wantKey        string
+		wantDescending bool
+		wantErr        bool
+	}{
+		{"Normal case: The default is the ascending order of deviceID", "", "deviceID", false, false},
+		{"Normal case: The sort key and the order are specified", "sort=notDetectedSince&order=desc", "notDetectedSince", true, false},
+		{"Error case: The sort key is unknown", "sort=status", "", false, true},
+		{"Error case: The order is unknown", "sort=lastSeenAt&order=up", "", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotKey, gotDescending, err := getResourceSortParams(setupTestGinContext(tt.query))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getResourceSortParams() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotKey != tt.wantKey || gotDescending != tt.wantDescending {
+				t.Errorf("getResourceSortParams() = %v, %v, want %v, %v", gotKey, gotDescending, tt.wantKey, tt.wantDescending)
+			}
+		})
+	}
+}
+
+func Test_rejectResourceSeenParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		wantErr bool
+	}{
+		{"Normal case: No parameters of the times are specified", "detail=true", false},
+		{"Error case: A parameter of the time range is specified", "lastSeenBefore=2025-06-01T00:00:00Z", true},
+		{"Error case: The sort key is specified", "sort=deviceID", true},
+		{"Error case: The order is specified", "order=desc", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := rejectResourceSeenParams(setupTestGinContext(tt.query)); (err != nil) != tt.wantErr {
+				t.Errorf("rejectResourceSeenParams() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+	"github.com/project-cdim/configuration-manager/syncjob"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetSyncJob handles the request to fetch the specified sync job, registered by RegisterDevice with the 'async' query parameter.
+// The job has its status, one of queued, running, succeeded and failed, and the number of the devices processed so far.
+// Once the job is finished, the status code and the body of the response of the hardware sync are returned as well,
+// as the result if it succeeded, or as the error if it failed. The result of a dry run contains the plan.
+//
+// Responses:
+//   - 200 OK: The sync job.
+//   - 404 Not Found: The sync job does not exist.
+//   - 500 Internal Server Error: An error occurred while fetching the sync job from the database.
+func GetSyncJob(c *gin.Context) {
+	common.Log.Info(fmt.Sprintf("%s[%s] start.", c.Request.URL.Path, c.Request.Method))
+	funcName := "GetSyncJob"
+
+	id := c.Param("id")
+	cmdb := database.NewCmDb()
+	err := cmdb.CmDbConnection()
+	if err != nil {
+		errorDatial := "CmDbConnection error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+	defer cmdb.CmDbDisconnection()
+
+	job, exists, err := syncjob.Find(cmdb.Db, id)
+	if err != nil {
+		errorDatial := "syncjob.Find error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+	if !exists {
+		errorDatial := "The target sync job did not exist"
+		common.Log.Warn(fmt.Sprintf("%s %s [id : %v]", funcName, errorDatial, id))
+		c.JSON(http.StatusNotFound, convertErrorResponse(http.StatusNotFound, errorDatial))
+		return
+	}
+
+	res := job.ToObject()
+	logResponseBody(res)
+	common.Log.Info(fmt.Sprintf("%s[%s] completed successfully.", c.Request.URL.Path, c.Request.Method))
+
+	c.JSON(http.StatusOK, res)
+}
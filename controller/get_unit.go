@@ -0,0 +1,69 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/project-cdim/configuration-manager/common"
+	cmapi_filter "github.com/project-cdim/configuration-manager/filter"
+	cmapi_repository "github.com/project-cdim/configuration-manager/repository"
+	cmapi_repository_unit "github.com/project-cdim/configuration-manager/repository/unit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetUnit retrieves a specific unit by its ID with its annotation and the resources contained in it.
+// The ID of a unit is the deviceID of the resource that represents the unit. The 'detail' query parameter
+// determines the level of detail of the resources in the same way as GetChassis.
+// If the unit is not found, it returns a 404 Not Found response.
+func GetUnit(c *gin.Context) {
+	common.Log.Info(fmt.Sprintf("%s[%s] start.", c.Request.URL.Path, c.Request.Method))
+	funcName := "GetUnit"
+
+	id := c.Param("id")
+	// Retrieve query parameter: detail
+	detail, err := getBoolQueryParam(c, "detail")
+	if err != nil {
+		errorDatial := "getBoolQueryParam error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
+	filter := cmapi_filter.NewNoFilter()
+	repository := cmapi_repository_unit.NewUnitRepository(id, detail)
+	res, err := cmapi_repository.RelayFind(&repository, filter)
+	if err != nil {
+		// Outputs JSON containing the error code and error message to the ResponseBody and terminates
+		errorDatial := "RelayFind error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	if res == nil {
+		errorDatial := "No search results"
+		common.Log.Warn(fmt.Sprintf("%s %s [id : %v]", funcName, errorDatial, id), false)
+		c.JSON(http.StatusNotFound, convertErrorResponse(http.StatusNotFound, errorDatial))
+		return
+	}
+
+	logResponseBody(res)
+	common.Log.Info(fmt.Sprintf("%s[%s] completed successfully.", c.Request.URL.Path, c.Request.Method))
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+	"github.com/project-cdim/configuration-manager/webhook"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetWebhookDeadLetterList handles the request to fetch the dead-letter list, the deliveries given up after the maximum number of attempts
+// over all the webhooks, newest first.
+//
+// Query Parameters:
+//   - limit: The maximum number of deliveries to return, from 1 to 1000. The default is 100.
+//
+// Responses:
+//   - 200 OK: The count and the list of the deliveries.
+//   - 400 Bad Request: A query parameter is invalid.
+//   - 500 Internal Server Error: An error occurred while fetching the deliveries from the database.
+func GetWebhookDeadLetterList(c *gin.Context) {
+	common.Log.Info(fmt.Sprintf("%s[%s] start.", c.Request.URL.Path, c.Request.Method))
+	funcName := "GetWebhookDeadLetterList"
+
+	limit, err := getIntQueryParam(c, "limit", defaultDeliveryListLimit)
+	if err != nil || limit < 1 || limit > maxDeliveryListLimit {
+		errorDatial := "limit query parameter error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, c.Query("limit")), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
+	cmdb := database.NewCmDb()
+	err = cmdb.CmDbConnection()
+	if err != nil {
+		errorDatial := "CmDbConnection error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+	defer cmdb.CmDbDisconnection()
+
+	deliveries, err := webhook.ListDeadLetters(cmdb.Db, int(limit))
+	if err != nil {
+		errorDatial := "webhook.ListDeadLetters error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	res := newDeliveryListResponse(deliveries)
+	logResponseBody(res)
+	common.Log.Info(fmt.Sprintf("%s[%s] completed successfully.", c.Request.URL.Path, c.Request.Method))
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,119 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+	"github.com/project-cdim/configuration-manager/webhook"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultDeliveryListLimit = 100
+	maxDeliveryListLimit     = 1000
+)
+
+// Statuses accepted by the status query parameter of the delivery list
+var deliveryStatuses = []string{webhook.DeliveryStatusPending, webhook.DeliveryStatusDelivered, webhook.DeliveryStatusDeadLetter}
+
+// GetWebhookDeliveryList handles the request to fetch the deliveries of the events to the specified webhook, newest first.
+// Each delivery has its status, the number of attempts, and the status code and the error of the last attempt.
+//
+// Query Parameters:
+//   - status: Only the deliveries in this status are returned. One of pending, delivered and deadLetter.
+//   - limit: The maximum number of deliveries to return, from 1 to 1000. The default is 100.
+//
+// Responses:
+//   - 200 OK: The count and the list of the deliveries.
+//   - 400 Bad Request: A query parameter is invalid.
+//   - 404 Not Found: The webhook does not exist.
+//   - 500 Internal Server Error: An error occurred while fetching the deliveries from the database.
+func GetWebhookDeliveryList(c *gin.Context) {
+	common.Log.Info(fmt.Sprintf("%s[%s] start.", c.Request.URL.Path, c.Request.Method))
+	funcName := "GetWebhookDeliveryList"
+
+	status := c.Query("status")
+	if len(status) > 0 && !slices.Contains(deliveryStatuses, status) {
+		errorDatial := "status query parameter error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, status), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
+	limit, err := getIntQueryParam(c, "limit", defaultDeliveryListLimit)
+	if err != nil || limit < 1 || limit > maxDeliveryListLimit {
+		errorDatial := "limit query parameter error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, c.Query("limit")), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
+	id := c.Param("id")
+	cmdb := database.NewCmDb()
+	err = cmdb.CmDbConnection()
+	if err != nil {
+		errorDatial := "CmDbConnection error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+	defer cmdb.CmDbDisconnection()
+
+	_, exists, err := webhook.Find(cmdb.Db, id)
+	if err != nil {
+		errorDatial := "webhook.Find error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+	if !exists {
+		errorDatial := "The target webhook did not exist"
+		common.Log.Warn(fmt.Sprintf("%s %s [id : %v]", funcName, errorDatial, id))
+		c.JSON(http.StatusNotFound, convertErrorResponse(http.StatusNotFound, errorDatial))
+		return
+	}
+
+	deliveries, err := webhook.ListDeliveries(cmdb.Db, id, status, int(limit))
+	if err != nil {
+		errorDatial := "webhook.ListDeliveries error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	res := newDeliveryListResponse(deliveries)
+	logResponseBody(res)
+	common.Log.Info(fmt.Sprintf("%s[%s] completed successfully.", c.Request.URL.Path, c.Request.Method))
+
+	c.JSON(http.StatusOK, res)
+}
+
+// newDeliveryListResponse creates the response listing the deliveries.
+func newDeliveryListResponse(deliveries []webhook.Delivery) gin.H {
+	objects := []map[string]any{}
+	for _, delivery := range deliveries {
+		objects = append(objects, delivery.ToObject())
+	}
+	return gin.H{
+		"count":      len(objects),
+		"deliveries": objects,
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/project-cdim/configuration-manager/webhook"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGetWebhookDeliveryList(t *testing.T) {
+	t.Skip("not test")
+}
+
+func Test_newDeliveryListResponse(t *testing.T) {
+	delivery := webhook.Delivery{ID: "delivery001", WebhookID: "hook001", EventID: "event001", EventType: "resource.created", Status: "pending",
+		NextAttemptAt: "2025-01-01T00:00:00Z", CreatedAt: "2025-01-01T00:00:00Z"}
+	tests := []struct {
+		name       string
+		deliveries []webhook.Delivery
+		want       gin.H
+	}{
+		{
+			name:       "Normal case: Deliveries",
+			deliveries: []webhook.Delivery{delivery},
+			want:       gin.H{"count": 1, "deliveries": []map[string]any{delivery.ToObject()}},
+		},
+		{
+			name:       "Normal case: No delivery",
+			deliveries: []webhook.Delivery{},
+			want:       gin.H{"count": 0, "deliveries": []map[string]any{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newDeliveryListResponse(tt.deliveries); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newDeliveryListResponse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
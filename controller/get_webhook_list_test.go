@@ -0,0 +1,55 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/project-cdim/configuration-manager/webhook"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGetWebhookList(t *testing.T) {
+	t.Skip("not test")
+}
+
+func Test_newWebhookListResponse(t *testing.T) {
+	hook := webhook.Webhook{ID: "hook001", URL: "http://localhost/hook", EventTypes: []string{}, Secret: "s3cr3t", CreatedAt: "2025-01-01T00:00:00Z"}
+	tests := []struct {
+		name  string
+		hooks []webhook.Webhook
+		want  gin.H
+	}{
+		{
+			name:  "Normal case: The secrets are not included",
+			hooks: []webhook.Webhook{hook},
+			want:  gin.H{"count": 1, "webhooks": []map[string]any{{"id": "hook001", "url": "http://localhost/hook", "eventTypes": []string{}, "createdAt": "2025-01-01T00:00:00Z"}}},
+		},
+		{
+			name:  "Normal case: No webhook",
+			hooks: []webhook.Webhook{},
+			want:  gin.H{"count": 0, "webhooks": []map[string]any{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newWebhookListResponse(tt.hooks); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newWebhookListResponse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
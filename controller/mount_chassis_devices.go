@@ -0,0 +1,88 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/project-cdim/configuration-manager/common"
+	cmapi_model_chassis "github.com/project-cdim/configuration-manager/model/chassis"
+	cmapi_repository "github.com/project-cdim/configuration-manager/repository"
+	cmapi_repository_chassis "github.com/project-cdim/configuration-manager/repository/chassis"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MountChassisDevices is a handler function to mount resources and CXL switches in a chassis.
+// The request body specifies "mounts", an array of elements each having either "deviceID" (a resource)
+// or "cxlSwitchID" (a CXL switch) and an optional "slot". A device mounted in another chassis is moved.
+// If any of the devices does not exist, none of the devices are mounted.
+//
+// Parameters:
+//   - c: gin.Context - Request context
+//
+// Response:
+//   - On success: 200 status code with the mounted devices
+//   - On validation error, if any of the devices does not exist: 400 status code
+//   - If the chassis does not exist: 404 status code
+//   - On server error: 500 status code
+func MountChassisDevices(c *gin.Context) {
+	common.Log.Info(fmt.Sprintf("%s[%s] start.", c.Request.URL.Path, c.Request.Method))
+	funcName := "MountChassisDevices"
+
+	id := c.Param("id")
+	properties, err := unmarshalRequestBodyForMap(c)
+	if err != nil {
+		errorDatial := "unmarshalRequestBodyForMap error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
+	// Validation of the requestBody
+	if !cmapi_model_chassis.ValidateMountProperty(properties) {
+		errorDatial := "Validation error"
+		common.Log.Error(fmt.Sprintf("%s %s", funcName, errorDatial), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
+	if !existsChassis(c, funcName, id) {
+		return
+	}
+
+	mountList := cmapi_model_chassis.NewMountList(id, properties)
+	repository := cmapi_repository_chassis.NewMountChassisRepository()
+	res, err := cmapi_repository.RelaySet(&repository, &mountList)
+	if errors.Is(err, cmapi_repository.ErrNotFound) {
+		errorDatial := "The specified device did not exist"
+		common.Log.Warn(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+	if err != nil {
+		errorDatial := "RelaySet error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	logResponseBody(res)
+	common.Log.Info(fmt.Sprintf("%s[%s] completed successfully.", c.Request.URL.Path, c.Request.Method))
+
+	c.JSON(http.StatusOK, res)
+}
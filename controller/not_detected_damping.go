@@ -0,0 +1,122 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+)
+
+// Environment variables to configure the damping of the transitions to the NotDetected state
+const (
+	// Number of consecutive hardware syncs that must miss a resource before it is put in the NotDetected state
+	envNotDetectedMissedSyncs = "CM_NOT_DETECTED_MISSED_SYNCS"
+	// Period, such as "10m", that must pass after a hardware sync first missed a resource before it is put in the NotDetected state
+	envNotDetectedGracePeriod = "CM_NOT_DETECTED_GRACE_PERIOD"
+)
+
+// Structure for storing the damping of the transitions to the NotDetected state.
+// A resource missed by a hardware sync is put in the NotDetected state only when both the number of consecutive missed
+// hardware syncs and the time since the first missed hardware sync reach the thresholds. Until then the miss is pending,
+// and the resource stays detected. The default is to put the resource in the NotDetected state at the first miss.
+type notDetectedDamping struct {
+	missedSyncs int64
+	gracePeriod time.Duration
+}
+
+// Damping of the transitions to the NotDetected state, loaded from the environment variables at startup
+var notDetectedDampingSetting = loadNotDetectedDamping(os.Getenv)
+
+// loadNotDetectedDamping loads the damping of the transitions to the NotDetected state using getenv.
+// A number of hardware syncs that is not a positive integer, or a period that is not a non-negative duration, is ignored.
+func loadNotDetectedDamping(getenv func(string) string) notDetectedDamping {
+	damping := notDetectedDamping{missedSyncs: 1}
+	if missedSyncs, err := strconv.ParseInt(strings.TrimSpace(getenv(envNotDetectedMissedSyncs)), 10, 64); err == nil && missedSyncs > 0 {
+		damping.missedSyncs = missedSyncs
+	}
+	if gracePeriod, err := time.ParseDuration(strings.TrimSpace(getenv(envNotDetectedGracePeriod))); err == nil && gracePeriod >= 0 {
+		damping.gracePeriod = gracePeriod
+	}
+	return damping
+}
+
+// cypher query to record the pending misses of the resource.
+// They are removed when the resource is reported again, because the hardware sync replaces the properties of the resource.
+const cypherSetPendingMisses = `
+	MATCH (vrs:%s {deviceID: '%s'})
+	SET vrs.pendingMisses = %d, vrs.pendingMissSince = "%s"
+`
+
+// cypher query to remove the pending misses of the resource put in the NotDetected state
+const cypherRemovePendingMisses = `
+	MATCH (vrs:%s {deviceID: '%s'})
+	REMOVE vrs.pendingMisses, vrs.pendingMissSince
+`
+
+// countMiss counts a hardware sync at now that missed the detected resource.
+// It returns the number of consecutive missed hardware syncs, the time of the first of them in ISO 8601,
+// and whether the miss is held pending. The first miss is at now if the resource has no pending misses.
+func (d notDetectedDamping) countMiss(dbExistingResource existingResource, now time.Time) (int64, string, bool) {
+	misses := dbExistingResource.pendingMisses + 1
+	since := dbExistingResource.pendingMissSince
+	sinceTime, err := time.Parse(time.RFC3339, since)
+	if err != nil {
+		since = now.UTC().Format(time.RFC3339)
+		sinceTime = now
+	}
+	return misses, since, misses < d.missedSyncs || now.Sub(sinceTime) < d.gracePeriod
+}
+
+// missResource counts a hardware sync that missed the detected resource. The miss is recorded as pending if the damping
+// holds the resource detected. Otherwise the resource is put in the NotDetected state since the first missed hardware sync.
+// It returns whether the resource was put in the NotDetected state.
+func (d notDetectedDamping) missResource(tx *sql.Tx, deviceID string, dbExistingResource existingResource, now time.Time) (bool, error) {
+	label, err := dbExistingResource.resourceType.convertToDBLabel()
+	if err != nil {
+		return false, err
+	}
+
+	misses, since, pending := d.countMiss(dbExistingResource, now)
+	if pending {
+		common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s, param3: %d, param4: %s", cypherSetPendingMisses, label, deviceID, misses, since))
+		_, err = execCypher(tx, database.GRAPH_NAME, mergeColumnCount, cypherSetPendingMisses, label, deviceID, misses, since)
+		if err != nil {
+			common.Log.Error(err.Error())
+			return false, err
+		}
+		return false, nil
+	}
+
+	err = markNotDetectedResource(tx, deviceID, dbExistingResource.resourceType, since, misses)
+	if err != nil {
+		return false, err
+	}
+	if dbExistingResource.pendingMisses > 0 {
+		common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s", cypherRemovePendingMisses, label, deviceID))
+		_, err = execCypher(tx, database.GRAPH_NAME, deleteColumnCount, cypherRemovePendingMisses, label, deviceID)
+		if err != nil {
+			common.Log.Error(err.Error())
+			return false, err
+		}
+	}
+	return true, nil
+}
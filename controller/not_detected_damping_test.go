@@ -0,0 +1,140 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_loadNotDetectedDamping(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want notDetectedDamping
+	}{
+		{
+			name: "Normal case: The first miss puts the resource in the NotDetected state by default",
+			env:  map[string]string{},
+			want: notDetectedDamping{missedSyncs: 1},
+		},
+		{
+			name: "Normal case: Both thresholds are configured",
+			env: map[string]string{
+				"CM_NOT_DETECTED_MISSED_SYNCS": " 3 ",
+				"CM_NOT_DETECTED_GRACE_PERIOD": "10m",
+			},
+			want: notDetectedDamping{missedSyncs: 3, gracePeriod: 10 * time.Minute},
+		},
+		{
+			name: "Normal case: Invalid values are ignored",
+			env: map[string]string{
+				"CM_NOT_DETECTED_MISSED_SYNCS": "0",
+				"CM_NOT_DETECTED_GRACE_PERIOD": "-1h",
+			},
+			want: notDetectedDamping{missedSyncs: 1},
+		},
+		{
+			name: "Normal case: Values that cannot be parsed are ignored",
+			env: map[string]string{
+				"CM_NOT_DETECTED_MISSED_SYNCS": "three",
+				"CM_NOT_DETECTED_GRACE_PERIOD": "10",
+			},
+			want: notDetectedDamping{missedSyncs: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := loadNotDetectedDamping(func(key string) string { return tt.env[key] })
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadNotDetectedDamping() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_notDetectedDamping_countMiss(t *testing.T) {
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		damping     notDetectedDamping
+		existing    existingResource
+		wantMisses  int64
+		wantSince   string
+		wantPending bool
+	}{
+		{
+			name:        "Normal case: The first miss is not held by default",
+			damping:     notDetectedDamping{missedSyncs: 1},
+			existing:    existingResource{},
+			wantMisses:  1,
+			wantSince:   "2025-06-30T12:00:00Z",
+			wantPending: false,
+		},
+		{
+			name:        "Normal case: The misses are held until the number of missed hardware syncs",
+			damping:     notDetectedDamping{missedSyncs: 3},
+			existing:    existingResource{pendingMisses: 1, pendingMissSince: "2025-06-30T11:00:00Z"},
+			wantMisses:  2,
+			wantSince:   "2025-06-30T11:00:00Z",
+			wantPending: true,
+		},
+		{
+			name:        "Normal case: The misses are not held after the number of missed hardware syncs",
+			damping:     notDetectedDamping{missedSyncs: 3},
+			existing:    existingResource{pendingMisses: 2, pendingMissSince: "2025-06-30T11:00:00Z"},
+			wantMisses:  3,
+			wantSince:   "2025-06-30T11:00:00Z",
+			wantPending: false,
+		},
+		{
+			name:        "Normal case: The misses are held during the grace period",
+			damping:     notDetectedDamping{missedSyncs: 1, gracePeriod: 2 * time.Hour},
+			existing:    existingResource{pendingMisses: 1, pendingMissSince: "2025-06-30T11:00:00Z"},
+			wantMisses:  2,
+			wantSince:   "2025-06-30T11:00:00Z",
+			wantPending: true,
+		},
+		{
+			name:        "Normal case: The misses are not held after the grace period",
+			damping:     notDetectedDamping{missedSyncs: 1, gracePeriod: time.Hour},
+			existing:    existingResource{pendingMisses: 1, pendingMissSince: "2025-06-30T11:00:00Z"},
+			wantMisses:  2,
+			wantSince:   "2025-06-30T11:00:00Z",
+			wantPending: false,
+		},
+		{
+			name:        "Normal case: The first miss is now if the time of the pending misses is unknown",
+			damping:     notDetectedDamping{missedSyncs: 1, gracePeriod: time.Hour},
+			existing:    existingResource{pendingMisses: 1, pendingMissSince: "unknown"},
+			wantMisses:  2,
+			wantSince:   "2025-06-30T12:00:00Z",
+			wantPending: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotMisses, gotSince, gotPending := tt.damping.countMiss(tt.existing, now)
+			if gotMisses != tt.wantMisses || gotSince != tt.wantSince || gotPending != tt.wantPending {
+				t.Errorf("countMiss() = %v, %v, %v, want %v, %v, %v", gotMisses, gotSince, gotPending, tt.wantMisses, tt.wantSince, tt.wantPending)
+			}
+		})
+	}
+}
+
+func Test_notDetectedDamping_missResource(t *testing.T) {
+	t.Skip("not test")
+}
@@ -0,0 +1,247 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+	cmapi_model "github.com/project-cdim/configuration-manager/model"
+	cmapi_model_resource "github.com/project-cdim/configuration-manager/model/resource"
+
+	"github.com/apache/age/drivers/golang/age"
+)
+
+// cypher query to merge the QuarantinedDevice Vertex holding a device of an unsupported resource type with its payload as reported.
+// The QuarantinedDevice Vertex has no Edges, so the device is excluded from the resources, nodes and CXL switches.
+const cypherMergeQuarantinedDevice = `
+	MERGE (vqd:QuarantinedDevice {deviceID: '%s'})
+	WITH vqd, coalesce(vqd.firstSeenAt, "%s") AS firstSeenAt
+	SET vqd = %s
+	SET vqd.firstSeenAt = firstSeenAt, vqd.lastSeenAt = "%s"
+`
+
+// cypher query to fetch the QuarantinedDevice Vertices in ascending order of the device ID
+const cypherSelectQuarantinedDeviceList = `
+	MATCH (vqd:QuarantinedDevice)
+	WITH vqd
+	ORDER BY vqd.deviceID
+	RETURN vqd
+`
+const selectQuarantinedDeviceListColumnCount = 1
+
+// cypher query to delete the QuarantinedDevice Vertex
+const cypherDeleteQuarantinedDevice = `
+	MATCH (vqd:QuarantinedDevice {deviceID: '%s'})
+	DELETE vqd
+`
+
+// Names of the properties of the QuarantinedDevice Vertex
+const (
+	quarantinedDeviceIDKey      = "deviceID"
+	quarantinedDeviceTypeKey    = "type"
+	quarantinedDevicePayloadKey = "payload"
+)
+
+// Structure for storing a device of an unsupported resource type quarantined by the hardware sync
+type quarantinedDevice struct {
+	deviceID     string
+	resourceType hwResourceType
+	payload      map[string]any // The device as last reported by the hardware sync
+	firstSeenAt  string         // Time when the device was first quarantined in ISO 8601
+	lastSeenAt   string         // Time when the device was last reported in ISO 8601
+}
+
+// toObject converts the quarantined device into the element of the response of GetQuarantinedDeviceList.
+func (d quarantinedDevice) toObject() map[string]any {
+	return map[string]any{
+		"deviceID":    d.deviceID,
+		"type":        string(d.resourceType),
+		"payload":     d.payload,
+		"firstSeenAt": d.firstSeenAt,
+		"lastSeenAt":  d.lastSeenAt,
+	}
+}
+
+// getQuarantinedDeviceList retrieves the quarantined devices in ascending order of the device ID.
+func getQuarantinedDeviceList(tx *sql.Tx) ([]quarantinedDevice, error) {
+	common.Log.Debug(fmt.Sprintf("query: %s", cypherSelectQuarantinedDeviceList))
+	cypherCursor, err := execCypher(tx, database.GRAPH_NAME, selectQuarantinedDeviceListColumnCount, cypherSelectQuarantinedDeviceList)
+	if err != nil {
+		common.Log.Error(err.Error())
+		return nil, err
+	}
+	defer cypherCursor.Close()
+
+	res := []quarantinedDevice{}
+	for cypherCursor.Next() {
+		row, err := cypherCursor.GetRow()
+		if err != nil {
+			common.Log.Error(err.Error())
+			return nil, err
+		}
+		device, err := composeQuarantinedDevice(row[0].(*age.Vertex).Props())
+		if err != nil {
+			common.Log.Error(err.Error())
+			return nil, err
+		}
+		res = append(res, device)
+	}
+	return res, nil
+}
+
+// composeQuarantinedDevice assembles the quarantined device from the properties of the QuarantinedDevice Vertex.
+// The strings in the payload are unquoted so that the payload is the same as reported.
+func composeQuarantinedDevice(props map[string]any) (quarantinedDevice, error) {
+	payload, err := common.UnquoteRecursive(props[quarantinedDevicePayloadKey])
+	if err != nil {
+		return quarantinedDevice{}, err
+	}
+	payloadMap, ok := payload.(map[string]any)
+	if !ok {
+		payloadMap = map[string]any{}
+	}
+	deviceID, _ := props[quarantinedDeviceIDKey].(string)
+	resourceType, _ := props[quarantinedDeviceTypeKey].(string)
+	firstSeenAt, _ := props[cmapi_model_resource.FirstSeenAtKey].(string)
+	lastSeenAt, _ := props[cmapi_model_resource.LastSeenAtKey].(string)
+	return quarantinedDevice{
+		deviceID:     deviceID,
+		resourceType: hwResourceType(resourceType),
+		payload:      payloadMap,
+		firstSeenAt:  firstSeenAt,
+		lastSeenAt:   lastSeenAt,
+	}, nil
+}
+
+// mergeQuarantinedDevice quarantines the device in the request, replacing its payload if it is already quarantined.
+func mergeQuarantinedDevice(tx *sql.Tx, requestResource map[string]any) error {
+	deviceID := requestResource["deviceID"].(string)
+	property, err := common.Map2CypherProperty(map[string]any{
+		quarantinedDeviceIDKey:      deviceID,
+		quarantinedDeviceTypeKey:    requestResource["type"],
+		quarantinedDevicePayloadKey: requestResource,
+	})
+	if err != nil {
+		return err
+	}
+
+	now := cmapi_model.CurrentTimeISO8601()
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s, param3: %s, param4: %s", cypherMergeQuarantinedDevice, deviceID, now, property, now))
+	_, err = execCypher(tx, database.GRAPH_NAME, mergeColumnCount, cypherMergeQuarantinedDevice, deviceID, now, property, now)
+	if err != nil {
+		common.Log.Error(err.Error())
+		return err
+	}
+	return nil
+}
+
+// deleteQuarantinedDevice deletes the quarantined device.
+func deleteQuarantinedDevice(tx *sql.Tx, deviceID string) error {
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s", cypherDeleteQuarantinedDevice, deviceID))
+	_, err := execCypher(tx, database.GRAPH_NAME, deleteColumnCount, cypherDeleteQuarantinedDevice, deviceID)
+	if err != nil {
+		common.Log.Error(err.Error())
+		return err
+	}
+	return nil
+}
+
+// Structure for storing the plan of the quarantine made by the hardware sync
+type quarantinePlan struct {
+	supported  []map[string]any // The devices to register as resources, including the promoted devices not in the request
+	quarantine []map[string]any // The devices in the request to quarantine
+	promote    []string         // The device IDs of the quarantined devices whose resource types are supported
+}
+
+// newQuarantinePlan decides which devices in the request to quarantine and which quarantined devices to promote.
+// The devices of the resource types that are not supported are quarantined. The quarantined devices whose resource types
+// have become supported are promoted to resources. A promoted device not in the request is registered with its payload,
+// which is the last report of the device.
+func newQuarantinePlan(requestResources []map[string]any, quarantined []quarantinedDevice) quarantinePlan {
+	plan := quarantinePlan{supported: []map[string]any{}, quarantine: []map[string]any{}, promote: []string{}}
+	requestedDeviceIDs := map[string]bool{}
+	for _, requestResource := range requestResources {
+		requestedDeviceIDs[requestResource["deviceID"].(string)] = true
+		if _, err := hwResourceType(requestResource["type"].(string)).convertToDBLabel(); err != nil {
+			plan.quarantine = append(plan.quarantine, requestResource)
+		} else {
+			plan.supported = append(plan.supported, requestResource)
+		}
+	}
+
+	quarantineDeviceIDs := map[string]bool{}
+	for _, requestResource := range plan.quarantine {
+		quarantineDeviceIDs[requestResource["deviceID"].(string)] = true
+	}
+	promote, promoted := newPromotion(quarantined, requestedDeviceIDs, quarantineDeviceIDs)
+	plan.promote = promote
+	plan.supported = append(plan.supported, promoted...)
+	return plan
+}
+
+// newPromotion decides which quarantined devices to promote, that is, the quarantined devices whose resource types are supported.
+// The devices quarantined again by the request, that is, reported with an unsupported resource type, are not promoted.
+// It returns the device IDs of the devices to promote, and the payloads of those not in the request to register as resources.
+func newPromotion(quarantined []quarantinedDevice, requestedDeviceIDs map[string]bool, quarantineDeviceIDs map[string]bool) ([]string, []map[string]any) {
+	promote := []string{}
+	promoted := []map[string]any{}
+	for _, device := range quarantined {
+		if _, err := device.resourceType.convertToDBLabel(); err != nil || quarantineDeviceIDs[device.deviceID] {
+			continue
+		}
+		promote = append(promote, device.deviceID)
+		if !requestedDeviceIDs[device.deviceID] && len(device.payload) > 0 {
+			promoted = append(promoted, device.payload)
+		}
+	}
+	return promote, promoted
+}
+
+// quarantineDevices quarantines the devices of the resource types that are not supported in the request, and promotes the
+// quarantined devices whose resource types have become supported (see newQuarantinePlan).
+// It returns the plan, whose supported devices are to be registered as resources.
+func quarantineDevices(tx *sql.Tx, requestResources *resourceRegister) (quarantinePlan, error) {
+	quarantined, err := getQuarantinedDeviceList(tx)
+	if err != nil {
+		return quarantinePlan{}, err
+	}
+
+	plan := newQuarantinePlan(requestResources.resource, quarantined)
+	err = applyQuarantinePlan(tx, plan)
+	if err != nil {
+		return quarantinePlan{}, err
+	}
+	return plan, nil
+}
+
+// applyQuarantinePlan quarantines the devices and deletes the quarantined devices to promote in the plan.
+func applyQuarantinePlan(tx *sql.Tx, plan quarantinePlan) error {
+	for _, requestResource := range plan.quarantine {
+		err := mergeQuarantinedDevice(tx, requestResource)
+		if err != nil {
+			return err
+		}
+	}
+	for _, deviceID := range plan.promote {
+		err := deleteQuarantinedDevice(tx, deviceID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_quarantinedDevice_toObject(t *testing.T) {
+	device := quarantinedDevice{
+		deviceID:     "dpu01",
+		resourceType: "DPU",
+		payload:      map[string]any{"deviceID": "dpu01", "type": "DPU"},
+		firstSeenAt:  "2025-06-01T00:00:00Z",
+		lastSeenAt:   "2025-06-02T00:00:00Z",
+	}
+	want := map[string]any{
+		"deviceID":    "dpu01",
+		"type":        "DPU",
+		"payload":     map[string]any{"deviceID": "dpu01", "type": "DPU"},
+		"firstSeenAt": "2025-06-01T00:00:00Z",
+		"lastSeenAt":  "2025-06-02T00:00:00Z",
+	}
+	if got := device.toObject(); !reflect.DeepEqual(got, want) {
+		t.Errorf("quarantinedDevice.toObject() = %v, want %v", got, want)
+	}
+}
+
+func Test_getQuarantinedDeviceList(t *testing.T) {
+	t.Skip("not test")
+}
+
+func Test_composeQuarantinedDevice(t *testing.T) {
+	tests := []struct {
+		name    string
+		props   map[string]any
+		want    quarantinedDevice
+		wantErr bool
+	}{
+		{
+			name: "Normal case: The strings in the payload are unquoted",
+			props: map[string]any{
+				"deviceID":    "dpu01",
+				"type":        "DPU",
+				"payload":     map[string]any{"deviceID": "dpu01", "type": "DPU", "model": `DPU \"X\"`, "links": []any{map[string]any{"deviceID": "cpu01"}}},
+				"firstSeenAt": "2025-06-01T00:00:00Z",
+				"lastSeenAt":  "2025-06-02T00:00:00Z",
+			},
+			want: quarantinedDevice{
+				deviceID:     "dpu01",
+				resourceType: "DPU",
+				payload:      map[string]any{"deviceID": "dpu01", "type": "DPU", "model": `DPU "X"`, "links": []any{map[string]any{"deviceID": "cpu01"}}},
+				firstSeenAt:  "2025-06-01T00:00:00Z",
+				lastSeenAt:   "2025-06-02T00:00:00Z",
+			},
+		},
+		{
+			name:  "Normal case: The device without the payload",
+			props: map[string]any{"deviceID": "dpu01", "type": "DPU"},
+			want:  quarantinedDevice{deviceID: "dpu01", resourceType: "DPU", payload: map[string]any{}},
+		},
+		{
+			name:    "Error case: The payload cannot be unquoted",
+			props:   map[string]any{"deviceID": "dpu01", "type": "DPU", "payload": map[string]any{"deviceID": `dpu01\`}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := composeQuarantinedDevice(tt.props)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("composeQuarantinedDevice() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("composeQuarantinedDevice() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_mergeQuarantinedDevice(t *testing.T) {
+	t.Skip("not test")
+}
+
+func Test_deleteQuarantinedDevice(t *testing.T) {
+	t.Skip("not test")
+}
+
+func Test_newQuarantinePlan(t *testing.T) {
+	cpu01 := map[string]any{"deviceID": "cpu01", "type": CPU}
+	dpu01 := map[string]any{"deviceID": "dpu01", "type": "DPU"}
+	gpu01 := map[string]any{"deviceID": "gpu01", "type": GPU}
+	tests := []struct {
+		name             string
+		requestResources []map[string]any
+		quarantined      []quarantinedDevice
+		want             quarantinePlan
+	}{
+		{
+			name:             "Normal case: The devices of the unsupported resource types are quarantined",
+			requestResources: []map[string]any{cpu01, dpu01},
+			want: quarantinePlan{
+				supported:  []map[string]any{cpu01},
+				quarantine: []map[string]any{dpu01},
+				promote:    []string{},
+			},
+		},
+		{
+			name:             "Normal case: The quarantined devices still unsupported are kept",
+			requestResources: []map[string]any{dpu01},
+			quarantined:      []quarantinedDevice{{deviceID: "dpu01", resourceType: "DPU", payload: dpu01}},
+			want: quarantinePlan{
+				supported:  []map[string]any{},
+				quarantine: []map[string]any{dpu01},
+				promote:    []string{},
+			},
+		},
+		{
+			name:             "Normal case: The quarantined device in the request is promoted with the request",
+			requestResources: []map[string]any{cpu01, gpu01},
+			quarantined:      []quarantinedDevice{{deviceID: "gpu01", resourceType: GPU, payload: map[string]any{"deviceID": "gpu01", "type": GPU, "old": true}}},
+			want: quarantinePlan{
+				supported:  []map[string]any{cpu01, gpu01},
+				quarantine: []map[string]any{},
+				promote:    []string{"gpu01"},
+			},
+		},
+		{
+			name:             "Normal case: The quarantined device not in the request is promoted with its payload",
+			requestResources: []map[string]any{cpu01},
+			quarantined: []quarantinedDevice{
+				{deviceID: "gpu01", resourceType: GPU, payload: gpu01},
+				{deviceID: "gpu02", resourceType: GPU, payload: map[string]any{}},
+			},
+			want: quarantinePlan{
+				supported:  []map[string]any{cpu01, gpu01},
+				quarantine: []map[string]any{},
+				promote:    []string{"gpu01", "gpu02"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newQuarantinePlan(tt.requestResources, tt.quarantined); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newQuarantinePlan() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newPromotion(t *testing.T) {
+	gpu01 := map[string]any{"deviceID": "gpu01", "type": GPU}
+	gpu02 := map[string]any{"deviceID": "gpu02", "type": GPU}
+	quarantined := []quarantinedDevice{
+		{deviceID: "dpu01", resourceType: "DPU", payload: map[string]any{"deviceID": "dpu01", "type": "DPU"}},
+		{deviceID: "gpu01", resourceType: GPU, payload: gpu01},
+		{deviceID: "gpu02", resourceType: GPU, payload: gpu02},
+	}
+
+	// The requested device IDs are those of all the batches of a streamed hardware sync
+	promote, promoted := newPromotion(quarantined, map[string]bool{"gpu02": true}, map[string]bool{})
+	if want := []string{"gpu01", "gpu02"}; !reflect.DeepEqual(promote, want) {
+		t.Errorf("newPromotion() promote = %v, want %v", promote, want)
+	}
+	if want := []map[string]any{gpu01}; !reflect.DeepEqual(promoted, want) {
+		t.Errorf("newPromotion() promoted = %v, want %v", promoted, want)
+	}
+}
+
+func Test_newPromotion_quarantinedAgain(t *testing.T) {
+	quarantined := []quarantinedDevice{
+		{deviceID: "gpu01", resourceType: GPU, payload: map[string]any{"deviceID": "gpu01", "type": GPU}},
+	}
+
+	// The device reported again with an unsupported resource type stays quarantined
+	promote, promoted := newPromotion(quarantined, map[string]bool{"gpu01": true}, map[string]bool{"gpu01": true})
+	if len(promote) > 0 || len(promoted) > 0 {
+		t.Errorf("newPromotion() = %v, %v, want no promotion", promote, promoted)
+	}
+}
+
+func Test_quarantineDevices(t *testing.T) {
+	t.Skip("not test")
+}
+
+func Test_applyQuarantinePlan(t *testing.T) {
+	t.Skip("not test")
+}
@@ -15,21 +15,24 @@
 package controller
 
 import (
-	"context"
 	"database/sql"
 	"fmt"
+	"io"
 	"net/http"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/project-cdim/configuration-manager/common"
 	"github.com/project-cdim/configuration-manager/database"
+	cmapi_model "github.com/project-cdim/configuration-manager/model"
+	cmapi_model_rule "github.com/project-cdim/configuration-manager/model/rule"
 	cmapi_repository "github.com/project-cdim/configuration-manager/repository"
+	cmapi_repository_rule "github.com/project-cdim/configuration-manager/repository/rule"
+	"github.com/project-cdim/configuration-manager/resourcetype"
 
 	"github.com/apache/age/drivers/golang/age"
 	"github.com/gin-gonic/gin"
-
-	dapr "github.com/dapr/go-sdk/client"
 )
 
 // Structure for registration information
@@ -42,6 +45,11 @@ type existingResource struct {
 	isNotDetected    bool
 	resourceType     hwResourceType
 	resourceGroupIDs []string
+	wasNotDetected   bool   // The NotDetected state of the resource before the hardware sync
+	syncSource       string // The source of the scoped hardware sync that last reported the resource
+	pendingMisses    int64  // The number of consecutive hardware syncs that missed the resource without putting it in the NotDetected state
+	pendingMissSince string // The time of the first of the pending misses in ISO 8601, or empty if there is none
+	contentHash      string // The content hash of the device information last written by the hardware sync, or empty if there is none
 }
 
 // Structure for storing node or switch information when fetching the list of existing nodes or switches
@@ -50,6 +58,24 @@ type existingNodeSwitch struct {
 	deviceDictionary map[string]hwResourceType
 }
 
+// Structure for storing chassis information when fetching the list of existing chassis
+// rackID is the rack to which the chassis is to be attached, and dbRackID is the rack to which the chassis was attached
+// at the start of the hardware sync. requestedRackID is the rack specified by the request, used to detect a chassis
+// located in different racks in the same request. deviceDictionary holds the resources mounted in the chassis; CXL switches mounted
+// in the chassis are not held because they are not reported by the hardware sync.
+type existingChassis struct {
+	rackID           string
+	dbRackID         string
+	requestedRackID  string
+	deviceDictionary map[string]mountedResource
+}
+
+// Structure for storing a resource mounted in a chassis
+type mountedResource struct {
+	resourceType hwResourceType
+	slot         string
+}
+
 // unitResources represents the relationship between a unit device and its associated resources.
 type unitResources struct {
 	unitDeviceID      string
@@ -60,7 +86,7 @@ type unitResources struct {
 // request resource and non-removable device IDs. It extracts the device ID from the
 // request resource and determines the related device IDs based on the resource type.
 //
-// For processor-type resources (Accelerator, CPU, DSP, FPGA, GPU, UnknownProcessor),
+// For the resource types anchoring a unit in the registry (the processors by default),
 // it includes both the unit device ID and all non-removable device IDs in the
 // relatedDeviceIDs slice. If no non-removable device IDs are provided, only the
 // unit device ID is included regardless of resource type.
@@ -79,9 +105,8 @@ func newUnitResources(requestResource map[string]any, nonRemovableDeviceIDs []st
 	if len(nonRemovableDeviceIDs) == 0 {
 		res.resourceDeviceIDs = append(res.resourceDeviceIDs, res.unitDeviceID)
 	} else {
-		resourceType := hwResourceType(requestResource["type"].(string))
-		switch resourceType {
-		case Accelerator, CPU, DSP, FPGA, GPU, UnknownProcessor:
+		resourceType, ok := resourcetype.Registered().Lookup(requestResource["type"].(string))
+		if ok && resourceType.Unit == resourcetype.UnitRoleAnchor {
 			res.resourceDeviceIDs = append(res.resourceDeviceIDs, res.unitDeviceID)
 			res.resourceDeviceIDs = append(res.resourceDeviceIDs, nonRemovableDeviceIDs...)
 		}
@@ -98,29 +123,21 @@ func (urr *unitResources) isRegisterable() bool {
 	return len(urr.resourceDeviceIDs) != 0
 }
 
-// resourceTypeList is a list of resource types.
+// resourceTypeList is a list of the labels of the resource types in the registry.
 // Note: The type has been changed from [...]string to []any to allow expanding
 // this list as variadic arguments when needed.
-var resourceTypeList = []any{
-	DB_CPU,
-	DB_Accelerator,
-	DB_DSP,
-	DB_FPGA,
-	DB_GPU,
-	DB_UnknownProcessor,
-	DB_Memory,
-	DB_Storage,
-	DB_NetworkInterface,
-	DB_GraphicController,
-	DB_VirtualMedia,
-}
+var resourceTypeList = resourcetype.Registered().Labels()
 
 // Parts of the Cypher query to fetch specific resources
 const queryResourceList_match_return string = `
 MATCH (vrs:%s)
 WHERE exists(vrs.deviceID) AND exists(vrs.type)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
-RETURN vrs.deviceID, vrs.type, COLLECT(vrsg.id)`
+OPTIONAL MATCH (vrs)-[endt:NotDetected]->(:NotDetectedDevice)
+OPTIONAL MATCH (vrs)-[:ReportedBy]->(vss:SyncSource)
+RETURN vrs.deviceID, vrs.type, COLLECT(vrsg.id), CASE WHEN endt IS NULL THEN false ELSE true END, CASE WHEN vss IS NULL THEN "" ELSE vss.id END,
+	CASE WHEN vrs.pendingMisses IS NULL THEN 0 ELSE vrs.pendingMisses END, CASE WHEN vrs.pendingMissSince IS NULL THEN "" ELSE vrs.pendingMissSince END,
+	CASE WHEN vrs.contentHash IS NULL THEN "" ELSE vrs.contentHash END`
 
 const queryResourceList_unionall string = `
 UNION ALL`
@@ -136,11 +153,16 @@ func getQueryResourceList() string {
 	return strings.Join(items, queryResourceList_unionall)
 }
 
-const selectDeviceListColumnCount = 3
+const selectDeviceListColumnCount = 8
 const (
 	selectDeviceListIndexDeviceID = iota
 	selectDeviceListIndexType
 	selectDeviceListIndexResourceGroupIDs
+	selectDeviceListIndexNotDetected
+	selectDeviceListIndexSyncSource
+	selectDeviceListIndexPendingMisses
+	selectDeviceListIndexPendingMissSince
+	selectDeviceListIndexContentHash
 )
 
 // cypher query to search node
@@ -172,82 +194,78 @@ const (
 	selectSwitchListIndexType
 )
 
-// cypher query to merge resource
-const cyperMergeResource = `
-	MERGE (vrs:%s {deviceID: '%s'})
-	SET vrs = %s
+// cypher query to search chassis with the rack to which they are attached and the resources mounted in them
+const cypherSelectChassisList string = `
+	MATCH (vch:Chassis)
+	OPTIONAL MATCH (vrc:Rack)-[:Attach]->(vch)
+	OPTIONAL MATCH (vch)-[emt:Mount]->(vrs)
+	WHERE exists(vrs.deviceID) AND exists(vrs.type)
+	WITH vch, vrc, emt, vrs
+	ORDER BY vch.id
+	RETURN
+		vch.id,
+		CASE WHEN vrc IS NULL THEN "" ELSE vrc.id END,
+		CASE WHEN vrs IS NULL THEN "" ELSE vrs.deviceID END,
+		CASE WHEN vrs IS NULL THEN "" ELSE vrs.type END,
+		CASE WHEN emt.slot IS NULL THEN "" ELSE emt.slot END
 `
+const selectChassisListColumnCount = 5
+const (
+	selectChassisListIndexChassisID = iota
+	selectChassisListIndexRackID
+	selectChassisListIndexDeviceID
+	selectChassisListIndexType
+	selectChassisListIndexSlot
+)
 
 const (
 	mergeColumnCount  = 0
 	deleteColumnCount = 0
 )
 
-// cypher query to create annotation vertex and have edge
-const cypherCreateAnnotation = `
-	MATCH (vrs:%s {deviceID: '%s'})
-	CREATE (van:Annotation {available: true})
-	CREATE (vrs)-[:Have]->(van)
-`
-
 // cypher query to delete notDetected edge from resource vertex
 const cypherDeleteResourceNotdetectedEdge = `
 	MATCH (:%s {deviceID: '%s'})-[endt:NotDetected]->(:NotDetectedDevice)
 	DELETE endt
 `
 
-// cypher query to create notDetected edge from resource vertex
+// cypher query to create notDetected edge from resource vertex.
+// The edge records since when the resource is not detected and the number of hardware syncs that did not detect it.
 const cypherCreateResourceNotdetectedEdge = `
 	MATCH (vrs:%s {deviceID: '%s'}), (vndd:NotDetectedDevice)
-	CREATE (vrs)-[:NotDetected]->(vndd)
-`
-
-// cypher query to delete notDetected edge from node vertex
-const cypherDeleteNodeNotdetectedEdge = `
-	MATCH (:Node {id: '%s'})-[endt:NotDetected]->(:NotDetectedDevice)
-	DELETE endt
-`
-
-// cypher query to delete notDetected edge from switch vertex
-const cypherDeleteSwitchNotdetectedEdge = `
-	MATCH (:CXLswitch {id: '%s'})-[endt:NotDetected]->(:NotDetectedDevice)
-	DELETE endt
-`
-
-// cypher query to merge node
-const cypherMergeNode = `
-	MERGE (vnd:Node {id: '%s'})
-	SET vnd = {id: '%s'}
+	CREATE (vrs)-[:NotDetected {since: '%s', missedSyncs: %d}]->(vndd)
 `
 
-// cypher query to merge switch
-const cypherMergeSwitch = `
-	MERGE (vcx:CXLswitch {id: '%s'})
-	SET vcx = {id: '%s'}
+// cypher query to count a hardware sync that did not detect the resource again on its notDetected edge.
+// The edges created before the time was recorded start counting the days from this hardware sync.
+const cypherIncrementResourceNotdetectedEdge = `
+	MATCH (:%s {deviceID: '%s'})-[endt:NotDetected]->(:NotDetectedDevice)
+	SET endt.missedSyncs = coalesce(endt.missedSyncs, 0) + 1, endt.since = coalesce(endt.since, '%s')
 `
 
-// cypher query to delete compose edge
-const cypherDeleteComposeEdge = `
-	MATCH (:Node {id: '%s'})-[ecm:Compose]->()
-	DELETE ecm
+// cypher query to merge rack
+// The properties registered via the rack API are kept, and the timestamps are set only when the rack is created.
+const cypherMergeRack = `
+	MERGE (vrc:Rack {id: '%s'})
+	SET vrc.createdAt = coalesce(vrc.createdAt, "%s"), vrc.updatedAt = coalesce(vrc.updatedAt, "%s")
 `
 
-// cypher query to delete connect edge
-const cypherDeleteConnectEdge = `
-	MATCH (:CXLswitch {id: '%s'})-[ecn:Connect]->()
-	DELETE ecn
+// cypher query to delete attach edge
+const cypherDeleteAttachEdge = `
+	MATCH (:Rack)-[eat:Attach]->(:Chassis {id: '%s'})
+	DELETE eat
 `
 
-// cypher query to create compose edge
-const cypherCreateComposeEdge = `
-	MATCH (vrs:%s {deviceID: '%s'}), (vnd:Node {id: '%s'})
-	CREATE (vnd)-[:Compose]->(vrs)
+// cypher query to remove the unit position of the chassis in the previous rack
+const cypherRemoveUnitPosition = `
+	MATCH (vch:Chassis {id: '%s'})
+	REMOVE vch.unitPosition
 `
 
-// cypher query to create connect edge
-const cypherCreateConnectEdge = `
-	MATCH (vrs:%s {deviceID: '%s'}), (vcx:CXLswitch {id: '%s'})
-	CREATE (vcx)-[:Connect]->(vrs)
+// cypher query to create attach edge
+const cypherCreateAttachEdge = `
+	MATCH (vrc:Rack {id: '%s'}), (vch:Chassis {id: '%s'})
+	CREATE (vrc)-[:Attach]->(vch)
 `
 
 // cypher query to delete node if it does'nt have at least one compose edge
@@ -258,63 +276,14 @@ const cypherDeleteNodeWithoutEdges = `
 	DETACH DELETE vnd
 `
 
-// cypher query to create include edge
-const cypherCreateIncludeEdge = `
-	MATCH (vrs:%s {deviceID: '%s'}), (vrsg:ResourceGroups {id: '%s'})
-	CREATE (vrsg)-[:Include]->(vrs)
-`
-
-// cypher query to merge Unit vertex, Annotation vertex, Have edge, and delete Contain edge.
-const cypherMergeUnitAndDeleteContain = `
-	MERGE (vut:Unit {deviceID: '%s'})
-	MERGE (vut)-[:Have]->(:Annotation {available: true})
-	WITH vut
-	MATCH (vut)-[ect:Contain]->()
-	DELETE ect
-`
-
-// cypher query to create Unit vertex, Annotation vertex, Have edge, and Contain edge.
-const cypherCreateContain = `
-	MATCH (vut:Unit {deviceID: '%s'})
-	MATCH %s
-	CREATE %s
+// cypher query to delete the specified node if it does'nt have at least one compose edge, used by a scoped hardware sync
+const cypherDeleteNodeWithoutEdgesByID = `
+	MATCH (vnd:Node {id: '%s'})
+	OPTIONAL MATCH (vnd)-[ecm:Compose]->() WITH vnd, count(ecm) AS edges
+	WHERE edges = 0
+	DETACH DELETE vnd
 `
 
-const (
-	// Parts of the Cypher query to create Contain edge
-	cypherCreateContainMatchParts = `(vrs%d:%s {deviceID: '%s'})`
-
-	// Parts of the Cypher query to create Contain edge
-	cypherCreateContainCreateParts = `(vut)-[:Contain]->(vrs%d)`
-)
-
-// DaprClient is an interface that defines the methods for publishing events to Dapr.
-// This interface is used to enable dependency injection for testing purposes.
-type DaprClient interface {
-	PublishEvent(ctx context.Context, pubsubName, topicName string, data interface{}, opts ...dapr.PublishEventOption) error
-}
-
-// DaprClientImpl is a wrapper around the official Dapr client.
-// This is the production implementation.
-type DaprClientImpl struct {
-	client dapr.Client
-}
-
-// PublishEvent publishes an event to the specified pubsub and topic using the Dapr client.
-func (d *DaprClientImpl) PublishEvent(ctx context.Context, pubsubName, topicName string, data interface{}, opts ...dapr.PublishEventOption) error {
-	return d.client.PublishEvent(ctx, pubsubName, topicName, data, opts...)
-}
-
-// DaprClientFactory is a function type that creates a new DaprClient.
-// This can be replaced with a mock implementation during testing.
-var DaprClientFactory = func() (DaprClient, error) {
-	client, err := dapr.NewClient()
-	if err != nil {
-		return nil, err
-	}
-	return &DaprClientImpl{client: client}, nil
-}
-
 // RegisterDevice registers multiple device information in the configuration management database (DB).
 // It starts by logging the beginning of the process and obtaining a DB connection.
 // The function then begins a transaction and defers its disconnection to ensure the DB connection is properly managed.
@@ -327,18 +296,125 @@ var DaprClientFactory = func() (DaprClient, error) {
 // Finally, it constructs a response object containing the count and IDs of the registered devices, marshals it into JSON,
 // logs the response for debugging purposes, and returns it to the client with a 201 Created status.
 // If the JSON marshaling fails, it logs the error and returns an error response.
+// The hardware sync completed event and the domain events are written to the outbox in the same transaction,
+// and are published asynchronously by the outbox relay after the commit.
+//
+// The query parameters 'source', 'chassisIDs' and 'cxlSwitchIDs' limit the hardware sync to a scope (see syncScope),
+// so that the hardware control agents owning different parts of the hardware do not put each other's resources in the NotDetected state.
+//
+// After the synchronization, the resources not detected for longer than the retention policy allows (see retentionPolicy) are purged
+// in the same transaction.
+//
+// When the 'dryRun' query parameter is true, the same synchronization is performed in a transaction that is rolled back,
+// and the changes that the synchronization would make are returned as a plan with a 200 OK status. No event is published.
+//
+// The devices of unsupported resource types are quarantined instead of being registered as resources (see quarantineDevices),
+// and are promoted to resources by the hardware sync once their resource types are supported.
+//
+// The devices whose content hashes have not changed since the previous hardware sync are not rewritten, and are reported as unchanged
+// instead of updated (see deviceContentHash).
+//
+// When the Content-Type is application/x-ndjson, the devices are streamed in the request body one per line, and are read, validated and
+// applied in batches so that the whole request body is not held in memory (see streamResources). The 'partial' query parameter is not
+// supported for the streamed devices. The size of the request body is limited by CM_SYNC_MAX_BODY_BYTES, and a larger request body is
+// responded with 413 Request Entity Too Large.
+//
+// When the 'partial' query parameter is true, the invalid devices are rejected instead of the whole request (see acceptDevices),
+// and the valid devices are synchronized. The rejected devices are listed with their reasons in the response, whose status is
+// 207 Multi-Status if any device is rejected. The registered resources of the rejected devices are not put in the NotDetected state.
+//
+// When the 'async' query parameter is true, the request body is stored as a sync job instead of being synchronized in the request,
+// and 202 Accepted is returned with the job and its location. The job is run by the sync job worker (see RunSyncJob), and its status,
+// progress and response are fetched by GetSyncJob. The request body of a sync job is stored and loaded in full, so its size is further
+// limited by CM_SYNC_ASYNC_MAX_BODY_BYTES, and a larger request body is responded with 413 Request Entity Too Large.
 func RegisterDevice(c *gin.Context) {
 	common.Log.Info(fmt.Sprintf("%s[%s] start.", c.Request.URL.Path, c.Request.Method))
 	funcName := "RegisterDevice"
 
+	// Retrieve query parameter: dryRun
+	dryRun, err := getBoolQueryParam(c, "dryRun")
+	if err != nil {
+		errorDatial := "getBoolQueryParam error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
+	// Retrieve query parameter: partial
+	partial, err := getBoolQueryParam(c, "partial")
+	if err != nil {
+		errorDatial := "getBoolQueryParam error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
+	// Retrieve query parameters: source, chassisIDs, cxlSwitchIDs
+	scope, err := getSyncScope(c)
+	if err != nil {
+		errorDatial := "getSyncScope error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
+	// Retrieve query parameter: async
+	async, err := getBoolQueryParam(c, "async")
+	if err != nil {
+		errorDatial := "getBoolQueryParam error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
+	// The devices streamed as NDJSON are applied in batches, which cannot reject the devices linked from the following batches
+	streamed := isStreamedSync(c)
+	if streamed && partial {
+		errorDatial := "partial is not supported for " + ndjsonContentType
+		common.Log.Error(fmt.Sprintf("%s %s", funcName, errorDatial), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+	limitSyncRequestBody(c, syncMaxBodyBytesSetting)
+
+	request := syncRequest{dryRun: dryRun, partial: partial, streamed: streamed, scope: scope}
+	if async {
+		// The request body is stored as a sync job, and the hardware sync is run by the sync job worker
+		enqueueSyncJob(c, funcName, request)
+		return
+	}
+
+	status, res := runDeviceSync(funcName, request, c.Request.Body, nil, nil)
+	if status < http.StatusBadRequest {
+		common.Log.Info(fmt.Sprintf("%s[%s] completed successfully.", c.Request.URL.Path, c.Request.Method))
+	}
+	c.JSON(status, res)
+}
+
+// runDeviceSync performs the hardware sync of RegisterDevice requested by request with the devices read from body,
+// and returns the status and the body of the response. The errors are logged with funcName.
+// If progress is not nil, it is called with the number of the processed devices after each batch of the devices is applied,
+// and the hardware sync fails if it returns an error. If recordResponse is not nil, it is called with the response of the hardware sync
+// in its transaction just before the commit, and the hardware sync fails if it returns an error.
+func runDeviceSync(
+	funcName string,
+	request syncRequest,
+	body io.Reader,
+	progress func(processed int) error,
+	recordResponse func(tx *sql.Tx, status int, res any) error,
+) (int, gin.H) {
+	dryRun := request.dryRun
+	partial := request.partial
+	streamed := request.streamed
+	scope := request.scope
+
 	// Get DB connection
 	cmdb := database.NewCmDb()
 	err := cmdb.CmDbBeginTransaction()
 	if err != nil {
 		errorDatial := "CmDbBeginTransaction error"
 		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
-		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
-		return
+		return http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial)
 	}
 	defer cmdb.CmDbDisconnection()
 
@@ -348,8 +424,7 @@ func RegisterDevice(c *gin.Context) {
 		cmdb.CmDbRollback()
 		errorDatial := "getDeviceIDList error"
 		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
-		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
-		return
+		return http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial)
 	}
 
 	// Get the list of already registered nodes
@@ -358,8 +433,7 @@ func RegisterDevice(c *gin.Context) {
 		cmdb.CmDbRollback()
 		errorDatial := "getNodeList error"
 		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
-		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
-		return
+		return http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial)
 	}
 
 	// Get the list of already registered switches
@@ -368,64 +442,251 @@ func RegisterDevice(c *gin.Context) {
 		cmdb.CmDbRollback()
 		errorDatial := "getCxlSwitchList error"
 		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
-		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
-		return
+		return http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial)
 	}
 
-	// Read the JSON from the RequestBody and expand it into a variable in the form of an array of Maps
-	registerDevieces, err := unmarshalRequestBodyForSlice(c)
+	// Get the list of already registered chassis
+	existsChassis, err := getChassisList(cmdb.Tx)
 	if err != nil {
 		cmdb.CmDbRollback()
-		errorDatial := "unmarshalRequestBodyForSlice error"
+		errorDatial := "getChassisList error"
 		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
-		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
-		return
+		return http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial)
 	}
 
-	// Read the array form of Maps converted from the JSON of the RequestBody and store it in the registration information structure
-	requestResources, err := validateRegisterData(registerDevieces)
+	// Get the assignment rules that decide the resource group of newly discovered resources
+	assignmentRules, err := cmapi_repository_rule.FindRules(cmdb)
 	if err != nil {
 		cmdb.CmDbRollback()
-		errorDatial := "validateRegisterData error"
+		errorDatial := "FindRules error"
 		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
-		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
-		return
+		return http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial)
+	}
+
+	var rejected []rejectedDevice
+	var syncDevices func() (syncResult, error)
+	syncErrorDatial := "registerResources error"
+	if streamed {
+		// The devices are read, validated and applied in batches by the synchronization
+		syncErrorDatial = "streamResources error"
+		syncDevices = func() (syncResult, error) {
+			sync := newResourceSync(cmdb.Tx, existsResources, existsNodes, existsSwitches, existsChassis, assignmentRules, scope)
+			sync.progress = progress
+			err := streamResources(cmdb.Tx, body, schemaValidationModeSetting, sync)
+			if err != nil {
+				return sync.result, err
+			}
+			return sync.finish()
+		}
+	} else {
+		// Read the JSON from the RequestBody and expand it into a variable in the form of an array of Maps
+		registerDevieces, err := unmarshalBodyForSlice(body)
+		if err != nil {
+			cmdb.CmDbRollback()
+			return syncErrorResponse(funcName, "unmarshalBodyForSlice error", err)
+		}
+
+		// Read the array form of Maps converted from the JSON of the RequestBody and store it in the registration information structure
+		var requestResources *resourceRegister
+		if partial {
+			// Only the valid devices are stored, and the invalid devices are rejected
+			requestResources, rejected, err = acceptDevices(registerDevieces, schemaValidationModeSetting, existsResources)
+			if err != nil {
+				cmdb.CmDbRollback()
+				errorDatial := "acceptDevices error"
+				common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+				return http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial)
+			}
+			for _, device := range rejected {
+				common.Log.Warn(fmt.Sprintf("%s device rejected. resourceIndex(%d), deviceID(%s), reason(%s) : %s", funcName, device.index, device.deviceID, device.reason, device.message))
+			}
+		} else {
+			requestResources, err = validateRegisterData(registerDevieces)
+			if err != nil {
+				cmdb.CmDbRollback()
+				errorDatial := "validateRegisterData error"
+				common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+				return http.StatusBadRequest, convertValidationErrorResponse(err, errorDatial)
+			}
+		}
+
+		// Quarantine the devices of unsupported resource types instead of failing the hardware sync,
+		// and promote the quarantined devices whose resource types have become supported
+		quarantine, err := quarantineDevices(cmdb.Tx, requestResources)
+		if err != nil {
+			cmdb.CmDbRollback()
+			errorDatial := "quarantineDevices error"
+			common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+			return http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial)
+		}
+		requestResources = &resourceRegister{resource: quarantine.supported}
+
+		// The same phases as registerResources, with the progress reported after all the devices are applied in one batch
+		syncDevices = func() (syncResult, error) {
+			sync := newResourceSync(cmdb.Tx, existsResources, existsNodes, existsSwitches, existsChassis, assignmentRules, scope)
+			sync.progress = progress
+			if err := sync.apply(requestResources.resource); err != nil {
+				return sync.result, err
+			}
+			result, err := sync.finish()
+			if err != nil {
+				return result, err
+			}
+			result.addQuarantinePlan(quarantine)
+			return result, nil
+		}
+	}
+
+	if dryRun {
+		return registerDeviceDryRun(funcName, &cmdb, syncDevices, syncErrorDatial, rejected)
 	}
 
 	// Compare the list of already registered resources with the JSON of the RequestBody and synchronize the entire content of the RequestBody with the DB
-	registerIdList, err := registerResources(cmdb.Tx, existsResources, existsNodes, existsSwitches, requestResources)
+	result, err := syncDevices()
+	if err != nil {
+		cmdb.CmDbRollback()
+		return syncErrorResponse(funcName, syncErrorDatial, err)
+	}
+
+	// Purge the resources not detected for longer than the retention policy allows
+	err = applyRetentionPolicy(cmdb.Tx, retentionPolicySetting, &result)
 	if err != nil {
 		cmdb.CmDbRollback()
-		errorDatial := "registerResources error"
+		errorDatial := "applyRetentionPolicy error"
 		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
-		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
-		return
+		return http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial)
+	}
+	result.sort()
+
+	// Write the hardware sync completed event and the domain events to the outbox in the same transaction as the synchronization,
+	// so that they are published by the outbox relay if and only if the synchronization is committed
+	err = enqueueHwSyncEvents(cmdb.Tx, result)
+	if err != nil {
+		cmdb.CmDbRollback()
+		errorDatial := "enqueueHwSyncEvents error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		return http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial)
 	}
-	cmdb.CmDbCommit()
 
 	res := map[string]any{
-		"count":     len(registerIdList),
-		"deviceIDs": registerIdList,
+		"count":                len(result.registeredDeviceIDs),
+		"deviceIDs":            result.registeredDeviceIDs,
+		"unchangedDeviceIDs":   result.unchangedDeviceIDs,
+		"quarantinedDeviceIDs": result.quarantinedDeviceIDs,
+		"promotedDeviceIDs":    result.promotedDeviceIDs,
+	}
+	status := http.StatusCreated
+	if partial {
+		res["rejected"] = rejectedDevicesToObject(rejected)
+		if len(rejected) > 0 {
+			status = http.StatusMultiStatus
+		}
+	}
+
+	// Record the response in the same transaction as the synchronization, e.g. as the result of its sync job
+	if recordResponse != nil {
+		err = recordResponse(cmdb.Tx, status, res)
+		if err != nil {
+			cmdb.CmDbRollback()
+			errorDatial := "recordResponse error"
+			common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+			return http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial)
+		}
+	}
+
+	err = cmdb.CmDbCommit()
+	if err != nil {
+		errorDatial := "CmDbCommit error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		return http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial)
 	}
 
 	// Log output of responseBody
 	logResponseBody(res)
-	common.Log.Info(fmt.Sprintf("%s[%s] completed successfully.", c.Request.URL.Path, c.Request.Method))
+	return status, res
+}
+
+// registerDeviceDryRun performs the synchronization of RegisterDevice, syncDevices, in the transaction of cmdb and always rolls it back.
+// The states of the graph before and after the synchronization are compared in the transaction, and the differences are
+// returned as a plan with a 200 OK status, in addition to the count and IDs of the devices that would be registered.
+// If the request is accepted partially, that is, rejected is not nil, the rejected devices are also returned.
+// If the synchronization fails, the same error response as RegisterDevice is returned with syncErrorDatial.
+func registerDeviceDryRun(
+	funcName string,
+	cmdb *database.CmDb,
+	syncDevices func() (syncResult, error),
+	syncErrorDatial string,
+	rejected []rejectedDevice,
+) (int, gin.H) {
+	// The transaction is never committed in a dry run
+	defer cmdb.CmDbRollback()
+
+	before, err := getSyncSnapshot(cmdb.Tx)
+	if err != nil {
+		errorDatial := "getSyncSnapshot error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		return http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial)
+	}
+
+	result, err := syncDevices()
+	if err != nil {
+		return syncErrorResponse(funcName, syncErrorDatial, err)
+	}
+
+	// The result is sorted after the purge in the same order as RegisterDevice
+	err = applyRetentionPolicy(cmdb.Tx, retentionPolicySetting, &result)
+	if err != nil {
+		errorDatial := "applyRetentionPolicy error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		return http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial)
+	}
+	result.sort()
 
-	ctx := context.Background()
-	client, err := DaprClientFactory()
+	after, err := getSyncSnapshot(cmdb.Tx)
 	if err != nil {
-		errorDatial := "publish generate error"
+		errorDatial := "getSyncSnapshot error"
 		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
-		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial)
 	}
-	if err := client.PublishEvent(ctx, "configuration_manager_hwsync", "configuration_manager.hwsync.completed", nil); err != nil {
-		errorDatial := "publish error"
+
+	plan := newSyncPlan(before, after)
+	planObject, err := common.UnquoteRecursive(plan.toObject())
+	if err != nil {
+		errorDatial := "UnquoteRecursive error"
 		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
-		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial)
+	}
+
+	res := map[string]any{
+		"dryRun":               true,
+		"count":                len(result.registeredDeviceIDs),
+		"deviceIDs":            result.registeredDeviceIDs,
+		"unchangedDeviceIDs":   result.unchangedDeviceIDs,
+		"quarantinedDeviceIDs": result.quarantinedDeviceIDs,
+		"promotedDeviceIDs":    result.promotedDeviceIDs,
+		"plan":                 planObject,
+	}
+	if rejected != nil {
+		res["rejected"] = rejectedDevicesToObject(rejected)
 	}
 
-	c.JSON(http.StatusCreated, res)
+	logResponseBody(res)
+	return http.StatusOK, res
+}
+
+// syncErrorResponse logs the error of reading or synchronizing the devices of RegisterDevice and returns the status and the body of the error response.
+// If the request body exceeds the maximum size, a 413 Request Entity Too Large status is returned. If the devices in the request are
+// invalid (see isInvalidRegisterData), a 400 Bad Request status is returned with the violations of the schemas if any.
+// Otherwise, for example on an error of the database, a 500 Internal Server Error status is returned.
+func syncErrorResponse(funcName string, errorDatial string, err error) (int, gin.H) {
+	common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+	if isRequestBodyTooLarge(err) {
+		return http.StatusRequestEntityTooLarge, convertErrorResponse(http.StatusRequestEntityTooLarge, errorDatial)
+	}
+	if isInvalidRegisterData(err) {
+		return http.StatusBadRequest, convertValidationErrorResponse(err, errorDatial)
+	}
+	return http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial)
 }
 
 // getDeviceIDList retrieves a list of existing device IDs from the database.
@@ -441,7 +702,7 @@ func getDeviceIDList(tx *sql.Tx) (map[string]existingResource, error) {
 	query := getQueryResourceList()
 	common.Log.Debug(fmt.Sprintf("query: %s, params: %v", query, resourceTypeList))
 	res := map[string]existingResource{}
-	cypherCursor, err := age.ExecCypher(tx, database.GRAPH_NAME, selectDeviceListColumnCount, query, resourceTypeList...)
+	cypherCursor, err := execCypher(tx, database.GRAPH_NAME, selectDeviceListColumnCount, query, resourceTypeList...)
 	if err != nil {
 		common.Log.Error(err.Error())
 		return nil, err
@@ -459,8 +720,22 @@ func getDeviceIDList(tx *sql.Tx) (map[string]existingResource, error) {
 		deviceID := cmapi_repository.ExtractEntityString(row[selectDeviceListIndexDeviceID].(*age.SimpleEntity))
 		resourceType := cmapi_repository.ExtractEntityString(row[selectDeviceListIndexType].(*age.SimpleEntity))
 		resourceGroupIDs := cmapi_repository.ExtractEntitySlice(row[selectDeviceListIndexResourceGroupIDs].(*age.SimpleEntity))
+		wasNotDetected := row[selectDeviceListIndexNotDetected].(*age.SimpleEntity).AsBool()
+		syncSource := cmapi_repository.ExtractEntityString(row[selectDeviceListIndexSyncSource].(*age.SimpleEntity))
+		pendingMisses := row[selectDeviceListIndexPendingMisses].(*age.SimpleEntity).AsInt64()
+		pendingMissSince := cmapi_repository.ExtractEntityString(row[selectDeviceListIndexPendingMissSince].(*age.SimpleEntity))
+		contentHash := cmapi_repository.ExtractEntityString(row[selectDeviceListIndexContentHash].(*age.SimpleEntity))
 		// The initial value of isNotDetected is "true: detected" (change to "false: not detected" when checking existence and it was detected)
-		res[deviceID] = existingResource{isNotDetected: true, resourceType: hwResourceType(resourceType), resourceGroupIDs: resourceGroupIDs}
+		res[deviceID] = existingResource{
+			isNotDetected:    true,
+			resourceType:     hwResourceType(resourceType),
+			resourceGroupIDs: resourceGroupIDs,
+			wasNotDetected:   wasNotDetected,
+			syncSource:       syncSource,
+			pendingMisses:    pendingMisses,
+			pendingMissSince: pendingMissSince,
+			contentHash:      contentHash,
+		}
 	}
 
 	return res, nil
@@ -479,7 +754,7 @@ func getDeviceIDList(tx *sql.Tx) (map[string]existingResource, error) {
 func getNodeList(tx *sql.Tx) (map[string]existingNodeSwitch, error) {
 	common.Log.Debug(fmt.Sprintf("query: %s", cypherSelectNodeList))
 	res := map[string]existingNodeSwitch{}
-	cypherCursor, err := age.ExecCypher(tx, database.GRAPH_NAME, selectNodeListColumnCount, cypherSelectNodeList)
+	cypherCursor, err := execCypher(tx, database.GRAPH_NAME, selectNodeListColumnCount, cypherSelectNodeList)
 	if err != nil {
 		common.Log.Error(err.Error())
 		return nil, err
@@ -528,7 +803,7 @@ func getNodeList(tx *sql.Tx) (map[string]existingNodeSwitch, error) {
 func getCxlSwitchList(tx *sql.Tx) (map[string]existingNodeSwitch, error) {
 	common.Log.Debug(fmt.Sprintf("query: %s", cyperSelectSwitchList))
 	res := map[string]existingNodeSwitch{}
-	cypherCursor, err := age.ExecCypher(tx, database.GRAPH_NAME, selectSwitchListColumnCount, cyperSelectSwitchList)
+	cypherCursor, err := execCypher(tx, database.GRAPH_NAME, selectSwitchListColumnCount, cyperSelectSwitchList)
 	if err != nil {
 		common.Log.Error(err.Error())
 		return nil, err
@@ -573,38 +848,75 @@ func getCxlSwitchList(tx *sql.Tx) (map[string]existingNodeSwitch, error) {
 	return res, nil
 }
 
-// validateRegisterData takes an array of maps representing unmarshalled request bodies and validates each for the presence and type of mandatory fields such as deviceID and type.
+// getChassisList retrieves the list of registered chassis with the rack to which each chassis is attached
+// and the resources mounted in it. Chassis without a rack or resources are also included.
+// The returned map is keyed by the chassis ID; rackID and dbRackID are both set to the current rack.
+func getChassisList(tx *sql.Tx) (map[string]existingChassis, error) {
+	common.Log.Debug(fmt.Sprintf("query: %s", cypherSelectChassisList))
+	res := map[string]existingChassis{}
+	cypherCursor, err := execCypher(tx, database.GRAPH_NAME, selectChassisListColumnCount, cypherSelectChassisList)
+	if err != nil {
+		common.Log.Error(err.Error())
+		return nil, err
+	}
+	defer cypherCursor.Close()
+
+	for cypherCursor.Next() {
+		row, err := cypherCursor.GetRow()
+		if err != nil {
+			common.Log.Error(err.Error())
+			return nil, err
+		}
+
+		chassisID := cmapi_repository.ExtractEntityString(row[selectChassisListIndexChassisID].(*age.SimpleEntity))
+		rackID := cmapi_repository.ExtractEntityString(row[selectChassisListIndexRackID].(*age.SimpleEntity))
+		deviceID := cmapi_repository.ExtractEntityString(row[selectChassisListIndexDeviceID].(*age.SimpleEntity))
+		deviceType := cmapi_repository.ExtractEntityString(row[selectChassisListIndexType].(*age.SimpleEntity))
+		slot := cmapi_repository.ExtractEntityString(row[selectChassisListIndexSlot].(*age.SimpleEntity))
+
+		chassis, ok := res[chassisID]
+		if !ok {
+			chassis = existingChassis{rackID: rackID, dbRackID: rackID, deviceDictionary: map[string]mountedResource{}}
+			res[chassisID] = chassis
+		}
+		if deviceID != "" && deviceType != "" {
+			chassis.deviceDictionary[deviceID] = mountedResource{resourceType: hwResourceType(deviceType), slot: slot}
+		}
+	}
+
+	return res, nil
+}
+
+// validateRegisterData takes an array of maps representing unmarshalled request bodies and validates each against the schemas shipped with the service.
 // This function is essential for ensuring that the data being registered meets the required format and contains all necessary information.
-// It iterates through each element in the input array, checking for the existence and data type of the "deviceID" and "type" fields.
-// If either the "deviceID" or "type" field is missing or if their data types are not strings, an error is returned.
-// This validation step is crucial as it enforces data integrity and prevents the registration of incomplete or malformed device information.
+// Every element must have the "deviceID" and "type" fields of string type. Depending on the validation mode (see schemaValidationMode),
+// the violations of the common schema and the schema of the resource type either make the validation fail or are logged as warnings.
+// If the validation fails, a registerDataValidationError listing every violation with the index of the element and the JSON pointer is returned.
 // Upon successful validation of all elements, the function returns a pointer to a resourceRegister struct populated with the validated data.
 func validateRegisterData(body []map[string]any) (*resourceRegister, error) {
-	resourceRegister := resourceRegister{}
+	return validateRegisterDataInMode(body, schemaValidationModeSetting)
+}
+
+// validateRegisterDataInMode validates the request bodies like validateRegisterData in the validation mode.
+func validateRegisterDataInMode(body []map[string]any, mode schemaValidationMode) (*resourceRegister, error) {
+	schemas, err := loadResourceSchemas()
+	if err != nil {
+		return nil, err
+	}
 
+	resourceRegister := resourceRegister{}
+	violations := []registerDataViolation{}
 	for index := range body {
 		resource := body[index]
 
-		valDeviceID, isDeviceIDExact := resource["deviceID"]
-		// The ["deviceID"] element must exist
-		if !isDeviceIDExact {
-			return nil, fmt.Errorf("JSON value check exist error [deviceID]. resourceIndex(%d), resource(%v)", index, resource)
-		}
-		// The ["deviceID"] must be of string type
-		if reflect.ValueOf(valDeviceID).Kind() != reflect.String {
-			return nil, fmt.Errorf("JSON value check type error [deviceID]. resourceIndex(%d), resource(%v)", index, resource)
-		}
-		valType, isTypeExact := resource["type"]
-		// The ["type"] element must exist
-		if !isTypeExact {
-			return nil, fmt.Errorf("JSON value check exist error. [type]. resourceIndex(%d), resource(%v)", index, resource)
-		}
-		// The ["type"] must be of string type
-		if reflect.ValueOf(valType).Kind() != reflect.String {
-			return nil, fmt.Errorf("JSON value check type error. [type]. resourceIndex(%d), resource(%v)", index, resource)
-		}
+		errs, warnings := validateDevice(schemas, index, resource, mode)
+		violations = append(violations, errs...)
+		logViolations(warnings)
 		resourceRegister.resource = append(resourceRegister.resource, resource)
 	}
+	if len(violations) > 0 {
+		return nil, &registerDataValidationError{violations: violations}
+	}
 
 	return &resourceRegister, nil
 }
@@ -628,99 +940,248 @@ func validateRegisterData(body []map[string]any) (*resourceRegister, error) {
 //   - dbExistsResources: Map of existing resources indexed by device ID, used to track detection states
 //   - dbExistsNodes: Map of existing nodes and their associated devices, maintaining node topology
 //   - dbExistsSwitches: Map of existing CXL switches and their connected devices, maintaining switch topology
+//   - dbExistsChassis: Map of existing chassis, their racks and their mounted resources, maintaining physical topology
 //   - requestResources: Validated resource registration data containing device information to register
+//   - assignmentRules: Assignment rules that decide the resource group of newly discovered resources
+//   - scope: Scope of the hardware sync. Only the resources, nodes and CXL switches in the scope are put in the NotDetected state or cleaned up
 //
 // Returns:
-//   - []string: List of device IDs that were successfully registered during this operation
+//   - syncResult: The device IDs that were successfully registered during this operation, and the changes made by it
 //   - error: Any error encountered during the registration process, causing transaction rollback
 //
 // The function ensures data consistency through transaction management and maintains the integrity
 // of the hardware topology graph by properly managing vertex and edge relationships.
+// The phases are performed by a resourceSync, to which all the resources in the request are applied in one batch.
 func registerResources(
 	tx *sql.Tx,
 	dbExistsResources map[string]existingResource,
 	dbExistsNodes map[string]existingNodeSwitch,
 	dbExistsSwitches map[string]existingNodeSwitch,
+	dbExistsChassis map[string]existingChassis,
 	requestResources *resourceRegister,
-) ([]string, error) {
-	// Return list for successfully registered IDs
-	registerIdList := []string{}
+	assignmentRules cmapi_model_rule.AssignmentRuleList,
+	scope syncScope,
+) (syncResult, error) {
+	sync := newResourceSync(tx, dbExistsResources, dbExistsNodes, dbExistsSwitches, dbExistsChassis, assignmentRules, scope)
+	err := sync.apply(requestResources.resource)
+	if err != nil {
+		return sync.result, err
+	}
+	return sync.finish()
+}
+
+// Structure for storing the state of a hardware sync that applies the resources in the request in one or more batches.
+// The resources are written by apply for each batch, and the resources not detected, the units, the nodes, the switches
+// and the chassis are reflected by finish after the last batch. Only the IDs of the resources are held across the batches.
+type resourceSync struct {
+	tx                *sql.Tx
+	dbExistsResources map[string]existingResource
+	dbExistsNodes     map[string]existingNodeSwitch
+	dbExistsSwitches  map[string]existingNodeSwitch
+	dbExistsChassis   map[string]existingChassis
+	assignmentRules   cmapi_model_rule.AssignmentRuleList
+	scope             syncScope
+	result            syncResult // Return list for successfully registered IDs and the changes made by the hardware sync
+	units             []unitResources
+	progress          func(processed int) error // Called with the number of the devices applied so far after each batch, if not nil. An error stops the synchronization
+	// The resources in the scope and the resources of the nodes and switches are determined before the request is mapped
+	dbNodeIDs       []string
+	dbSwitchIDs     []string
+	scopedDeviceIDs map[string]bool
+	nodeSnapshot    map[string][]string
+	switchSnapshot  map[string][]string
+	chassisSnapshot map[string]existingChassis
+}
+
+// newResourceSync creates a resourceSync of the hardware sync in the scope, taking the snapshots of the existing nodes, switches and chassis.
+// The maps of the existing resources, nodes, switches and chassis are updated as the resources are applied.
+func newResourceSync(
+	tx *sql.Tx,
+	dbExistsResources map[string]existingResource,
+	dbExistsNodes map[string]existingNodeSwitch,
+	dbExistsSwitches map[string]existingNodeSwitch,
+	dbExistsChassis map[string]existingChassis,
+	assignmentRules cmapi_model_rule.AssignmentRuleList,
+	scope syncScope,
+) *resourceSync {
+	result := newSyncResult()
+	result.scope = scope
+	result.scopedDeviceIDs = scope.scopedDeviceIDs(dbExistsResources, dbExistsChassis, dbExistsSwitches)
+	return &resourceSync{
+		tx:                tx,
+		dbExistsResources: dbExistsResources,
+		dbExistsNodes:     dbExistsNodes,
+		dbExistsSwitches:  dbExistsSwitches,
+		dbExistsChassis:   dbExistsChassis,
+		assignmentRules:   assignmentRules,
+		scope:             scope,
+		result:            result,
+		units:             []unitResources{},
+		dbNodeIDs:         sortedKeys(dbExistsNodes),
+		dbSwitchIDs:       sortedKeys(dbExistsSwitches),
+		scopedDeviceIDs:   result.scopedDeviceIDs,
+		nodeSnapshot:      snapshotNodeSwitches(dbExistsNodes),
+		switchSnapshot:    snapshotNodeSwitches(dbExistsSwitches),
+		chassisSnapshot:   snapshotChassis(dbExistsChassis),
+	}
+}
 
-	for _, requestResource := range requestResources.resource {
+// apply writes a batch of the resources in the request, and maps them to their nodes, switches and chassis.
+func (s *resourceSync) apply(requestResources []map[string]any) error {
+	dbExistsResources := s.dbExistsResources
+	// The writes of the resources are collected in the loop and executed in batches per label (see writeResources)
+	writes := newResourceWrites(s.scope.source)
+
+	for _, requestResource := range requestResources {
 		deviceID := requestResource["deviceID"].(string)
 		resourceType := hwResourceType(requestResource["type"].(string))
+		label, err := resourceType.convertToDBLabel()
+		if err != nil {
+			return err
+		}
 
 		// Merge of resource Vertex and annotation Vertex
 		// Also performing the following at the same time
 		// - Creating Have Edge that connects resource and annotation Vertex
 		// - Deleting NotDetected Edge that connects resource and NotDetectedDevice Vertex
-		err := mergeResource(tx, deviceID, resourceType, requestResource, dbExistsResources)
+		// - Creating Include Edge that connects a newly discovered resource and the resource group decided by the assignment rules
+		// The properties of a resource whose content hash has not changed are not replaced, and only the time when it was last seen is refreshed
+		contentHash, err := deviceContentHash(requestResource)
 		if err != nil {
-			return nil, err
+			return err
+		}
+		existing, exists := dbExistsResources[deviceID]
+		unchanged := exists && existing.isUnchanged(contentHash)
+		if unchanged {
+			writes.addUnchanged(label, deviceID)
+		} else {
+			resourceGroupID := common.DefaultGroupId
+			if !exists {
+				resourceGroupID, _, err = resolveResourceGroupID(s.assignmentRules, requestResource)
+				if err != nil {
+					// The resource is still registered, in the resource group decided by the valid rules
+					common.Log.Error(fmt.Sprintf("assignment rule error. deviceID(%s), resourceGroupID(%s) : %s", deviceID, resourceGroupID, err.Error()), false)
+				}
+			}
+			writes.addResource(label, deviceID, withContentHash(requestResource, contentHash), !exists, resourceGroupID)
+		}
+		// Record the source of the hardware sync that reported the resource
+		if s.scope.changedSyncSource(deviceID, dbExistsResources) {
+			writes.addReportedBy(label, deviceID)
 		}
+		s.result.addDetectedDevice(deviceID, dbExistsResources, unchanged)
 
 		// Check if the obtained requestID exists in dbExistsResources
 		updateResourcesAsDetected(dbExistsResources, deviceID, resourceType)
 
 		// Check if the node mentioned in links exists in dbExistsNodes
-		nodeID := mappingNodes(requestResource, dbExistsNodes)
+		nodeID := mappingNodes(requestResource, s.dbExistsNodes)
 		// If the specified deviceID exists in nodes other than the specified nodeID (or in all nodes if nodeID is not specified), delete the specified deviceID information from the target node
-		deleteDeviceIDFromOtherNodeSwitches(deviceID, nodeID, dbExistsNodes)
+		deleteDeviceIDFromOtherNodeSwitches(deviceID, nodeID, s.dbExistsNodes)
 
 		// Check if DeviceSwitchInfo information exists in existSwitchData
-		switchID := mappingSwitches(requestResource, dbExistsSwitches)
+		switchID := mappingSwitches(requestResource, s.dbExistsSwitches)
 		// If the specified deviceID exists in switches other than the specified switchID (or in all switches if switchID is not specified), delete the specified deviceID information from the target switch
-		deleteDeviceIDFromOtherNodeSwitches(deviceID, switchID, dbExistsSwitches)
+		deleteDeviceIDFromOtherNodeSwitches(deviceID, switchID, s.dbExistsSwitches)
+
+		// Check if the chassis mentioned in location exists in dbExistsChassis
+		chassisID, err := mappingChassis(requestResource, s.dbExistsChassis)
+		if err != nil {
+			return err
+		}
+		// A resource whose location specifies a chassis is moved from the other chassis.
+		// A resource without a location remains in the chassis in which it was mounted via the chassis API.
+		if len(chassisID) > 0 {
+			deleteDeviceIDFromOtherChassis(deviceID, chassisID, s.dbExistsChassis)
+		}
+
+		// Record the unit of the resource, reflected after all the resources are registered
+		unit := newUnitResources(requestResource, getNonRemovableDeviceIds(requestResource))
+		if unit.isRegisterable() {
+			s.units = append(s.units, unit)
+		}
 
 		// Set the registered resource information in the return list
-		registerIdList = append(registerIdList, deviceID)
+		s.result.registeredDeviceIDs = append(s.result.registeredDeviceIDs, deviceID)
+	}
+
+	if err := writeResources(s.tx, writes); err != nil {
+		return err
+	}
+	if s.progress != nil {
+		if err := s.progress(len(s.result.registeredDeviceIDs)); err != nil {
+			return err
+		}
 	}
+	return nil
+}
+
+// finish reflects the resources not detected by the hardware sync, the units, the nodes, the switches and the chassis
+// after all the resources in the request are applied, and returns the result of the hardware sync.
+func (s *resourceSync) finish() (syncResult, error) {
+	tx := s.tx
+	scope := s.scope
+	result := s.result
 
 	// Loop through the list in dbExistsResources where isNotDetected is true
-	for deviceID, existingResource := range dbExistsResources {
+	for deviceID, existingResource := range s.dbExistsResources {
+		// The resources of the other scopes keep their state
+		if existingResource.isNotDetected && s.scopedDeviceIDs != nil && !s.scopedDeviceIDs[deviceID] {
+			continue
+		}
 		// Reflect the NotDetected state of the resource in the DB
-		err := syncNotDetectedResource(tx, deviceID, existingResource)
+		marked, err := syncNotDetectedResource(tx, deviceID, existingResource)
 		if err != nil {
-			return nil, err
+			return result, err
+		}
+		if marked {
+			result.notDetectedDeviceIDs = append(result.notDetectedDeviceIDs, deviceID)
+		} else if existingResource.isNotDetected && !existingResource.wasNotDetected {
+			result.pendingDeviceIDs = append(result.pendingDeviceIDs, deviceID)
 		}
 	}
 
-	for _, requestResource := range requestResources.resource {
-		err := mergeUnit(tx, requestResource, dbExistsResources)
-		if err != nil {
-			return nil, err
-		}
+	err := writeUnits(tx, s.units, s.dbExistsResources)
+	if err != nil {
+		return result, err
 	}
 
+	// Record the nodes and switches created or removed by the hardware sync
+	result.addNodeSwitchChanges(s.dbNodeIDs, s.dbExistsNodes, s.dbSwitchIDs, s.dbExistsSwitches)
+
 	// Merge and logically delete node Vertex based on the information in dbExistsNodes
-	for nodeID, existingNode := range dbExistsNodes {
-		// Reflect the node's Vertex and Edge in the DB
-		err := syncNode(tx, nodeID, existingNode)
-		if err != nil {
-			return nil, err
-		}
+	// A scoped hardware sync reflects only the nodes whose resources have been changed by it
+	nodeIDsToSync := scope.nodeSwitchIDsToSync(s.nodeSnapshot, s.dbExistsNodes)
+	// Reflect the nodes' Vertices and Compose Edges in the DB
+	err = writeNodeSwitches(tx, DB_Node, edgeTypeCompose, nodeIDsToSync, s.dbExistsNodes)
+	if err != nil {
+		return result, err
 	}
 
 	// Physically delete the node Vertex (Target for deletion: Nodes that do not have any Compose Edge connected)
 	// Reason for physical deletion: Since nodes without any linked resources will not be reused, physical deletion is performed to prevent unnecessary nodes from remaining.
-	common.Log.Debug(fmt.Sprintf("query: %s", cypherDeleteNodeWithoutEdges))
-	_, err := age.ExecCypher(tx, database.GRAPH_NAME, deleteColumnCount, cypherDeleteNodeWithoutEdges)
+	err = deleteNodesWithoutEdges(tx, scope, nodeIDsToSync)
 	if err != nil {
-		common.Log.Error(err.Error())
-		return nil, err
+		return result, err
 	}
 
 	// Merge and logically delete switch Vertex based on the information in dbExistsSwitches
-	for switchID, existingSwitch := range dbExistsSwitches {
+	// Reflect the switches' Vertices and Connect Edges in the DB
+	err = writeNodeSwitches(tx, DB_CXLswitch, edgeTypeConnect, scope.nodeSwitchIDsToSync(s.switchSnapshot, s.dbExistsSwitches), s.dbExistsSwitches)
+	if err != nil {
+		return result, err
+	}
 
-		// Reflect the switch's Vertex and Edge in the DB
-		err := syncSwitch(tx, switchID, existingSwitch)
-		if err != nil {
-			return nil, err
-		}
+	// Merge chassis and rack Vertices and reflect the Attach and Mount Edges based on the information in dbExistsChassis
+	// Chassis and racks are not deleted even if no resources are mounted, because they are physical equipment registered independently of the resources.
+	// A scoped hardware sync reflects only the chassis whose rack or resources have been changed by it
+	err = writeChassis(tx, scope.chassisIDsToSync(s.chassisSnapshot, s.dbExistsChassis), s.dbExistsChassis)
+	if err != nil {
+		return result, err
 	}
 
-	return registerIdList, nil
+	result.sort()
+	return result, nil
 }
 
 // updateResourcesAsDetected updates the dbExistsResources map for a given deviceID and resourceType.
@@ -752,7 +1213,7 @@ func updateResourcesAsDetected(dbExistsResources map[string]existingResource, de
 // This function is critical for maintaining the topology of resources and their connections within a network or system. It processes each resource, identifying its node based on the resource type and links information, and then updates the mapping of resources to nodes in the database.
 //
 // The function operates as follows:
-// - For CPU resources, it uses the CPU's deviceID as the nodeID directly, reflecting a self-referential node association.
+// - For the node anchors in the registry such as CPU, it uses the resource's deviceID as the nodeID directly, reflecting a self-referential node association.
 // - For other resource types, it extracts the nodeID from the 'deviceID' field within the first element of the 'links' array, which represents the connection to a CPU or another pivotal resource.
 // - If the nodeID is already present in the dbExistsNodes map, it adds the current resource to the node's resource dictionary.
 // - If the nodeID is not present, it creates a new entry in the dbExistsNodes map with the current resource.
@@ -772,36 +1233,11 @@ func mappingNodes(
 	deviceID := requestResource["deviceID"].(string)
 	resourceType := hwResourceType(requestResource["type"].(string))
 
-	links, ok := requestResource["links"]
-	if !ok {
-		return
-	}
-	if reflect.ValueOf(links).Kind() != reflect.Slice {
-		return
-	}
-	linkAnyList := links.([]any)
-	if len(linkAnyList) <= 0 {
+	nodeID = extractNodeID(requestResource)
+	if len(nodeID) == 0 {
 		return
 	}
 
-	// Obtain nodeID
-	switch resourceType {
-	case CPU:
-		// For CPU, use its own deviceID as the nodeID
-		nodeID = deviceID
-	default:
-		linkMap, ok := linkAnyList[0].(map[string]any)
-		if !ok {
-			return
-		}
-
-		// For resources other than CPU, the deviceID written in the links of the resource is the CPU's DeviceID, which is the nodeID
-		nodeID, ok = linkMap["deviceID"].(string)
-		if !ok {
-			return
-		}
-	}
-
 	existNodeData, ok := dbExistsNodes[nodeID]
 	if ok {
 		// If the node being processed exists in the existing DB or request node information, add it as resource information belonging to the node
@@ -840,24 +1276,20 @@ func mappingSwitches(
 	deviceID := requestResource["deviceID"].(string)
 	resourceType := hwResourceType(requestResource["type"].(string))
 
-	deviceSwitchInfo, deviceSwitchInfoOk := requestResource["deviceSwitchInfo"]
-	if deviceSwitchInfoOk {
-		var ok bool
-		switchID, ok = deviceSwitchInfo.(string)
-		if ok && len(switchID) > 0 {
-			existSwitchData, ok := dbExistsSwitches[switchID]
-			if ok {
-				// If the switch being processed exists in the existing DB or request switch information, add it as resource information belonging to the switch
-				existSwitchData.deviceDictionary[deviceID] = hwResourceType(resourceType)
-				dbExistsSwitches[switchID] = existSwitchData
-			} else {
-				// For a new switch, create resource information belonging to the switch
-				dbExistsSwitches[switchID] = existingNodeSwitch{
-					isNotDetected: false,
-					deviceDictionary: map[string]hwResourceType{
-						deviceID: hwResourceType(resourceType),
-					},
-				}
+	switchID = extractSwitchID(requestResource)
+	if len(switchID) > 0 {
+		existSwitchData, ok := dbExistsSwitches[switchID]
+		if ok {
+			// If the switch being processed exists in the existing DB or request switch information, add it as resource information belonging to the switch
+			existSwitchData.deviceDictionary[deviceID] = hwResourceType(resourceType)
+			dbExistsSwitches[switchID] = existSwitchData
+		} else {
+			// For a new switch, create resource information belonging to the switch
+			dbExistsSwitches[switchID] = existingNodeSwitch{
+				isNotDetected: false,
+				deviceDictionary: map[string]hwResourceType{
+					deviceID: hwResourceType(resourceType),
+				},
 			}
 		}
 	}
@@ -865,6 +1297,204 @@ func mappingSwitches(
 	return
 }
 
+// mappingChassis processes the 'location' of the requestResource to identify the chassis in which the resource is mounted
+// and the rack to which the chassis is attached, and updates dbExistsChassis accordingly.
+// If the chassis exists in dbExistsChassis, the resource is added to the chassis's device dictionary; otherwise a new entry is created.
+// If the location specifies a rack, the chassis is to be attached to the rack. A location without a chassis is ignored.
+//
+// Parameters:
+// - requestResource: A map representing a single resource, including its deviceID, type, and location.
+// - dbExistsChassis: A map of existing chassis, where each key is a chassisID.
+//
+// Returns:
+// - chassisID: The identifier of the chassis in which the resource is mounted, or an empty string if the location does not specify a chassis.
+// - err: An error if the chassis is specified to be attached to a different rack by another resource in the same request.
+func mappingChassis(
+	requestResource map[string]any,
+	dbExistsChassis map[string]existingChassis,
+) (chassisID string, err error) {
+	deviceID := requestResource["deviceID"].(string)
+	resourceType := hwResourceType(requestResource["type"].(string))
+
+	chassisID, rackID := extractLocation(requestResource)
+	if len(chassisID) == 0 {
+		return
+	}
+	mounted := mountedResource{resourceType: resourceType, slot: extractSlot(requestResource)}
+
+	existChassisData, ok := dbExistsChassis[chassisID]
+	if !ok {
+		// For a new chassis, create resource information mounted in the chassis
+		dbExistsChassis[chassisID] = existingChassis{
+			rackID:           rackID,
+			requestedRackID:  rackID,
+			deviceDictionary: map[string]mountedResource{deviceID: mounted},
+		}
+		return
+	}
+
+	if len(rackID) > 0 {
+		if len(existChassisData.requestedRackID) > 0 && existChassisData.requestedRackID != rackID {
+			// The rack of the chassis has already been specified by another resource in the same request
+			return "", invalidDevicef("JSON value check error [location]. chassis(%s) is located in both rack(%s) and rack(%s)", chassisID, existChassisData.requestedRackID, rackID)
+		}
+		existChassisData.rackID = rackID
+		existChassisData.requestedRackID = rackID
+	}
+	existChassisData.deviceDictionary[deviceID] = mounted
+	dbExistsChassis[chassisID] = existChassisData
+
+	return
+}
+
+// extractNodeID determines the node to which the resource belongs from the links information of the resource.
+// For the node anchors in the registry such as CPU resources, the resource's own deviceID is the nodeID. For other resources, the deviceID in the first element
+// of the 'links' array is the nodeID. An empty string is returned if the node cannot be determined.
+//
+// Parameters:
+// - requestResource: A map representing a single resource, including its deviceID, type, and links information.
+//
+// Returns:
+// - The identifier of the node associated with the resource, or an empty string.
+func extractNodeID(requestResource map[string]any) string {
+	links, ok := requestResource["links"]
+	if !ok {
+		return ""
+	}
+	if reflect.ValueOf(links).Kind() != reflect.Slice {
+		return ""
+	}
+	linkAnyList := links.([]any)
+	if len(linkAnyList) <= 0 {
+		return ""
+	}
+
+	// Obtain nodeID
+	if resourceType, ok := resourcetype.Registered().Lookup(requestResource["type"].(string)); ok && resourceType.NodeAnchor {
+		// For the node anchor such as CPU, use its own deviceID as the nodeID
+		nodeID, _ := requestResource["deviceID"].(string)
+		return nodeID
+	}
+	linkMap, ok := linkAnyList[0].(map[string]any)
+	if !ok {
+		return ""
+	}
+
+	// For resources other than the node anchors, the deviceID written in the links of the resource is the anchor's DeviceID such as the CPU's, which is the nodeID
+	nodeID, _ := linkMap["deviceID"].(string)
+	return nodeID
+}
+
+// extractSwitchID determines the CXL switch to which the resource is connected from the 'deviceSwitchInfo' of the resource.
+// An empty string is returned if 'deviceSwitchInfo' does not exist or is not a string.
+//
+// Parameters:
+// - requestResource: A map representing a single resource, including its deviceSwitchInfo.
+//
+// Returns:
+// - The identifier of the switch associated with the resource, or an empty string.
+func extractSwitchID(requestResource map[string]any) string {
+	switchID, _ := requestResource["deviceSwitchInfo"].(string)
+	return switchID
+}
+
+// extractLocation obtains the chassis and rack in which the resource is mounted from the 'location' of the resource.
+// Items that do not exist or are not strings are returned as empty strings.
+//
+// Parameters:
+// - requestResource: A map representing a single resource, including its location.
+//
+// Returns:
+// - chassisID: The identifier of the chassis in which the resource is mounted.
+// - rackID: The identifier of the rack to which the chassis is attached.
+func extractLocation(requestResource map[string]any) (chassisID string, rackID string) {
+	location, ok := requestResource["location"].(map[string]any)
+	if !ok {
+		return
+	}
+	chassisID, _ = location["chassisID"].(string)
+	rackID, _ = location["rackID"].(string)
+	return
+}
+
+// extractSlot obtains the slot in the chassis in which the resource is mounted from the 'location' of the resource.
+// An empty string is returned if the slot does not exist or is not a string.
+//
+// Parameters:
+// - requestResource: A map representing a single resource, including its location.
+//
+// Returns:
+// - The slot in the chassis, or an empty string.
+func extractSlot(requestResource map[string]any) string {
+	location, ok := requestResource["location"].(map[string]any)
+	if !ok {
+		return ""
+	}
+	slot, _ := location["slot"].(string)
+	return slot
+}
+
+// newDeviceContext creates the attributes of the resource evaluated by assignment rules.
+//
+// Parameters:
+// - requestResource: A map representing a single resource in the request.
+//
+// Returns:
+// - A DeviceContext holding the type, node, CXL switch, chassis, rack and properties of the resource.
+func newDeviceContext(requestResource map[string]any) cmapi_model_rule.DeviceContext {
+	chassisID, rackID := extractLocation(requestResource)
+	deviceID, _ := requestResource["deviceID"].(string)
+	resourceType, _ := requestResource["type"].(string)
+
+	return cmapi_model_rule.DeviceContext{
+		DeviceID:    deviceID,
+		Type:        resourceType,
+		NodeID:      extractNodeID(requestResource),
+		CXLSwitchID: extractSwitchID(requestResource),
+		ChassisID:   chassisID,
+		RackID:      rackID,
+		Device:      requestResource,
+	}
+}
+
+// resolveResourceGroupID determines the resource group to which a newly discovered resource is assigned.
+// The assignment rules are evaluated in order of priority, and the resource group of the first matched rule is returned.
+// If no rule matches, the default group is returned.
+// The invalid rules are skipped in the evaluation. If any of them precedes the matched rule, the resource might have been
+// assigned to another resource group, so an error naming them is returned together with the resource group decided by the valid rules.
+//
+// Parameters:
+// - rules: The assignment rules to be evaluated.
+// - requestResource: A map representing a single resource in the request.
+//
+// Returns:
+// - resourceGroupID: The identifier of the resource group to which the resource is assigned.
+// - matchedRule: The matched rule, or nil if the default group is applied.
+// - err: An error if an invalid rule was skipped before the matched rule.
+func resolveResourceGroupID(rules cmapi_model_rule.AssignmentRuleList, requestResource map[string]any) (resourceGroupID string, matchedRule *cmapi_model_rule.AssignmentRule, err error) {
+	matchedRule = rules.Resolve(newDeviceContext(requestResource))
+	resourceGroupID = common.DefaultGroupId
+	if matchedRule != nil {
+		resourceGroupID = matchedRule.ResourceGroupID()
+	}
+
+	// The rules are sorted in evaluation order by Resolve
+	skipped := []string{}
+	for i := range rules.Rules {
+		rule := &rules.Rules[i]
+		if rule == matchedRule {
+			break
+		}
+		if !rule.Validate() {
+			skipped = append(skipped, rule.Id)
+		}
+	}
+	if len(skipped) > 0 {
+		return resourceGroupID, matchedRule, fmt.Errorf("invalid assignment rules were skipped: %v", skipped)
+	}
+	return resourceGroupID, matchedRule, nil
+}
+
 // deleteDeviceIDFromOtherNodeSwitches removes a device from all nodes or switches except for a specified one.
 // This function iterates through the dbExists map, which contains the existing nodes and switches, identified by their IDs.
 // If a node or switch ID does not match the excludedNodeSwitchID, the function attempts to delete the deviceID from its deviceDictionary.
@@ -889,266 +1519,148 @@ func deleteDeviceIDFromOtherNodeSwitches(
 	}
 }
 
-// syncNotDetectedResource updates the database to reflect the not detected state of a resource.
-// This function is responsible for managing the state of resources in the database, specifically focusing on resources that are not detected.
-// It performs two main operations if the resource is marked as not detected:
-// 1. Deletes an existing edge between the resource vertex and the NotDetectedDevice vertex if such an edge exists.
-// 2. Creates a new edge between the resource vertex and the NotDetectedDevice vertex to indicate the resource is not detected.
-//
-// The function uses Cypher queries to interact with the graph database, constructing queries based on the resource type and device ID.
-// It logs the Cypher queries for debugging purposes and executes them using the age.ExecCypher function.
+// deleteDeviceIDFromOtherChassis removes a resource from all chassis except for the specified one.
+// It is the counterpart of deleteDeviceIDFromOtherNodeSwitches for chassis, so that a resource is mounted in only one chassis.
 //
 // Parameters:
-// - tx: A *sql.Tx transaction associated with the current database operation.
-// - deviceID: The unique identifier of the device associated with the resource.
-// - dbExistingResource: An existingResource struct containing details about the resource, including its not detected state and resource type.
-//
-// Returns:
-// - An error if the operation fails at any point, including errors in converting the resource type to a database label, deleting the existing edge, or creating a new edge.
-//
-// This function ensures that the database accurately reflects the detection state of resources, which is crucial for maintaining the integrity of the network topology.
-func syncNotDetectedResource(tx *sql.Tx, deviceID string, dbExistingResource existingResource) error {
-	if dbExistingResource.isNotDetected {
-		resourceType := dbExistingResource.resourceType
-		label, err := resourceType.convertToDBLabel()
-		if err != nil {
-			return err
-		}
-		// If the resource Vertex in the check result list and the NotDetectedDevice Vertex are already connected by an Edge, delete that Edge once
-		common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s", cypherDeleteResourceNotdetectedEdge, label, deviceID))
-		_, err = age.ExecCypher(tx, database.GRAPH_NAME, deleteColumnCount, cypherDeleteResourceNotdetectedEdge, label, deviceID)
-		if err != nil {
-			common.Log.Error(err.Error())
-			return err
-		}
-
-		// Connect the resource Vertex in the check result list and the NotDetectedDevice Vertex with an Edge
-		common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s", cypherCreateResourceNotdetectedEdge, label, deviceID))
-		_, err = age.ExecCypher(tx, database.GRAPH_NAME, deleteColumnCount, cypherCreateResourceNotdetectedEdge, label, deviceID)
-		if err != nil {
-			common.Log.Error(err.Error())
-			return err
+// - deviceID: The unique identifier of the resource to be removed.
+// - excludedChassisID: The ID of the chassis from which the resource should not be removed.
+// - dbExistsChassis: A map where the key is the chassis ID and the value is an existingChassis struct.
+func deleteDeviceIDFromOtherChassis(
+	deviceID string,
+	excludedChassisID string,
+	dbExistsChassis map[string]existingChassis,
+) {
+	for chassisID, exists := range dbExistsChassis {
+		if chassisID == excludedChassisID {
+			continue
 		}
+		delete(exists.deviceDictionary, deviceID)
 	}
-	return nil
 }
 
-// syncNode reflects the state of a node in the database by updating its vertex and edges.
-// This function performs several key operations to ensure the database accurately represents the current state of a node within the network:
-// 1. Deletes the NotDetected edge that connects the node vertex to the NotDetectedDevice vertex, if such an edge exists.
-// 2. Merges the node vertex to ensure it exists in the database with the correct properties.
-// 3. Deletes any existing Compose edges associated with the node vertex, which represent connections to other resources.
-// 4. Creates new Compose edges between the node and each of its resources, based on the existingNode.deviceDictionary.
+// syncNotDetectedResource updates the database to reflect the not detected state of a resource.
+// This function is responsible for managing the state of resources in the database, specifically focusing on resources that are not detected.
+// If the resource is marked as not detected, it performs one of the following operations:
+// 1. If the resource was already not detected, counts the hardware sync on the edge between the resource vertex and the NotDetectedDevice vertex.
+// 2. Otherwise, counts the miss of the resource, and creates an edge between the resource vertex and the NotDetectedDevice vertex to indicate
+// the resource is not detected since the first miss unless the damping (see notDetectedDamping) holds the miss pending.
 //
-// These operations are performed using Cypher queries, which are constructed and executed within the function. The function logs each query for debugging purposes.
+// The function uses Cypher queries to interact with the graph database, constructing queries based on the resource type and device ID.
+// It logs the Cypher queries for debugging purposes and executes them using the execCypher function.
 //
 // Parameters:
 // - tx: A *sql.Tx transaction associated with the current database operation.
-// - nodeID: The unique identifier of the node being synchronized.
-// - existingNode: An existingNodeSwitch struct representing the current state of the node, including its resources.
+// - deviceID: The unique identifier of the device associated with the resource.
+// - dbExistingResource: An existingResource struct containing details about the resource, including its not detected state and resource type.
 //
 // Returns:
-// - An error if any operation fails, including errors from deleting edges, merging the node vertex, or creating new edges.
+// - Whether the resource was newly put in the NotDetected state.
+// - An error if the operation fails at any point, including errors in converting the resource type to a database label, updating the existing edge, or creating a new edge.
 //
-// This function is crucial for maintaining the integrity and accuracy of the network topology represented in the database.
-func syncNode(tx *sql.Tx, nodeID string, existingNode existingNodeSwitch) error {
-	// Delete the NotDetected Edge that connects the Node Vertex and the NotDetectedDevice Vertex
-	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s", cypherDeleteNodeNotdetectedEdge, nodeID))
-	_, err := age.ExecCypher(tx, database.GRAPH_NAME, deleteColumnCount, cypherDeleteNodeNotdetectedEdge, nodeID)
-	if err != nil {
-		common.Log.Error(err.Error())
-		return err
+// The recorded time and number of hardware syncs are used by the retention policy to purge the resources not detected for a long time.
+func syncNotDetectedResource(tx *sql.Tx, deviceID string, dbExistingResource existingResource) (bool, error) {
+	if !dbExistingResource.isNotDetected {
+		return false, nil
+	}
+	if !dbExistingResource.wasNotDetected {
+		return notDetectedDampingSetting.missResource(tx, deviceID, dbExistingResource, time.Now().UTC())
 	}
 
-	// Merge the Node Vertex
-	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s", cypherMergeNode, nodeID, nodeID))
-	_, err = age.ExecCypher(tx, database.GRAPH_NAME, mergeColumnCount, cypherMergeNode, nodeID, nodeID)
+	label, err := dbExistingResource.resourceType.convertToDBLabel()
 	if err != nil {
-		common.Log.Error(err.Error())
-		return err
+		return false, err
 	}
-
-	// Delete the Compose Edge associated with the Node Vertex
-	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s", cypherDeleteComposeEdge, nodeID))
-	_, err = age.ExecCypher(tx, database.GRAPH_NAME, deleteColumnCount, cypherDeleteComposeEdge, nodeID)
+	now := cmapi_model.CurrentTimeISO8601()
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s, param3: %s", cypherIncrementResourceNotdetectedEdge, label, deviceID, now))
+	_, err = execCypher(tx, database.GRAPH_NAME, mergeColumnCount, cypherIncrementResourceNotdetectedEdge, label, deviceID, now)
 	if err != nil {
 		common.Log.Error(err.Error())
-		return err
-	}
-
-	// Connect the Node and Resource Vertices with a Compose Edge
-	for deviceID, resourceType := range existingNode.deviceDictionary {
-		label, err := resourceType.convertToDBLabel()
-		if err != nil {
-			return err
-		}
-		common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s, param3: %s", cypherCreateComposeEdge, label, deviceID, nodeID))
-		_, err = age.ExecCypher(tx, database.GRAPH_NAME, mergeColumnCount, cypherCreateComposeEdge, label, deviceID, nodeID)
-		if err != nil {
-			common.Log.Error(err.Error())
-			return err
-		}
+		return false, err
 	}
-	return nil
+	return false, nil
 }
 
-// syncSwitch updates the database to reflect the current state of a switch, including its connections.
-// This function ensures that the database accurately represents the switch's state by performing several operations:
-// 1. Deletes the NotDetected edge between the switch vertex and the NotDetectedDevice vertex, if it exists.
-// 2. Merges the switch vertex to ensure it exists in the database with the correct properties.
-// 3. Deletes all Connect edges associated with the switch vertex to remove outdated connections.
-// 4. Creates new Connect edges between the switch and its connected devices, based on the existingSwitch.deviceDictionary.
-//
-// These operations use Cypher queries to interact with the graph database. The function logs each query for debugging purposes and executes them to update the database.
-//
-// Parameters:
-// - tx: A *sql.Tx transaction associated with the current database operation.
-// - switchID: The unique identifier of the switch being synchronized.
-// - existingSwitch: An existingNodeSwitch struct representing the current state of the switch, including its connections.
-//
-// Returns:
-// - An error if any operation fails, including errors from deleting edges, merging the switch vertex, or creating new edges.
-//
-// This function plays a crucial role in maintaining the integrity and accuracy of the network topology represented in the database.
-//
-// Reflect the Switch's Vertex and Edge in the DB
-func syncSwitch(tx *sql.Tx, switchID string, existingSwitch existingNodeSwitch) error {
-	// Delete the NotDetected Edge that connects the Switch Vertex and the NotDetectedDevice Vertex
-	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s", cypherDeleteSwitchNotdetectedEdge, switchID))
-	_, err := age.ExecCypher(tx, database.GRAPH_NAME, deleteColumnCount, cypherDeleteSwitchNotdetectedEdge, switchID)
+// markNotDetectedResource puts the resource in the NotDetected state since the time in ISO 8601, replacing its notDetected edge if any.
+// missedSyncs is the number of hardware syncs that did not detect the resource to record on the edge.
+func markNotDetectedResource(tx *sql.Tx, deviceID string, resourceType hwResourceType, since string, missedSyncs int64) error {
+	label, err := resourceType.convertToDBLabel()
 	if err != nil {
-		common.Log.Error(err.Error())
 		return err
 	}
-
-	// Merge the Switch Vertex
-	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s", cypherMergeSwitch, switchID, switchID))
-	_, err = age.ExecCypher(tx, database.GRAPH_NAME, mergeColumnCount, cypherMergeSwitch, switchID, switchID)
+	// If the resource Vertex and the NotDetectedDevice Vertex are already connected by an Edge, delete that Edge once
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s", cypherDeleteResourceNotdetectedEdge, label, deviceID))
+	_, err = execCypher(tx, database.GRAPH_NAME, deleteColumnCount, cypherDeleteResourceNotdetectedEdge, label, deviceID)
 	if err != nil {
 		common.Log.Error(err.Error())
 		return err
 	}
 
-	// Delete all Connect Edges associated with the Switch Vertex
-	// After deletion, reattach all necessary Edges
-	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s", cypherDeleteConnectEdge, switchID))
-	_, err = age.ExecCypher(tx, database.GRAPH_NAME, deleteColumnCount, cypherDeleteConnectEdge, switchID)
+	// Connect the resource Vertex and the NotDetectedDevice Vertex with an Edge
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s, param3: %s, param4: %d", cypherCreateResourceNotdetectedEdge, label, deviceID, since, missedSyncs))
+	_, err = execCypher(tx, database.GRAPH_NAME, deleteColumnCount, cypherCreateResourceNotdetectedEdge, label, deviceID, since, missedSyncs)
 	if err != nil {
 		common.Log.Error(err.Error())
 		return err
 	}
+	return nil
+}
 
-	// Connect the Node and Switch Vertices with a Connect Edge
-	for deviceID, resourceType := range existingSwitch.deviceDictionary {
-		label, err := resourceType.convertToDBLabel()
-		if err != nil {
-			return err
-		}
-		common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s, param3: %s", cypherCreateConnectEdge, label, deviceID, switchID))
-		_, err = age.ExecCypher(tx, database.GRAPH_NAME, mergeColumnCount, cypherCreateConnectEdge, label, deviceID, switchID)
+// deleteNodesWithoutEdges physically deletes the nodes that do not have any Compose edge.
+// In the full scope, all such nodes are deleted. In a scoped hardware sync, only the nodes in nodeIDs, which are those reflected by it, are deleted.
+func deleteNodesWithoutEdges(tx *sql.Tx, scope syncScope, nodeIDs []string) error {
+	if scope.isFull() {
+		common.Log.Debug(fmt.Sprintf("query: %s", cypherDeleteNodeWithoutEdges))
+		_, err := execCypher(tx, database.GRAPH_NAME, deleteColumnCount, cypherDeleteNodeWithoutEdges)
 		if err != nil {
 			common.Log.Error(err.Error())
 			return err
 		}
+		return nil
 	}
-	return nil
+
+	ids := []any{}
+	for _, nodeID := range nodeIDs {
+		ids = append(ids, nodeID)
+	}
+	return execBatches(tx, cypherBulkDeleteNodeWithoutEdges, ids)
 }
 
-// mergeResource synchronizes the state of a resource in the database with its current state.
-// This function performs several key operations to ensure the database accurately reflects the resource's state:
-// 1. Deletes the Have edge between the Resource vertex and the Annotation vertex, if it exists.
-// 2. Merges the Resource vertex with updated properties from the requestResource map.
-// 3. Conditionally merges the Annotation vertex if the resource did not exist during the last hardware sync.
-// 4. Registers a new Have edge between the Resource and Annotation vertices.
-// 5. Deletes the NotDetected edge for the resource, if it exists.
-// 6. Conditionally creates a new Include edge from the "default group" to the resource if the resource did not exist during the last hardware sync.
-//
-// These operations involve executing Cypher queries to interact with the graph database, and the function logs each query for debugging purposes.
-//
-// Parameters:
-// - tx: A *sql.Tx transaction associated with the current database operation.
-// - deviceID: The unique identifier of the device associated with the resource.
-// - resourceType: An hwResourceType enum value representing the type of the resource.
-// - requestResource: A map containing the properties of the resource to be merged into the database.
-// - dbExistsResources: A map of existingResource structs representing resources that existed during the last hardware sync.
-//
-// Returns:
-// - An error if any operation fails, including errors from deleting edges, merging vertices, or creating new edges.
-//
-// This function is crucial for maintaining the accuracy and integrity of the resource information stored in the database.
-//
-// Merge Resource Vertex and Annotation Vertex
-func mergeResource(tx *sql.Tx, deviceID string, resourceType hwResourceType, requestResource map[string]any, dbExistsResources map[string]existingResource) error {
-	label, err := resourceType.convertToDBLabel()
+// attachChassisToRack moves the chassis to the rack. The rack vertex is merged, the Attach edge from the previous rack is replaced,
+// and the unit position of the chassis is removed because it is only valid in the previous rack.
+func attachChassisToRack(tx *sql.Tx, chassisID string, rackID string) error {
+	now := cmapi_model.CurrentTimeISO8601()
+
+	// Merge the Rack Vertex
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s", cypherMergeRack, rackID, now))
+	_, err := execCypher(tx, database.GRAPH_NAME, mergeColumnCount, cypherMergeRack, rackID, now, now)
 	if err != nil {
+		common.Log.Error(err.Error())
 		return err
 	}
 
-	// Merge the Resource Vertex
-	property, err := common.Map2CypherProperty(requestResource)
+	// Delete the Attach Edge from the previous Rack Vertex
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s", cypherDeleteAttachEdge, chassisID))
+	_, err = execCypher(tx, database.GRAPH_NAME, deleteColumnCount, cypherDeleteAttachEdge, chassisID)
 	if err != nil {
+		common.Log.Error(err.Error())
 		return err
 	}
-	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s, param3: %s", cyperMergeResource, label, deviceID, property))
-	_, err = age.ExecCypher(tx, database.GRAPH_NAME, mergeColumnCount, cyperMergeResource, label, deviceID, property)
+
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s", cypherRemoveUnitPosition, chassisID))
+	_, err = execCypher(tx, database.GRAPH_NAME, deleteColumnCount, cypherRemoveUnitPosition, chassisID)
 	if err != nil {
 		common.Log.Error(err.Error())
 		return err
 	}
 
-	if _, ok := dbExistsResources[deviceID]; !ok {
-		// For initial registration, create the Annotation Vertex
-		common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s", cypherCreateAnnotation, label, deviceID))
-		_, err := age.ExecCypher(tx, database.GRAPH_NAME, mergeColumnCount, cypherCreateAnnotation, label, deviceID)
-		if err != nil {
-			common.Log.Error(err.Error())
-			return err
-		}
-	}
-
-	// Delete the NotDetected Edge
-	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s", cypherDeleteResourceNotdetectedEdge, label, deviceID))
-	_, err = age.ExecCypher(tx, database.GRAPH_NAME, deleteColumnCount, cypherDeleteResourceNotdetectedEdge, label, deviceID)
+	// Connect the Rack and Chassis Vertices with an Attach Edge
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s", cypherCreateAttachEdge, rackID, chassisID))
+	_, err = execCypher(tx, database.GRAPH_NAME, mergeColumnCount, cypherCreateAttachEdge, rackID, chassisID)
 	if err != nil {
 		common.Log.Error(err.Error())
 		return err
 	}
-
-	if _, ok := dbExistsResources[deviceID]; !ok {
-		// For initial registration, create the Include Edge with the default group
-		common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s, param3: %s", cypherCreateIncludeEdge, label, deviceID, common.DefaultGroupId))
-		_, err := age.ExecCypher(tx, database.GRAPH_NAME, mergeColumnCount, cypherCreateIncludeEdge, label, deviceID, common.DefaultGroupId)
-		if err != nil {
-			common.Log.Error(err.Error())
-			return err
-		}
-	}
-
-	return nil
-}
-
-// mergeUnit merges unit resource data from a request with existing database resources.
-// It identifies non-removable device IDs from the request, creates a unit resource relation,
-// and registers the unit graph in the database if the relation is not registerable.
-//
-// Parameters:
-//   - tx: Database transaction for executing operations
-//   - requestResource: Map containing unit resource data from the request
-//   - dbExistsResources: Map of existing resources in the database indexed by string keys
-//
-// Returns:
-//   - error: Any error that occurred during the merge operation, nil if successful
-func mergeUnit(tx *sql.Tx, requestResource map[string]any, dbExistsResources map[string]existingResource) error {
-	nonRemovableDeviceIDs := getNonRemovableDeviceIds(requestResource)
-
-	unitResources := newUnitResources(requestResource, nonRemovableDeviceIDs)
-	if unitResources.isRegisterable() {
-		err := registerUnitGraph(tx, unitResources, dbExistsResources)
-		if err != nil {
-			return err
-		}
-	}
-
 	return nil
 }
 
@@ -1216,84 +1728,3 @@ func getNonRemovableDeviceIds(requestResource map[string]any) []string {
 	return res
 }
 
-// registerUnitGraph creates or updates a unit node in the graph database and establishes
-// containment relationships with its associated resources.
-//
-// The function performs the following operations:
-// 1. Merges a unit node and deletes existing containment relationships
-// 2. Creates new containment relationships between the unit and its resources
-//
-// Parameters:
-//   - tx: Database transaction for executing graph operations
-//   - unitResources: Contains unit device ID and associated resource information
-//   - dbExistsResources: Map of existing resources in the database to avoid duplicates
-//
-// Returns:
-//   - error: nil on success, otherwise an error describing what went wrong
-//
-// The function uses Cypher queries to interact with the Apache AGE graph database
-// and logs debug information for query execution and error details.
-func registerUnitGraph(tx *sql.Tx, unitResources unitResources, dbExistsResources map[string]existingResource) error {
-	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s", cypherMergeUnitAndDeleteContain, unitResources.unitDeviceID))
-	_, err := age.ExecCypher(tx, database.GRAPH_NAME, mergeColumnCount, cypherMergeUnitAndDeleteContain, unitResources.unitDeviceID)
-	if err != nil {
-		common.Log.Error(err.Error())
-		return err
-	}
-
-	matches, creates, err := createContainQuery(unitResources, dbExistsResources)
-	if err != nil {
-		common.Log.Error(err.Error())
-		return err
-	}
-
-	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s, param3: %s", cypherCreateContain, unitResources.unitDeviceID, matches, creates))
-	_, err = age.ExecCypher(tx, database.GRAPH_NAME, mergeColumnCount, cypherCreateContain, unitResources.unitDeviceID, matches, creates)
-	if err != nil {
-		common.Log.Error(err.Error())
-		return err
-	}
-
-	return nil
-}
-
-// createContainQuery constructs Cypher query parts for creating "contain" relationships
-// between a unit and its related devices in a Neo4j database.
-//
-// It takes a unitResources containing device IDs and a map of existing database resources,
-// then generates MATCH and CREATE clauses for each valid related device.
-//
-// Parameters:
-//   - unitResources: contains the list of related device IDs to process
-//   - dbExistsResources: map of device ID to existing resource information
-//
-// Returns:
-//   - string: comma-separated MATCH clauses for Cypher query
-//   - string: comma-separated CREATE clauses for Cypher query
-//   - error: any error encountered during label conversion
-//
-// The function skips devices that don't exist in dbExistsResources and logs warnings.
-// If a resource type cannot be converted to a database label, an error is returned.
-func createContainQuery(unitResources unitResources, dbExistsResources map[string]existingResource) (string, string, error) {
-	matches := make([]string, 0, len(unitResources.resourceDeviceIDs))
-	creates := make([]string, 0, len(unitResources.resourceDeviceIDs))
-
-	for i, relatedDeviceID := range unitResources.resourceDeviceIDs {
-		resource, ok := dbExistsResources[relatedDeviceID]
-		if !ok {
-			// This condition should not be reached unless the "nonRemovableDevices" in the resource received from HWControl contains an invalid deviceID
-			common.Log.Warn(fmt.Sprintf("Resource with deviceID %s does not exist in dbExistsResources", relatedDeviceID))
-			continue
-		}
-
-		label, err := resource.resourceType.convertToDBLabel()
-		if err != nil {
-			// This error should not occur because invalid resource types are already checked when registering resources in this API
-			return "", "", err
-		}
-		matches = append(matches, fmt.Sprintf(cypherCreateContainMatchParts, i, label, relatedDeviceID))
-		creates = append(creates, fmt.Sprintf(cypherCreateContainCreateParts, i))
-	}
-
-	return strings.Join(matches, ", "), strings.Join(creates, ", "), nil
-}
@@ -0,0 +1,145 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+	"github.com/project-cdim/configuration-manager/syncjob"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Environment variable to configure the maximum size in bytes of the request body of the hardware sync run as a sync job
+const envSyncAsyncMaxBodyBytes = "CM_SYNC_ASYNC_MAX_BODY_BYTES"
+
+// Default maximum size in bytes of the request body of the hardware sync run as a sync job (64 MiB).
+// The request body is held in memory and stored in a single column of the sync job, so the limit is much smaller than CM_SYNC_MAX_BODY_BYTES.
+const defaultSyncAsyncMaxBodyBytes int64 = 64 << 20
+
+// Maximum size in bytes of the request body of the hardware sync run as a sync job, loaded from the environment variable at startup
+var syncAsyncMaxBodyBytesSetting = loadSyncAsyncMaxBodyBytes(os.Getenv)
+
+// loadSyncAsyncMaxBodyBytes loads the maximum size in bytes of the request body of the hardware sync run as a sync job using getenv.
+// A size that is not a positive integer is ignored.
+func loadSyncAsyncMaxBodyBytes(getenv func(string) string) int64 {
+	if maxBodyBytes, err := strconv.ParseInt(strings.TrimSpace(getenv(envSyncAsyncMaxBodyBytes)), 10, 64); err == nil && maxBodyBytes > 0 {
+		return maxBodyBytes
+	}
+	return defaultSyncAsyncMaxBodyBytes
+}
+
+// Structure for storing the query parameters of a hardware sync, either requested to RegisterDevice or stored in a sync job.
+type syncRequest struct {
+	dryRun   bool
+	partial  bool
+	streamed bool // Whether the devices are streamed in the request body as NDJSON
+	scope    syncScope
+}
+
+// newSyncRequest converts the parameters stored in a sync job into a syncRequest.
+func newSyncRequest(parameters syncjob.Parameters) syncRequest {
+	return syncRequest{
+		dryRun:   parameters.DryRun,
+		partial:  parameters.Partial,
+		streamed: parameters.Streamed,
+		scope: syncScope{
+			source:       parameters.Source,
+			chassisIDs:   parameters.ChassisIDs,
+			cxlSwitchIDs: parameters.CxlSwitchIDs,
+		},
+	}
+}
+
+// toParameters converts the syncRequest into the parameters stored in a sync job.
+func (r *syncRequest) toParameters() syncjob.Parameters {
+	return syncjob.Parameters{
+		DryRun:       r.dryRun,
+		Partial:      r.partial,
+		Streamed:     r.streamed,
+		Source:       r.scope.source,
+		ChassisIDs:   r.scope.chassisIDs,
+		CxlSwitchIDs: r.scope.cxlSwitchIDs,
+	}
+}
+
+// enqueueSyncJob stores the request body of RegisterDevice as a sync job of the hardware sync requested by request,
+// and returns the queued job with a 202 Accepted status and its location.
+// The request body is read in full, within the maximum size limited by CM_SYNC_ASYNC_MAX_BODY_BYTES, but is not validated until the job is run.
+func enqueueSyncJob(c *gin.Context, funcName string, request syncRequest) {
+	content, err := readSyncJobContent(c, syncAsyncMaxBodyBytesSetting)
+	if err != nil {
+		// A request body larger than the maximum size is responded with 413 Request Entity Too Large
+		status, res := syncErrorResponse(funcName, "readSyncJobContent error", err)
+		c.JSON(status, res)
+		return
+	}
+
+	cmdb := database.NewCmDb()
+	err = cmdb.CmDbConnection()
+	if err != nil {
+		errorDatial := "CmDbConnection error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+	defer cmdb.CmDbDisconnection()
+
+	job, err := syncjob.Enqueue(cmdb.Db, request.toParameters(), content)
+	if err != nil {
+		errorDatial := "syncjob.Enqueue error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	res := job.ToObject()
+	logResponseBody(res)
+	common.Log.Info(fmt.Sprintf("%s[%s] completed successfully.", c.Request.URL.Path, c.Request.Method))
+
+	c.Header("Location", syncJobLocation(c.Request.URL.Path, job.ID))
+	c.JSON(http.StatusAccepted, res)
+}
+
+// readSyncJobContent reads the request body to store as the content of a sync job, limiting its size to the maximum size.
+// Reading more than the maximum size fails with an error for which isRequestBodyTooLarge is true.
+func readSyncJobContent(c *gin.Context, maxBodyBytes int64) ([]byte, error) {
+	limitSyncRequestBody(c, maxBodyBytes)
+	return io.ReadAll(c.Request.Body)
+}
+
+// syncJobLocation returns the path of the sync job of id, next to the path of the devices requested.
+func syncJobLocation(devicesPath string, id string) string {
+	return path.Join(path.Dir(devicesPath), "sync-jobs", id)
+}
+
+// RunSyncJob runs the hardware sync of the sync job in the same way as RegisterDevice, and returns the status and the body of its response.
+// It is the syncjob.Runner of the sync job worker. The number of the devices applied so far is reported with progress after each batch,
+// which is the whole request for the devices in a JSON array, or streamBatchSize devices for the devices streamed as NDJSON.
+// The response of a hardware sync that is committed is recorded with finish in its transaction.
+func RunSyncJob(job syncjob.Job, progress func(processed int) error, finish syncjob.Finish) (int, any) {
+	common.Log.Info(fmt.Sprintf("sync job %s start.", job.ID))
+	funcName := "RunSyncJob"
+	return runDeviceSync(funcName, newSyncRequest(job.Parameters), bytes.NewReader(job.Content), progress, finish)
+}
@@ -0,0 +1,136 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/project-cdim/configuration-manager/syncjob"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Test_loadSyncAsyncMaxBodyBytes(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  int64
+	}{
+		{name: "Normal case: Not set", value: "", want: defaultSyncAsyncMaxBodyBytes},
+		{name: "Normal case: A positive size", value: " 1048576 ", want: 1048576},
+		{name: "Error case: Zero is ignored", value: "0", want: defaultSyncAsyncMaxBodyBytes},
+		{name: "Error case: A size with a unit is ignored", value: "64MiB", want: defaultSyncAsyncMaxBodyBytes},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string {
+				if key == envSyncAsyncMaxBodyBytes {
+					return tt.value
+				}
+				return ""
+			}
+			if got := loadSyncAsyncMaxBodyBytes(getenv); got != tt.want {
+				t.Errorf("loadSyncAsyncMaxBodyBytes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// The default is much smaller than the maximum size of the request body of the hardware sync in the request
+	if defaultSyncAsyncMaxBodyBytes >= defaultSyncMaxBodyBytes {
+		t.Errorf("defaultSyncAsyncMaxBodyBytes = %v, want less than %v", defaultSyncAsyncMaxBodyBytes, defaultSyncMaxBodyBytes)
+	}
+}
+
+func Test_readSyncJobContent(t *testing.T) {
+	body := `{"deviceID":"res101","type":"CPU"}` + "\n"
+	tests := []struct {
+		name         string
+		maxBodyBytes int64
+		wantStatus   int
+	}{
+		{name: "Normal case: The request body is as large as the maximum size", maxBodyBytes: int64(len(body))},
+		{name: "Error case: The request body is one byte larger than the maximum size", maxBodyBytes: int64(len(body)) - 1, wantStatus: http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPost, "/devices?async=true", strings.NewReader(body))
+			c.Request.Header.Set("Content-Type", ndjsonContentType)
+
+			got, err := readSyncJobContent(c, tt.maxBodyBytes)
+			if tt.wantStatus == 0 {
+				if err != nil || string(got) != body {
+					t.Errorf("readSyncJobContent() = %q, %v, want %q", got, err, body)
+				}
+				return
+			}
+			if status, _ := syncErrorResponse("Test_readSyncJobContent", "readSyncJobContent error", err); status != tt.wantStatus {
+				t.Errorf("syncErrorResponse() status = %v, want %v", status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_newSyncRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		request syncRequest
+	}{
+		{
+			name:    "Normal case: Full scope",
+			request: syncRequest{dryRun: true, partial: true, scope: syncScope{}},
+		},
+		{
+			name:    "Normal case: Scoped and streamed",
+			request: syncRequest{streamed: true, scope: syncScope{source: "agent01", chassisIDs: []string{"chassis01"}, cxlSwitchIDs: []string{"sw01", "sw02"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The request is restored from the parameters stored in the sync job
+			if got := newSyncRequest(tt.request.toParameters()); !reflect.DeepEqual(got, tt.request) {
+				t.Errorf("newSyncRequest() = %+v, want %+v", got, tt.request)
+			}
+		})
+	}
+}
+
+func Test_syncRequest_toParameters(t *testing.T) {
+	request := syncRequest{dryRun: true, streamed: true, scope: syncScope{source: "agent01", chassisIDs: []string{"chassis01"}}}
+	want := syncjob.Parameters{DryRun: true, Streamed: true, Source: "agent01", ChassisIDs: []string{"chassis01"}}
+	if got := request.toParameters(); !reflect.DeepEqual(got, want) {
+		t.Errorf("toParameters() = %+v, want %+v", got, want)
+	}
+}
+
+func Test_enqueueSyncJob(t *testing.T) {
+	t.Skip("not test")
+}
+
+func Test_syncJobLocation(t *testing.T) {
+	got := syncJobLocation("/cdim/api/v1/devices", "0190a4c2-7e1f-7b3a-9c4d-2f6e8a1b3c5d")
+	if want := "/cdim/api/v1/sync-jobs/0190a4c2-7e1f-7b3a-9c4d-2f6e8a1b3c5d"; got != want {
+		t.Errorf("syncJobLocation() = %v, want %v", got, want)
+	}
+}
+
+func TestRunSyncJob(t *testing.T) {
+	t.Skip("not test")
+}
@@ -0,0 +1,465 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+	cmapi_model "github.com/project-cdim/configuration-manager/model"
+
+	"github.com/apache/age/drivers/golang/age"
+)
+
+// execCypher executes the Cypher queries of the hardware sync. The benchmarks replace it to count the statements.
+var execCypher = age.ExecCypher
+
+// The maximum number of rows written by a statement of the hardware sync, which bounds the size of the statement
+const bulkWriteBatchSize = 500
+
+// The hardware sync writes the vertices and edges with UNWIND over the lists of rows, executing a statement per label and per edge type
+// instead of per device. The first parameter of each query is the list of rows.
+
+// cypher query to merge the resources of a label
+// The properties are replaced with those reported by the hardware sync, keeping the time when the resource was first seen.
+const cypherBulkMergeResource = `
+	UNWIND %s AS row
+	MERGE (vrs:%s {deviceID: row.deviceID})
+	WITH vrs, row, coalesce(vrs.firstSeenAt, "%s") AS firstSeenAt
+	SET vrs = row.properties
+	SET vrs.firstSeenAt = firstSeenAt, vrs.lastSeenAt = "%s"
+`
+
+// cypher query to create the annotation vertices and have edges of the newly registered resources of a label
+const cypherBulkCreateAnnotation = `
+	UNWIND %s AS deviceID
+	MATCH (vrs:%s {deviceID: deviceID})
+	CREATE (van:Annotation {available: true})
+	CREATE (vrs)-[:Have]->(van)
+`
+
+// cypher query to delete notDetected edges from the resources of a label
+const cypherBulkDeleteResourceNotdetectedEdge = `
+	UNWIND %s AS deviceID
+	MATCH (:%s {deviceID: deviceID})-[endt:NotDetected]->(:NotDetectedDevice)
+	DELETE endt
+`
+
+// cypher query to create include edges to the newly registered resources of a label from the resource groups decided by the assignment rules
+const cypherBulkCreateIncludeEdge = `
+	UNWIND %s AS row
+	MATCH (vrs:%s {deviceID: row.deviceID}), (vrsg:ResourceGroups {id: row.resourceGroupID})
+	CREATE (vrsg)-[:Include]->(vrs)
+`
+
+// cypher query to delete reportedBy edges from the resources of a label
+const cypherBulkDeleteReportedByEdge = `
+	UNWIND %s AS deviceID
+	MATCH (:%s {deviceID: deviceID})-[erb:ReportedBy]->(:SyncSource)
+	DELETE erb
+`
+
+// cypher query to merge the source of scoped hardware syncs
+const cypherMergeSyncSource = `
+	MERGE (vss:SyncSource {id: '%s'})
+`
+
+// cypher query to create reportedBy edges from the resources of a label to the source of the hardware sync
+const cypherBulkCreateReportedByEdge = `
+	UNWIND %s AS deviceID
+	MATCH (vrs:%s {deviceID: deviceID}), (vss:SyncSource {id: '%s'})
+	CREATE (vrs)-[:ReportedBy]->(vss)
+`
+
+// cypher query to delete notDetected edges from the nodes or switches
+const cypherBulkDeleteNodeSwitchNotdetectedEdge = `
+	UNWIND %s AS vertexID
+	MATCH (:%s {id: vertexID})-[endt:NotDetected]->(:NotDetectedDevice)
+	DELETE endt
+`
+
+// cypher query to merge the nodes or switches, keeping the time when each was first seen
+const cypherBulkMergeNodeSwitch = `
+	UNWIND %s AS vertexID
+	MERGE (vns:%s {id: vertexID})
+	WITH vns, vertexID, coalesce(vns.firstSeenAt, "%s") AS firstSeenAt
+	SET vns = {id: vertexID, firstSeenAt: firstSeenAt, lastSeenAt: "%s"}
+`
+
+// cypher query to delete the compose edges of the nodes or the connect edges of the switches
+const cypherBulkDeleteNodeSwitchEdge = `
+	UNWIND %s AS vertexID
+	MATCH (:%s {id: vertexID})-[ens:%s]->()
+	DELETE ens
+`
+
+// cypher query to create the compose edges of the nodes or the connect edges of the switches to the resources of a label
+const cypherBulkCreateNodeSwitchEdge = `
+	UNWIND %s AS row
+	MATCH (vrs:%s {deviceID: row.deviceID}), (vns:%s {id: row.id})
+	CREATE (vns)-[:%s]->(vrs)
+`
+
+// cypher query to merge the chassis
+// The properties registered via the chassis API are kept, and the timestamps are set only when the chassis is created.
+const cypherBulkMergeChassis = `
+	UNWIND %s AS chassisID
+	MERGE (vch:Chassis {id: chassisID})
+	SET vch.createdAt = coalesce(vch.createdAt, "%s"), vch.updatedAt = coalesce(vch.updatedAt, "%s")
+`
+
+// cypher query to delete mount edges from the chassis to resources
+// Mount edges to CXL switches are kept because CXL switches are not reported by the hardware sync.
+const cypherBulkDeleteMountEdge = `
+	UNWIND %s AS chassisID
+	MATCH (:Chassis {id: chassisID})-[emt:Mount]->(vrs)
+	WHERE exists(vrs.deviceID)
+	DELETE emt
+`
+
+// cypher query to create mount edges from the chassis to the resources of a label, with the slots of the resources if specified
+const (
+	cypherBulkCreateMountEdge = `
+	UNWIND %s AS row
+	MATCH (vrs:%s {deviceID: row.deviceID}), (vch:Chassis {id: row.chassisID})
+	CREATE (vch)-[:Mount]->(vrs)
+`
+	cypherBulkCreateSlotMountEdge = `
+	UNWIND %s AS row
+	MATCH (vrs:%s {deviceID: row.deviceID}), (vch:Chassis {id: row.chassisID})
+	CREATE (vch)-[:Mount {slot: row.slot}]->(vrs)
+`
+)
+
+// cypher query to delete the specified nodes if they don't have at least one compose edge, used by a scoped hardware sync
+const cypherBulkDeleteNodeWithoutEdges = `
+	UNWIND %s AS nodeID
+	MATCH (vnd:Node {id: nodeID})
+	OPTIONAL MATCH (vnd)-[ecm:Compose]->() WITH vnd, count(ecm) AS edges
+	WHERE edges = 0
+	DETACH DELETE vnd
+`
+
+// cypher query to merge Unit vertices, and create Annotation vertex and Have edge for each unit that does not have an annotation.
+// The annotation is created only once so that the annotation updated via the unit API is kept.
+const cypherBulkMergeUnit = `
+	UNWIND %s AS unitID
+	MERGE (vut:Unit {deviceID: unitID})
+	WITH vut
+	OPTIONAL MATCH (vut)-[:Have]->(van:Annotation)
+	WITH vut, count(van) AS annotationCount
+	WHERE annotationCount = 0
+	CREATE (vut)-[:Have]->(:Annotation {available: true})
+`
+
+// cypher query to delete Contain edges of the units
+const cypherBulkDeleteContain = `
+	UNWIND %s AS unitID
+	MATCH (:Unit {deviceID: unitID})-[ect:Contain]->()
+	DELETE ect
+`
+
+// cypher query to create Contain edges from the units to the resources of a label
+const cypherBulkCreateContain = `
+	UNWIND %s AS row
+	MATCH (vut:Unit {deviceID: row.unitID}), (vrs:%s {deviceID: row.deviceID})
+	CREATE (vut)-[:Contain]->(vrs)
+`
+
+// Labels and edge types of the nodes and switches written by writeNodeSwitches
+const (
+	DB_Node         = "Node"
+	DB_CXLswitch    = "CXLswitch"
+	edgeTypeCompose = "Compose"
+	edgeTypeConnect = "Connect"
+)
+
+// Structure for storing the writes of the resources reported by the hardware sync, grouped by the labels of the resources
+type resourceWrites struct {
+	source       string           // The source of the hardware sync to record on the reportedBy edges
+	resources    map[string][]any // Rows of the resources to merge: {deviceID, properties}
+	deviceIDs    map[string][]any // Device IDs of the resources to merge
+	newDeviceIDs map[string][]any // Device IDs of the newly registered resources
+	includes     map[string][]any // Rows of the newly registered resources with their resource groups: {deviceID, resourceGroupID}
+	reportedBy   map[string][]any // Device IDs of the resources whose sources have changed
+	unchanged    map[string][]any // Device IDs of the resources whose content hashes have not changed
+}
+
+// newResourceWrites creates an empty resourceWrites for the hardware sync from the source.
+func newResourceWrites(source string) resourceWrites {
+	return resourceWrites{
+		source:       source,
+		resources:    map[string][]any{},
+		deviceIDs:    map[string][]any{},
+		newDeviceIDs: map[string][]any{},
+		includes:     map[string][]any{},
+		reportedBy:   map[string][]any{},
+		unchanged:    map[string][]any{},
+	}
+}
+
+// addResource adds the resource in the request to merge. A newly registered resource gets an annotation and is included in the resource group.
+func (w *resourceWrites) addResource(label string, deviceID string, requestResource map[string]any, isNew bool, resourceGroupID string) {
+	w.resources[label] = append(w.resources[label], map[string]any{"deviceID": deviceID, "properties": requestResource})
+	w.deviceIDs[label] = append(w.deviceIDs[label], deviceID)
+	if isNew {
+		w.newDeviceIDs[label] = append(w.newDeviceIDs[label], deviceID)
+		w.includes[label] = append(w.includes[label], map[string]any{"deviceID": deviceID, "resourceGroupID": resourceGroupID})
+	}
+}
+
+// addReportedBy adds the resource whose source has changed to replace its reportedBy edge.
+func (w *resourceWrites) addReportedBy(label string, deviceID string) {
+	w.reportedBy[label] = append(w.reportedBy[label], deviceID)
+}
+
+// addUnchanged adds the resource whose content hash has not changed to refresh the time when it was last seen.
+func (w *resourceWrites) addUnchanged(label string, deviceID string) {
+	w.unchanged[label] = append(w.unchanged[label], deviceID)
+}
+
+// execBatches executes the query for the rows in batches of at most bulkWriteBatchSize rows.
+// The Cypher list of the rows in a batch is the first argument of the query, followed by args.
+func execBatches(tx *sql.Tx, query string, rows []any, args ...any) error {
+	for start := 0; start < len(rows); start += bulkWriteBatchSize {
+		list, err := common.Slice2CypherProperty(rows[start:min(start+bulkWriteBatchSize, len(rows))])
+		if err != nil {
+			return err
+		}
+		params := append([]any{list}, args...)
+		common.Log.Debug(fmt.Sprintf("query: %s, params: %v", query, params))
+		_, err = execCypher(tx, database.GRAPH_NAME, mergeColumnCount, query, params...)
+		if err != nil {
+			common.Log.Error(err.Error())
+			return err
+		}
+	}
+	return nil
+}
+
+// writeResources merges the resources and records their sources in batches per label.
+// For each label, the resource vertices are merged, the newly registered resources get annotations, the NotDetected edges are deleted,
+// and the newly registered resources are included in the resource groups decided by the assignment rules.
+// Only the times when the unchanged resources were last seen are refreshed.
+func writeResources(tx *sql.Tx, writes resourceWrites) error {
+	now := cmapi_model.CurrentTimeISO8601()
+	for _, label := range sortedKeys(writes.resources) {
+		err := execBatches(tx, cypherBulkMergeResource, writes.resources[label], label, now, now)
+		if err != nil {
+			return err
+		}
+		err = execBatches(tx, cypherBulkCreateAnnotation, writes.newDeviceIDs[label], label)
+		if err != nil {
+			return err
+		}
+		err = execBatches(tx, cypherBulkDeleteResourceNotdetectedEdge, writes.deviceIDs[label], label)
+		if err != nil {
+			return err
+		}
+		err = execBatches(tx, cypherBulkCreateIncludeEdge, writes.includes[label], label)
+		if err != nil {
+			return err
+		}
+	}
+	for _, label := range sortedKeys(writes.unchanged) {
+		err := execBatches(tx, cypherBulkTouchResource, writes.unchanged[label], label, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Replace the ReportedBy Edges to the previous sources. If the source is empty, the ReportedBy Edges are only deleted.
+	for _, label := range sortedKeys(writes.reportedBy) {
+		err := execBatches(tx, cypherBulkDeleteReportedByEdge, writes.reportedBy[label], label)
+		if err != nil {
+			return err
+		}
+	}
+	if len(writes.source) == 0 || len(writes.reportedBy) == 0 {
+		return nil
+	}
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s", cypherMergeSyncSource, writes.source))
+	_, err := execCypher(tx, database.GRAPH_NAME, mergeColumnCount, cypherMergeSyncSource, writes.source)
+	if err != nil {
+		common.Log.Error(err.Error())
+		return err
+	}
+	for _, label := range sortedKeys(writes.reportedBy) {
+		err := execBatches(tx, cypherBulkCreateReportedByEdge, writes.reportedBy[label], label, writes.source)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeNodeSwitches reflects the nodes or switches of the IDs in batches. The vertices of the label are merged and their
+// NotDetected edges are deleted, and their edges of the edge type to the resources are replaced based on the deviceDictionary.
+func writeNodeSwitches(tx *sql.Tx, label string, edgeType string, ids []string, nodeSwitches map[string]existingNodeSwitch) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	vertexIDs := []any{}
+	edges := map[string][]any{}
+	for _, id := range ids {
+		vertexIDs = append(vertexIDs, id)
+		for _, deviceID := range sortedKeys(nodeSwitches[id].deviceDictionary) {
+			resourceLabel, err := nodeSwitches[id].deviceDictionary[deviceID].convertToDBLabel()
+			if err != nil {
+				return err
+			}
+			edges[resourceLabel] = append(edges[resourceLabel], map[string]any{"deviceID": deviceID, "id": id})
+		}
+	}
+
+	now := cmapi_model.CurrentTimeISO8601()
+	err := execBatches(tx, cypherBulkDeleteNodeSwitchNotdetectedEdge, vertexIDs, label)
+	if err != nil {
+		return err
+	}
+	err = execBatches(tx, cypherBulkMergeNodeSwitch, vertexIDs, label, now, now)
+	if err != nil {
+		return err
+	}
+	// Delete all Edges of the vertices, and reattach all necessary Edges
+	err = execBatches(tx, cypherBulkDeleteNodeSwitchEdge, vertexIDs, label, edgeType)
+	if err != nil {
+		return err
+	}
+	for _, resourceLabel := range sortedKeys(edges) {
+		err := execBatches(tx, cypherBulkCreateNodeSwitchEdge, edges[resourceLabel], resourceLabel, label, edgeType)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeChassis reflects the chassis of the IDs in batches, including their racks and mounted resources.
+// The chassis vertices are merged keeping the properties registered via the chassis API, the chassis to be attached to
+// different racks are moved (see attachChassisToRack), and the Mount edges to resources are replaced based on the deviceDictionary.
+func writeChassis(tx *sql.Tx, chassisIDs []string, chassis map[string]existingChassis) error {
+	if len(chassisIDs) == 0 {
+		return nil
+	}
+	ids := []any{}
+	mounts := map[string][]any{}
+	slotMounts := map[string][]any{}
+	for _, chassisID := range chassisIDs {
+		ids = append(ids, chassisID)
+		for _, deviceID := range sortedKeys(chassis[chassisID].deviceDictionary) {
+			mounted := chassis[chassisID].deviceDictionary[deviceID]
+			label, err := mounted.resourceType.convertToDBLabel()
+			if err != nil {
+				return err
+			}
+			if len(mounted.slot) > 0 {
+				slotMounts[label] = append(slotMounts[label], map[string]any{"deviceID": deviceID, "chassisID": chassisID, "slot": mounted.slot})
+			} else {
+				mounts[label] = append(mounts[label], map[string]any{"deviceID": deviceID, "chassisID": chassisID})
+			}
+		}
+	}
+
+	now := cmapi_model.CurrentTimeISO8601()
+	err := execBatches(tx, cypherBulkMergeChassis, ids, now, now)
+	if err != nil {
+		return err
+	}
+	for _, chassisID := range chassisIDs {
+		existing := chassis[chassisID]
+		if len(existing.rackID) > 0 && existing.rackID != existing.dbRackID {
+			err := attachChassisToRack(tx, chassisID, existing.rackID)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Delete all Mount Edges from the Chassis Vertices to resources, and reattach all necessary Edges
+	err = execBatches(tx, cypherBulkDeleteMountEdge, ids)
+	if err != nil {
+		return err
+	}
+	for _, label := range sortedKeys(mounts) {
+		err := execBatches(tx, cypherBulkCreateMountEdge, mounts[label], label)
+		if err != nil {
+			return err
+		}
+	}
+	for _, label := range sortedKeys(slotMounts) {
+		err := execBatches(tx, cypherBulkCreateSlotMountEdge, slotMounts[label], label)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeUnits merges the units of the resources in the request and replaces their Contain edges in batches (see newUnitResources).
+func writeUnits(tx *sql.Tx, units []unitResources, dbExistsResources map[string]existingResource) error {
+	if len(units) == 0 {
+		return nil
+	}
+	unitIDs, contains, err := newUnitContains(units, dbExistsResources)
+	if err != nil {
+		return err
+	}
+
+	err = execBatches(tx, cypherBulkMergeUnit, unitIDs)
+	if err != nil {
+		return err
+	}
+	err = execBatches(tx, cypherBulkDeleteContain, unitIDs)
+	if err != nil {
+		return err
+	}
+	for _, label := range sortedKeys(contains) {
+		err := execBatches(tx, cypherBulkCreateContain, contains[label], label)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newUnitContains creates the IDs of the units, and the rows of the Contain edges from the units to their resources grouped by
+// the labels of the resources: {unitID, deviceID}.
+// The resources that don't exist in dbExistsResources are skipped with warnings.
+// If a resource type cannot be converted to a database label, an error is returned.
+func newUnitContains(units []unitResources, dbExistsResources map[string]existingResource) ([]any, map[string][]any, error) {
+	unitIDs := []any{}
+	contains := map[string][]any{}
+	for _, unit := range units {
+		unitIDs = append(unitIDs, unit.unitDeviceID)
+		for _, relatedDeviceID := range unit.resourceDeviceIDs {
+			resource, ok := dbExistsResources[relatedDeviceID]
+			if !ok {
+				// This condition should not be reached unless the "nonRemovableDevices" in the resource received from HWControl contains an invalid deviceID
+				common.Log.Warn(fmt.Sprintf("Resource with deviceID %s does not exist in dbExistsResources", relatedDeviceID))
+				continue
+			}
+			label, err := resource.resourceType.convertToDBLabel()
+			if err != nil {
+				// This error should not occur because invalid resource types are already checked when registering resources in this API
+				return nil, nil, err
+			}
+			contains[label] = append(contains[label], map[string]any{"unitID": unit.unitDeviceID, "deviceID": relatedDeviceID})
+		}
+	}
+	return unitIDs, contains, nil
+}
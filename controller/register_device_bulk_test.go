@@ -0,0 +1,435 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/project-cdim/configuration-manager/common"
+	cmapi_model_rule "github.com/project-cdim/configuration-manager/model/rule"
+
+	"github.com/apache/age/drivers/golang/age"
+)
+
+// Structure for storing a statement executed via execCypher
+type recordedStatement struct {
+	query  string
+	params []any
+}
+
+// recordStatements replaces execCypher with a recorder of the statements during the test.
+func recordStatements(t testing.TB) *[]recordedStatement {
+	statements := &[]recordedStatement{}
+	originalExecCypher := execCypher
+	execCypher = func(tx *sql.Tx, graphName string, columnCount int, cypher string, args ...any) (*age.CypherCursor, error) {
+		*statements = append(*statements, recordedStatement{query: cypher, params: args})
+		return nil, nil
+	}
+	t.Cleanup(func() { execCypher = originalExecCypher })
+	return statements
+}
+
+// queriesOf returns the queries of the statements.
+func queriesOf(statements []recordedStatement) []string {
+	res := []string{}
+	for _, statement := range statements {
+		res = append(res, statement.query)
+	}
+	return res
+}
+
+func Test_resourceWrites_addResource(t *testing.T) {
+	cpu01 := map[string]any{"deviceID": "cpu01", "type": CPU}
+	mem01 := map[string]any{"deviceID": "mem01", "type": Memory}
+	writes := newResourceWrites("hwc01")
+	writes.addResource("CPU", "cpu01", cpu01, true, "group01")
+	writes.addResource("Memory", "mem01", mem01, false, common.DefaultGroupId)
+	writes.addReportedBy("CPU", "cpu01")
+	writes.addUnchanged("Memory", "mem02")
+
+	want := resourceWrites{
+		source: "hwc01",
+		resources: map[string][]any{
+			"CPU":    {map[string]any{"deviceID": "cpu01", "properties": cpu01}},
+			"Memory": {map[string]any{"deviceID": "mem01", "properties": mem01}},
+		},
+		deviceIDs:    map[string][]any{"CPU": {"cpu01"}, "Memory": {"mem01"}},
+		newDeviceIDs: map[string][]any{"CPU": {"cpu01"}},
+		includes:     map[string][]any{"CPU": {map[string]any{"deviceID": "cpu01", "resourceGroupID": "group01"}}},
+		reportedBy:   map[string][]any{"CPU": {"cpu01"}},
+		unchanged:    map[string][]any{"Memory": {"mem02"}},
+	}
+	if !reflect.DeepEqual(writes, want) {
+		t.Errorf("resourceWrites = %v, want %v", writes, want)
+	}
+}
+
+func Test_execBatches(t *testing.T) {
+	statements := recordStatements(t)
+	rows := []any{}
+	for i := range bulkWriteBatchSize*2 + 1 {
+		rows = append(rows, fmt.Sprintf("dev%04d", i))
+	}
+	if err := execBatches(nil, cypherBulkDeleteResourceNotdetectedEdge, rows, "CPU"); err != nil {
+		t.Fatal(err)
+	}
+	if len(*statements) != 3 {
+		t.Fatalf("execBatches() executed %d statements, want 3", len(*statements))
+	}
+	if want := []any{`["dev1000"]`, "CPU"}; !reflect.DeepEqual((*statements)[2].params, want) {
+		t.Errorf("execBatches() params = %v, want %v", (*statements)[2].params, want)
+	}
+
+	*statements = nil
+	if err := execBatches(nil, cypherBulkDeleteResourceNotdetectedEdge, []any{}, "CPU"); err != nil {
+		t.Fatal(err)
+	}
+	if len(*statements) != 0 {
+		t.Errorf("execBatches() executed %d statements for no rows, want 0", len(*statements))
+	}
+}
+
+func Test_writeResources(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   []string
+	}{
+		{
+			name:   "Normal case: The resources are written per label, the unchanged resources are touched, and the reportedBy edges are replaced",
+			source: "hwc01",
+			want: []string{
+				cypherBulkMergeResource, cypherBulkCreateAnnotation, cypherBulkDeleteResourceNotdetectedEdge, cypherBulkCreateIncludeEdge,
+				cypherBulkMergeResource, cypherBulkDeleteResourceNotdetectedEdge,
+				cypherBulkTouchResource,
+				cypherBulkDeleteReportedByEdge, cypherMergeSyncSource, cypherBulkCreateReportedByEdge,
+			},
+		},
+		{
+			name:   "Normal case: The reportedBy edges are only deleted by the hardware sync without a source",
+			source: "",
+			want: []string{
+				cypherBulkMergeResource, cypherBulkCreateAnnotation, cypherBulkDeleteResourceNotdetectedEdge, cypherBulkCreateIncludeEdge,
+				cypherBulkMergeResource, cypherBulkDeleteResourceNotdetectedEdge,
+				cypherBulkTouchResource,
+				cypherBulkDeleteReportedByEdge,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statements := recordStatements(t)
+			writes := newResourceWrites(tt.source)
+			writes.addResource("Memory", "mem01", map[string]any{"deviceID": "mem01", "type": Memory}, false, common.DefaultGroupId)
+			writes.addResource("CPU", "cpu01", map[string]any{"deviceID": "cpu01", "type": CPU}, true, common.DefaultGroupId)
+			writes.addReportedBy("Memory", "mem01")
+			writes.addUnchanged("Memory", "mem02")
+			if err := writeResources(nil, writes); err != nil {
+				t.Fatal(err)
+			}
+			if got := queriesOf(*statements); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("writeResources() queries = %v, want %v", got, tt.want)
+			}
+			// The labels are written in ascending order
+			if got := (*statements)[0].params[1]; got != "CPU" {
+				t.Errorf("writeResources() first label = %v, want CPU", got)
+			}
+		})
+	}
+}
+
+func Test_writeNodeSwitches(t *testing.T) {
+	statements := recordStatements(t)
+	nodes := map[string]existingNodeSwitch{
+		"cpu01": {deviceDictionary: map[string]hwResourceType{"cpu01": CPU, "mem01": Memory, "mem02": Memory}},
+		"cpu02": {deviceDictionary: map[string]hwResourceType{}},
+	}
+	if err := writeNodeSwitches(nil, DB_Node, edgeTypeCompose, []string{"cpu01", "cpu02"}, nodes); err != nil {
+		t.Fatal(err)
+	}
+	want := []recordedStatement{
+		{query: cypherBulkDeleteNodeSwitchNotdetectedEdge, params: []any{`["cpu01","cpu02"]`, DB_Node}},
+		{query: cypherBulkMergeNodeSwitch, params: []any{`["cpu01","cpu02"]`, DB_Node, (*statements)[1].params[2], (*statements)[1].params[3]}},
+		{query: cypherBulkDeleteNodeSwitchEdge, params: []any{`["cpu01","cpu02"]`, DB_Node, edgeTypeCompose}},
+		{query: cypherBulkCreateNodeSwitchEdge, params: []any{`[{deviceID:"cpu01",id:"cpu01"}]`, "CPU", DB_Node, edgeTypeCompose}},
+		{query: cypherBulkCreateNodeSwitchEdge, params: []any{`[{deviceID:"mem01",id:"cpu01"},{deviceID:"mem02",id:"cpu01"}]`, "Memory", DB_Node, edgeTypeCompose}},
+	}
+	if !reflect.DeepEqual(*statements, want) {
+		t.Errorf("writeNodeSwitches() statements = %v, want %v", *statements, want)
+	}
+
+	*statements = nil
+	if err := writeNodeSwitches(nil, DB_CXLswitch, edgeTypeConnect, []string{}, map[string]existingNodeSwitch{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(*statements) != 0 {
+		t.Errorf("writeNodeSwitches() executed %d statements for no switches, want 0", len(*statements))
+	}
+}
+
+func Test_writeChassis(t *testing.T) {
+	statements := recordStatements(t)
+	chassis := map[string]existingChassis{
+		"ch01": {rackID: "rack01", dbRackID: "rack01", deviceDictionary: map[string]mountedResource{
+			"cpu01": {resourceType: CPU, slot: "1"},
+			"mem01": {resourceType: Memory},
+		}},
+		"ch02": {rackID: "rack02", dbRackID: "rack01", deviceDictionary: map[string]mountedResource{}},
+	}
+	if err := writeChassis(nil, []string{"ch01", "ch02"}, chassis); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		cypherBulkMergeChassis,
+		// Only ch02 is moved to another rack
+		cypherMergeRack, cypherDeleteAttachEdge, cypherRemoveUnitPosition, cypherCreateAttachEdge,
+		cypherBulkDeleteMountEdge, cypherBulkCreateMountEdge, cypherBulkCreateSlotMountEdge,
+	}
+	if got := queriesOf(*statements); !reflect.DeepEqual(got, want) {
+		t.Errorf("writeChassis() queries = %v, want %v", got, want)
+	}
+	if got, want := (*statements)[7].params, []any{`[{chassisID:"ch01",deviceID:"cpu01",slot:"1"}]`, "CPU"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("writeChassis() params = %v, want %v", got, want)
+	}
+}
+
+func Test_writeUnits(t *testing.T) {
+	statements := recordStatements(t)
+	units := []unitResources{
+		{unitDeviceID: "cpu01", resourceDeviceIDs: []string{"cpu01", "mem01"}},
+		{unitDeviceID: "mem01", resourceDeviceIDs: []string{"mem01"}},
+	}
+	dbExistsResources := map[string]existingResource{
+		"cpu01": {resourceType: CPU},
+		"mem01": {resourceType: Memory},
+		"mem02": {resourceType: Memory},
+	}
+	if err := writeUnits(nil, units, dbExistsResources); err != nil {
+		t.Fatal(err)
+	}
+	want := []recordedStatement{
+		{query: cypherBulkMergeUnit, params: []any{`["cpu01","mem01"]`}},
+		{query: cypherBulkDeleteContain, params: []any{`["cpu01","mem01"]`}},
+		{query: cypherBulkCreateContain, params: []any{`[{deviceID:"cpu01",unitID:"cpu01"}]`, "CPU"}},
+		{query: cypherBulkCreateContain, params: []any{`[{deviceID:"mem01",unitID:"cpu01"},{deviceID:"mem01",unitID:"mem01"}]`, "Memory"}},
+	}
+	if !reflect.DeepEqual(*statements, want) {
+		t.Errorf("writeUnits() statements = %v, want %v", *statements, want)
+	}
+}
+
+func Test_newUnitContains(t *testing.T) {
+	tests := []struct {
+		name              string
+		units             []unitResources
+		dbExistsResources map[string]existingResource
+		wantUnitIDs       []any
+		wantContains      map[string][]any
+		wantErr           bool
+	}{
+		{
+			name:              "Normal case: The unit without the related devices",
+			units:             []unitResources{{unitDeviceID: "unit001", resourceDeviceIDs: []string{}}},
+			dbExistsResources: map[string]existingResource{},
+			wantUnitIDs:       []any{"unit001"},
+			wantContains:      map[string][]any{},
+		},
+		{
+			name: "Normal case: The related devices are grouped by the labels",
+			units: []unitResources{
+				{unitDeviceID: "unit001", resourceDeviceIDs: []string{"dev001", "dev002", "dev003"}},
+				{unitDeviceID: "unit002", resourceDeviceIDs: []string{"dev004"}},
+			},
+			dbExistsResources: map[string]existingResource{
+				"dev001": {resourceType: hwResourceType(CPU)},
+				"dev002": {resourceType: hwResourceType(Memory)},
+				"dev003": {resourceType: hwResourceType(Memory)},
+				"dev004": {resourceType: hwResourceType(GPU)},
+			},
+			wantUnitIDs: []any{"unit001", "unit002"},
+			wantContains: map[string][]any{
+				"CPU":    {map[string]any{"unitID": "unit001", "deviceID": "dev001"}},
+				"Memory": {map[string]any{"unitID": "unit001", "deviceID": "dev002"}, map[string]any{"unitID": "unit001", "deviceID": "dev003"}},
+				"GPU":    {map[string]any{"unitID": "unit002", "deviceID": "dev004"}},
+			},
+		},
+		{
+			name:  "Normal case: The related device not in dbExistsResources is skipped",
+			units: []unitResources{{unitDeviceID: "unit001", resourceDeviceIDs: []string{"dev001", "dev999"}}},
+			dbExistsResources: map[string]existingResource{
+				"dev001": {resourceType: hwResourceType(CPU)},
+			},
+			wantUnitIDs:  []any{"unit001"},
+			wantContains: map[string][]any{"CPU": {map[string]any{"unitID": "unit001", "deviceID": "dev001"}}},
+		},
+		{
+			name:  "Error case: The resource type of the related device is unknown",
+			units: []unitResources{{unitDeviceID: "unit001", resourceDeviceIDs: []string{"dev001"}}},
+			dbExistsResources: map[string]existingResource{
+				"dev001": {resourceType: hwResourceType("unknown")},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotUnitIDs, gotContains, err := newUnitContains(tt.units, tt.dbExistsResources)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newUnitContains() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotUnitIDs, tt.wantUnitIDs) {
+				t.Errorf("newUnitContains() gotUnitIDs = %v, want %v", gotUnitIDs, tt.wantUnitIDs)
+			}
+			if !reflect.DeepEqual(gotContains, tt.wantContains) {
+				t.Errorf("newUnitContains() gotContains = %v, want %v", gotContains, tt.wantContains)
+			}
+		})
+	}
+}
+
+// Latency of a statement simulated by the benchmarks, which is dominated by the two round-trips to prepare and execute it
+const benchmarkStatementLatency = 100 * time.Microsecond
+
+// newSyntheticInventory creates the devices reported by the hardware sync for the servers.
+// A server has a CPU with two non-removable memories, a storage connected to a CXL switch and a network interface.
+// Four servers are mounted in a chassis, and eight chassis are attached to a rack.
+func newSyntheticInventory(servers int) []map[string]any {
+	inventory := []map[string]any{}
+	for server := range servers {
+		cpuID := fmt.Sprintf("cpu-%05d", server)
+		memoryIDs := []string{fmt.Sprintf("mem-%05d-0", server), fmt.Sprintf("mem-%05d-1", server)}
+		location := func(slot int) map[string]any {
+			return map[string]any{
+				"chassisID": fmt.Sprintf("ch-%04d", server/4),
+				"rackID":    fmt.Sprintf("rack-%03d", server/32),
+				"slot":      fmt.Sprintf("%d-%d", server%4, slot),
+			}
+		}
+		linkToCPU := []any{map[string]any{"type": CPU, "deviceID": cpuID}}
+		status := map[string]any{"state": "Enabled", "health": "OK"}
+
+		inventory = append(inventory, map[string]any{
+			"deviceID": cpuID, "type": CPU, "status": status, "location": location(0),
+			"links": []any{
+				map[string]any{"type": Memory, "deviceID": memoryIDs[0]},
+				map[string]any{"type": Memory, "deviceID": memoryIDs[1]},
+			},
+			"constraints": map[string]any{
+				"nonRemovableDevices": []any{map[string]any{"deviceID": memoryIDs[0]}, map[string]any{"deviceID": memoryIDs[1]}},
+			},
+		})
+		for i, memoryID := range memoryIDs {
+			inventory = append(inventory, map[string]any{
+				"deviceID": memoryID, "type": Memory, "status": status, "location": location(i + 1), "links": linkToCPU,
+				"capacityMiB": 65536,
+			})
+		}
+		inventory = append(inventory, map[string]any{
+			"deviceID": fmt.Sprintf("sto-%05d", server), "type": Storage, "status": status, "location": location(3), "links": linkToCPU,
+			"deviceSwitchInfo": fmt.Sprintf("cxl-%03d", server/16),
+		})
+		inventory = append(inventory, map[string]any{
+			"deviceID": fmt.Sprintf("nic-%05d", server), "type": NetworkInterface, "status": status, "location": location(4), "links": linkToCPU,
+		})
+	}
+	return inventory
+}
+
+// newSyntheticExistingState creates the state of the database in which the inventory has already been synchronized.
+// If hashed is true, the resources hold the content hashes of the inventory, so that they are unchanged by its hardware sync.
+func newSyntheticExistingState(inventory []map[string]any, hashed bool) (map[string]existingResource, map[string]existingNodeSwitch, map[string]existingNodeSwitch, map[string]existingChassis) {
+	resources := map[string]existingResource{}
+	for _, device := range inventory {
+		contentHash := ""
+		if hashed {
+			contentHash, _ = deviceContentHash(device)
+		}
+		resources[device["deviceID"].(string)] = existingResource{
+			isNotDetected:    true,
+			resourceType:     hwResourceType(device["type"].(string)),
+			resourceGroupIDs: []string{},
+			contentHash:      contentHash,
+		}
+	}
+	nodes := map[string]existingNodeSwitch{}
+	switches := map[string]existingNodeSwitch{}
+	chassis := map[string]existingChassis{}
+	for _, device := range inventory {
+		mappingNodes(device, nodes)
+		mappingSwitches(device, switches)
+		mappingChassis(device, chassis)
+	}
+	for chassisID, existing := range chassis {
+		existing.dbRackID = existing.rackID
+		existing.requestedRackID = ""
+		chassis[chassisID] = existing
+	}
+	return resources, nodes, switches, chassis
+}
+
+// BenchmarkRegisterResources measures the hardware sync of synthetic inventories with a simulated latency per statement.
+// The number of the statements executed by a hardware sync is reported as statements/op.
+// The inventory is synchronized initially, again with changed devices, and again with unchanged devices.
+//
+// Baseline measured with the hardware sync issuing the statements per device, before they were batched by UNWIND,
+// against the batched hardware sync (-benchtime 3x). The unchanged devices were not skipped by the baseline:
+//
+//	devices  sync       baseline statements/op  time/op  batched statements/op  time/op
+//	500      initial                      5072   5.93 s                    140   0.23 s
+//	500      resync                       3972   4.51 s                     32   0.10 s
+//	500      unchanged                    3972   4.54 s                     28   0.07 s
+//	5000     initial                     50690   57.5 s                   1108   3.20 s
+//	5000     resync                      39690   46.0 s                     88   2.05 s
+//	5000     unchanged                   39690   46.4 s                     78   1.10 s
+func BenchmarkRegisterResources(b *testing.B) {
+	var statements atomic.Int64
+	originalExecCypher := execCypher
+	execCypher = func(tx *sql.Tx, graphName string, columnCount int, cypher string, args ...any) (*age.CypherCursor, error) {
+		// Build the statement as the driver does
+		_ = fmt.Sprintf(cypher, args...)
+		statements.Add(1)
+		time.Sleep(benchmarkStatementLatency)
+		return nil, nil
+	}
+	defer func() { execCypher = originalExecCypher }()
+
+	for _, servers := range []int{100, 1000} {
+		inventory := newSyntheticInventory(servers)
+		for _, sync := range []string{"initial", "resync", "unchanged"} {
+			b.Run(fmt.Sprintf("devices=%d/%s", len(inventory), sync), func(b *testing.B) {
+				statements.Store(0)
+				for b.Loop() {
+					b.StopTimer()
+					resources, nodes, switches, chassis := map[string]existingResource{}, map[string]existingNodeSwitch{}, map[string]existingNodeSwitch{}, map[string]existingChassis{}
+					if sync != "initial" {
+						resources, nodes, switches, chassis = newSyntheticExistingState(inventory, sync == "unchanged")
+					}
+					b.StartTimer()
+					_, err := registerResources(nil, resources, nodes, switches, chassis, &resourceRegister{resource: inventory}, cmapi_model_rule.AssignmentRuleList{}, syncScope{})
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+				b.ReportMetric(float64(statements.Load())/float64(b.N), "statements/op")
+			})
+		}
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"database/sql"
+	"slices"
+
+	"github.com/project-cdim/configuration-manager/changelog"
+	cmapi_model "github.com/project-cdim/configuration-manager/model"
+	"github.com/project-cdim/configuration-manager/outbox"
+
+	"github.com/google/uuid"
+)
+
+// Version of the payload of the hardware sync completed event.
+// Increase the major version when making a change that is incompatible with the existing subscribers.
+// The minor version 1.1 added the unchanged devices, which were counted as updated in 1.0.
+const hwSyncCompletedEventVersion = "1.1"
+
+// CloudEvents type of the hardware sync completed event
+const hwSyncCompletedEventType = "configuration_manager.hwsync.completed"
+
+// Dapr pub/sub component and topic of the hardware sync completed event
+const (
+	hwSyncCompletedEventPubsubName = "configuration_manager_hwsync"
+	hwSyncCompletedEventTopic      = "configuration_manager.hwsync.completed"
+)
+
+// Structure for storing the result of the hardware sync, used as the response and the payload of the hardware sync completed event
+type syncResult struct {
+	registeredDeviceIDs  []string // Device IDs registered by the request
+	addedDeviceIDs       []string // Device IDs registered for the first time
+	updatedDeviceIDs     []string // Device IDs of existing resources that were detected again with changed device information
+	unchangedDeviceIDs   []string // Device IDs of existing resources that were detected again with unchanged device information
+	notDetectedDeviceIDs []string // Device IDs of resources that were newly put in the NotDetected state
+	pendingDeviceIDs     []string // Device IDs of resources that were missed but held pending by the NotDetected damping
+	redetectedDeviceIDs  []string // Device IDs of resources that were detected after being in the NotDetected state
+	purgedDeviceIDs      []string // Device IDs of resources that were purged by the retention policy
+	quarantinedDeviceIDs []string // Device IDs of devices of unsupported resource types that were quarantined
+	promotedDeviceIDs    []string // Device IDs of quarantined devices that were promoted to resources
+	createdNodeIDs       []string
+	removedNodeIDs       []string
+	createdSwitchIDs     []string
+	removedSwitchIDs     []string
+	scope                syncScope       // Scope of the hardware sync
+	scopedDeviceIDs      map[string]bool // IDs of the existing resources in the scope at the start of the hardware sync, or nil for the full scope
+}
+
+// newSyncResult creates an empty syncResult whose lists are not nil so that they are serialized as empty arrays.
+func newSyncResult() syncResult {
+	return syncResult{
+		registeredDeviceIDs:  []string{},
+		addedDeviceIDs:       []string{},
+		updatedDeviceIDs:     []string{},
+		unchangedDeviceIDs:   []string{},
+		notDetectedDeviceIDs: []string{},
+		pendingDeviceIDs:     []string{},
+		redetectedDeviceIDs:  []string{},
+		purgedDeviceIDs:      []string{},
+		quarantinedDeviceIDs: []string{},
+		promotedDeviceIDs:    []string{},
+		createdNodeIDs:       []string{},
+		removedNodeIDs:       []string{},
+		createdSwitchIDs:     []string{},
+		removedSwitchIDs:     []string{},
+	}
+}
+
+// addDetectedDevice classifies a device included in the request as added, updated, unchanged or re-detected,
+// based on the state of the resource in the DB at the start of the hardware sync and whether its content hash has changed.
+// It must be called before the state of the device in dbExistsResources is updated.
+func (sr *syncResult) addDetectedDevice(deviceID string, dbExistsResources map[string]existingResource, unchanged bool) {
+	existing, ok := dbExistsResources[deviceID]
+	switch {
+	case !ok:
+		sr.addedDeviceIDs = append(sr.addedDeviceIDs, deviceID)
+	case existing.wasNotDetected:
+		sr.redetectedDeviceIDs = append(sr.redetectedDeviceIDs, deviceID)
+	case unchanged:
+		sr.unchangedDeviceIDs = append(sr.unchangedDeviceIDs, deviceID)
+	default:
+		sr.updatedDeviceIDs = append(sr.updatedDeviceIDs, deviceID)
+	}
+}
+
+// addNodeSwitchChanges records the nodes and switches created or removed by the hardware sync.
+// dbNodeIDs and dbSwitchIDs are the IDs that existed at the start of the hardware sync, and dbExistsNodes and dbExistsSwitches
+// are the mapped states after processing the request.
+// A node without any resources is physically deleted, whereas a switch is never deleted.
+func (sr *syncResult) addNodeSwitchChanges(
+	dbNodeIDs []string,
+	dbExistsNodes map[string]existingNodeSwitch,
+	dbSwitchIDs []string,
+	dbExistsSwitches map[string]existingNodeSwitch,
+) {
+	for nodeID, existingNode := range dbExistsNodes {
+		if !slices.Contains(dbNodeIDs, nodeID) {
+			sr.createdNodeIDs = append(sr.createdNodeIDs, nodeID)
+		} else if len(existingNode.deviceDictionary) == 0 {
+			sr.removedNodeIDs = append(sr.removedNodeIDs, nodeID)
+		}
+	}
+	for switchID := range dbExistsSwitches {
+		if !slices.Contains(dbSwitchIDs, switchID) {
+			sr.createdSwitchIDs = append(sr.createdSwitchIDs, switchID)
+		}
+	}
+}
+
+// addQuarantinePlan records the devices quarantined and promoted by the hardware sync.
+// The promoted devices are also recorded as added when they are registered as resources.
+func (sr *syncResult) addQuarantinePlan(plan quarantinePlan) {
+	for _, requestResource := range plan.quarantine {
+		sr.quarantinedDeviceIDs = append(sr.quarantinedDeviceIDs, requestResource["deviceID"].(string))
+	}
+	sr.promotedDeviceIDs = append(sr.promotedDeviceIDs, plan.promote...)
+}
+
+// sort sorts every list except the registered device IDs, which keep the order of the request.
+func (sr *syncResult) sort() {
+	for _, ids := range []*[]string{
+		&sr.addedDeviceIDs,
+		&sr.updatedDeviceIDs,
+		&sr.unchangedDeviceIDs,
+		&sr.notDetectedDeviceIDs,
+		&sr.pendingDeviceIDs,
+		&sr.redetectedDeviceIDs,
+		&sr.purgedDeviceIDs,
+		&sr.quarantinedDeviceIDs,
+		&sr.promotedDeviceIDs,
+		&sr.createdNodeIDs,
+		&sr.removedNodeIDs,
+		&sr.createdSwitchIDs,
+		&sr.removedSwitchIDs,
+	} {
+		slices.Sort(*ids)
+		*ids = slices.Compact(*ids)
+	}
+}
+
+// toEventData converts the result into the payload of the hardware sync completed event.
+// The syncID is also used as the ID of the CloudEvent so that subscribers can detect redelivered events.
+func (sr *syncResult) toEventData() map[string]any {
+	syncID, _ := uuid.NewV7()
+	return map[string]any{
+		"version":   hwSyncCompletedEventVersion,
+		"syncID":    syncID.String(),
+		"timestamp": cmapi_model.CurrentTimeISO8601(),
+		"counts": map[string]any{
+			"registered":      len(sr.registeredDeviceIDs),
+			"added":           len(sr.addedDeviceIDs),
+			"updated":         len(sr.updatedDeviceIDs),
+			"unchanged":       len(sr.unchangedDeviceIDs),
+			"notDetected":     len(sr.notDetectedDeviceIDs),
+			"pending":         len(sr.pendingDeviceIDs),
+			"redetected":      len(sr.redetectedDeviceIDs),
+			"purged":          len(sr.purgedDeviceIDs),
+			"quarantined":     len(sr.quarantinedDeviceIDs),
+			"promoted":        len(sr.promotedDeviceIDs),
+			"createdNodes":    len(sr.createdNodeIDs),
+			"removedNodes":    len(sr.removedNodeIDs),
+			"createdSwitches": len(sr.createdSwitchIDs),
+			"removedSwitches": len(sr.removedSwitchIDs),
+		},
+		"devices": map[string]any{
+			"added":       sr.addedDeviceIDs,
+			"updated":     sr.updatedDeviceIDs,
+			"unchanged":   sr.unchangedDeviceIDs,
+			"notDetected": sr.notDetectedDeviceIDs,
+			"pending":     sr.pendingDeviceIDs,
+			"redetected":  sr.redetectedDeviceIDs,
+			"purged":      sr.purgedDeviceIDs,
+			"quarantined": sr.quarantinedDeviceIDs,
+			"promoted":    sr.promotedDeviceIDs,
+		},
+		"nodes": map[string]any{
+			"created": sr.createdNodeIDs,
+			"removed": sr.removedNodeIDs,
+		},
+		"cxlSwitches": map[string]any{
+			"created": sr.createdSwitchIDs,
+			"removed": sr.removedSwitchIDs,
+		},
+		"scope": sr.scope.toObject(),
+	}
+}
+
+// toDomainEvents converts the result into the domain events of the resources, nodes and CXL switches changed by the hardware sync.
+func (sr *syncResult) toDomainEvents() []domainEvent {
+	events := []domainEvent{}
+	for _, deviceID := range sr.addedDeviceIDs {
+		events = append(events, newDomainEvent(domainEventResourceCreated, deviceID, map[string]any{"deviceID": deviceID}))
+	}
+	for _, deviceID := range sr.notDetectedDeviceIDs {
+		events = append(events, newDomainEvent(domainEventResourceNotDetected, deviceID, map[string]any{"deviceID": deviceID}))
+	}
+	for _, deviceID := range sr.purgedDeviceIDs {
+		events = append(events, newDomainEvent(domainEventResourceDeleted, deviceID, map[string]any{"deviceID": deviceID}))
+	}
+	for _, deviceID := range sr.quarantinedDeviceIDs {
+		events = append(events, newDomainEvent(domainEventResourceQuarantined, deviceID, map[string]any{"deviceID": deviceID}))
+	}
+	for _, nodeID := range sr.createdNodeIDs {
+		events = append(events, newDomainEvent(domainEventNodeComposed, nodeID, map[string]any{"nodeID": nodeID}))
+	}
+	for _, nodeID := range sr.removedNodeIDs {
+		events = append(events, newDomainEvent(domainEventNodeDecomposed, nodeID, map[string]any{"nodeID": nodeID}))
+	}
+	for _, switchID := range sr.createdSwitchIDs {
+		events = append(events, newDomainEvent(domainEventCxlSwitchConnected, switchID, map[string]any{"switchID": switchID}))
+	}
+	return events
+}
+
+// toOutboxEvent converts the result into the hardware sync completed event to write to the outbox.
+func (sr *syncResult) toOutboxEvent() outbox.Event {
+	eventData := sr.toEventData()
+	return outbox.Event{
+		ID:         eventData["syncID"].(string),
+		PubsubName: hwSyncCompletedEventPubsubName,
+		Topic:      hwSyncCompletedEventTopic,
+		Type:       hwSyncCompletedEventType,
+		Payload:    eventData,
+	}
+}
+
+// enqueueHwSyncEvents records the domain events of the result and the hardware sync completed event in the change log,
+// and writes them to the outbox in the transaction tx. The hardware sync completed event follows the domain events.
+func enqueueHwSyncEvents(tx *sql.Tx, result syncResult) error {
+	err := enqueueDomainEvents(tx, result.toDomainEvents())
+	if err != nil {
+		return err
+	}
+
+	event := result.toOutboxEvent()
+	err = changelog.Append(tx, changelog.Entry{ID: event.ID, Type: event.Type, Subject: event.ID, Data: event.Payload})
+	if err != nil {
+		return err
+	}
+	return outbox.Enqueue(tx, event)
+}
@@ -0,0 +1,283 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func Test_syncResult_addDetectedDevice(t *testing.T) {
+	dbExistsResources := map[string]existingResource{
+		"res101": {isNotDetected: true, resourceType: "CPU", resourceGroupIDs: []string{}, wasNotDetected: false},
+		"res102": {isNotDetected: true, resourceType: "CPU", resourceGroupIDs: []string{}, wasNotDetected: true},
+	}
+	tests := []struct {
+		name      string
+		deviceID  string
+		unchanged bool
+		want      syncResult
+	}{
+		{
+			name:     "Normal case: A device that does not exist in the DB is added",
+			deviceID: "res103",
+			want: func() syncResult {
+				sr := newSyncResult()
+				sr.addedDeviceIDs = []string{"res103"}
+				return sr
+			}(),
+		},
+		{
+			name:     "Normal case: A detected device that exists in the DB is updated",
+			deviceID: "res101",
+			want: func() syncResult {
+				sr := newSyncResult()
+				sr.updatedDeviceIDs = []string{"res101"}
+				return sr
+			}(),
+		},
+		{
+			name:      "Normal case: A detected device whose content hash has not changed is unchanged",
+			deviceID:  "res101",
+			unchanged: true,
+			want: func() syncResult {
+				sr := newSyncResult()
+				sr.unchangedDeviceIDs = []string{"res101"}
+				return sr
+			}(),
+		},
+		{
+			name:     "Normal case: A not detected device that exists in the DB is re-detected",
+			deviceID: "res102",
+			want: func() syncResult {
+				sr := newSyncResult()
+				sr.redetectedDeviceIDs = []string{"res102"}
+				return sr
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newSyncResult()
+			got.addDetectedDevice(tt.deviceID, dbExistsResources, tt.unchanged)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("addDetectedDevice() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_syncResult_addNodeSwitchChanges(t *testing.T) {
+	tests := []struct {
+		name             string
+		dbNodeIDs        []string
+		dbExistsNodes    map[string]existingNodeSwitch
+		dbSwitchIDs      []string
+		dbExistsSwitches map[string]existingNodeSwitch
+		want             syncResult
+	}{
+		{
+			name:      "Normal case: New nodes and switches are created, and nodes without resources are removed",
+			dbNodeIDs: []string{"node001", "node002"},
+			dbExistsNodes: map[string]existingNodeSwitch{
+				"node001": {deviceDictionary: map[string]hwResourceType{"res101": "CPU"}},
+				"node002": {deviceDictionary: map[string]hwResourceType{}},
+				"node003": {deviceDictionary: map[string]hwResourceType{"res102": "CPU"}},
+			},
+			dbSwitchIDs: []string{"switch001"},
+			dbExistsSwitches: map[string]existingNodeSwitch{
+				"switch001": {deviceDictionary: map[string]hwResourceType{}},
+				"switch002": {deviceDictionary: map[string]hwResourceType{"res101": "CPU"}},
+			},
+			want: func() syncResult {
+				sr := newSyncResult()
+				sr.createdNodeIDs = []string{"node003"}
+				sr.removedNodeIDs = []string{"node002"}
+				sr.createdSwitchIDs = []string{"switch002"}
+				return sr
+			}(),
+		},
+		{
+			name:             "Normal case: Nothing changes",
+			dbNodeIDs:        []string{},
+			dbExistsNodes:    map[string]existingNodeSwitch{},
+			dbSwitchIDs:      []string{},
+			dbExistsSwitches: map[string]existingNodeSwitch{},
+			want:             newSyncResult(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newSyncResult()
+			got.addNodeSwitchChanges(tt.dbNodeIDs, tt.dbExistsNodes, tt.dbSwitchIDs, tt.dbExistsSwitches)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("addNodeSwitchChanges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_syncResult_addQuarantinePlan(t *testing.T) {
+	got := newSyncResult()
+	got.addQuarantinePlan(quarantinePlan{
+		supported:  []map[string]any{{"deviceID": "cpu101", "type": "CPU"}},
+		quarantine: []map[string]any{{"deviceID": "dpu101", "type": "DPU"}},
+		promote:    []string{"cpu101"},
+	})
+
+	want := newSyncResult()
+	want.quarantinedDeviceIDs = []string{"dpu101"}
+	want.promotedDeviceIDs = []string{"cpu101"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("addQuarantinePlan() = %v, want %v", got, want)
+	}
+}
+
+func Test_syncResult_sort(t *testing.T) {
+	got := newSyncResult()
+	got.registeredDeviceIDs = []string{"res103", "res101"}
+	got.addedDeviceIDs = []string{"res103", "res101", "res103"}
+	got.createdNodeIDs = []string{"node002", "node001"}
+	got.purgedDeviceIDs = []string{"res105", "res104"}
+
+	want := newSyncResult()
+	want.registeredDeviceIDs = []string{"res103", "res101"}
+	want.addedDeviceIDs = []string{"res101", "res103"}
+	want.createdNodeIDs = []string{"node001", "node002"}
+	want.purgedDeviceIDs = []string{"res104", "res105"}
+
+	got.sort()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sort() = %v, want %v", got, want)
+	}
+}
+
+func Test_syncResult_toEventData(t *testing.T) {
+	sr := newSyncResult()
+	sr.registeredDeviceIDs = []string{"res101", "res102", "res106"}
+	sr.addedDeviceIDs = []string{"res101"}
+	sr.unchangedDeviceIDs = []string{"res106"}
+	sr.redetectedDeviceIDs = []string{"res102"}
+	sr.notDetectedDeviceIDs = []string{"res103"}
+	sr.pendingDeviceIDs = []string{"res105"}
+	sr.purgedDeviceIDs = []string{"res104"}
+	sr.quarantinedDeviceIDs = []string{"dpu101"}
+	sr.createdNodeIDs = []string{"node001"}
+
+	got := sr.toEventData()
+
+	if got["version"] != hwSyncCompletedEventVersion {
+		t.Errorf("toEventData() version = %v, want %v", got["version"], hwSyncCompletedEventVersion)
+	}
+	if _, err := uuid.Parse(got["syncID"].(string)); err != nil {
+		t.Errorf("toEventData() syncID = %v, want UUID", got["syncID"])
+	}
+	if len(got["timestamp"].(string)) == 0 {
+		t.Errorf("toEventData() timestamp is empty")
+	}
+	wantCounts := map[string]any{
+		"registered":      3,
+		"added":           1,
+		"updated":         0,
+		"unchanged":       1,
+		"notDetected":     1,
+		"pending":         1,
+		"redetected":      1,
+		"purged":          1,
+		"quarantined":     1,
+		"promoted":        0,
+		"createdNodes":    1,
+		"removedNodes":    0,
+		"createdSwitches": 0,
+		"removedSwitches": 0,
+	}
+	if !reflect.DeepEqual(got["counts"], wantCounts) {
+		t.Errorf("toEventData() counts = %v, want %v", got["counts"], wantCounts)
+	}
+	wantDevices := map[string]any{
+		"added":       []string{"res101"},
+		"updated":     []string{},
+		"unchanged":   []string{"res106"},
+		"notDetected": []string{"res103"},
+		"pending":     []string{"res105"},
+		"redetected":  []string{"res102"},
+		"purged":      []string{"res104"},
+		"quarantined": []string{"dpu101"},
+		"promoted":    []string{},
+	}
+	if !reflect.DeepEqual(got["devices"], wantDevices) {
+		t.Errorf("toEventData() devices = %v, want %v", got["devices"], wantDevices)
+	}
+	wantNodes := map[string]any{"created": []string{"node001"}, "removed": []string{}}
+	if !reflect.DeepEqual(got["nodes"], wantNodes) {
+		t.Errorf("toEventData() nodes = %v, want %v", got["nodes"], wantNodes)
+	}
+	wantSwitches := map[string]any{"created": []string{}, "removed": []string{}}
+	if !reflect.DeepEqual(got["cxlSwitches"], wantSwitches) {
+		t.Errorf("toEventData() cxlSwitches = %v, want %v", got["cxlSwitches"], wantSwitches)
+	}
+	wantScope := map[string]any{"full": true, "source": "", "chassisIDs": []string{}, "cxlSwitchIDs": []string{}}
+	if !reflect.DeepEqual(got["scope"], wantScope) {
+		t.Errorf("toEventData() scope = %v, want %v", got["scope"], wantScope)
+	}
+}
+
+func Test_syncResult_toDomainEvents(t *testing.T) {
+	sr := newSyncResult()
+	sr.addedDeviceIDs = []string{"res101"}
+	sr.updatedDeviceIDs = []string{"res102"}
+	sr.notDetectedDeviceIDs = []string{"res103"}
+	sr.purgedDeviceIDs = []string{"res104"}
+	sr.quarantinedDeviceIDs = []string{"dpu101"}
+	sr.createdNodeIDs = []string{"node001"}
+	sr.removedNodeIDs = []string{"node002"}
+	sr.createdSwitchIDs = []string{"switch001"}
+
+	want := []domainEvent{
+		newDomainEvent(domainEventResourceCreated, "res101", map[string]any{"deviceID": "res101"}),
+		newDomainEvent(domainEventResourceNotDetected, "res103", map[string]any{"deviceID": "res103"}),
+		newDomainEvent(domainEventResourceDeleted, "res104", map[string]any{"deviceID": "res104"}),
+		newDomainEvent(domainEventResourceQuarantined, "dpu101", map[string]any{"deviceID": "dpu101"}),
+		newDomainEvent(domainEventNodeComposed, "node001", map[string]any{"nodeID": "node001"}),
+		newDomainEvent(domainEventNodeDecomposed, "node002", map[string]any{"nodeID": "node002"}),
+		newDomainEvent(domainEventCxlSwitchConnected, "switch001", map[string]any{"switchID": "switch001"}),
+	}
+	if got := sr.toDomainEvents(); !reflect.DeepEqual(got, want) {
+		t.Errorf("toDomainEvents() = %v, want %v", got, want)
+	}
+}
+
+func Test_syncResult_toOutboxEvent(t *testing.T) {
+	sr := newSyncResult()
+	sr.addedDeviceIDs = []string{"res101"}
+
+	got := sr.toOutboxEvent()
+
+	if got.ID != got.Payload["syncID"] {
+		t.Errorf("toOutboxEvent() ID = %v, want %v", got.ID, got.Payload["syncID"])
+	}
+	if got.PubsubName != "configuration_manager_hwsync" || got.Topic != "configuration_manager.hwsync.completed" || got.Type != "configuration_manager.hwsync.completed" {
+		t.Errorf("toOutboxEvent() = %v", got)
+	}
+	if !reflect.DeepEqual(got.Payload["devices"].(map[string]any)["added"], []string{"res101"}) {
+		t.Errorf("toOutboxEvent() payload = %v", got.Payload)
+	}
+}
+
+func Test_enqueueHwSyncEvents(t *testing.T) {
+	t.Skip("not test")
+}
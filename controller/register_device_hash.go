@@ -0,0 +1,61 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"maps"
+
+	cmapi_model_resource "github.com/project-cdim/configuration-manager/model/resource"
+)
+
+// Prefix of the content hash, naming the hash algorithm so that it can be changed without mistaking the old hashes for matches
+const contentHashPrefix = "sha256:"
+
+// cypher query to refresh the time when the unchanged resources of a label were last seen, without replacing their properties
+const cypherBulkTouchResource = `
+	UNWIND %s AS deviceID
+	MATCH (vrs:%s {deviceID: deviceID})
+	SET vrs.lastSeenAt = "%s"
+`
+
+// deviceContentHash returns the content hash of the device information reported by the hardware sync.
+// The device information is canonicalised as JSON whose object keys are sorted at every level, so the hash does not depend
+// on the order of the keys in the request. The content hash held in the device information itself is not hashed.
+func deviceContentHash(requestResource map[string]any) (string, error) {
+	device := maps.Clone(requestResource)
+	delete(device, cmapi_model_resource.ContentHashKey)
+	canonical, err := json.Marshal(device)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(canonical)
+	return contentHashPrefix + hex.EncodeToString(sum[:]), nil
+}
+
+// withContentHash returns a copy of the device information with the content hash, to be written as the properties of the resource vertex.
+func withContentHash(requestResource map[string]any, contentHash string) map[string]any {
+	properties := maps.Clone(requestResource)
+	properties[cmapi_model_resource.ContentHashKey] = contentHash
+	return properties
+}
+
+// isUnchanged reports whether the resource holds the content hash and is in the state to which the hardware sync would restore it,
+// that is, detected without pending misses. The properties of an unchanged resource need not be replaced.
+func (r existingResource) isUnchanged(contentHash string) bool {
+	return len(r.contentHash) > 0 && r.contentHash == contentHash && !r.wasNotDetected && r.pendingMisses == 0
+}
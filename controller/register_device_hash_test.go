@@ -0,0 +1,132 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_deviceContentHash(t *testing.T) {
+	device := map[string]any{
+		"deviceID": "res101",
+		"type":     "CPU",
+		"links":    []any{map[string]any{"type": "CXLswitch", "deviceID": "sw01"}},
+		"constraints": map[string]any{
+			"nonRemovableDevices": []any{map[string]any{"deviceID": "mem01"}},
+		},
+	}
+	want, err := deviceContentHash(device)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(want, contentHashPrefix) || len(want) != len(contentHashPrefix)+64 {
+		t.Fatalf("deviceContentHash() = %v, want a SHA-256 hash with the prefix", want)
+	}
+
+	tests := []struct {
+		name      string
+		device    map[string]any
+		wantEqual bool
+	}{
+		{
+			name:      "Normal case: The hash does not depend on the order of the keys",
+			device:    map[string]any{"constraints": map[string]any{"nonRemovableDevices": []any{map[string]any{"deviceID": "mem01"}}}, "type": "CPU", "links": []any{map[string]any{"deviceID": "sw01", "type": "CXLswitch"}}, "deviceID": "res101"},
+			wantEqual: true,
+		},
+		{
+			name:      "Normal case: The content hash held in the device information is not hashed",
+			device:    withContentHash(device, "sha256:0123"),
+			wantEqual: true,
+		},
+		{
+			name:      "Normal case: A changed nested value changes the hash",
+			device:    map[string]any{"deviceID": "res101", "type": "CPU", "links": []any{map[string]any{"type": "CXLswitch", "deviceID": "sw02"}}, "constraints": map[string]any{"nonRemovableDevices": []any{map[string]any{"deviceID": "mem01"}}}},
+			wantEqual: false,
+		},
+		{
+			name:      "Normal case: An added property changes the hash",
+			device:    map[string]any{"deviceID": "res101", "type": "CPU", "links": []any{map[string]any{"type": "CXLswitch", "deviceID": "sw01"}}, "constraints": map[string]any{"nonRemovableDevices": []any{map[string]any{"deviceID": "mem01"}}}, "status": map[string]any{"health": "OK"}},
+			wantEqual: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := deviceContentHash(tt.device)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (got == want) != tt.wantEqual {
+				t.Errorf("deviceContentHash() = %v, want equal to %v: %v", got, want, tt.wantEqual)
+			}
+		})
+	}
+
+	if _, err := deviceContentHash(map[string]any{"deviceID": "res101", "invalid": make(chan int)}); err == nil {
+		t.Errorf("deviceContentHash() error = nil, want an error for the device that cannot be encoded")
+	}
+}
+
+func Test_withContentHash(t *testing.T) {
+	device := map[string]any{"deviceID": "res101", "type": "CPU"}
+	got := withContentHash(device, "sha256:0123")
+	if got["contentHash"] != "sha256:0123" || got["deviceID"] != "res101" {
+		t.Errorf("withContentHash() = %v", got)
+	}
+	if _, ok := device["contentHash"]; ok {
+		t.Errorf("withContentHash() changed the device information of the request: %v", device)
+	}
+}
+
+func Test_existingResource_isUnchanged(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource existingResource
+		want     bool
+	}{
+		{
+			name:     "Normal case: The detected resource with the same content hash",
+			resource: existingResource{contentHash: "sha256:0123"},
+			want:     true,
+		},
+		{
+			name:     "Normal case: The content hash has changed",
+			resource: existingResource{contentHash: "sha256:4567"},
+			want:     false,
+		},
+		{
+			name:     "Normal case: The resource written before the content hash was introduced",
+			resource: existingResource{contentHash: ""},
+			want:     false,
+		},
+		{
+			name:     "Normal case: The resource in the NotDetected state is re-detected",
+			resource: existingResource{contentHash: "sha256:0123", wasNotDetected: true},
+			want:     false,
+		},
+		{
+			name:     "Normal case: The pending misses of the resource are to be removed",
+			resource: existingResource{contentHash: "sha256:0123", pendingMisses: 1, pendingMissSince: "2025-06-10T00:00:00Z"},
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.resource.isUnchanged("sha256:0123"); got != tt.want {
+				t.Errorf("isUnchanged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
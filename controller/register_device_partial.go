@@ -0,0 +1,186 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Reasons why a device in the request is rejected by the hardware sync accepting the request partially
+const (
+	// The device does not satisfy the schemas
+	rejectReasonSchemaViolation = "schemaViolation"
+	// The device has been registered as a resource of another type
+	rejectReasonTypeChanged = "typeChanged"
+	// The device ID is the same as that of a preceding device in the request
+	rejectReasonDuplicateDeviceID = "duplicateDeviceID"
+	// The device is located in a chassis that a preceding device in the request locates in another rack
+	rejectReasonConflictingLocation = "conflictingLocation"
+	// The device links to a device that is neither accepted in the request nor registered
+	rejectReasonUnresolvableLink = "unresolvableLink"
+)
+
+// Structure for storing a device in the request rejected by the hardware sync accepting the request partially
+type rejectedDevice struct {
+	index      int    // Index of the device in the request
+	deviceID   string // Device ID of the device, or empty if it is not a string
+	reason     string
+	message    string
+	violations []registerDataViolation // Violations of the schemas, only if the reason is rejectReasonSchemaViolation
+}
+
+// toObject converts the rejected device into the element of the rejected devices of the response.
+func (r rejectedDevice) toObject() map[string]any {
+	res := map[string]any{
+		"index":    r.index,
+		"deviceID": r.deviceID,
+		"reason":   r.reason,
+		"message":  r.message,
+	}
+	if r.reason == rejectReasonSchemaViolation {
+		violations := []map[string]any{}
+		for _, violation := range r.violations {
+			violations = append(violations, violation.toObject())
+		}
+		res["violations"] = violations
+	}
+	return res
+}
+
+// rejectedDevicesToObject converts the rejected devices into the rejected devices of the response.
+func rejectedDevicesToObject(rejected []rejectedDevice) []map[string]any {
+	res := []map[string]any{}
+	for _, device := range rejected {
+		res = append(res, device.toObject())
+	}
+	return res
+}
+
+// acceptDevices validates each device in the request like validateRegisterData, but rejects only the invalid devices instead of the whole request.
+// In addition to the schemas, a device is rejected if it cannot be registered consistently with the resources in the DB and the preceding devices
+// in the request (see the reject reasons). A device linking to a rejected device is also rejected.
+// The registered resources of the rejected devices are marked as detected in dbExistsResources so that they keep their state,
+// instead of being put in the NotDetected state by the hardware sync.
+//
+// Returns:
+//   - The accepted devices in the order of the request
+//   - The rejected devices in the order of the request
+//   - An error if the schemas cannot be loaded
+func acceptDevices(
+	body []map[string]any,
+	mode schemaValidationMode,
+	dbExistsResources map[string]existingResource,
+) (*resourceRegister, []rejectedDevice, error) {
+	schemas, err := loadResourceSchemas()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rejected := map[int]rejectedDevice{}
+	reject := func(index int, reason string, message string, violations []registerDataViolation) {
+		deviceID, _ := body[index]["deviceID"].(string)
+		rejected[index] = rejectedDevice{index: index, deviceID: deviceID, reason: reason, message: message, violations: violations}
+	}
+
+	acceptedDeviceIDs := map[string]bool{}
+	requestedRackIDs := map[string]string{}
+	for index, device := range body {
+		errs, warnings := validateDevice(schemas, index, device, mode)
+		logViolations(warnings)
+		if len(errs) > 0 {
+			reject(index, rejectReasonSchemaViolation, "the device does not satisfy the schemas", errs)
+			continue
+		}
+
+		deviceID := device["deviceID"].(string)
+		resourceType := hwResourceType(device["type"].(string))
+		if existing, ok := dbExistsResources[deviceID]; ok && existing.resourceType != resourceType {
+			reject(index, rejectReasonTypeChanged, fmt.Sprintf("the device is registered as %s", existing.resourceType), nil)
+			continue
+		}
+		if acceptedDeviceIDs[deviceID] {
+			reject(index, rejectReasonDuplicateDeviceID, "the device ID is duplicated in the request", nil)
+			continue
+		}
+		chassisID, rackID := extractLocation(device)
+		if len(chassisID) > 0 && len(rackID) > 0 {
+			if requestedRackID, ok := requestedRackIDs[chassisID]; ok && requestedRackID != rackID {
+				reject(index, rejectReasonConflictingLocation, fmt.Sprintf("the chassis %s is located in the rack %s by another device", chassisID, requestedRackID), nil)
+				continue
+			}
+			requestedRackIDs[chassisID] = rackID
+		}
+		acceptedDeviceIDs[deviceID] = true
+	}
+
+	// Reject the devices linking to unresolvable devices until no more devices are rejected,
+	// because the rejected devices can make the links of the others unresolvable
+	for changed := true; changed; {
+		changed = false
+		for index, device := range body {
+			if _, ok := rejected[index]; ok {
+				continue
+			}
+			for _, linkedDeviceID := range extractLinkedDeviceIDs(device) {
+				if _, ok := dbExistsResources[linkedDeviceID]; !ok && !acceptedDeviceIDs[linkedDeviceID] {
+					reject(index, rejectReasonUnresolvableLink, fmt.Sprintf("the linked device %s is neither accepted nor registered", linkedDeviceID), nil)
+					delete(acceptedDeviceIDs, device["deviceID"].(string))
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	accepted := resourceRegister{resource: []map[string]any{}}
+	rejectedList := []rejectedDevice{}
+	for index, device := range body {
+		rejectedDevice, ok := rejected[index]
+		if !ok {
+			accepted.resource = append(accepted.resource, device)
+			continue
+		}
+		rejectedList = append(rejectedList, rejectedDevice)
+		// The registered resource of the rejected device keeps its state
+		if existing, ok := dbExistsResources[rejectedDevice.deviceID]; ok {
+			existing.isNotDetected = false
+			dbExistsResources[rejectedDevice.deviceID] = existing
+		}
+	}
+	return &accepted, rejectedList, nil
+}
+
+// extractLinkedDeviceIDs obtains the device IDs that the device links to from its 'links' and 'constraints/nonRemovableDevices',
+// ignoring the elements that are not device IDs.
+func extractLinkedDeviceIDs(device map[string]any) []string {
+	deviceIDs := []string{}
+	links, _ := device["links"].([]any)
+	for _, link := range links {
+		linkMap, _ := link.(map[string]any)
+		if deviceID, ok := linkMap["deviceID"].(string); ok && !slices.Contains(deviceIDs, deviceID) {
+			deviceIDs = append(deviceIDs, deviceID)
+		}
+	}
+	constraints, _ := device["constraints"].(map[string]any)
+	nonRemovableDevices, _ := constraints["nonRemovableDevices"].([]any)
+	for _, nonRemovableDevice := range nonRemovableDevices {
+		nonRemovableDeviceMap, _ := nonRemovableDevice.(map[string]any)
+		if deviceID, ok := nonRemovableDeviceMap["deviceID"].(string); ok && !slices.Contains(deviceIDs, deviceID) {
+			deviceIDs = append(deviceIDs, deviceID)
+		}
+	}
+	return deviceIDs
+}
@@ -0,0 +1,152 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_acceptDevices(t *testing.T) {
+	status := map[string]any{"state": "Enabled"}
+	body := []map[string]any{
+		{"deviceID": "cpu01", "type": CPU, "status": status, "location": map[string]any{"chassisID": "ch01", "rackID": "rack01"}},
+		{"deviceID": "mem01", "type": Memory, "status": status, "links": []any{map[string]any{"deviceID": "cpu01"}}},
+		{"deviceID": 1, "type": Memory},
+		{"deviceID": "dpu01", "type": "DPU", "status": status},
+		{"deviceID": "gpu01", "type": GPU, "status": status},
+		{"deviceID": "cpu01", "type": CPU, "status": status},
+		{"deviceID": "cpu02", "type": CPU, "status": status, "location": map[string]any{"chassisID": "ch01", "rackID": "rack02"}},
+		{"deviceID": "mem02", "type": Memory, "status": status, "links": []any{map[string]any{"deviceID": "cpu02"}}},
+		{"deviceID": "mem03", "type": Memory, "status": status, "links": []any{map[string]any{"deviceID": "cpu03"}}},
+		{
+			"deviceID": "cpu04", "type": CPU, "status": status,
+			"constraints": map[string]any{"nonRemovableDevices": []any{map[string]any{"deviceID": "mem02"}}},
+		},
+	}
+	dbExistsResources := map[string]existingResource{
+		"gpu01": {isNotDetected: true, resourceType: hwResourceType(FPGA)},
+		"cpu03": {isNotDetected: true, resourceType: hwResourceType(CPU), wasNotDetected: true},
+		"mem04": {isNotDetected: true, resourceType: hwResourceType(Memory)},
+	}
+
+	got, gotRejected, err := acceptDevices(body, schemaValidationLenient, dbExistsResources)
+	if err != nil {
+		t.Fatalf("acceptDevices() error = %v", err)
+	}
+
+	// The device of the unsupported type is accepted to be quarantined
+	want := []map[string]any{body[0], body[1], body[3], body[8]}
+	if !reflect.DeepEqual(got.resource, want) {
+		t.Errorf("acceptDevices() = %v, want %v", got.resource, want)
+	}
+	wantRejected := []rejectedDevice{
+		{
+			index: 2, deviceID: "", reason: rejectReasonSchemaViolation, message: "the device does not satisfy the schemas",
+			violations: []registerDataViolation{{index: 2, pointer: "/deviceID", message: "must be of type string"}},
+		},
+		{index: 4, deviceID: "gpu01", reason: rejectReasonTypeChanged, message: "the device is registered as FPGA"},
+		{index: 5, deviceID: "cpu01", reason: rejectReasonDuplicateDeviceID, message: "the device ID is duplicated in the request"},
+		{index: 6, deviceID: "cpu02", reason: rejectReasonConflictingLocation, message: "the chassis ch01 is located in the rack rack01 by another device"},
+		{index: 7, deviceID: "mem02", reason: rejectReasonUnresolvableLink, message: "the linked device cpu02 is neither accepted nor registered"},
+		{index: 9, deviceID: "cpu04", reason: rejectReasonUnresolvableLink, message: "the linked device mem02 is neither accepted nor registered"},
+	}
+	if !reflect.DeepEqual(gotRejected, wantRejected) {
+		t.Errorf("acceptDevices() rejected = %v, want %v", gotRejected, wantRejected)
+	}
+
+	// The registered resources of the rejected devices keep their state
+	wantExistsResources := map[string]existingResource{
+		"gpu01": {isNotDetected: false, resourceType: hwResourceType(FPGA)},
+		"cpu03": {isNotDetected: true, resourceType: hwResourceType(CPU), wasNotDetected: true},
+		"mem04": {isNotDetected: true, resourceType: hwResourceType(Memory)},
+	}
+	if !reflect.DeepEqual(dbExistsResources, wantExistsResources) {
+		t.Errorf("acceptDevices() dbExistsResources = %v, want %v", dbExistsResources, wantExistsResources)
+	}
+}
+
+func Test_acceptDevices_strict(t *testing.T) {
+	body := []map[string]any{
+		{"deviceID": "cpu01", "type": CPU, "status": map[string]any{"state": "Enabled"}},
+		{"deviceID": "cpu02", "type": CPU},
+	}
+	got, gotRejected, err := acceptDevices(body, schemaValidationStrict, map[string]existingResource{})
+	if err != nil {
+		t.Fatalf("acceptDevices() error = %v", err)
+	}
+	if !reflect.DeepEqual(got.resource, body[:1]) {
+		t.Errorf("acceptDevices() = %v, want %v", got.resource, body[:1])
+	}
+	if len(gotRejected) != 1 || gotRejected[0].reason != rejectReasonSchemaViolation || gotRejected[0].deviceID != "cpu02" {
+		t.Errorf("acceptDevices() rejected = %v", gotRejected)
+	}
+}
+
+func Test_extractLinkedDeviceIDs(t *testing.T) {
+	tests := []struct {
+		name   string
+		device map[string]any
+		want   []string
+	}{
+		{
+			"Normal case: The links and the non-removable devices",
+			map[string]any{
+				"links":       []any{map[string]any{"deviceID": "mem01"}, map[string]any{"deviceID": "mem02"}},
+				"constraints": map[string]any{"nonRemovableDevices": []any{map[string]any{"deviceID": "mem01"}, map[string]any{"deviceID": "gpu01"}}},
+			},
+			[]string{"mem01", "mem02", "gpu01"},
+		},
+		{
+			"Normal case: The elements that are not device IDs are ignored",
+			map[string]any{
+				"links":       []any{"mem01", map[string]any{"deviceID": 1}},
+				"constraints": "gpu01",
+			},
+			[]string{},
+		},
+		{"Normal case: No links", map[string]any{}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractLinkedDeviceIDs(tt.device); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("extractLinkedDeviceIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_rejectedDevicesToObject(t *testing.T) {
+	rejected := []rejectedDevice{
+		{index: 0, deviceID: "cpu01", reason: rejectReasonDuplicateDeviceID, message: "the device ID is duplicated in the request"},
+		{
+			index: 1, deviceID: "", reason: rejectReasonSchemaViolation, message: "the device does not satisfy the schemas",
+			violations: []registerDataViolation{{index: 1, pointer: "/deviceID", message: "is required"}},
+		},
+	}
+	want := []map[string]any{
+		{"index": 0, "deviceID": "cpu01", "reason": "duplicateDeviceID", "message": "the device ID is duplicated in the request"},
+		{
+			"index": 1, "deviceID": "", "reason": "schemaViolation", "message": "the device does not satisfy the schemas",
+			"violations": []map[string]any{{"index": 1, "pointer": "/deviceID", "message": "is required"}},
+		},
+	}
+	if got := rejectedDevicesToObject(rejected); !reflect.DeepEqual(got, want) {
+		t.Errorf("rejectedDevicesToObject() = %v, want %v", got, want)
+	}
+	if got := rejectedDevicesToObject(nil); !reflect.DeepEqual(got, []map[string]any{}) {
+		t.Errorf("rejectedDevicesToObject() = %v, want empty", got)
+	}
+}
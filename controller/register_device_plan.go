@@ -0,0 +1,444 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+	cmapi_model_resource "github.com/project-cdim/configuration-manager/model/resource"
+	cmapi_repository "github.com/project-cdim/configuration-manager/repository"
+
+	"github.com/apache/age/drivers/golang/age"
+)
+
+// Parts of the Cypher query to fetch the properties and the detection state of specific resources
+const cypherSelectSnapshotResources_match_return string = `
+MATCH (vrs:%s)
+WHERE exists(vrs.deviceID)
+OPTIONAL MATCH (vrs)-[endt:NotDetected]->(:NotDetectedDevice)
+RETURN vrs, CASE WHEN endt IS NULL THEN true ELSE false END`
+
+const selectSnapshotResourcesColumnCount = 2
+const (
+	selectSnapshotResourcesIndexResource = iota
+	selectSnapshotResourcesIndexDetected
+)
+
+// cypher query to search units and the resources contained in them
+const cypherSelectUnitList string = `
+	MATCH (vut:Unit)
+	OPTIONAL MATCH (vut)-[:Contain]->(vrs)
+	RETURN vut.deviceID, CASE WHEN vrs.deviceID IS NULL THEN "" ELSE vrs.deviceID END
+`
+const selectUnitListColumnCount = 2
+const (
+	selectUnitListIndexUnitID = iota
+	selectUnitListIndexDeviceID
+)
+
+// Structure for storing the state of the graph that is affected by the hardware sync.
+// It is taken before and after registerResources in a dry run, and the difference is reported as a syncPlan.
+type syncSnapshot struct {
+	resources map[string]snapshotResource
+	nodes     map[string]existingNodeSwitch
+	switches  map[string]existingNodeSwitch
+	units     map[string][]string
+}
+
+// Structure for storing the properties and the detection state of a resource in a syncSnapshot
+type snapshotResource struct {
+	properties map[string]any
+	detected   bool
+}
+
+// Structure for storing the changes of the graph that the hardware sync would make.
+type syncPlan struct {
+	createDevices      []string
+	updateDevices      []deviceUpdate
+	notDetectedDevices []string
+	purgeDevices       []string
+	rehomeDevices      []deviceRehome
+	createNodes        []string
+	deleteNodes        []string
+	createSwitches     []string
+	deleteSwitches     []string
+	createUnits        []string
+	updateUnits        []unitUpdate
+	deleteUnits        []string
+}
+
+// Structure for storing the changed properties of a resource.
+// Each changed property holds the value before and after the hardware sync; a removed property has no value after it.
+type deviceUpdate struct {
+	deviceID          string
+	changedProperties map[string]any
+}
+
+// Structure for storing the move of a resource between nodes or CXL switches.
+// kind is either "node" or "cxlSwitch", and an empty from or to means that the resource is not connected.
+type deviceRehome struct {
+	deviceID string
+	kind     string
+	from     string
+	to       string
+}
+
+// Structure for storing the change of the resources contained in a unit
+type unitUpdate struct {
+	unitID string
+	add    []string
+	remove []string
+}
+
+// getSyncSnapshot retrieves the state of the graph that is affected by the hardware sync, namely the resources
+// with their properties and detection states, the nodes and CXL switches with their resources, and the units with their resources.
+//
+// Parameters:
+//   - tx: The transaction in which the hardware sync is performed.
+//
+// Returns:
+//   - syncSnapshot: The state of the graph.
+//   - error: An error if any query fails.
+func getSyncSnapshot(tx *sql.Tx) (syncSnapshot, error) {
+	res := syncSnapshot{}
+
+	resources, err := getSnapshotResources(tx)
+	if err != nil {
+		return res, err
+	}
+	res.resources = resources
+
+	nodes, err := getNodeList(tx)
+	if err != nil {
+		return res, err
+	}
+	res.nodes = nodes
+
+	switches, err := getCxlSwitchList(tx)
+	if err != nil {
+		return res, err
+	}
+	res.switches = switches
+
+	units, err := getUnitMemberList(tx)
+	if err != nil {
+		return res, err
+	}
+	res.units = units
+
+	return res, nil
+}
+
+// getSnapshotResources retrieves the properties and the detection states of all resources, keyed by deviceID.
+func getSnapshotResources(tx *sql.Tx) (map[string]snapshotResource, error) {
+	items := []string{}
+	for range resourceTypeList {
+		items = append(items, cypherSelectSnapshotResources_match_return)
+	}
+	query := strings.Join(items, queryResourceList_unionall)
+
+	common.Log.Debug(fmt.Sprintf("query: %s, params: %v", query, resourceTypeList))
+	res := map[string]snapshotResource{}
+	cypherCursor, err := execCypher(tx, database.GRAPH_NAME, selectSnapshotResourcesColumnCount, query, resourceTypeList...)
+	if err != nil {
+		common.Log.Error(err.Error())
+		return nil, err
+	}
+	defer cypherCursor.Close()
+
+	for cypherCursor.Next() {
+		row, err := cypherCursor.GetRow()
+		if err != nil {
+			common.Log.Error(err.Error())
+			return nil, err
+		}
+
+		properties := row[selectSnapshotResourcesIndexResource].(*age.Vertex).Props()
+		// The times when the resource was seen change at every hardware sync, and are not regarded as changes of the device
+		delete(properties, cmapi_model_resource.FirstSeenAtKey)
+		delete(properties, cmapi_model_resource.LastSeenAtKey)
+		delete(properties, cmapi_model_resource.PendingMissesKey)
+		delete(properties, cmapi_model_resource.PendingMissSinceKey)
+		delete(properties, cmapi_model_resource.ContentHashKey)
+		deviceID, _ := properties["deviceID"].(string)
+		res[deviceID] = snapshotResource{
+			properties: properties,
+			detected:   row[selectSnapshotResourcesIndexDetected].(*age.SimpleEntity).AsBool(),
+		}
+	}
+
+	return res, nil
+}
+
+// getUnitMemberList retrieves the units and the deviceIDs of the resources contained in them, keyed by the unit ID.
+func getUnitMemberList(tx *sql.Tx) (map[string][]string, error) {
+	common.Log.Debug(fmt.Sprintf("query: %s", cypherSelectUnitList))
+	res := map[string][]string{}
+	cypherCursor, err := execCypher(tx, database.GRAPH_NAME, selectUnitListColumnCount, cypherSelectUnitList)
+	if err != nil {
+		common.Log.Error(err.Error())
+		return nil, err
+	}
+	defer cypherCursor.Close()
+
+	for cypherCursor.Next() {
+		row, err := cypherCursor.GetRow()
+		if err != nil {
+			common.Log.Error(err.Error())
+			return nil, err
+		}
+
+		unitID := cmapi_repository.ExtractEntityString(row[selectUnitListIndexUnitID].(*age.SimpleEntity))
+		deviceID := cmapi_repository.ExtractEntityString(row[selectUnitListIndexDeviceID].(*age.SimpleEntity))
+		if _, ok := res[unitID]; !ok {
+			res[unitID] = []string{}
+		}
+		if deviceID != "" {
+			res[unitID] = append(res[unitID], deviceID)
+		}
+	}
+
+	return res, nil
+}
+
+// newSyncPlan compares the states of the graph before and after the hardware sync and returns the changes as a syncPlan.
+// All lists in the plan are sorted so that the plan is stable for the same request.
+//
+// Parameters:
+//   - before: The state of the graph before registerResources.
+//   - after: The state of the graph after registerResources.
+//
+// Returns:
+//   - syncPlan: The changes that the hardware sync would make.
+func newSyncPlan(before syncSnapshot, after syncSnapshot) syncPlan {
+	plan := syncPlan{
+		createDevices:      []string{},
+		updateDevices:      []deviceUpdate{},
+		notDetectedDevices: []string{},
+		purgeDevices:       []string{},
+		rehomeDevices:      []deviceRehome{},
+		createUnits:        []string{},
+		updateUnits:        []unitUpdate{},
+		deleteUnits:        []string{},
+	}
+
+	for _, deviceID := range sortedKeys(after.resources) {
+		afterResource := after.resources[deviceID]
+		beforeResource, ok := before.resources[deviceID]
+		if !ok {
+			plan.createDevices = append(plan.createDevices, deviceID)
+			continue
+		}
+		if changed := diffProperties(beforeResource.properties, afterResource.properties); len(changed) > 0 {
+			plan.updateDevices = append(plan.updateDevices, deviceUpdate{deviceID: deviceID, changedProperties: changed})
+		}
+		if beforeResource.detected && !afterResource.detected {
+			plan.notDetectedDevices = append(plan.notDetectedDevices, deviceID)
+		}
+	}
+
+	// The resources purged by the retention policy no longer exist after the hardware sync, and are not reported as moved
+	_, plan.purgeDevices = diffKeys(before.resources, after.resources)
+	keptResources := map[string]snapshotResource{}
+	for deviceID, resource := range before.resources {
+		if _, ok := after.resources[deviceID]; ok {
+			keptResources[deviceID] = resource
+		}
+	}
+
+	plan.rehomeDevices = append(plan.rehomeDevices, diffHomes(keptResources, before.nodes, after.nodes, "node")...)
+	plan.rehomeDevices = append(plan.rehomeDevices, diffHomes(keptResources, before.switches, after.switches, "cxlSwitch")...)
+	plan.createNodes, plan.deleteNodes = diffKeys(before.nodes, after.nodes)
+	plan.createSwitches, plan.deleteSwitches = diffKeys(before.switches, after.switches)
+	plan.createUnits, plan.deleteUnits = diffKeys(before.units, after.units)
+
+	for _, unitID := range sortedKeys(after.units) {
+		beforeDeviceIDs, ok := before.units[unitID]
+		if !ok {
+			continue
+		}
+		add, remove := diffLists(beforeDeviceIDs, after.units[unitID])
+		if len(add) > 0 || len(remove) > 0 {
+			plan.updateUnits = append(plan.updateUnits, unitUpdate{unitID: unitID, add: add, remove: remove})
+		}
+	}
+
+	return plan
+}
+
+// diffProperties returns the properties that differ between before and after, with the values before and after the change.
+func diffProperties(before map[string]any, after map[string]any) map[string]any {
+	res := map[string]any{}
+	for key, afterValue := range after {
+		beforeValue, ok := before[key]
+		if !ok {
+			res[key] = map[string]any{"after": afterValue}
+		} else if !reflect.DeepEqual(beforeValue, afterValue) {
+			res[key] = map[string]any{"before": beforeValue, "after": afterValue}
+		}
+	}
+	for key, beforeValue := range before {
+		if _, ok := after[key]; !ok {
+			res[key] = map[string]any{"before": beforeValue}
+		}
+	}
+	return res
+}
+
+// diffHomes returns the resources in resources, which existed before the hardware sync, that are moved to another node or CXL switch.
+// If a resource belongs to several nodes or CXL switches, the one with the smallest ID is regarded as its home.
+func diffHomes(resources map[string]snapshotResource, before map[string]existingNodeSwitch, after map[string]existingNodeSwitch, kind string) []deviceRehome {
+	beforeHomes := homesOf(before)
+	afterHomes := homesOf(after)
+
+	deviceIDs := []string{}
+	for deviceID := range beforeHomes {
+		deviceIDs = append(deviceIDs, deviceID)
+	}
+	for deviceID := range afterHomes {
+		if _, ok := beforeHomes[deviceID]; !ok {
+			deviceIDs = append(deviceIDs, deviceID)
+		}
+	}
+	slices.Sort(deviceIDs)
+
+	res := []deviceRehome{}
+	for _, deviceID := range deviceIDs {
+		if _, ok := resources[deviceID]; !ok {
+			// A newly created resource is reported as a created device
+			continue
+		}
+		if beforeHomes[deviceID] != afterHomes[deviceID] {
+			res = append(res, deviceRehome{deviceID: deviceID, kind: kind, from: beforeHomes[deviceID], to: afterHomes[deviceID]})
+		}
+	}
+	return res
+}
+
+// homesOf returns the node or CXL switch to which each resource belongs, keyed by deviceID.
+func homesOf(nodeSwitches map[string]existingNodeSwitch) map[string]string {
+	res := map[string]string{}
+	for _, id := range sortedKeys(nodeSwitches) {
+		for deviceID := range nodeSwitches[id].deviceDictionary {
+			if _, ok := res[deviceID]; !ok {
+				res[deviceID] = id
+			}
+		}
+	}
+	return res
+}
+
+// diffKeys returns the sorted keys that exist only in after, and those that exist only in before.
+func diffKeys[V any](before map[string]V, after map[string]V) ([]string, []string) {
+	created := []string{}
+	for _, key := range sortedKeys(after) {
+		if _, ok := before[key]; !ok {
+			created = append(created, key)
+		}
+	}
+	deleted := []string{}
+	for _, key := range sortedKeys(before) {
+		if _, ok := after[key]; !ok {
+			deleted = append(deleted, key)
+		}
+	}
+	return created, deleted
+}
+
+// diffLists returns the sorted values that exist only in after, and those that exist only in before.
+func diffLists(before []string, after []string) ([]string, []string) {
+	add := []string{}
+	for _, value := range after {
+		if !slices.Contains(before, value) {
+			add = append(add, value)
+		}
+	}
+	remove := []string{}
+	for _, value := range before {
+		if !slices.Contains(after, value) {
+			remove = append(remove, value)
+		}
+	}
+	slices.Sort(add)
+	slices.Sort(remove)
+	return add, remove
+}
+
+// sortedKeys returns the keys of the map in ascending order.
+func sortedKeys[V any](m map[string]V) []string {
+	res := make([]string, 0, len(m))
+	for key := range m {
+		res = append(res, key)
+	}
+	slices.Sort(res)
+	return res
+}
+
+// toObject converts the syncPlan to a map representation for the response of a dry run.
+func (sp *syncPlan) toObject() map[string]any {
+	updateDevices := []map[string]any{}
+	for _, update := range sp.updateDevices {
+		updateDevices = append(updateDevices, map[string]any{
+			"deviceID":          update.deviceID,
+			"changedProperties": update.changedProperties,
+		})
+	}
+	rehomeDevices := []map[string]any{}
+	for _, rehome := range sp.rehomeDevices {
+		rehomeDevices = append(rehomeDevices, map[string]any{
+			"deviceID": rehome.deviceID,
+			"type":     rehome.kind,
+			"from":     rehome.from,
+			"to":       rehome.to,
+		})
+	}
+	updateUnits := []map[string]any{}
+	for _, update := range sp.updateUnits {
+		updateUnits = append(updateUnits, map[string]any{
+			"unitID": update.unitID,
+			"add":    update.add,
+			"remove": update.remove,
+		})
+	}
+
+	return map[string]any{
+		"devices": map[string]any{
+			"create":      sp.createDevices,
+			"update":      updateDevices,
+			"notDetected": sp.notDetectedDevices,
+			"purge":       sp.purgeDevices,
+			"rehome":      rehomeDevices,
+		},
+		"nodes": map[string]any{
+			"create": sp.createNodes,
+			"delete": sp.deleteNodes,
+		},
+		"cxlSwitches": map[string]any{
+			"create": sp.createSwitches,
+			"delete": sp.deleteSwitches,
+		},
+		"units": map[string]any{
+			"create": sp.createUnits,
+			"update": updateUnits,
+			"delete": sp.deleteUnits,
+		},
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_getSyncSnapshot(t *testing.T) {
+	t.Skip("not test")
+}
+
+func Test_getSnapshotResources(t *testing.T) {
+	t.Skip("not test")
+}
+
+func Test_getUnitMemberList(t *testing.T) {
+	t.Skip("not test")
+}
+
+func Test_newSyncPlan(t *testing.T) {
+	before := syncSnapshot{
+		resources: map[string]snapshotResource{
+			"cpu01": {map[string]any{"deviceID": "cpu01", "type": "CPU", "status": "OK"}, true},
+			"mem01": {map[string]any{"deviceID": "mem01", "type": "memory"}, true},
+			"mem02": {map[string]any{"deviceID": "mem02", "type": "memory"}, true},
+			"mem03": {map[string]any{"deviceID": "mem03", "type": "memory"}, false},
+			"mem04": {map[string]any{"deviceID": "mem04", "type": "memory"}, false},
+		},
+		nodes: map[string]existingNodeSwitch{
+			"cpu01": {deviceDictionary: map[string]hwResourceType{"cpu01": CPU, "mem01": Memory, "mem02": Memory, "mem04": Memory}},
+		},
+		switches: map[string]existingNodeSwitch{
+			"sw01": {deviceDictionary: map[string]hwResourceType{"mem01": Memory}},
+		},
+		units: map[string][]string{
+			"cpu01": {"cpu01", "mem01"},
+			"mem02": {"mem02"},
+		},
+	}
+	after := syncSnapshot{
+		resources: map[string]snapshotResource{
+			"cpu01": {map[string]any{"deviceID": "cpu01", "type": "CPU", "status": "Warning"}, true},
+			"cpu02": {map[string]any{"deviceID": "cpu02", "type": "CPU"}, true},
+			"mem01": {map[string]any{"deviceID": "mem01", "type": "memory"}, true},
+			"mem02": {map[string]any{"deviceID": "mem02", "type": "memory"}, false},
+			"mem03": {map[string]any{"deviceID": "mem03", "type": "memory"}, false},
+		},
+		nodes: map[string]existingNodeSwitch{
+			"cpu02": {deviceDictionary: map[string]hwResourceType{"cpu02": CPU, "mem01": Memory}},
+		},
+		switches: map[string]existingNodeSwitch{
+			"sw01": {deviceDictionary: map[string]hwResourceType{"mem01": Memory}},
+			"sw02": {deviceDictionary: map[string]hwResourceType{}},
+		},
+		units: map[string][]string{
+			"cpu01": {"cpu01"},
+			"cpu02": {"cpu02", "mem01"},
+		},
+	}
+	want := syncPlan{
+		createDevices: []string{"cpu02"},
+		updateDevices: []deviceUpdate{
+			{"cpu01", map[string]any{"status": map[string]any{"before": "OK", "after": "Warning"}}},
+		},
+		notDetectedDevices: []string{"mem02"},
+		purgeDevices:       []string{"mem04"},
+		rehomeDevices: []deviceRehome{
+			{"cpu01", "node", "cpu01", ""},
+			{"mem01", "node", "cpu01", "cpu02"},
+			{"mem02", "node", "cpu01", ""},
+		},
+		createNodes:    []string{"cpu02"},
+		deleteNodes:    []string{"cpu01"},
+		createSwitches: []string{"sw02"},
+		deleteSwitches: []string{},
+		createUnits:    []string{"cpu02"},
+		updateUnits:    []unitUpdate{{"cpu01", []string{}, []string{"mem01"}}},
+		deleteUnits:    []string{"mem02"},
+	}
+
+	if got := newSyncPlan(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("newSyncPlan() = %v, want %v", got, want)
+	}
+}
+
+func Test_diffProperties(t *testing.T) {
+	tests := []struct {
+		name   string
+		before map[string]any
+		after  map[string]any
+		want   map[string]any
+	}{
+		{
+			"Normal case: Added, changed and removed properties are returned",
+			map[string]any{"deviceID": "cpu01", "status": "OK", "links": []any{"mem01"}},
+			map[string]any{"deviceID": "cpu01", "status": "Warning", "model": "X1"},
+			map[string]any{
+				"status": map[string]any{"before": "OK", "after": "Warning"},
+				"model":  map[string]any{"after": "X1"},
+				"links":  map[string]any{"before": []any{"mem01"}},
+			},
+		},
+		{
+			"Normal case: Nested properties with the same values are not returned",
+			map[string]any{"deviceID": "cpu01", "location": map[string]any{"chassisID": "ch01"}},
+			map[string]any{"deviceID": "cpu01", "location": map[string]any{"chassisID": "ch01"}},
+			map[string]any{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diffProperties(tt.before, tt.after); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffProperties() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_homesOf(t *testing.T) {
+	nodeSwitches := map[string]existingNodeSwitch{
+		"node02": {deviceDictionary: map[string]hwResourceType{"mem01": Memory, "mem02": Memory}},
+		"node01": {deviceDictionary: map[string]hwResourceType{"mem01": Memory}},
+	}
+	want := map[string]string{"mem01": "node01", "mem02": "node02"}
+	if got := homesOf(nodeSwitches); !reflect.DeepEqual(got, want) {
+		t.Errorf("homesOf() = %v, want %v", got, want)
+	}
+}
+
+func Test_diffLists(t *testing.T) {
+	gotAdd, gotRemove := diffLists([]string{"b", "a", "c"}, []string{"d", "a", "e"})
+	if !reflect.DeepEqual(gotAdd, []string{"d", "e"}) || !reflect.DeepEqual(gotRemove, []string{"b", "c"}) {
+		t.Errorf("diffLists() = %v, %v, want %v, %v", gotAdd, gotRemove, []string{"d", "e"}, []string{"b", "c"})
+	}
+}
+
+func Test_syncPlan_toObject(t *testing.T) {
+	plan := syncPlan{
+		createDevices:      []string{"cpu02"},
+		updateDevices:      []deviceUpdate{{"cpu01", map[string]any{"status": map[string]any{"before": "OK", "after": "Warning"}}}},
+		notDetectedDevices: []string{"mem02"},
+		purgeDevices:       []string{"mem04"},
+		rehomeDevices:      []deviceRehome{{"mem01", "cxlSwitch", "sw01", "sw02"}},
+		createNodes:        []string{"cpu02"},
+		deleteNodes:        []string{},
+		createSwitches:     []string{},
+		deleteSwitches:     []string{},
+		createUnits:        []string{"cpu02"},
+		updateUnits:        []unitUpdate{{"cpu01", []string{}, []string{"mem01"}}},
+		deleteUnits:        []string{},
+	}
+	want := map[string]any{
+		"devices": map[string]any{
+			"create":      []string{"cpu02"},
+			"update":      []map[string]any{{"deviceID": "cpu01", "changedProperties": map[string]any{"status": map[string]any{"before": "OK", "after": "Warning"}}}},
+			"notDetected": []string{"mem02"},
+			"purge":       []string{"mem04"},
+			"rehome":      []map[string]any{{"deviceID": "mem01", "type": "cxlSwitch", "from": "sw01", "to": "sw02"}},
+		},
+		"nodes": map[string]any{
+			"create": []string{"cpu02"},
+			"delete": []string{},
+		},
+		"cxlSwitches": map[string]any{
+			"create": []string{},
+			"delete": []string{},
+		},
+		"units": map[string]any{
+			"create": []string{"cpu02"},
+			"update": []map[string]any{{"unitID": "cpu01", "add": []string{}, "remove": []string{"mem01"}}},
+			"delete": []string{},
+		},
+	}
+	if got := plan.toObject(); !reflect.DeepEqual(got, want) {
+		t.Errorf("syncPlan.toObject() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	cmapi_model "github.com/project-cdim/configuration-manager/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+// syncScope is the scope of a hardware sync, used when several hardware control agents each own a subset of the hardware.
+// A scoped hardware sync puts in the NotDetected state and cleans up only the resources previously reported in its scope,
+// and leaves the resources, nodes and CXL switches of the other scopes untouched.
+// An existing resource is in the scope if it was last reported by the source, is mounted in one of the chassis,
+// is connected to one of the CXL switches, or is one of deviceIDs. A scope without any of them is the full scope, in which every resource is synchronized.
+// deviceIDs is not specified by the query parameters; it is used by the upsert of a single device, which keeps the source recorded on the resource.
+type syncScope struct {
+	source       string
+	chassisIDs   []string
+	cxlSwitchIDs []string
+	deviceIDs    []string
+}
+
+// getSyncScope obtains the scope of the hardware sync from the query parameters 'source', 'chassisIDs' and 'cxlSwitchIDs'.
+// The lists are comma-separated. An error is returned if the source is not a valid ID.
+func getSyncScope(c *gin.Context) (syncScope, error) {
+	scope := syncScope{
+		source:       strings.TrimSpace(c.Query("source")),
+		chassisIDs:   splitIDList(c.Query("chassisIDs")),
+		cxlSwitchIDs: splitIDList(c.Query("cxlSwitchIDs")),
+	}
+	if len(scope.source) > 0 && !cmapi_model.ValidateID(scope.source) {
+		return scope, fmt.Errorf("invalid source: %q", scope.source)
+	}
+	return scope, nil
+}
+
+// splitIDList splits a comma-separated list of IDs, removing empty and duplicate elements.
+func splitIDList(value string) []string {
+	ids := []string{}
+	for _, id := range strings.Split(value, ",") {
+		id = strings.TrimSpace(id)
+		if len(id) > 0 && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// isFull reports whether the scope is the full scope.
+func (s *syncScope) isFull() bool {
+	return len(s.source) == 0 && len(s.chassisIDs) == 0 && len(s.cxlSwitchIDs) == 0 && len(s.deviceIDs) == 0
+}
+
+// toObject converts the scope into a map for the payload of the hardware sync completed event.
+func (s *syncScope) toObject() map[string]any {
+	chassisIDs := []string{}
+	chassisIDs = append(chassisIDs, s.chassisIDs...)
+	cxlSwitchIDs := []string{}
+	cxlSwitchIDs = append(cxlSwitchIDs, s.cxlSwitchIDs...)
+	return map[string]any{
+		"full":         s.isFull(),
+		"source":       s.source,
+		"chassisIDs":   chassisIDs,
+		"cxlSwitchIDs": cxlSwitchIDs,
+	}
+}
+
+// scopedDeviceIDs returns the IDs of the existing resources in the scope, based on the state of the DB at the start of the hardware sync.
+// It must be called before the request is mapped to dbExistsChassis and dbExistsSwitches. nil is returned for the full scope.
+func (s *syncScope) scopedDeviceIDs(
+	dbExistsResources map[string]existingResource,
+	dbExistsChassis map[string]existingChassis,
+	dbExistsSwitches map[string]existingNodeSwitch,
+) map[string]bool {
+	if s.isFull() {
+		return nil
+	}
+
+	res := map[string]bool{}
+	if len(s.source) > 0 {
+		for deviceID, existing := range dbExistsResources {
+			if existing.syncSource == s.source {
+				res[deviceID] = true
+			}
+		}
+	}
+	for _, chassisID := range s.chassisIDs {
+		if chassis, ok := dbExistsChassis[chassisID]; ok {
+			for deviceID := range chassis.deviceDictionary {
+				res[deviceID] = true
+			}
+		}
+	}
+	for _, switchID := range s.cxlSwitchIDs {
+		if cxlSwitch, ok := dbExistsSwitches[switchID]; ok {
+			for deviceID := range cxlSwitch.deviceDictionary {
+				res[deviceID] = true
+			}
+		}
+	}
+	for _, deviceID := range s.deviceIDs {
+		if _, ok := dbExistsResources[deviceID]; ok {
+			res[deviceID] = true
+		}
+	}
+	return res
+}
+
+// snapshotNodeSwitches copies the resources of each node or switch, so that the changes made by the hardware sync can be detected.
+func snapshotNodeSwitches(dbExists map[string]existingNodeSwitch) map[string][]string {
+	res := map[string][]string{}
+	for id, exists := range dbExists {
+		res[id] = sortedKeys(exists.deviceDictionary)
+	}
+	return res
+}
+
+// nodeSwitchIDsToSync returns the IDs of the nodes or switches to reflect in the DB, in ascending order.
+// In the full scope, all of them are reflected. In a scoped hardware sync, only those created by the hardware sync
+// or whose resources have been changed from the snapshot taken at the start are reflected.
+func (s *syncScope) nodeSwitchIDsToSync(snapshot map[string][]string, dbExists map[string]existingNodeSwitch) []string {
+	ids := sortedKeys(dbExists)
+	if s.isFull() {
+		return ids
+	}
+	return slices.DeleteFunc(ids, func(id string) bool {
+		before, ok := snapshot[id]
+		return ok && slices.Equal(before, sortedKeys(dbExists[id].deviceDictionary))
+	})
+}
+
+// snapshotChassis copies the rack and the mounted resources of each chassis, so that the changes made by the hardware sync can be detected.
+func snapshotChassis(dbExistsChassis map[string]existingChassis) map[string]existingChassis {
+	res := map[string]existingChassis{}
+	for id, exists := range dbExistsChassis {
+		exists.deviceDictionary = maps.Clone(exists.deviceDictionary)
+		res[id] = exists
+	}
+	return res
+}
+
+// chassisIDsToSync returns the IDs of the chassis to reflect in the DB, in ascending order.
+// In the full scope, all of them are reflected. In a scoped hardware sync, only those created by the hardware sync
+// or whose rack or mounted resources have been changed from the snapshot taken at the start are reflected.
+func (s *syncScope) chassisIDsToSync(snapshot map[string]existingChassis, dbExistsChassis map[string]existingChassis) []string {
+	ids := sortedKeys(dbExistsChassis)
+	if s.isFull() {
+		return ids
+	}
+	return slices.DeleteFunc(ids, func(id string) bool {
+		before, ok := snapshot[id]
+		after := dbExistsChassis[id]
+		return ok && before.rackID == after.rackID && maps.Equal(before.deviceDictionary, after.deviceDictionary)
+	})
+}
+
+// changedSyncSource reports whether the source recorded on the resource is to be replaced by the source of the scope.
+// The source is recorded on the resources reported by a scoped hardware sync with a source, and is removed when the resource is
+// reported by a hardware sync without a source, so that the resource is owned by the last hardware control agent that reported it.
+func (s *syncScope) changedSyncSource(deviceID string, dbExistsResources map[string]existingResource) bool {
+	if len(s.source) == 0 && len(s.deviceIDs) > 0 {
+		// The upsert of a single device keeps the source
+		return false
+	}
+	existing, ok := dbExistsResources[deviceID]
+	if !ok {
+		return len(s.source) > 0
+	}
+	return existing.syncSource != s.source
+}
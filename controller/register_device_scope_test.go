@@ -0,0 +1,252 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_getSyncScope(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    syncScope
+		wantErr bool
+	}{
+		{
+			name:    "Normal case: No scope is specified",
+			query:   "",
+			want:    syncScope{source: "", chassisIDs: []string{}, cxlSwitchIDs: []string{}},
+			wantErr: false,
+		},
+		{
+			name:    "Normal case: All the scopes are specified",
+			query:   "source=agent-1&chassisIDs=ch01,%20ch02,,ch01&cxlSwitchIDs=sw01",
+			want:    syncScope{source: "agent-1", chassisIDs: []string{"ch01", "ch02"}, cxlSwitchIDs: []string{"sw01"}},
+			wantErr: false,
+		},
+		{
+			name:    "Error case: The source is not a valid ID",
+			query:   "source=agent'1",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := setupTestGinContext(tt.query)
+			got, err := getSyncScope(c)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getSyncScope() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getSyncScope() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_syncScope_isFull(t *testing.T) {
+	tests := []struct {
+		name  string
+		scope syncScope
+		want  bool
+	}{
+		{name: "Normal case: Full scope", scope: syncScope{}, want: true},
+		{name: "Normal case: Source", scope: syncScope{source: "agent-1"}, want: false},
+		{name: "Normal case: Chassis", scope: syncScope{chassisIDs: []string{"ch01"}}, want: false},
+		{name: "Normal case: CXL switches", scope: syncScope{cxlSwitchIDs: []string{"sw01"}}, want: false},
+		{name: "Normal case: Single device", scope: syncScope{deviceIDs: []string{"res101"}}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.scope.isFull(); got != tt.want {
+				t.Errorf("isFull() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_syncScope_toObject(t *testing.T) {
+	scope := syncScope{source: "agent-1", chassisIDs: []string{"ch01"}}
+	want := map[string]any{"full": false, "source": "agent-1", "chassisIDs": []string{"ch01"}, "cxlSwitchIDs": []string{}}
+	if got := scope.toObject(); !reflect.DeepEqual(got, want) {
+		t.Errorf("toObject() = %v, want %v", got, want)
+	}
+}
+
+func Test_syncScope_scopedDeviceIDs(t *testing.T) {
+	dbExistsResources := map[string]existingResource{
+		"res101": {resourceType: CPU, syncSource: "agent-1"},
+		"res102": {resourceType: Memory, syncSource: "agent-2"},
+		"res103": {resourceType: Storage},
+		"res104": {resourceType: GPU},
+	}
+	dbExistsChassis := map[string]existingChassis{
+		"ch01": {deviceDictionary: map[string]mountedResource{"res103": {resourceType: Storage}}},
+	}
+	dbExistsSwitches := map[string]existingNodeSwitch{
+		"sw01": {deviceDictionary: map[string]hwResourceType{"res104": GPU}},
+	}
+	tests := []struct {
+		name  string
+		scope syncScope
+		want  map[string]bool
+	}{
+		{
+			name:  "Normal case: Full scope",
+			scope: syncScope{},
+			want:  nil,
+		},
+		{
+			name:  "Normal case: Resources last reported by the source",
+			scope: syncScope{source: "agent-1"},
+			want:  map[string]bool{"res101": true},
+		},
+		{
+			name:  "Normal case: Resources mounted in the chassis or connected to the switches",
+			scope: syncScope{chassisIDs: []string{"ch01", "ch99"}, cxlSwitchIDs: []string{"sw01"}},
+			want:  map[string]bool{"res103": true, "res104": true},
+		},
+		{
+			name:  "Normal case: Single device",
+			scope: syncScope{deviceIDs: []string{"res102", "res999"}},
+			want:  map[string]bool{"res102": true},
+		},
+		{
+			name:  "Normal case: Unknown source",
+			scope: syncScope{source: "agent-9"},
+			want:  map[string]bool{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.scope.scopedDeviceIDs(dbExistsResources, dbExistsChassis, dbExistsSwitches); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("scopedDeviceIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_syncScope_nodeSwitchIDsToSync(t *testing.T) {
+	dbExistsNodes := map[string]existingNodeSwitch{
+		"node001": {deviceDictionary: map[string]hwResourceType{"res101": CPU, "res102": Memory}},
+		"node002": {deviceDictionary: map[string]hwResourceType{"res201": CPU}},
+		"node003": {deviceDictionary: map[string]hwResourceType{"res301": CPU}},
+	}
+	snapshot := snapshotNodeSwitches(dbExistsNodes)
+	wantSnapshot := map[string][]string{"node001": {"res101", "res102"}, "node002": {"res201"}, "node003": {"res301"}}
+	if !reflect.DeepEqual(snapshot, wantSnapshot) {
+		t.Fatalf("snapshotNodeSwitches() = %v, want %v", snapshot, wantSnapshot)
+	}
+
+	// res102 moves from node001 to a new node, and node003 is not changed
+	delete(dbExistsNodes["node001"].deviceDictionary, "res102")
+	dbExistsNodes["node002"].deviceDictionary["res201"] = CPU
+	dbExistsNodes["node004"] = existingNodeSwitch{deviceDictionary: map[string]hwResourceType{"res102": Memory}}
+
+	tests := []struct {
+		name  string
+		scope syncScope
+		want  []string
+	}{
+		{
+			name:  "Normal case: All the nodes are reflected in the full scope",
+			scope: syncScope{},
+			want:  []string{"node001", "node002", "node003", "node004"},
+		},
+		{
+			name:  "Normal case: Only the changed and created nodes are reflected in a scoped hardware sync",
+			scope: syncScope{source: "agent-1"},
+			want:  []string{"node001", "node004"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.scope.nodeSwitchIDsToSync(snapshot, dbExistsNodes); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("nodeSwitchIDsToSync() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_syncScope_changedSyncSource(t *testing.T) {
+	dbExistsResources := map[string]existingResource{
+		"res101": {resourceType: CPU, syncSource: "agent-1"},
+		"res102": {resourceType: Memory},
+	}
+	tests := []struct {
+		name     string
+		scope    syncScope
+		deviceID string
+		want     bool
+	}{
+		{name: "Normal case: Same source", scope: syncScope{source: "agent-1"}, deviceID: "res101", want: false},
+		{name: "Normal case: Another source", scope: syncScope{source: "agent-2"}, deviceID: "res101", want: true},
+		{name: "Normal case: Reported without a source", scope: syncScope{}, deviceID: "res101", want: true},
+		{name: "Normal case: No source before and after", scope: syncScope{chassisIDs: []string{"ch01"}}, deviceID: "res102", want: false},
+		{name: "Normal case: New resource with a source", scope: syncScope{source: "agent-1"}, deviceID: "res103", want: true},
+		{name: "Normal case: New resource without a source", scope: syncScope{}, deviceID: "res103", want: false},
+		{name: "Normal case: Single device keeps the source", scope: syncScope{deviceIDs: []string{"res101"}}, deviceID: "res101", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.scope.changedSyncSource(tt.deviceID, dbExistsResources); got != tt.want {
+				t.Errorf("changedSyncSource() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_syncScope_chassisIDsToSync(t *testing.T) {
+	dbExistsChassis := map[string]existingChassis{
+		"ch01": {rackID: "rack01", dbRackID: "rack01", deviceDictionary: map[string]mountedResource{"res101": {resourceType: CPU, slot: "1"}}},
+		"ch02": {rackID: "rack01", dbRackID: "rack01", deviceDictionary: map[string]mountedResource{"res201": {resourceType: CPU, slot: "1"}}},
+		"ch03": {rackID: "rack01", dbRackID: "rack01", deviceDictionary: map[string]mountedResource{}},
+	}
+	snapshot := snapshotChassis(dbExistsChassis)
+
+	// The slot of res101 is changed, ch03 is moved to another rack, and ch04 is created
+	dbExistsChassis["ch01"].deviceDictionary["res101"] = mountedResource{resourceType: CPU, slot: "2"}
+	ch03 := dbExistsChassis["ch03"]
+	ch03.rackID = "rack02"
+	dbExistsChassis["ch03"] = ch03
+	dbExistsChassis["ch04"] = existingChassis{deviceDictionary: map[string]mountedResource{"res401": {resourceType: CPU}}}
+
+	tests := []struct {
+		name  string
+		scope syncScope
+		want  []string
+	}{
+		{
+			name:  "Normal case: All the chassis are reflected in the full scope",
+			scope: syncScope{},
+			want:  []string{"ch01", "ch02", "ch03", "ch04"},
+		},
+		{
+			name:  "Normal case: Only the changed and created chassis are reflected in a scoped hardware sync",
+			scope: syncScope{deviceIDs: []string{"res101"}},
+			want:  []string{"ch01", "ch03", "ch04"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.scope.chassisIDsToSync(snapshot, dbExistsChassis); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("chassisIDsToSync() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/project-cdim/configuration-manager/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Media type of the request body of the hardware sync that streams the devices as NDJSON, one device per line
+const ndjsonContentType = "application/x-ndjson"
+
+// Environment variable to configure the maximum size in bytes of the request body of the hardware sync
+const envSyncMaxBodyBytes = "CM_SYNC_MAX_BODY_BYTES"
+
+// Default maximum size in bytes of the request body of the hardware sync (1 GiB)
+const defaultSyncMaxBodyBytes int64 = 1 << 30
+
+// Number of the devices streamed in the request body that are validated and applied at once
+const streamBatchSize = bulkWriteBatchSize
+
+// Maximum size in bytes of the request body of the hardware sync, loaded from the environment variable at startup
+var syncMaxBodyBytesSetting = loadSyncMaxBodyBytes(os.Getenv)
+
+// loadSyncMaxBodyBytes loads the maximum size in bytes of the request body of the hardware sync using getenv.
+// A size that is not a positive integer is ignored.
+func loadSyncMaxBodyBytes(getenv func(string) string) int64 {
+	if maxBodyBytes, err := strconv.ParseInt(strings.TrimSpace(getenv(envSyncMaxBodyBytes)), 10, 64); err == nil && maxBodyBytes > 0 {
+		return maxBodyBytes
+	}
+	return defaultSyncMaxBodyBytes
+}
+
+// limitSyncRequestBody limits the size of the request body of the hardware sync to the maximum size.
+// Reading more than the maximum size from the request body fails with an error for which isRequestBodyTooLarge is true.
+func limitSyncRequestBody(c *gin.Context, maxBodyBytes int64) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBodyBytes)
+}
+
+// isRequestBodyTooLarge reports whether the error is caused by the request body exceeding the maximum size.
+func isRequestBodyTooLarge(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.As(err, &maxBytesErr)
+}
+
+// isStreamedSync reports whether the devices of the hardware sync are streamed in the request body as NDJSON.
+func isStreamedSync(c *gin.Context) bool {
+	return c.ContentType() == ndjsonContentType
+}
+
+// Structure for reading the devices streamed in the request body as NDJSON in batches.
+// Only the devices of the batch being read are held in memory.
+type deviceStream struct {
+	decoder *json.Decoder
+	index   int // Index of the next device in the request
+}
+
+// newDeviceStream creates a deviceStream reading the devices from the body.
+func newDeviceStream(body io.Reader) *deviceStream {
+	return &deviceStream{decoder: json.NewDecoder(body)}
+}
+
+// next reads at most size devices from the stream. The blank lines are skipped.
+// It returns io.EOF if no device remains, and an error with the index of the device if a device is not a JSON object.
+func (s *deviceStream) next(size int) ([]map[string]any, error) {
+	devices := []map[string]any{}
+	for len(devices) < size {
+		var device map[string]any
+		err := s.decoder.Decode(&device)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, invalidDevicef("resourceIndex(%d) cannot be read as a JSON object: %w", s.index, err)
+		}
+		if device == nil {
+			return nil, invalidDevicef("resourceIndex(%d) cannot be read as a JSON object: null", s.index)
+		}
+		devices = append(devices, device)
+		s.index++
+	}
+	if len(devices) == 0 {
+		return nil, io.EOF
+	}
+	return devices, nil
+}
+
+// streamResources applies the devices streamed in the body as NDJSON to the resourceSync in batches of streamBatchSize devices.
+// Each batch is validated like validateRegisterData in the validation mode, and the devices of the resource types that are not
+// supported are quarantined before the others are applied (see quarantineDevices). The quarantined devices whose resource types
+// have become supported are promoted after the last batch. The synchronization fails at the first batch violating the schemas,
+// with the violations of the batch. The quarantined and promoted devices are recorded in the result of the resourceSync.
+func streamResources(tx *sql.Tx, body io.Reader, mode schemaValidationMode, sync *resourceSync) error {
+	schemas, err := loadResourceSchemas()
+	if err != nil {
+		return err
+	}
+	quarantined, err := getQuarantinedDeviceList(tx)
+	if err != nil {
+		return err
+	}
+
+	requestedDeviceIDs := map[string]bool{}
+	quarantineDeviceIDs := map[string]bool{}
+	stream := newDeviceStream(body)
+	for {
+		firstIndex := stream.index
+		batch, err := stream.next(streamBatchSize)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			common.Log.Error(err.Error())
+			return err
+		}
+		common.Log.Debug(fmt.Sprintf("streamResources batch. resourceIndex(%d-%d)", firstIndex, stream.index-1))
+
+		violations := []registerDataViolation{}
+		for i, device := range batch {
+			errs, warnings := validateDevice(schemas, firstIndex+i, device, mode)
+			violations = append(violations, errs...)
+			logViolations(warnings)
+		}
+		if len(violations) > 0 {
+			return &registerDataValidationError{violations: violations}
+		}
+
+		// The quarantined devices are promoted after the last batch
+		plan := newQuarantinePlan(batch, nil)
+		for _, requestResource := range plan.quarantine {
+			err := mergeQuarantinedDevice(tx, requestResource)
+			if err != nil {
+				return err
+			}
+			quarantineDeviceIDs[requestResource["deviceID"].(string)] = true
+		}
+		sync.result.addQuarantinePlan(plan)
+		for _, device := range batch {
+			requestedDeviceIDs[device["deviceID"].(string)] = true
+		}
+
+		err = sync.apply(plan.supported)
+		if err != nil {
+			return err
+		}
+	}
+
+	promote, promoted := newPromotion(quarantined, requestedDeviceIDs, quarantineDeviceIDs)
+	for _, deviceID := range promote {
+		err := deleteQuarantinedDevice(tx, deviceID)
+		if err != nil {
+			return err
+		}
+	}
+	sync.result.addQuarantinePlan(quarantinePlan{promote: promote})
+	return sync.apply(promoted)
+}
@@ -0,0 +1,163 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Test_loadSyncMaxBodyBytes(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  int64
+	}{
+		{name: "Normal case: Not set", value: "", want: defaultSyncMaxBodyBytes},
+		{name: "Normal case: A positive size", value: " 1048576 ", want: 1048576},
+		{name: "Error case: Zero is ignored", value: "0", want: defaultSyncMaxBodyBytes},
+		{name: "Error case: A negative size is ignored", value: "-1", want: defaultSyncMaxBodyBytes},
+		{name: "Error case: A size with a unit is ignored", value: "1GiB", want: defaultSyncMaxBodyBytes},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string {
+				if key == envSyncMaxBodyBytes {
+					return tt.value
+				}
+				return ""
+			}
+			if got := loadSyncMaxBodyBytes(getenv); got != tt.want {
+				t.Errorf("loadSyncMaxBodyBytes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_isRequestBodyTooLarge(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/devices", strings.NewReader(`[{"deviceID":"res101","type":"CPU"}]`))
+	limitSyncRequestBody(c, 8)
+
+	_, err := io.ReadAll(c.Request.Body)
+	if !isRequestBodyTooLarge(err) {
+		t.Errorf("isRequestBodyTooLarge(%v) = false, want true", err)
+	}
+	if isRequestBodyTooLarge(errors.New("unexpected EOF")) {
+		t.Errorf("isRequestBodyTooLarge() = true for another error, want false")
+	}
+}
+
+func Test_isStreamedSync(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		want        bool
+	}{
+		{name: "Normal case: NDJSON", contentType: "application/x-ndjson", want: true},
+		{name: "Normal case: NDJSON with the charset", contentType: "application/x-ndjson; charset=utf-8", want: true},
+		{name: "Normal case: JSON", contentType: "application/json", want: false},
+		{name: "Normal case: Not specified", contentType: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPost, "/devices", nil)
+			c.Request.Header.Set("Content-Type", tt.contentType)
+			if got := isStreamedSync(c); got != tt.want {
+				t.Errorf("isStreamedSync() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_deviceStream_next(t *testing.T) {
+	t.Run("Normal case: The devices are read in batches, skipping the blank lines", func(t *testing.T) {
+		body := `{"deviceID":"res101","type":"CPU"}
+{"deviceID":"res102","type":"memory"}
+
+{"deviceID":"res103","type":"memory"}
+`
+		stream := newDeviceStream(strings.NewReader(body))
+		want := [][]map[string]any{
+			{{"deviceID": "res101", "type": "CPU"}, {"deviceID": "res102", "type": "memory"}},
+			{{"deviceID": "res103", "type": "memory"}},
+		}
+		for _, wantBatch := range want {
+			got, err := stream.next(2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, wantBatch) {
+				t.Errorf("next() = %v, want %v", got, wantBatch)
+			}
+		}
+		if _, err := stream.next(2); !errors.Is(err, io.EOF) {
+			t.Errorf("next() error = %v, want io.EOF", err)
+		}
+		if stream.index != 3 {
+			t.Errorf("next() index = %v, want 3", stream.index)
+		}
+	})
+
+	tests := []struct {
+		name      string
+		body      string
+		wantError string
+	}{
+		{
+			name:      "Error case: A device is not a JSON object",
+			body:      "{\"deviceID\":\"res101\",\"type\":\"CPU\"}\n[{\"deviceID\":\"res102\"}]\n",
+			wantError: "resourceIndex(1)",
+		},
+		{
+			name:      "Error case: A device is null",
+			body:      "null\n",
+			wantError: "resourceIndex(0)",
+		},
+		{
+			name:      "Error case: A device is cut off",
+			body:      "{\"deviceID\":\"res101\",\"type\":\"CPU\"}\n{\"deviceID\":\"res1",
+			wantError: "resourceIndex(1)",
+		},
+		{
+			name:      "Error case: A line is not JSON",
+			body:      "deviceID=res101\n",
+			wantError: "resourceIndex(0)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := newDeviceStream(strings.NewReader(tt.body))
+			_, err := stream.next(streamBatchSize)
+			if err == nil || errors.Is(err, io.EOF) || !strings.Contains(err.Error(), tt.wantError) {
+				t.Errorf("next() error = %v, want an error of %s", err, tt.wantError)
+			}
+		})
+	}
+}
+
+func Test_streamResources(t *testing.T) {
+	t.Skip("not test")
+}
@@ -15,10 +15,15 @@
 package controller
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"reflect"
-	"strings"
+	"slices"
 	"testing"
+
+	"github.com/project-cdim/configuration-manager/common"
+	cmapi_model_rule "github.com/project-cdim/configuration-manager/model/rule"
 )
 
 func Test_newUnitResources(t *testing.T) {
@@ -266,6 +271,10 @@ func TestRegisterDevice(t *testing.T) {
 	t.Skip("not test")
 }
 
+func Test_registerDeviceDryRun(t *testing.T) {
+	t.Skip("not test")
+}
+
 func Test_getDeviceIDList(t *testing.T) {
 	t.Skip("not test")
 }
@@ -278,6 +287,10 @@ func Test_getCxlSwitchList(t *testing.T) {
 	t.Skip("not test")
 }
 
+func Test_getChassisList(t *testing.T) {
+	t.Skip("not test")
+}
+
 func Test_validateRegisterData(t *testing.T) {
 	type args struct {
 		body []map[string]any
@@ -365,10 +378,150 @@ func Test_validateRegisterData(t *testing.T) {
 	}
 }
 
+func Test_validateRegisterDataInMode(t *testing.T) {
+	body := []map[string]any{
+		{"deviceID": "cpu01", "type": CPU, "status": map[string]any{"state": "Enabled"}},
+		{"deviceID": "mem01", "type": Memory, "links": "cpu01"},
+		{"type": Storage},
+	}
+
+	_, err := validateRegisterDataInMode(body, schemaValidationStrict)
+	want := []registerDataViolation{
+		{index: 1, pointer: "/links", message: "must be of type array"},
+		{index: 1, pointer: "/status", message: "is required"},
+		{index: 2, pointer: "/deviceID", message: "is required"},
+	}
+	var validationErr *registerDataValidationError
+	if !errors.As(err, &validationErr) || !reflect.DeepEqual(validationErr.violations, want) {
+		t.Errorf("validateRegisterDataInMode() error = %v, want %v", err, want)
+	}
+
+	_, err = validateRegisterDataInMode(body, schemaValidationLenient)
+	want = []registerDataViolation{{index: 2, pointer: "/deviceID", message: "is required"}}
+	if !errors.As(err, &validationErr) || !reflect.DeepEqual(validationErr.violations, want) {
+		t.Errorf("validateRegisterDataInMode() error = %v, want %v", err, want)
+	}
+
+	got, err := validateRegisterDataInMode(body[:2], schemaValidationLenient)
+	if err != nil || !reflect.DeepEqual(got.resource, body[:2]) {
+		t.Errorf("validateRegisterDataInMode() = %v, %v, want %v", got, err, body[:2])
+	}
+}
+
 func Test_registerResources(t *testing.T) {
 	t.Skip("not test")
 }
 
+func Test_resourceSync_apply(t *testing.T) {
+	statements := recordStatements(t)
+	dbExistsResources := map[string]existingResource{
+		"mem01": {isNotDetected: true, resourceType: Memory, resourceGroupIDs: []string{}},
+	}
+	dbExistsNodes := map[string]existingNodeSwitch{}
+	sync := newResourceSync(nil, dbExistsResources, dbExistsNodes, map[string]existingNodeSwitch{}, map[string]existingChassis{}, cmapi_model_rule.AssignmentRuleList{}, syncScope{})
+	progress := []int{}
+	sync.progress = func(processed int) error {
+		progress = append(progress, processed)
+		return nil
+	}
+
+	// The resources are applied in two batches, and the second batch links to the resource of the first batch
+	batches := [][]map[string]any{
+		{
+			{"deviceID": "cpu01", "type": CPU, "constraints": map[string]any{"nonRemovableDevices": []any{map[string]any{"deviceID": "mem01"}}}},
+		},
+		{
+			{"deviceID": "mem01", "type": Memory, "links": []any{map[string]any{"type": CPU, "deviceID": "cpu01"}}},
+			{"deviceID": "mem02", "type": Memory},
+		},
+	}
+	for _, batch := range batches {
+		if err := sync.apply(batch); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if want := []string{"cpu01", "mem01", "mem02"}; !reflect.DeepEqual(sync.result.registeredDeviceIDs, want) {
+		t.Errorf("apply() registeredDeviceIDs = %v, want %v", sync.result.registeredDeviceIDs, want)
+	}
+	if want := []string{"cpu01", "mem02"}; !reflect.DeepEqual(sync.result.addedDeviceIDs, want) {
+		t.Errorf("apply() addedDeviceIDs = %v, want %v", sync.result.addedDeviceIDs, want)
+	}
+	wantUnits := []unitResources{
+		{unitDeviceID: "cpu01", resourceDeviceIDs: []string{"cpu01", "mem01"}},
+		{unitDeviceID: "mem01", resourceDeviceIDs: []string{"mem01"}},
+		{unitDeviceID: "mem02", resourceDeviceIDs: []string{"mem02"}},
+	}
+	if !reflect.DeepEqual(sync.units, wantUnits) {
+		t.Errorf("apply() units = %v, want %v", sync.units, wantUnits)
+	}
+	if dbExistsResources["mem01"].isNotDetected || dbExistsResources["mem02"].isNotDetected {
+		t.Errorf("apply() dbExistsResources = %v, want the applied resources detected", dbExistsResources)
+	}
+	wantNodes := map[string]hwResourceType{"mem01": Memory}
+	if !reflect.DeepEqual(dbExistsNodes["cpu01"].deviceDictionary, wantNodes) {
+		t.Errorf("apply() dbExistsNodes = %v, want %v", dbExistsNodes, wantNodes)
+	}
+	if want := []int{1, 3}; !reflect.DeepEqual(progress, want) {
+		t.Errorf("apply() progress = %v, want %v", progress, want)
+	}
+	// The resources of each batch are written when the batch is applied
+	if got := slices.Index(queriesOf(*statements), cypherBulkMergeResource); got != 0 {
+		t.Errorf("apply() executed %v first, want the merge of the resources of the first batch", queriesOf(*statements))
+	}
+}
+
+func Test_resourceSync_apply_progressError(t *testing.T) {
+	recordStatements(t)
+	sync := newResourceSync(nil, map[string]existingResource{}, map[string]existingNodeSwitch{}, map[string]existingNodeSwitch{}, map[string]existingChassis{}, cmapi_model_rule.AssignmentRuleList{}, syncScope{})
+	errStop := errors.New("stopped")
+	sync.progress = func(processed int) error { return errStop }
+
+	// The synchronization is stopped by the error of progress, e.g. when its sync job has been claimed by another worker
+	if err := sync.apply([]map[string]any{{"deviceID": "cpu01", "type": CPU}}); !errors.Is(err, errStop) {
+		t.Errorf("apply() error = %v, want %v", err, errStop)
+	}
+}
+
+func Test_resourceSync_finish(t *testing.T) {
+	t.Skip("not test")
+}
+
+func Test_syncErrorResponse(t *testing.T) {
+	tooLarge := &http.MaxBytesError{Limit: 8}
+	validation := &registerDataValidationError{violations: []registerDataViolation{{index: 2, pointer: "/type", message: "missing"}}}
+	tests := []struct {
+		name           string
+		err            error
+		wantStatus     int
+		wantViolations bool
+	}{
+		{name: "Normal case: The request body is too large", err: fmt.Errorf("resourceIndex(3) cannot be read as a JSON object: %w", tooLarge), wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "Normal case: The devices violate the schemas", err: validation, wantStatus: http.StatusBadRequest, wantViolations: true},
+		{name: "Normal case: The device cannot be registered", err: invalidDevicef("unexpected type in JSON. type(%v)", "gpu"), wantStatus: http.StatusBadRequest},
+		{name: "Normal case: An error of the database", err: errors.New("connection reset by peer"), wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, res := syncErrorResponse("RegisterDevice", "streamResources error", tt.err)
+			if status != tt.wantStatus {
+				t.Errorf("syncErrorResponse() status = %v, want %v", status, tt.wantStatus)
+			}
+			if _, got := res["violations"]; got != tt.wantViolations {
+				t.Errorf("syncErrorResponse() body = %v, want violations: %v", res, tt.wantViolations)
+			}
+		})
+	}
+}
+
+func Test_deleteNodesWithoutEdges(t *testing.T) {
+	t.Skip("not test")
+}
+
+func Test_attachChassisToRack(t *testing.T) {
+	t.Skip("not test")
+}
+
 func Test_updateResourcesAsDetected(t *testing.T) {
 	type args struct {
 		dbExistsResources map[string]existingResource
@@ -861,6 +1014,328 @@ func Test_mappingSwitches(t *testing.T) {
 	}
 }
 
+func Test_extractNodeID(t *testing.T) {
+	tests := []struct {
+		name            string
+		requestResource map[string]any
+		want            string
+	}{
+		{
+			"Normal case: For CPU, its own deviceID is the nodeID",
+			map[string]any{"deviceID": "cpu01", "type": "CPU", "links": []any{map[string]any{"deviceID": "mem01"}}},
+			"cpu01",
+		},
+		{
+			"Normal case: For other resources, the deviceID in the first link is the nodeID",
+			map[string]any{"deviceID": "mem01", "type": "memory", "links": []any{map[string]any{"deviceID": "cpu01"}}},
+			"cpu01",
+		},
+		{
+			"Normal case: No links",
+			map[string]any{"deviceID": "mem01", "type": "memory"},
+			"",
+		},
+		{
+			"Normal case: links is empty",
+			map[string]any{"deviceID": "cpu01", "type": "CPU", "links": []any{}},
+			"",
+		},
+		{
+			"Normal case: The first link is not a map",
+			map[string]any{"deviceID": "mem01", "type": "memory", "links": []any{"cpu01"}},
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractNodeID(tt.requestResource); got != tt.want {
+				t.Errorf("extractNodeID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_extractSwitchID(t *testing.T) {
+	tests := []struct {
+		name            string
+		requestResource map[string]any
+		want            string
+	}{
+		{"Normal case: deviceSwitchInfo is a string", map[string]any{"deviceSwitchInfo": "sw01"}, "sw01"},
+		{"Normal case: No deviceSwitchInfo", map[string]any{}, ""},
+		{"Normal case: deviceSwitchInfo is not a string", map[string]any{"deviceSwitchInfo": 1}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractSwitchID(tt.requestResource); got != tt.want {
+				t.Errorf("extractSwitchID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_extractLocation(t *testing.T) {
+	tests := []struct {
+		name            string
+		requestResource map[string]any
+		wantChassisID   string
+		wantRackID      string
+	}{
+		{"Normal case: location has chassisID and rackID", map[string]any{"location": map[string]any{"chassisID": "ch01", "rackID": "rack01"}}, "ch01", "rack01"},
+		{"Normal case: location has only chassisID", map[string]any{"location": map[string]any{"chassisID": "ch01"}}, "ch01", ""},
+		{"Normal case: No location", map[string]any{}, "", ""},
+		{"Normal case: location is not a map", map[string]any{"location": "rack01"}, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotChassisID, gotRackID := extractLocation(tt.requestResource)
+			if gotChassisID != tt.wantChassisID || gotRackID != tt.wantRackID {
+				t.Errorf("extractLocation() = %v, %v, want %v, %v", gotChassisID, gotRackID, tt.wantChassisID, tt.wantRackID)
+			}
+		})
+	}
+}
+
+func Test_extractSlot(t *testing.T) {
+	tests := []struct {
+		name            string
+		requestResource map[string]any
+		want            string
+	}{
+		{"Normal case: location has slot", map[string]any{"location": map[string]any{"chassisID": "ch01", "slot": "3"}}, "3"},
+		{"Normal case: location has no slot", map[string]any{"location": map[string]any{"chassisID": "ch01"}}, ""},
+		{"Normal case: slot is not a string", map[string]any{"location": map[string]any{"chassisID": "ch01", "slot": 3}}, ""},
+		{"Normal case: No location", map[string]any{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractSlot(tt.requestResource); got != tt.want {
+				t.Errorf("extractSlot() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_mappingChassis(t *testing.T) {
+	tests := []struct {
+		name            string
+		requestResource map[string]any
+		dbExistsChassis map[string]existingChassis
+		wantChassisID   string
+		wantErr         bool
+		want            map[string]existingChassis
+	}{
+		{
+			"Normal case: A new chassis is created from the location",
+			map[string]any{"deviceID": "mem01", "type": "memory", "location": map[string]any{"chassisID": "ch01", "rackID": "rack01", "slot": "1"}},
+			map[string]existingChassis{},
+			"ch01",
+			false,
+			map[string]existingChassis{
+				"ch01": {rackID: "rack01", requestedRackID: "rack01", deviceDictionary: map[string]mountedResource{"mem01": {hwResourceType(Memory), "1"}}},
+			},
+		},
+		{
+			"Normal case: The resource is added to an existing chassis and the chassis is moved to another rack",
+			map[string]any{"deviceID": "mem01", "type": "memory", "location": map[string]any{"chassisID": "ch01", "rackID": "rack02"}},
+			map[string]existingChassis{
+				"ch01": {rackID: "rack01", dbRackID: "rack01", deviceDictionary: map[string]mountedResource{"cpu01": {hwResourceType(CPU), ""}}},
+			},
+			"ch01",
+			false,
+			map[string]existingChassis{
+				"ch01": {rackID: "rack02", dbRackID: "rack01", requestedRackID: "rack02", deviceDictionary: map[string]mountedResource{
+					"cpu01": {hwResourceType(CPU), ""},
+					"mem01": {hwResourceType(Memory), ""},
+				}},
+			},
+		},
+		{
+			"Normal case: The rack of an existing chassis is kept if the location has no rackID",
+			map[string]any{"deviceID": "mem01", "type": "memory", "location": map[string]any{"chassisID": "ch01"}},
+			map[string]existingChassis{
+				"ch01": {rackID: "rack01", dbRackID: "rack01", deviceDictionary: map[string]mountedResource{}},
+			},
+			"ch01",
+			false,
+			map[string]existingChassis{
+				"ch01": {rackID: "rack01", dbRackID: "rack01", deviceDictionary: map[string]mountedResource{"mem01": {hwResourceType(Memory), ""}}},
+			},
+		},
+		{
+			"Normal case: The resource without a chassis in its location is ignored",
+			map[string]any{"deviceID": "mem01", "type": "memory", "location": map[string]any{"rackID": "rack01"}},
+			map[string]existingChassis{},
+			"",
+			false,
+			map[string]existingChassis{},
+		},
+		{
+			"Error case: The chassis is located in different racks in the same request",
+			map[string]any{"deviceID": "mem01", "type": "memory", "location": map[string]any{"chassisID": "ch01", "rackID": "rack02"}},
+			map[string]existingChassis{
+				"ch01": {rackID: "rack01", dbRackID: "rack01", requestedRackID: "rack01", deviceDictionary: map[string]mountedResource{}},
+			},
+			"",
+			true,
+			map[string]existingChassis{
+				"ch01": {rackID: "rack01", dbRackID: "rack01", requestedRackID: "rack01", deviceDictionary: map[string]mountedResource{}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotChassisID, err := mappingChassis(tt.requestResource, tt.dbExistsChassis)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("mappingChassis() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotChassisID != tt.wantChassisID {
+				t.Errorf("mappingChassis() = %v, want %v", gotChassisID, tt.wantChassisID)
+			}
+			if !reflect.DeepEqual(tt.dbExistsChassis, tt.want) {
+				t.Errorf("mappingChassis() dbExistsChassis = %v, want %v", tt.dbExistsChassis, tt.want)
+			}
+		})
+	}
+}
+
+func Test_deleteDeviceIDFromOtherChassis(t *testing.T) {
+	dbExistsChassis := map[string]existingChassis{
+		"ch01": {deviceDictionary: map[string]mountedResource{"mem01": {hwResourceType(Memory), "1"}}},
+		"ch02": {deviceDictionary: map[string]mountedResource{"mem01": {hwResourceType(Memory), "2"}, "cpu01": {hwResourceType(CPU), ""}}},
+	}
+	want := map[string]existingChassis{
+		"ch01": {deviceDictionary: map[string]mountedResource{"mem01": {hwResourceType(Memory), "1"}}},
+		"ch02": {deviceDictionary: map[string]mountedResource{"cpu01": {hwResourceType(CPU), ""}}},
+	}
+	deleteDeviceIDFromOtherChassis("mem01", "ch01", dbExistsChassis)
+	if !reflect.DeepEqual(dbExistsChassis, want) {
+		t.Errorf("deleteDeviceIDFromOtherChassis() = %v, want %v", dbExistsChassis, want)
+	}
+}
+
+func Test_newDeviceContext(t *testing.T) {
+	requestResource := map[string]any{
+		"deviceID":         "mem01",
+		"type":             "memory",
+		"links":            []any{map[string]any{"deviceID": "cpu01"}},
+		"deviceSwitchInfo": "sw01",
+		"location":         map[string]any{"chassisID": "ch01", "rackID": "rack01"},
+	}
+	want := cmapi_model_rule.DeviceContext{
+		DeviceID:    "mem01",
+		Type:        "memory",
+		NodeID:      "cpu01",
+		CXLSwitchID: "sw01",
+		ChassisID:   "ch01",
+		RackID:      "rack01",
+		Device:      requestResource,
+	}
+	if got := newDeviceContext(requestResource); !reflect.DeepEqual(got, want) {
+		t.Errorf("newDeviceContext() = %v, want %v", got, want)
+	}
+}
+
+func Test_resolveResourceGroupID(t *testing.T) {
+	rules := cmapi_model_rule.AssignmentRuleList{
+		Rules: []cmapi_model_rule.AssignmentRule{
+			{
+				Id: "rule01",
+				Properties: map[string]any{
+					"name":            "memory in ch01",
+					"priority":        int64(10),
+					"resourceGroupID": "group01",
+					"conditions":      map[string]any{"type": "memory", "chassisID": "ch01"},
+				},
+				CreatedAt: "2021-01-01T00:00:00Z",
+				UpdatedAt: "2021-01-01T00:00:00Z",
+			},
+		},
+	}
+	// The rule without conditions is invalid, and is evaluated before rule01 and after rule02
+	brokenRules := cmapi_model_rule.AssignmentRuleList{
+		Rules: []cmapi_model_rule.AssignmentRule{
+			rules.Rules[0],
+			{
+				Id:         "rule02",
+				Properties: map[string]any{"name": "cpu", "priority": int64(1), "resourceGroupID": "group02", "conditions": map[string]any{"type": "CPU"}},
+				CreatedAt:  "2021-01-01T00:00:00Z",
+				UpdatedAt:  "2021-01-01T00:00:00Z",
+			},
+			{
+				Id:         "rule03",
+				Properties: map[string]any{"name": "broken", "priority": int64(5), "resourceGroupID": "group03"},
+				CreatedAt:  "2021-01-01T00:00:00Z",
+				UpdatedAt:  "2021-01-01T00:00:00Z",
+			},
+		},
+	}
+	tests := []struct {
+		name            string
+		rules           cmapi_model_rule.AssignmentRuleList
+		requestResource map[string]any
+		want            string
+		wantMatched     bool
+		wantErr         bool
+	}{
+		{
+			"Normal case: The resource group of the matched rule is returned",
+			rules,
+			map[string]any{"deviceID": "mem01", "type": "memory", "location": map[string]any{"chassisID": "ch01"}},
+			"group01",
+			true,
+			false,
+		},
+		{
+			"Normal case: The default group is returned if no rule matches",
+			rules,
+			map[string]any{"deviceID": "mem02", "type": "memory", "location": map[string]any{"chassisID": "ch02"}},
+			common.DefaultGroupId,
+			false,
+			false,
+		},
+		{
+			"Normal case: The invalid rule after the matched rule is not reported",
+			brokenRules,
+			map[string]any{"deviceID": "cpu01", "type": "CPU"},
+			"group02",
+			true,
+			false,
+		},
+		{
+			"Error case: The invalid rule before the matched rule is reported",
+			brokenRules,
+			map[string]any{"deviceID": "mem01", "type": "memory", "location": map[string]any{"chassisID": "ch01"}},
+			"group01",
+			true,
+			true,
+		},
+		{
+			"Error case: The invalid rule is reported if no rule matches",
+			brokenRules,
+			map[string]any{"deviceID": "mem02", "type": "memory", "location": map[string]any{"chassisID": "ch02"}},
+			common.DefaultGroupId,
+			false,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, matchedRule, err := resolveResourceGroupID(tt.rules, tt.requestResource)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("resolveResourceGroupID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("resolveResourceGroupID() = %v, want %v", got, tt.want)
+			}
+			if (matchedRule != nil) != tt.wantMatched {
+				t.Errorf("resolveResourceGroupID() matchedRule = %v, wantMatched %v", matchedRule, tt.wantMatched)
+			}
+		})
+	}
+}
+
 func Test_deleteDeviceIDFromOtherNodeSwitches(t *testing.T) {
 	type args struct {
 		deviceID             string                        // Target deviceID to delete
@@ -1041,19 +1516,7 @@ func Test_syncNotDetectedResource(t *testing.T) {
 	t.Skip("not test")
 }
 
-func Test_syncNode(t *testing.T) {
-	t.Skip("not test")
-}
-
-func Test_syncSwitch(t *testing.T) {
-	t.Skip("not test")
-}
-
-func Test_mergeResource(t *testing.T) {
-	t.Skip("not test")
-}
-
-func Test_mergeUnit(t *testing.T) {
+func Test_markNotDetectedResource(t *testing.T) {
 	t.Skip("not test")
 }
 
@@ -1194,130 +1657,6 @@ func Test_getNonRemovableDeviceIds(t *testing.T) {
 	}
 }
 
-func Test_registerUnitGraphics(t *testing.T) {
-	t.Skip("not test")
-}
-
-func Test_createContainQuery(t *testing.T) {
-	type args struct {
-		unitResourceRelation unitResources
-		dbExistsResources    map[string]existingResource
-	}
-	tests := []struct {
-		name        string
-		args        args
-		wantMatches string
-		wantCreates string
-		wantErr     bool
-	}{
-		{
-			name: "empty relatedDeviceIDs - should return empty strings",
-			args: args{
-				unitResourceRelation: unitResources{
-					unitDeviceID:      "unit001",
-					resourceDeviceIDs: []string{},
-				},
-				dbExistsResources: map[string]existingResource{},
-			},
-			wantMatches: "",
-			wantCreates: "",
-			wantErr:     false,
-		},
-		{
-			name: "single related device - should create match and create parts",
-			args: args{
-				unitResourceRelation: unitResources{
-					unitDeviceID:      "unit001",
-					resourceDeviceIDs: []string{"dev001"},
-				},
-				dbExistsResources: map[string]existingResource{
-					"dev001": {isNotDetected: false, resourceType: hwResourceType(CPU)},
-				},
-			},
-			wantMatches: fmt.Sprintf(cypherCreateContainMatchParts, 0, "CPU", "dev001"),
-			wantCreates: fmt.Sprintf(cypherCreateContainCreateParts, 0),
-			wantErr:     false,
-		},
-		{
-			name: "multiple related devices - should create multiple match and create parts",
-			args: args{
-				unitResourceRelation: unitResources{
-					unitDeviceID:      "unit001",
-					resourceDeviceIDs: []string{"dev001", "dev002", "dev003"},
-				},
-				dbExistsResources: map[string]existingResource{
-					"dev001": {isNotDetected: false, resourceType: hwResourceType(CPU)},
-					"dev002": {isNotDetected: false, resourceType: hwResourceType(Memory)},
-					"dev003": {isNotDetected: false, resourceType: hwResourceType(GPU)},
-				},
-			},
-			wantMatches: strings.Join([]string{
-				fmt.Sprintf(cypherCreateContainMatchParts, 0, "CPU", "dev001"),
-				fmt.Sprintf(cypherCreateContainMatchParts, 1, "Memory", "dev002"),
-				fmt.Sprintf(cypherCreateContainMatchParts, 2, "GPU", "dev003"),
-			}, ", "),
-			wantCreates: strings.Join([]string{
-				fmt.Sprintf(cypherCreateContainCreateParts, 0),
-				fmt.Sprintf(cypherCreateContainCreateParts, 1),
-				fmt.Sprintf(cypherCreateContainCreateParts, 2),
-			}, ", "),
-			wantErr: false,
-		},
-		{
-			name: "related device not in dbExistsResources - should skip missing device",
-			args: args{
-				unitResourceRelation: unitResources{
-					unitDeviceID:      "unit001",
-					resourceDeviceIDs: []string{"dev001", "dev999", "dev002"},
-				},
-				dbExistsResources: map[string]existingResource{
-					"dev001": {isNotDetected: false, resourceType: hwResourceType(CPU)},
-					"dev002": {isNotDetected: false, resourceType: hwResourceType(Memory)},
-				},
-			},
-			wantMatches: strings.Join([]string{
-				fmt.Sprintf(cypherCreateContainMatchParts, 0, "CPU", "dev001"),
-				fmt.Sprintf(cypherCreateContainMatchParts, 2, "Memory", "dev002"),
-			}, ", "),
-			wantCreates: strings.Join([]string{
-				fmt.Sprintf(cypherCreateContainCreateParts, 0),
-				fmt.Sprintf(cypherCreateContainCreateParts, 2),
-			}, ", "),
-			wantErr: false,
-		},
-		{
-			name: "all related devices missing from dbExistsResources - should return empty strings",
-			args: args{
-				unitResourceRelation: unitResources{
-					unitDeviceID:      "unit001",
-					resourceDeviceIDs: []string{"dev999", "dev998"},
-				},
-				dbExistsResources: map[string]existingResource{
-					"dev001": {isNotDetected: false, resourceType: hwResourceType(CPU)},
-				},
-			},
-			wantMatches: "",
-			wantCreates: "",
-			wantErr:     false,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			gotMatches, gotCreates, err := createContainQuery(tt.args.unitResourceRelation, tt.args.dbExistsResources)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("createContainQuery() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if gotMatches != tt.wantMatches {
-				t.Errorf("createContainQuery() gotMatches = %v, want %v", gotMatches, tt.wantMatches)
-			}
-			if gotCreates != tt.wantCreates {
-				t.Errorf("createContainQuery() gotCreates = %v, want %v", gotCreates, tt.wantCreates)
-			}
-		})
-	}
-}
-
 func createTestValue_resourceRegister() *resourceRegister {
 	res := resourceRegister{resource: []map[string]any{
 		{"deviceID": "id12", "type": Accelerator},
@@ -1341,54 +1680,98 @@ const queryResourceList string = `
 MATCH (vrs:%s)
 WHERE exists(vrs.deviceID) AND exists(vrs.type)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
-RETURN vrs.deviceID, vrs.type, COLLECT(vrsg.id)
+OPTIONAL MATCH (vrs)-[endt:NotDetected]->(:NotDetectedDevice)
+OPTIONAL MATCH (vrs)-[:ReportedBy]->(vss:SyncSource)
+RETURN vrs.deviceID, vrs.type, COLLECT(vrsg.id), CASE WHEN endt IS NULL THEN false ELSE true END, CASE WHEN vss IS NULL THEN "" ELSE vss.id END,
+	CASE WHEN vrs.pendingMisses IS NULL THEN 0 ELSE vrs.pendingMisses END, CASE WHEN vrs.pendingMissSince IS NULL THEN "" ELSE vrs.pendingMissSince END,
+	CASE WHEN vrs.contentHash IS NULL THEN "" ELSE vrs.contentHash END
 UNION ALL
 MATCH (vrs:%s)
 WHERE exists(vrs.deviceID) AND exists(vrs.type)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
-RETURN vrs.deviceID, vrs.type, COLLECT(vrsg.id)
+OPTIONAL MATCH (vrs)-[endt:NotDetected]->(:NotDetectedDevice)
+OPTIONAL MATCH (vrs)-[:ReportedBy]->(vss:SyncSource)
+RETURN vrs.deviceID, vrs.type, COLLECT(vrsg.id), CASE WHEN endt IS NULL THEN false ELSE true END, CASE WHEN vss IS NULL THEN "" ELSE vss.id END,
+	CASE WHEN vrs.pendingMisses IS NULL THEN 0 ELSE vrs.pendingMisses END, CASE WHEN vrs.pendingMissSince IS NULL THEN "" ELSE vrs.pendingMissSince END,
+	CASE WHEN vrs.contentHash IS NULL THEN "" ELSE vrs.contentHash END
 UNION ALL
 MATCH (vrs:%s)
 WHERE exists(vrs.deviceID) AND exists(vrs.type)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
-RETURN vrs.deviceID, vrs.type, COLLECT(vrsg.id)
+OPTIONAL MATCH (vrs)-[endt:NotDetected]->(:NotDetectedDevice)
+OPTIONAL MATCH (vrs)-[:ReportedBy]->(vss:SyncSource)
+RETURN vrs.deviceID, vrs.type, COLLECT(vrsg.id), CASE WHEN endt IS NULL THEN false ELSE true END, CASE WHEN vss IS NULL THEN "" ELSE vss.id END,
+	CASE WHEN vrs.pendingMisses IS NULL THEN 0 ELSE vrs.pendingMisses END, CASE WHEN vrs.pendingMissSince IS NULL THEN "" ELSE vrs.pendingMissSince END,
+	CASE WHEN vrs.contentHash IS NULL THEN "" ELSE vrs.contentHash END
 UNION ALL
 MATCH (vrs:%s)
 WHERE exists(vrs.deviceID) AND exists(vrs.type)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
-RETURN vrs.deviceID, vrs.type, COLLECT(vrsg.id)
+OPTIONAL MATCH (vrs)-[endt:NotDetected]->(:NotDetectedDevice)
+OPTIONAL MATCH (vrs)-[:ReportedBy]->(vss:SyncSource)
+RETURN vrs.deviceID, vrs.type, COLLECT(vrsg.id), CASE WHEN endt IS NULL THEN false ELSE true END, CASE WHEN vss IS NULL THEN "" ELSE vss.id END,
+	CASE WHEN vrs.pendingMisses IS NULL THEN 0 ELSE vrs.pendingMisses END, CASE WHEN vrs.pendingMissSince IS NULL THEN "" ELSE vrs.pendingMissSince END,
+	CASE WHEN vrs.contentHash IS NULL THEN "" ELSE vrs.contentHash END
 UNION ALL
 MATCH (vrs:%s)
 WHERE exists(vrs.deviceID) AND exists(vrs.type)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
-RETURN vrs.deviceID, vrs.type, COLLECT(vrsg.id)
+OPTIONAL MATCH (vrs)-[endt:NotDetected]->(:NotDetectedDevice)
+OPTIONAL MATCH (vrs)-[:ReportedBy]->(vss:SyncSource)
+RETURN vrs.deviceID, vrs.type, COLLECT(vrsg.id), CASE WHEN endt IS NULL THEN false ELSE true END, CASE WHEN vss IS NULL THEN "" ELSE vss.id END,
+	CASE WHEN vrs.pendingMisses IS NULL THEN 0 ELSE vrs.pendingMisses END, CASE WHEN vrs.pendingMissSince IS NULL THEN "" ELSE vrs.pendingMissSince END,
+	CASE WHEN vrs.contentHash IS NULL THEN "" ELSE vrs.contentHash END
 UNION ALL
 MATCH (vrs:%s)
 WHERE exists(vrs.deviceID) AND exists(vrs.type)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
-RETURN vrs.deviceID, vrs.type, COLLECT(vrsg.id)
+OPTIONAL MATCH (vrs)-[endt:NotDetected]->(:NotDetectedDevice)
+OPTIONAL MATCH (vrs)-[:ReportedBy]->(vss:SyncSource)
+RETURN vrs.deviceID, vrs.type, COLLECT(vrsg.id), CASE WHEN endt IS NULL THEN false ELSE true END, CASE WHEN vss IS NULL THEN "" ELSE vss.id END,
+	CASE WHEN vrs.pendingMisses IS NULL THEN 0 ELSE vrs.pendingMisses END, CASE WHEN vrs.pendingMissSince IS NULL THEN "" ELSE vrs.pendingMissSince END,
+	CASE WHEN vrs.contentHash IS NULL THEN "" ELSE vrs.contentHash END
 UNION ALL
 MATCH (vrs:%s)
 WHERE exists(vrs.deviceID) AND exists(vrs.type)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
-RETURN vrs.deviceID, vrs.type, COLLECT(vrsg.id)
+OPTIONAL MATCH (vrs)-[endt:NotDetected]->(:NotDetectedDevice)
+OPTIONAL MATCH (vrs)-[:ReportedBy]->(vss:SyncSource)
+RETURN vrs.deviceID, vrs.type, COLLECT(vrsg.id), CASE WHEN endt IS NULL THEN false ELSE true END, CASE WHEN vss IS NULL THEN "" ELSE vss.id END,
+	CASE WHEN vrs.pendingMisses IS NULL THEN 0 ELSE vrs.pendingMisses END, CASE WHEN vrs.pendingMissSince IS NULL THEN "" ELSE vrs.pendingMissSince END,
+	CASE WHEN vrs.contentHash IS NULL THEN "" ELSE vrs.contentHash END
 UNION ALL
 MATCH (vrs:%s)
 WHERE exists(vrs.deviceID) AND exists(vrs.type)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
-RETURN vrs.deviceID, vrs.type, COLLECT(vrsg.id)
+OPTIONAL MATCH (vrs)-[endt:NotDetected]->(:NotDetectedDevice)
+OPTIONAL MATCH (vrs)-[:ReportedBy]->(vss:SyncSource)
+RETURN vrs.deviceID, vrs.type, COLLECT(vrsg.id), CASE WHEN endt IS NULL THEN false ELSE true END, CASE WHEN vss IS NULL THEN "" ELSE vss.id END,
+	CASE WHEN vrs.pendingMisses IS NULL THEN 0 ELSE vrs.pendingMisses END, CASE WHEN vrs.pendingMissSince IS NULL THEN "" ELSE vrs.pendingMissSince END,
+	CASE WHEN vrs.contentHash IS NULL THEN "" ELSE vrs.contentHash END
 UNION ALL
 MATCH (vrs:%s)
 WHERE exists(vrs.deviceID) AND exists(vrs.type)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
-RETURN vrs.deviceID, vrs.type, COLLECT(vrsg.id)
+OPTIONAL MATCH (vrs)-[endt:NotDetected]->(:NotDetectedDevice)
+OPTIONAL MATCH (vrs)-[:ReportedBy]->(vss:SyncSource)
+RETURN vrs.deviceID, vrs.type, COLLECT(vrsg.id), CASE WHEN endt IS NULL THEN false ELSE true END, CASE WHEN vss IS NULL THEN "" ELSE vss.id END,
+	CASE WHEN vrs.pendingMisses IS NULL THEN 0 ELSE vrs.pendingMisses END, CASE WHEN vrs.pendingMissSince IS NULL THEN "" ELSE vrs.pendingMissSince END,
+	CASE WHEN vrs.contentHash IS NULL THEN "" ELSE vrs.contentHash END
 UNION ALL
 MATCH (vrs:%s)
 WHERE exists(vrs.deviceID) AND exists(vrs.type)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
-RETURN vrs.deviceID, vrs.type, COLLECT(vrsg.id)
+OPTIONAL MATCH (vrs)-[endt:NotDetected]->(:NotDetectedDevice)
+OPTIONAL MATCH (vrs)-[:ReportedBy]->(vss:SyncSource)
+RETURN vrs.deviceID, vrs.type, COLLECT(vrsg.id), CASE WHEN endt IS NULL THEN false ELSE true END, CASE WHEN vss IS NULL THEN "" ELSE vss.id END,
+	CASE WHEN vrs.pendingMisses IS NULL THEN 0 ELSE vrs.pendingMisses END, CASE WHEN vrs.pendingMissSince IS NULL THEN "" ELSE vrs.pendingMissSince END,
+	CASE WHEN vrs.contentHash IS NULL THEN "" ELSE vrs.contentHash END
 UNION ALL
 MATCH (vrs:%s)
 WHERE exists(vrs.deviceID) AND exists(vrs.type)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
-RETURN vrs.deviceID, vrs.type, COLLECT(vrsg.id)`
+OPTIONAL MATCH (vrs)-[endt:NotDetected]->(:NotDetectedDevice)
+OPTIONAL MATCH (vrs)-[:ReportedBy]->(vss:SyncSource)
+RETURN vrs.deviceID, vrs.type, COLLECT(vrsg.id), CASE WHEN endt IS NULL THEN false ELSE true END, CASE WHEN vss IS NULL THEN "" ELSE vss.id END,
+	CASE WHEN vrs.pendingMisses IS NULL THEN 0 ELSE vrs.pendingMisses END, CASE WHEN vrs.pendingMissSince IS NULL THEN "" ELSE vrs.pendingMissSince END,
+	CASE WHEN vrs.contentHash IS NULL THEN "" ELSE vrs.contentHash END`
@@ -0,0 +1,200 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/resourcetype"
+	"github.com/project-cdim/configuration-manager/schema"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Environment variable to configure the validation mode of the devices reported by the hardware sync
+const envSchemaValidationMode = "CM_SCHEMA_VALIDATION_MODE"
+
+// schemaValidationMode defines how the devices reported by the hardware sync are validated against the schemas shipped with the service.
+// Every device must satisfy the device schema in both modes. In the strict mode, a device must also satisfy the common schema and the schema
+// of its resource type. In the lenient mode, the violations of these schemas are only logged so that the devices are registered as reported.
+type schemaValidationMode string
+
+// Validation modes of the devices reported by the hardware sync
+const (
+	schemaValidationStrict  schemaValidationMode = "strict"
+	schemaValidationLenient schemaValidationMode = "lenient"
+)
+
+// Validation mode of the devices reported by the hardware sync, loaded from the environment variable at startup
+var schemaValidationModeSetting = loadSchemaValidationMode(os.Getenv)
+
+// loadSchemaValidationMode loads the validation mode using getenv. The mode is lenient unless it is configured to be strict.
+func loadSchemaValidationMode(getenv func(string) string) schemaValidationMode {
+	if strings.EqualFold(strings.TrimSpace(getenv(envSchemaValidationMode)), string(schemaValidationStrict)) {
+		return schemaValidationStrict
+	}
+	return schemaValidationLenient
+}
+
+// loadResourceSchemas loads the schemas of the devices of the registered resource types only once.
+var loadResourceSchemas = sync.OnceValues(func() (map[string]*schema.Schema, error) {
+	return loadRegisteredResourceSchemas(resourcetype.Registered(), os.ReadFile)
+})
+
+// loadRegisteredResourceSchemas loads the schemas of the devices shipped with the service, and the schemas of the resource types
+// in the registry that point to their schema files, which are read by readFile and replace the shipped ones.
+// A registered type without a schema is logged as a warning, because its devices are validated only against the device and common schemas.
+func loadRegisteredResourceSchemas(registry *resourcetype.Registry, readFile func(string) ([]byte, error)) (map[string]*schema.Schema, error) {
+	schemas, err := schema.LoadResourceSchemas()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, resourceType := range registry.Types() {
+		if len(resourceType.Schema) > 0 {
+			data, err := readFile(resourceType.Schema)
+			if err != nil {
+				return nil, fmt.Errorf("cannot read the schema of the resource type %s: %w", resourceType.Name, err)
+			}
+			typeSchema, err := schema.Parse(data)
+			if err != nil {
+				return nil, fmt.Errorf("invalid schema of the resource type %s: %w", resourceType.Name, err)
+			}
+			schemas[resourceType.Name] = typeSchema
+		} else if _, ok := schemas[resourceType.Name]; !ok {
+			common.Log.Warn(fmt.Sprintf("resource type %s has no schema. Its devices are validated only against the device and common schemas.", resourceType.Name))
+		}
+	}
+	return schemas, nil
+}
+
+// Structure for storing a part of a device in the request that does not satisfy the schemas
+type registerDataViolation struct {
+	index   int    // Index of the device in the request
+	pointer string // JSON pointer to the part in the device
+	message string
+}
+
+// toObject converts the violation into the element of the violations of the error response.
+func (v registerDataViolation) toObject() map[string]any {
+	return map[string]any{
+		"index":   v.index,
+		"pointer": v.pointer,
+		"message": v.message,
+	}
+}
+
+// registerDataValidationError is the error of the devices in the request that do not satisfy the schemas.
+type registerDataValidationError struct {
+	violations []registerDataViolation
+}
+
+// Error lists the violations in the message.
+func (e *registerDataValidationError) Error() string {
+	messages := []string{}
+	for _, violation := range e.violations {
+		messages = append(messages, fmt.Sprintf("resourceIndex(%d) %s %s", violation.index, violation.pointer, violation.message))
+	}
+	return "JSON schema validation error. " + strings.Join(messages, ", ")
+}
+
+// invalidDeviceError is the error of a device in the request that cannot be registered for a reason other than the schemas,
+// such as an unexpected type or an inconsistent location.
+type invalidDeviceError struct {
+	err error
+}
+
+// Error returns the message of the wrapped error.
+func (e *invalidDeviceError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *invalidDeviceError) Unwrap() error {
+	return e.err
+}
+
+// invalidDevicef formats the error of a device in the request as fmt.Errorf does.
+func invalidDevicef(format string, args ...any) error {
+	return &invalidDeviceError{err: fmt.Errorf(format, args...)}
+}
+
+// isInvalidRegisterData reports whether the error is caused by the devices in the request rather than by the database,
+// that is, the devices violate the schemas, cannot be registered, or are not valid JSON.
+func isInvalidRegisterData(err error) bool {
+	var validationErr *registerDataValidationError
+	var deviceErr *invalidDeviceError
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	return errors.As(err, &validationErr) || errors.As(err, &deviceErr) || errors.As(err, &syntaxErr) || errors.As(err, &typeErr)
+}
+
+// validateDevice validates the device at the index in the request against the schemas.
+// It returns the violations that reject the device in the mode, and the violations that are only to be logged.
+func validateDevice(schemas map[string]*schema.Schema, index int, device map[string]any, mode schemaValidationMode) ([]registerDataViolation, []registerDataViolation) {
+	toViolations := func(violations []schema.Violation) []registerDataViolation {
+		res := []registerDataViolation{}
+		for _, violation := range violations {
+			res = append(res, registerDataViolation{index: index, pointer: violation.Pointer, message: violation.Message})
+		}
+		return res
+	}
+
+	errs := toViolations(schemas[schema.DeviceSchemaName].Validate(device))
+	if len(errs) > 0 {
+		// The other schemas are meaningless for a device whose resource type is unknown
+		return errs, []registerDataViolation{}
+	}
+
+	others := toViolations(schemas[schema.CommonSchemaName].Validate(device))
+	if typeSchema, ok := schemas[device["type"].(string)]; ok {
+		others = append(others, toViolations(typeSchema.Validate(device))...)
+	}
+	if mode == schemaValidationStrict {
+		return others, []registerDataViolation{}
+	}
+	return errs, others
+}
+
+// convertValidationErrorResponse converts the error of validateRegisterData into the error response.
+// If the devices do not satisfy the schemas, every violation is listed under the "violations" key.
+func convertValidationErrorResponse(err error, errorDatial string) gin.H {
+	// Copy the response not to add the violations to the shared response
+	res := maps.Clone(convertErrorResponse(http.StatusBadRequest, errorDatial))
+	var validationErr *registerDataValidationError
+	if errors.As(err, &validationErr) {
+		violations := []map[string]any{}
+		for _, violation := range validationErr.violations {
+			violations = append(violations, violation.toObject())
+		}
+		res["violations"] = violations
+	}
+	return res
+}
+
+// logViolations logs the violations that do not reject the devices as warnings.
+func logViolations(violations []registerDataViolation) {
+	for _, violation := range violations {
+		common.Log.Warn(fmt.Sprintf("JSON schema violation. resourceIndex(%d) %s %s", violation.index, violation.pointer, violation.message))
+	}
+}
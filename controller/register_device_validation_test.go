@@ -0,0 +1,252 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/project-cdim/configuration-manager/resourcetype"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Test_loadSchemaValidationMode(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want schemaValidationMode
+	}{
+		{"Normal case: The mode is lenient by default", map[string]string{}, schemaValidationLenient},
+		{"Normal case: The strict mode is configured", map[string]string{"CM_SCHEMA_VALIDATION_MODE": " Strict "}, schemaValidationStrict},
+		{"Normal case: An unknown mode is lenient", map[string]string{"CM_SCHEMA_VALIDATION_MODE": "severe"}, schemaValidationLenient},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := loadSchemaValidationMode(func(key string) string { return tt.env[key] }); got != tt.want {
+				t.Errorf("loadSchemaValidationMode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_loadRegisteredResourceSchemas(t *testing.T) {
+	files := map[string][]byte{
+		"/etc/cm/DPU.json":     []byte(`{"type": "object", "required": ["attribute"]}`),
+		"/etc/cm/invalid.json": []byte(`{"type": "object", "patternProperties": {}}`),
+	}
+	readFile := func(path string) ([]byte, error) {
+		data, ok := files[path]
+		if !ok {
+			return nil, fmt.Errorf("no such file: %s", path)
+		}
+		return data, nil
+	}
+	tests := []struct {
+		name       string
+		additional []resourcetype.Type
+		wantDPU    bool
+		wantErr    bool
+	}{
+		{
+			name:       "Normal case: The schema of a registered type is loaded from its file",
+			additional: []resourcetype.Type{{Name: "DPU", Label: "DPU", Schema: "/etc/cm/DPU.json"}},
+			wantDPU:    true,
+		},
+		{
+			name:       "Normal case: A registered type without a schema is only warned",
+			additional: []resourcetype.Type{{Name: "DPU", Label: "DPU"}},
+		},
+		{
+			name:       "Error case: The schema file cannot be read",
+			additional: []resourcetype.Type{{Name: "DPU", Label: "DPU", Schema: "/etc/cm/missing.json"}},
+			wantErr:    true,
+		},
+		{
+			name:       "Error case: The schema has a keyword that is not supported",
+			additional: []resourcetype.Type{{Name: "DPU", Label: "DPU", Schema: "/etc/cm/invalid.json"}},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry, err := resourcetype.NewRegistry(tt.additional)
+			if err != nil {
+				t.Fatalf("NewRegistry() error = %v", err)
+			}
+			got, err := loadRegisteredResourceSchemas(registry, readFile)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadRegisteredResourceSchemas() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if _, ok := got["DPU"]; ok != tt.wantDPU {
+				t.Errorf("loadRegisteredResourceSchemas() has the schema of DPU = %v, want %v", ok, tt.wantDPU)
+			}
+			if _, ok := got[CPU]; !ok {
+				t.Errorf("loadRegisteredResourceSchemas() does not have the shipped schema of CPU")
+			}
+		})
+	}
+}
+
+func Test_validateDevice(t *testing.T) {
+	schemas, err := loadResourceSchemas()
+	if err != nil {
+		t.Fatalf("loadResourceSchemas() error = %v", err)
+	}
+	malformed := map[string]any{"deviceID": "mem01", "type": Memory, "links": "cpu01", "attribute": map[string]any{"capacityMiB": -1}}
+	tests := []struct {
+		name         string
+		device       map[string]any
+		mode         schemaValidationMode
+		wantErrs     []registerDataViolation
+		wantWarnings []registerDataViolation
+	}{
+		{
+			name:         "Normal case: The device satisfies the schemas",
+			device:       map[string]any{"deviceID": "cpu01", "type": CPU, "status": map[string]any{"state": "Enabled"}, "links": []any{}},
+			mode:         schemaValidationStrict,
+			wantErrs:     []registerDataViolation{},
+			wantWarnings: []registerDataViolation{},
+		},
+		{
+			name:     "Normal case: The violations of the common and resource type schemas are warnings in the lenient mode",
+			device:   malformed,
+			mode:     schemaValidationLenient,
+			wantErrs: []registerDataViolation{},
+			wantWarnings: []registerDataViolation{
+				{index: 2, pointer: "/links", message: "must be of type array"},
+				{index: 2, pointer: "/status", message: "is required"},
+				{index: 2, pointer: "/attribute/capacityMiB", message: "must be greater than or equal to 0"},
+			},
+		},
+		{
+			name:   "Error case: The violations of the common and resource type schemas are errors in the strict mode",
+			device: malformed,
+			mode:   schemaValidationStrict,
+			wantErrs: []registerDataViolation{
+				{index: 2, pointer: "/links", message: "must be of type array"},
+				{index: 2, pointer: "/status", message: "is required"},
+				{index: 2, pointer: "/attribute/capacityMiB", message: "must be greater than or equal to 0"},
+			},
+			wantWarnings: []registerDataViolation{},
+		},
+		{
+			name:   "Error case: The violations of the device schema are errors in the lenient mode",
+			device: map[string]any{"deviceID": 1, "links": "cpu01"},
+			mode:   schemaValidationLenient,
+			wantErrs: []registerDataViolation{
+				{index: 2, pointer: "/type", message: "is required"},
+				{index: 2, pointer: "/deviceID", message: "must be of type string"},
+			},
+			wantWarnings: []registerDataViolation{},
+		},
+		{
+			name:         "Normal case: A device of a type without a schema is validated against the common schema",
+			device:       map[string]any{"deviceID": "dev01", "type": "unknownType"},
+			mode:         schemaValidationStrict,
+			wantErrs:     []registerDataViolation{},
+			wantWarnings: []registerDataViolation{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotErrs, gotWarnings := validateDevice(schemas, 2, tt.device, tt.mode)
+			if !reflect.DeepEqual(gotErrs, tt.wantErrs) {
+				t.Errorf("validateDevice() errs = %v, want %v", gotErrs, tt.wantErrs)
+			}
+			if !reflect.DeepEqual(gotWarnings, tt.wantWarnings) {
+				t.Errorf("validateDevice() warnings = %v, want %v", gotWarnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func Test_registerDataValidationError_Error(t *testing.T) {
+	err := &registerDataValidationError{violations: []registerDataViolation{
+		{index: 0, pointer: "/type", message: "is required"},
+		{index: 3, pointer: "/links", message: "must be of type array"},
+	}}
+	want := "JSON schema validation error. resourceIndex(0) /type is required, resourceIndex(3) /links must be of type array"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %v, want %v", got, want)
+	}
+}
+
+func Test_isInvalidRegisterData(t *testing.T) {
+	_, syntaxErr := unmarshalBodyForSlice(strings.NewReader(`[{"deviceID":`))
+	_, typeErr := unmarshalBodyForSlice(strings.NewReader(`{"deviceID":"dev01"}`))
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "Normal case: The devices violate the schemas", err: &registerDataValidationError{}, want: true},
+		{name: "Normal case: The device cannot be registered", err: fmt.Errorf("apply error: %w", invalidDevicef("unexpected type in JSON. type(%v)", "gpu")), want: true},
+		{name: "Normal case: The request body is not valid JSON", err: syntaxErr, want: true},
+		{name: "Normal case: The request body is not an array", err: typeErr, want: true},
+		{name: "Normal case: An error of the database", err: errors.New("connection reset by peer"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isInvalidRegisterData(tt.err); got != tt.want {
+				t.Errorf("isInvalidRegisterData(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_convertValidationErrorResponse(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want gin.H
+	}{
+		{
+			name: "Normal case: The violations are listed",
+			err:  &registerDataValidationError{violations: []registerDataViolation{{index: 1, pointer: "/deviceID", message: "is required"}}},
+			want: gin.H{
+				"code":       "badRequest",
+				"message":    "Bad Request. Check the request parameters.",
+				"details":    "validateRegisterData error",
+				"violations": []map[string]any{{"index": 1, "pointer": "/deviceID", "message": "is required"}},
+			},
+		},
+		{
+			name: "Normal case: Other errors have no violations",
+			err:  errors.New("error"),
+			want: gin.H{
+				"code":    "badRequest",
+				"message": "Bad Request. Check the request parameters.",
+				"details": "validateRegisterData error",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := convertValidationErrorResponse(tt.err, "validateRegisterData error"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("convertValidationErrorResponse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if _, ok := StatusToResponse[400]["violations"]; ok {
+		t.Errorf("convertValidationErrorResponse() modified the shared response")
+	}
+}
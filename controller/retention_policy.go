@@ -0,0 +1,240 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+	cmapi_repository "github.com/project-cdim/configuration-manager/repository"
+
+	"github.com/apache/age/drivers/golang/age"
+)
+
+// Environment variables to configure the retention policy of the resources in the NotDetected state
+const (
+	// Number of days after which a resource not detected since then is purged
+	envRetentionNotDetectedDays = "CM_RETENTION_NOT_DETECTED_DAYS"
+	// Number of hardware syncs not detecting a resource after which it is purged
+	envRetentionNotDetectedSyncs = "CM_RETENTION_NOT_DETECTED_SYNCS"
+)
+
+// Structure for storing the retention policy of the resources in the NotDetected state.
+// A resource is purged when either criterion is met. A criterion of 0 is disabled.
+type retentionPolicy struct {
+	notDetectedDays  int64
+	notDetectedSyncs int64
+}
+
+// Retention policy, loaded from the environment variables at startup
+var retentionPolicySetting = loadRetentionPolicy(os.Getenv)
+
+// loadRetentionPolicy loads the retention policy using getenv.
+// Both criteria are disabled by default, and a value that is not a positive integer disables the criterion.
+func loadRetentionPolicy(getenv func(string) string) retentionPolicy {
+	parse := func(key string) int64 {
+		value, err := strconv.ParseInt(strings.TrimSpace(getenv(key)), 10, 64)
+		if err != nil || value < 0 {
+			return 0
+		}
+		return value
+	}
+	return retentionPolicy{
+		notDetectedDays:  parse(envRetentionNotDetectedDays),
+		notDetectedSyncs: parse(envRetentionNotDetectedSyncs),
+	}
+}
+
+// enabled returns whether any criterion of the policy is enabled.
+func (p retentionPolicy) enabled() bool {
+	return p.notDetectedDays > 0 || p.notDetectedSyncs > 0
+}
+
+// expired returns whether the resource meets a criterion of the policy at now.
+// The days criterion does not apply to a resource whose time of the NotDetected state is unknown.
+func (p retentionPolicy) expired(resource notDetectedResource, now time.Time) bool {
+	if p.notDetectedSyncs > 0 && resource.missedSyncs >= p.notDetectedSyncs {
+		return true
+	}
+	if p.notDetectedDays > 0 {
+		days, ok := resource.notDetectedDays(now)
+		return ok && days >= p.notDetectedDays
+	}
+	return false
+}
+
+// toObject converts the policy into the form of the response.
+func (p retentionPolicy) toObject() map[string]any {
+	return map[string]any{
+		"notDetectedDays":  p.notDetectedDays,
+		"notDetectedSyncs": p.notDetectedSyncs,
+	}
+}
+
+// cypher query to search the resources in the NotDetected state with the time and the number of hardware syncs recorded on their edges
+const cypherSelectNotDetectedResourceList = `
+	MATCH (vrs)-[endt:NotDetected]->(:NotDetectedDevice)
+	WHERE exists(vrs.deviceID) AND exists(vrs.type)
+	WITH vrs, endt
+	ORDER BY vrs.deviceID
+	RETURN vrs.deviceID, vrs.type, CASE WHEN endt.since IS NULL THEN "" ELSE endt.since END, CASE WHEN endt.missedSyncs IS NULL THEN 0 ELSE endt.missedSyncs END
+`
+const selectNotDetectedResourceListColumnCount = 4
+const (
+	selectNotDetectedResourceListIndexDeviceID = iota
+	selectNotDetectedResourceListIndexType
+	selectNotDetectedResourceListIndexSince
+	selectNotDetectedResourceListIndexMissedSyncs
+)
+
+// Structure for storing a resource in the NotDetected state
+type notDetectedResource struct {
+	deviceID     string
+	resourceType hwResourceType
+	since        string // Time since when the resource is not detected in ISO 8601, or empty if unknown
+	missedSyncs  int64  // Number of hardware syncs that did not detect the resource
+}
+
+// notDetectedDays returns the number of whole days the resource has not been detected at now.
+// ok is false if the time since when the resource is not detected is unknown.
+func (r notDetectedResource) notDetectedDays(now time.Time) (days int64, ok bool) {
+	since, err := time.Parse(time.RFC3339, r.since)
+	if err != nil {
+		return 0, false
+	}
+	return int64(now.Sub(since) / (24 * time.Hour)), true
+}
+
+// toObject converts the resource into the form of the purge candidate report.
+func (r notDetectedResource) toObject(now time.Time) map[string]any {
+	res := map[string]any{
+		"deviceID":         r.deviceID,
+		"type":             string(r.resourceType),
+		"notDetectedSince": nil,
+		"notDetectedDays":  nil,
+		"missedSyncs":      r.missedSyncs,
+	}
+	if days, ok := r.notDetectedDays(now); ok {
+		res["notDetectedSince"] = r.since
+		res["notDetectedDays"] = days
+	}
+	return res
+}
+
+// getNotDetectedResourceList retrieves the resources in the NotDetected state in ascending order of the device ID.
+func getNotDetectedResourceList(tx *sql.Tx) ([]notDetectedResource, error) {
+	common.Log.Debug(fmt.Sprintf("query: %s", cypherSelectNotDetectedResourceList))
+	cypherCursor, err := execCypher(tx, database.GRAPH_NAME, selectNotDetectedResourceListColumnCount, cypherSelectNotDetectedResourceList)
+	if err != nil {
+		common.Log.Error(err.Error())
+		return nil, err
+	}
+	defer cypherCursor.Close()
+
+	res := []notDetectedResource{}
+	for cypherCursor.Next() {
+		row, err := cypherCursor.GetRow()
+		if err != nil {
+			common.Log.Error(err.Error())
+			return nil, err
+		}
+		res = append(res, notDetectedResource{
+			deviceID:     cmapi_repository.ExtractEntityString(row[selectNotDetectedResourceListIndexDeviceID].(*age.SimpleEntity)),
+			resourceType: hwResourceType(cmapi_repository.ExtractEntityString(row[selectNotDetectedResourceListIndexType].(*age.SimpleEntity))),
+			since:        cmapi_repository.ExtractEntityString(row[selectNotDetectedResourceListIndexSince].(*age.SimpleEntity)),
+			missedSyncs:  row[selectNotDetectedResourceListIndexMissedSyncs].(*age.SimpleEntity).AsInt64(),
+		})
+	}
+	return res, nil
+}
+
+// getPurgeCandidates returns the resources that meet the policy at now, keeping their order.
+func getPurgeCandidates(resources []notDetectedResource, policy retentionPolicy, now time.Time) []notDetectedResource {
+	candidates := []notDetectedResource{}
+	for _, resource := range resources {
+		if policy.expired(resource, now) {
+			candidates = append(candidates, resource)
+		}
+	}
+	return candidates
+}
+
+// filterScopedResources returns the resources in the scope of a hardware sync, keeping their order.
+// All the resources are returned if scopedDeviceIDs is nil, that is, for the full scope.
+func filterScopedResources(resources []notDetectedResource, scopedDeviceIDs map[string]bool) []notDetectedResource {
+	if scopedDeviceIDs == nil {
+		return resources
+	}
+	res := []notDetectedResource{}
+	for _, resource := range resources {
+		if scopedDeviceIDs[resource.deviceID] {
+			res = append(res, resource)
+		}
+	}
+	return res
+}
+
+// applyRetentionPolicy purges the resources in the NotDetected state that meet the policy, and records the purged
+// resources and the nodes left without resources in result. It is called after the hardware sync has counted the
+// resources it did not detect, in the same transaction.
+// A scoped hardware sync only purges the resources in its scope, so that it does not delete the resources of the other scopes.
+// The units that contained the purged resources are examined once after all the resources are purged.
+func applyRetentionPolicy(tx *sql.Tx, policy retentionPolicy, result *syncResult) error {
+	if !policy.enabled() {
+		return nil
+	}
+
+	resources, err := getNotDetectedResourceList(tx)
+	if err != nil {
+		return err
+	}
+	candidates := getPurgeCandidates(filterScopedResources(resources, result.scopedDeviceIDs), policy, time.Now().UTC())
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	existsNodes, err := getNodeList(tx)
+	if err != nil {
+		return err
+	}
+	unitIDs := map[string]bool{}
+	for _, candidate := range candidates {
+		containingUnitIDs, removedNodeIDs, err := deletePurgedResource(tx, candidate.deviceID, candidate.resourceType, existsNodes)
+		if err != nil {
+			return err
+		}
+		for _, unitID := range containingUnitIDs {
+			unitIDs[unitID] = true
+		}
+		// The nodes composed of the purged resource are no longer composed of it when the next candidate is purged
+		for _, existingNode := range existsNodes {
+			delete(existingNode.deviceDictionary, candidate.deviceID)
+		}
+		result.purgedDeviceIDs = append(result.purgedDeviceIDs, candidate.deviceID)
+		result.removedNodeIDs = append(result.removedNodeIDs, removedNodeIDs...)
+	}
+	// A unit may contain several purged resources, and it is left without resources only after all of them are purged
+	if err := deleteUnitsWithoutContain(tx, sortedKeys(unitIDs)); err != nil {
+		return err
+	}
+	common.Log.Info(fmt.Sprintf("applyRetentionPolicy purged %d resources : %v", len(candidates), result.purgedDeviceIDs))
+	return nil
+}
@@ -0,0 +1,225 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	cmapi_model_rule "github.com/project-cdim/configuration-manager/model/rule"
+)
+
+func Test_loadRetentionPolicy(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want retentionPolicy
+	}{
+		{
+			name: "Normal case: Both criteria are disabled by default",
+			env:  map[string]string{},
+			want: retentionPolicy{},
+		},
+		{
+			name: "Normal case: Both criteria are configured",
+			env: map[string]string{
+				"CM_RETENTION_NOT_DETECTED_DAYS":  "30",
+				"CM_RETENTION_NOT_DETECTED_SYNCS": " 10 ",
+			},
+			want: retentionPolicy{notDetectedDays: 30, notDetectedSyncs: 10},
+		},
+		{
+			name: "Normal case: Invalid values disable the criteria",
+			env: map[string]string{
+				"CM_RETENTION_NOT_DETECTED_DAYS":  "-1",
+				"CM_RETENTION_NOT_DETECTED_SYNCS": "ten",
+			},
+			want: retentionPolicy{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := loadRetentionPolicy(func(key string) string { return tt.env[key] })
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadRetentionPolicy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_retentionPolicy_enabled(t *testing.T) {
+	if (retentionPolicy{}).enabled() {
+		t.Errorf("enabled() = true, want false")
+	}
+	if !(retentionPolicy{notDetectedSyncs: 1}).enabled() {
+		t.Errorf("enabled() = false, want true")
+	}
+}
+
+func Test_retentionPolicy_expired(t *testing.T) {
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		policy   retentionPolicy
+		resource notDetectedResource
+		want     bool
+	}{
+		{
+			name:     "Normal case: Not detected for the number of days",
+			policy:   retentionPolicy{notDetectedDays: 7},
+			resource: notDetectedResource{since: "2025-06-23T12:00:00Z"},
+			want:     true,
+		},
+		{
+			name:     "Normal case: Not detected for less than the number of days",
+			policy:   retentionPolicy{notDetectedDays: 7},
+			resource: notDetectedResource{since: "2025-06-23T12:00:01Z"},
+			want:     false,
+		},
+		{
+			name:     "Normal case: Not detected by the number of hardware syncs",
+			policy:   retentionPolicy{notDetectedDays: 7, notDetectedSyncs: 3},
+			resource: notDetectedResource{since: "2025-06-30T00:00:00Z", missedSyncs: 3},
+			want:     true,
+		},
+		{
+			name:     "Normal case: The days criterion does not apply to an unknown time",
+			policy:   retentionPolicy{notDetectedDays: 7, notDetectedSyncs: 3},
+			resource: notDetectedResource{since: "", missedSyncs: 2},
+			want:     false,
+		},
+		{
+			name:     "Normal case: The disabled policy never expires",
+			policy:   retentionPolicy{},
+			resource: notDetectedResource{since: "2020-01-01T00:00:00Z", missedSyncs: 100},
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.expired(tt.resource, now); got != tt.want {
+				t.Errorf("expired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_retentionPolicy_toObject(t *testing.T) {
+	want := map[string]any{"notDetectedDays": int64(30), "notDetectedSyncs": int64(0)}
+	if got := (retentionPolicy{notDetectedDays: 30}).toObject(); !reflect.DeepEqual(got, want) {
+		t.Errorf("toObject() = %v, want %v", got, want)
+	}
+}
+
+func Test_notDetectedResource_toObject(t *testing.T) {
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	resource := notDetectedResource{deviceID: "res101", resourceType: Storage, since: "2025-06-28T13:00:00Z", missedSyncs: 2}
+	want := map[string]any{
+		"deviceID":         "res101",
+		"type":             "storage",
+		"notDetectedSince": "2025-06-28T13:00:00Z",
+		"notDetectedDays":  int64(1),
+		"missedSyncs":      int64(2),
+	}
+	if got := resource.toObject(now); !reflect.DeepEqual(got, want) {
+		t.Errorf("toObject() = %v, want %v", got, want)
+	}
+
+	// The edges created before the time was recorded have no time
+	resource.since = ""
+	want["notDetectedSince"] = nil
+	want["notDetectedDays"] = nil
+	if got := resource.toObject(now); !reflect.DeepEqual(got, want) {
+		t.Errorf("toObject() = %v, want %v", got, want)
+	}
+}
+
+func Test_getNotDetectedResourceList(t *testing.T) {
+	t.Skip("not test")
+}
+
+func Test_getPurgeCandidates(t *testing.T) {
+	now := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+	resources := []notDetectedResource{
+		{deviceID: "res101", missedSyncs: 5},
+		{deviceID: "res102", missedSyncs: 1},
+		{deviceID: "res103", missedSyncs: 9},
+	}
+	want := []notDetectedResource{resources[0], resources[2]}
+	if got := getPurgeCandidates(resources, retentionPolicy{notDetectedSyncs: 5}, now); !reflect.DeepEqual(got, want) {
+		t.Errorf("getPurgeCandidates() = %v, want %v", got, want)
+	}
+	if got := getPurgeCandidates(resources, retentionPolicy{}, now); len(got) != 0 {
+		t.Errorf("getPurgeCandidates() = %v, want empty", got)
+	}
+}
+
+func Test_filterScopedResources(t *testing.T) {
+	resources := []notDetectedResource{
+		{deviceID: "res101", missedSyncs: 5},
+		{deviceID: "res102", missedSyncs: 5},
+		{deviceID: "res103", missedSyncs: 5},
+	}
+	tests := []struct {
+		name            string
+		scopedDeviceIDs map[string]bool
+		want            []notDetectedResource
+	}{
+		{name: "Normal case: Full scope", scopedDeviceIDs: nil, want: resources},
+		{name: "Normal case: Scoped hardware sync", scopedDeviceIDs: map[string]bool{"res101": true, "res103": true, "res201": true}, want: []notDetectedResource{resources[0], resources[2]}},
+		{name: "Normal case: No resource in the scope", scopedDeviceIDs: map[string]bool{}, want: []notDetectedResource{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := filterScopedResources(resources, tt.scopedDeviceIDs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filterScopedResources() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newResourceSync_scopedDeviceIDs(t *testing.T) {
+	// The purge of a scoped hardware sync is limited to the resources in the chassis of the scope
+	dbExistsResources := map[string]existingResource{
+		"res101": {isNotDetected: true, resourceType: Memory},
+		"res201": {isNotDetected: true, resourceType: Memory},
+	}
+	dbExistsChassis := map[string]existingChassis{
+		"chassis01": {deviceDictionary: map[string]mountedResource{"res101": {resourceType: Memory}}},
+		"chassis02": {deviceDictionary: map[string]mountedResource{"res201": {resourceType: Memory}}},
+	}
+	scoped := newResourceSync(nil, dbExistsResources, map[string]existingNodeSwitch{}, map[string]existingNodeSwitch{}, dbExistsChassis,
+		cmapi_model_rule.AssignmentRuleList{}, syncScope{chassisIDs: []string{"chassis01"}})
+	if want := map[string]bool{"res101": true}; !reflect.DeepEqual(scoped.result.scopedDeviceIDs, want) {
+		t.Errorf("newResourceSync() result.scopedDeviceIDs = %v, want %v", scoped.result.scopedDeviceIDs, want)
+	}
+	full := newResourceSync(nil, dbExistsResources, map[string]existingNodeSwitch{}, map[string]existingNodeSwitch{}, dbExistsChassis,
+		cmapi_model_rule.AssignmentRuleList{}, syncScope{})
+	if full.result.scopedDeviceIDs != nil {
+		t.Errorf("newResourceSync() result.scopedDeviceIDs = %v, want nil", full.result.scopedDeviceIDs)
+	}
+}
+
+func Test_applyRetentionPolicy(t *testing.T) {
+	// The disabled policy does not access the database
+	result := newSyncResult()
+	if err := applyRetentionPolicy(nil, retentionPolicy{}, &result); err != nil {
+		t.Errorf("applyRetentionPolicy() error = %v", err)
+	}
+	if !reflect.DeepEqual(result, newSyncResult()) {
+		t.Errorf("applyRetentionPolicy() result = %v, want %v", result, newSyncResult())
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/project-cdim/configuration-manager/changelog"
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	changeStreamPollInterval      = 1 * time.Second  // Interval to poll the change log for new changes
+	changeStreamHeartbeatInterval = 15 * time.Second // Interval to send a comment to keep the connection alive
+	changeStreamBatchSize         = 100              // Maximum number of changes fetched in a poll
+	changeStreamRetryMillis       = 3000             // Reconnection delay advised to the client
+)
+
+// StreamChanges handles the request to stream the changes recorded in the change log as Server-Sent Events.
+//
+// Each change is sent as an event whose id is the sequence number, whose event is the type of the change,
+// and whose data is the change in JSON. A client resumes from where it left off with the Last-Event-ID header,
+// which EventSource sends automatically on reconnection. Without it, the 'since' query parameter is used,
+// and the stream starts after the changes that have been recorded so far if neither is specified.
+//
+// Responses:
+//   - 200 OK: The event stream. It continues until the client disconnects or an error occurs.
+//   - 400 Bad Request: The Last-Event-ID header or the 'since' query parameter is invalid.
+//   - 500 Internal Server Error: An error occurred while connecting to the database.
+func StreamChanges(c *gin.Context) {
+	common.Log.Info(fmt.Sprintf("%s[%s] start.", c.Request.URL.Path, c.Request.Method))
+	funcName := "StreamChanges"
+
+	since, specified, err := getChangeStreamStart(c)
+	if err != nil {
+		errorDatial := "Last-Event-ID or since error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
+	cmdb := database.NewCmDb()
+	err = cmdb.CmDbConnection()
+	if err != nil {
+		errorDatial := "CmDbConnection error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+	defer cmdb.CmDbDisconnection()
+
+	if !specified {
+		// Skip the changes recorded so far
+		since, err = changelog.LatestSeq(cmdb.Db)
+		if err != nil {
+			errorDatial := "changelog.LatestSeq error"
+			common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+			c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+			return
+		}
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", changeStreamRetryMillis)
+	c.Writer.Flush()
+
+	ticker := time.NewTicker(changeStreamPollInterval)
+	defer ticker.Stop()
+	heartbeat := time.NewTicker(changeStreamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	lastSeq := since
+	for {
+		changes, err := changelog.List(cmdb.Db, lastSeq, changeStreamBatchSize)
+		if err != nil {
+			// The client reconnects with the Last-Event-ID and resumes the stream
+			common.Log.Error(fmt.Sprintf("%s changelog.List error : %s", funcName, err.Error()), false)
+			return
+		}
+		for _, change := range changes {
+			if err := writeChangeEvent(c.Writer, change); err != nil {
+				common.Log.Warn(fmt.Sprintf("%s writeChangeEvent error : %s", funcName, err.Error()), false)
+				return
+			}
+			lastSeq = change.Seq
+		}
+		if len(changes) > 0 {
+			c.Writer.Flush()
+		}
+		if len(changes) == changeStreamBatchSize {
+			// More changes are waiting
+			continue
+		}
+
+		select {
+		case <-c.Request.Context().Done():
+			common.Log.Info(fmt.Sprintf("%s[%s] completed successfully.", c.Request.URL.Path, c.Request.Method))
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": keep-alive\n\n")
+			c.Writer.Flush()
+		case <-ticker.C:
+		}
+	}
+}
+
+// getChangeStreamStart returns the sequence number after which the change stream starts.
+// The Last-Event-ID header takes precedence over the 'since' query parameter.
+// specified is false if neither is specified.
+func getChangeStreamStart(c *gin.Context) (since int64, specified bool, err error) {
+	value := strings.TrimSpace(c.GetHeader("Last-Event-ID"))
+	if len(value) == 0 {
+		value = strings.TrimSpace(c.Query("since"))
+	}
+	if len(value) == 0 {
+		return 0, false, nil
+	}
+
+	since, err = strconv.ParseInt(value, 10, 64)
+	if err != nil || since < 0 {
+		return 0, false, fmt.Errorf("invalid sequence number: %q", value)
+	}
+	return since, true, nil
+}
+
+// writeChangeEvent writes the change to w in the format of Server-Sent Events.
+func writeChangeEvent(w io.Writer, change changelog.Change) error {
+	data, err := json.Marshal(change.ToObject())
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", change.Seq, change.Type, data)
+	return err
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/project-cdim/configuration-manager/changelog"
+)
+
+func TestStreamChanges(t *testing.T) {
+	t.Skip("not test")
+}
+
+func Test_getChangeStreamStart(t *testing.T) {
+	tests := []struct {
+		name          string
+		lastEventID   string
+		query         string
+		want          int64
+		wantSpecified bool
+		wantErr       bool
+	}{
+		{name: "Normal case: Last-Event-ID takes precedence", lastEventID: "15", query: "since=10", want: 15, wantSpecified: true},
+		{name: "Normal case: since is used without Last-Event-ID", lastEventID: "", query: "since=10", want: 10, wantSpecified: true},
+		{name: "Normal case: Neither is specified", lastEventID: "", query: "", want: 0, wantSpecified: false},
+		{name: "Error case: Last-Event-ID is not a number", lastEventID: "abc", query: "", wantErr: true},
+		{name: "Error case: since is negative", lastEventID: "", query: "since=-1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := setupTestGinContext(tt.query)
+			if len(tt.lastEventID) > 0 {
+				c.Request.Header.Set("Last-Event-ID", tt.lastEventID)
+			}
+			got, gotSpecified, err := getChangeStreamStart(c)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getChangeStreamStart() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want || gotSpecified != tt.wantSpecified {
+				t.Errorf("getChangeStreamStart() = %v, %v, want %v, %v", got, gotSpecified, tt.want, tt.wantSpecified)
+			}
+		})
+	}
+}
+
+func Test_writeChangeEvent(t *testing.T) {
+	change := changelog.Change{Seq: 12, ID: "event001", Type: "resource.created", Subject: "res101", Data: json.RawMessage(`{"deviceID":"res101"}`), Timestamp: "2025-01-01T00:00:00Z"}
+	var buf bytes.Buffer
+	if err := writeChangeEvent(&buf, change); err != nil {
+		t.Fatalf("writeChangeEvent() error = %v", err)
+	}
+	want := "id: 12\nevent: resource.created\n" +
+		`data: {"data":{"deviceID":"res101"},"id":"event001","seq":12,"subject":"res101","timestamp":"2025-01-01T00:00:00Z","type":"resource.created"}` + "\n\n"
+	if got := buf.String(); got != want {
+		t.Errorf("writeChangeEvent() = %q, want %q", got, want)
+	}
+}
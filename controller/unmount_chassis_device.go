@@ -0,0 +1,63 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/project-cdim/configuration-manager/common"
+	cmapi_repository "github.com/project-cdim/configuration-manager/repository"
+	cmapi_repository_chassis "github.com/project-cdim/configuration-manager/repository/chassis"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UnmountChassisDevice is a handler that unmounts a device from the specified chassis.
+// The device is specified by the deviceID of a resource or the ID of a CXL switch.
+//
+// Parameters:
+// - c: gin.Context, the request context
+//
+// Response:
+// - On success: 204 status code
+// - If the device is not mounted in the chassis: 404 status code
+// - On server error: 500 status code
+func UnmountChassisDevice(c *gin.Context) {
+	common.Log.Info(fmt.Sprintf("%s[%s] start.", c.Request.URL.Path, c.Request.Method))
+	funcName := "UnmountChassisDevice"
+
+	id := c.Param("id")
+	deviceID := c.Param("deviceID")
+	repository := cmapi_repository_chassis.NewUnmountChassisRepository(id, deviceID)
+	err := cmapi_repository.RelayDelete(&repository)
+	if errors.Is(err, cmapi_repository.ErrNotFound) {
+		errorDatial := "The target device was not mounted in the chassis"
+		common.Log.Warn(fmt.Sprintf("%s %s [id : %v, deviceID : %v]", funcName, errorDatial, id, deviceID), false)
+		c.JSON(http.StatusNotFound, convertErrorResponse(http.StatusNotFound, errorDatial))
+		return
+	}
+	if err != nil {
+		errorDatial := "RelayDelete error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	common.Log.Info(fmt.Sprintf("%s[%s] completed successfully.", c.Request.URL.Path, c.Request.Method))
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -15,6 +15,7 @@
 package controller
 
 import (
+	"database/sql"
 	"fmt"
 	"net/http"
 
@@ -24,6 +25,7 @@ import (
 	cmapi_repository "github.com/project-cdim/configuration-manager/repository"
 	cmapi_repository_annotation "github.com/project-cdim/configuration-manager/repository/annotation"
 	cmapi_repository_resource "github.com/project-cdim/configuration-manager/repository/resource"
+	cmapi_repository_unit "github.com/project-cdim/configuration-manager/repository/unit"
 
 	"github.com/gin-gonic/gin"
 )
@@ -31,14 +33,11 @@ import (
 // UpdateAnnotation handles the update of annotations for a given resource ID.
 //
 // It retrieves the resource based on the provided ID, updates its annotations with the properties
-// provided in the request body, and persists the changes. The function handles different scenarios
-// based on the resource type (CPU or non-CPU) and the presence of non-removable devices associated
-// with the resource.
+// provided in the request body, and persists the changes.
 //
-// When non-removable devices are associated with the resource, annotations will be updated for all
-// related resources as a batch operation. For CPU resources, the annotations are updated for both
-// the CPU itself and its non-removable devices. For non-CPU resources with associated CPU resources,
-// the annotations are updated for both the non-CPU resource and any related resources through the CPU.
+// When the resource is contained in a unit, the annotation is updated for the unit and all resources
+// contained in the unit as a batch operation in the same way as UpdateUnitAnnotation, so that the members
+// of a unit always share the same annotation. Otherwise, only the annotation of the resource is updated.
 //
 // Parameters:
 //
@@ -88,45 +87,36 @@ func UpdateAnnotation(c *gin.Context) {
 		return
 	}
 
-	// Collect the device IDs to be updated.
-	deviceIDs := make([]string, 0, 10)
-	device := resource["device"].(map[string]any)
-	nonRemovableDeviceIDs := getNonRemovableDeviceIDs(device)
-
-	if len(nonRemovableDeviceIDs) == 0 {
-		deviceIDs = append(deviceIDs, id)
-	} else {
-		if device["type"].(string) == CPU {
-			deviceIDs = append(deviceIDs, id)
-			deviceIDs = append(deviceIDs, nonRemovableDeviceIDs...)
-		} else {
-			if len(nonRemovableDeviceIDs) > 1 {
-				// Since it is assumed that there is only one nonRemovableDevices element, a warning is output if there are two or more.
-				common.Log.Warn(fmt.Sprintf("%s %s [id : %v]", funcName, "For resources other than CPU, there were two or more elements in the nonRemovableDevices element.", id), false)
-			}
-
-			cpuDeviceID := nonRemovableDeviceIDs[0]
-			getRepository = cmapi_repository_resource.NewResourceRepository(cpuDeviceID)
-			cpuResource, err := cmapi_repository.RelayFind(&getRepository, filter)
-			if err != nil {
-				errorDatial := "RelayFind error"
-				common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
-				c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
-				return
-			}
-
-			cpuDevice := cpuResource["device"].(map[string]any)
-			cpuNonRemovableDeviceIDs := getNonRemovableDeviceIDs(cpuDevice)
+	annotation := cmapi_model_annotation.NewAnnotation()
+	annotation.Properties = annotationProperties
 
-			deviceIDs = append(deviceIDs, cpuDeviceID)
-			deviceIDs = append(deviceIDs, cpuNonRemovableDeviceIDs...)
+	var repository cmapi_repository.RepositorySetter
+	deviceIDs := []string{id}
+	unitID, _ := resource["unitID"].(string)
+	if len(unitID) > 0 {
+		// Update the annotations of the unit and all resources contained in it
+		unitRepository := cmapi_repository_annotation.NewUpdateUnitAnnotationRepository(unitID)
+		repository = &unitRepository
+
+		// Fetch the resources contained in the unit to notify the change of their annotations
+		unitGetRepository := cmapi_repository_unit.NewUnitRepository(unitID, false)
+		unit, err := cmapi_repository.RelayFind(&unitGetRepository, filter)
+		if err != nil {
+			errorDatial := "RelayFind error"
+			common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+			c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+			return
 		}
+		deviceIDs = getUnitMemberIDs(unit)
+	} else {
+		annotationRepository := cmapi_repository_annotation.NewUpdateAnnotationRepository([]string{id})
+		repository = &annotationRepository
 	}
 
-	annotation := cmapi_model_annotation.NewAnnotation()
-	annotation.Properties = annotationProperties
-	repository := cmapi_repository_annotation.NewUpdateAnnotationRepository(deviceIDs)
-	res, err := cmapi_repository.RelaySet(&repository, &annotation)
+	// The events are written to the outbox in the same transaction as the update
+	res, err := cmapi_repository.RelaySetWithHook(repository, &annotation, func(tx *sql.Tx, res map[string]any) error {
+		return enqueueDomainEvents(tx, newAnnotationChangedEvents(deviceIDs, unitID, res))
+	})
 	if err != nil {
 		errorDatial := "RelaySet error"
 		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
@@ -139,47 +129,3 @@ func UpdateAnnotation(c *gin.Context) {
 
 	c.JSON(http.StatusOK, res)
 }
-
-// getNonRemovableDeviceIDs extracts the deviceIDs of non-removable devices from a device map.
-// It expects the device map to contain a "constraints" field, which is a map.
-// The "constraints" map should contain a "nonRemovableDevices" field, which is a list of maps.
-// Each map in the "nonRemovableDevices" list should contain a "deviceID" field, which is a string.
-// If any of these fields are missing or have the wrong type, a warning is logged and an empty list is returned.
-// It returns a list of deviceIDs of non-removable devices.
-func getNonRemovableDeviceIDs(device map[string]any) []string {
-	constraints, ok := device["constraints"].(map[string]any)
-	if !ok {
-		common.Log.Warn(fmt.Sprintf("constraints field is missing or not a map. resource(%v)", device))
-		return []string{}
-	}
-
-	nonRemovableDevices, ok := constraints["nonRemovableDevices"].([]any)
-	if !ok {
-		common.Log.Warn(fmt.Sprintf("constraints/nonRemovableDevices field is missing or not a list. resource(%v)", device))
-		return []string{}
-	}
-
-	if len(nonRemovableDevices) == 0 {
-		common.Log.Warn(fmt.Sprintf("constraints/nonRemovableDevices contains no elements. resource(%v)", device))
-		return []string{}
-	}
-
-	res := []string{}
-	for i, nonRemovableDevice := range nonRemovableDevices {
-		nonRemovableDeviceMap, ok := nonRemovableDevice.(map[string]any)
-		if !ok {
-			common.Log.Warn(fmt.Sprintf("constraints/nonRemovableDevices[%d] is not a map. resource(%v)", i, device))
-			return []string{}
-		}
-
-		deviceID, ok := nonRemovableDeviceMap["deviceID"].(string)
-		if !ok {
-			common.Log.Warn(fmt.Sprintf("constraints/nonRemovableDevices[%d]/deviceID field is missing or not a string. resource(%v)", i, device))
-			return []string{}
-		}
-
-		res = append(res, deviceID)
-	}
-
-	return res
-}
@@ -15,6 +15,7 @@
 package controller
 
 import (
+	"database/sql"
 	"fmt"
 	"net/http"
 
@@ -96,7 +97,10 @@ func UpdateGroup(c *gin.Context) {
 
 	group := cmapi_model_group.NewGroupForUpdate(groupFromDb, properties)
 	repository := cmapi_repository_group.NewUpdateGroupRepository()
-	res, err := cmapi_repository.RelaySet(&repository, &group)
+	// The event is written to the outbox in the same transaction as the update
+	res, err := cmapi_repository.RelaySetWithHook(&repository, &group, func(tx *sql.Tx, res map[string]any) error {
+		return enqueueDomainEvents(tx, []domainEvent{newDomainEvent(domainEventResourceGroupUpdated, id, res)})
+	})
 	if err != nil {
 		errorDatial := "RelaySet error"
 		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
@@ -0,0 +1,100 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/project-cdim/configuration-manager/common"
+	cmapi_filter "github.com/project-cdim/configuration-manager/filter"
+	cmapi_model_annotation "github.com/project-cdim/configuration-manager/model/annotation"
+	cmapi_repository "github.com/project-cdim/configuration-manager/repository"
+	cmapi_repository_annotation "github.com/project-cdim/configuration-manager/repository/annotation"
+	cmapi_repository_unit "github.com/project-cdim/configuration-manager/repository/unit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UpdateUnitAnnotation handles the update of the annotation of a given unit ID.
+//
+// It checks that the unit exists, and then updates the annotation of the unit and the annotations of
+// all resources contained in the unit with the properties provided in the request body, so that the
+// members of the unit always share the same annotation.
+//
+// Parameters:
+//
+// c: *gin.Context - The Gin context containing the request and response information.
+// The request context must include the unit ID as a parameter.
+//
+// Responses:
+//
+// 200 OK: The annotation was successfully updated. The response body contains the updated annotation.
+// 400 Bad Request: The request body could not be unmarshaled. The response body contains an error message.
+// 404 Not Found: The unit with the given ID does not exist. The response body contains an error message.
+// 500 Internal Server Error: An error occurred while retrieving or updating the unit in the database.
+// The response body contains an error message.
+func UpdateUnitAnnotation(c *gin.Context) {
+	common.Log.Info(fmt.Sprintf("%s[%s] start.", c.Request.URL.Path, c.Request.Method))
+	funcName := "UpdateUnitAnnotation"
+
+	id := c.Param("id")
+	// Reads the JSON from the RequestBody and expands it into a Map variable
+	annotationProperties, err := unmarshalRequestBodyForMap(c)
+	if err != nil {
+		errorDatial := "unmarshalRequestBodyForMap error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
+	// Checks if the target unit exists by searching once
+	filter := cmapi_filter.NewNoFilter()
+	getRepository := cmapi_repository_unit.NewUnitRepository(id, false)
+	unit, err := cmapi_repository.RelayFind(&getRepository, filter)
+	if err != nil {
+		errorDatial := "RelayFind error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	if unit == nil {
+		errorDatial := "The target unit for update did not exist"
+		common.Log.Warn(fmt.Sprintf("%s %s [id : %v]", funcName, errorDatial, id), false)
+		c.JSON(http.StatusNotFound, convertErrorResponse(http.StatusNotFound, errorDatial))
+		return
+	}
+
+	annotation := cmapi_model_annotation.NewAnnotation()
+	annotation.Properties = annotationProperties
+	repository := cmapi_repository_annotation.NewUpdateUnitAnnotationRepository(id)
+	// The events are written to the outbox in the same transaction as the update
+	res, err := cmapi_repository.RelaySetWithHook(&repository, &annotation, func(tx *sql.Tx, res map[string]any) error {
+		return enqueueDomainEvents(tx, newAnnotationChangedEvents(getUnitMemberIDs(unit), id, res))
+	})
+	if err != nil {
+		errorDatial := "RelaySet error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	logResponseBody(res)
+	common.Log.Info(fmt.Sprintf("%s[%s] completed successfully.", c.Request.URL.Path, c.Request.Method))
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,232 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+	cmapi_repository_rule "github.com/project-cdim/configuration-manager/repository/rule"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Results of the upsert of a single device
+const (
+	upsertDeviceResultAdded       = "added"       // The device was registered for the first time
+	upsertDeviceResultUpdated     = "updated"     // The existing device was updated
+	upsertDeviceResultRedetected  = "redetected"  // The device was updated and detected again after being in the NotDetected state
+	upsertDeviceResultUnchanged   = "unchanged"   // The existing device was detected again without any change
+	upsertDeviceResultQuarantined = "quarantined" // The device of an unsupported resource type was quarantined
+)
+
+// UpsertDevice registers or updates a single device without resending the full inventory to RegisterDevice.
+// The device in the request body is merged through the same logic as the hardware sync, including its node, CXL switch,
+// chassis and unit, in a scope limited to the device, so that the other devices are neither put in the NotDetected state nor changed.
+// The deviceID in the body may be omitted, but must be the same as the path parameter if specified.
+// As in the hardware sync, the device of an unsupported resource type is quarantined instead of being registered as a resource,
+// and the quarantined device is promoted to a resource when its resource type has become supported.
+// The domain events of the changes are written to the outbox in the same transaction. The hardware sync completed event is not published.
+//
+// Parameters:
+//   - c: gin.Context - Request context
+//
+// Response:
+//   - On success: HTTP status 201 (Created) if the device is registered for the first time, otherwise 200 (OK), with the device ID and the result
+//   - On validation error, or if the device cannot be registered: HTTP status 400 (Bad Request)
+//   - On server error: HTTP status 500 (Internal Server Error)
+func UpsertDevice(c *gin.Context) {
+	common.Log.Info(fmt.Sprintf("%s[%s] start.", c.Request.URL.Path, c.Request.Method))
+	funcName := "UpsertDevice"
+
+	id := c.Param("id")
+	properties, err := unmarshalRequestBodyForMap(c)
+	if err != nil {
+		errorDatial := "unmarshalRequestBodyForMap error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+
+	// The deviceID in the body must be the same as the path parameter
+	if deviceID, exists := properties["deviceID"]; exists && deviceID != id {
+		errorDatial := "deviceID mismatch error"
+		common.Log.Error(fmt.Sprintf("%s %s : %v, %s", funcName, errorDatial, deviceID, id), false)
+		c.JSON(http.StatusBadRequest, convertErrorResponse(http.StatusBadRequest, errorDatial))
+		return
+	}
+	properties["deviceID"] = id
+
+	requestResources, err := validateRegisterData([]map[string]any{properties})
+	if err != nil {
+		errorDatial := "validateRegisterData error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusBadRequest, convertValidationErrorResponse(err, errorDatial))
+		return
+	}
+
+	// Get DB connection
+	cmdb := database.NewCmDb()
+	err = cmdb.CmDbBeginTransaction()
+	if err != nil {
+		errorDatial := "CmDbBeginTransaction error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+	defer cmdb.CmDbDisconnection()
+
+	// Get the lists of already registered resources, nodes, switches and chassis
+	existsResources, err := getDeviceIDList(cmdb.Tx)
+	if err != nil {
+		errorDatial := "getDeviceIDList error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	existsNodes, err := getNodeList(cmdb.Tx)
+	if err != nil {
+		errorDatial := "getNodeList error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	existsSwitches, err := getCxlSwitchList(cmdb.Tx)
+	if err != nil {
+		errorDatial := "getCxlSwitchList error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	existsChassis, err := getChassisList(cmdb.Tx)
+	if err != nil {
+		errorDatial := "getChassisList error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	// Get the assignment rules that decide the resource group of a newly discovered resource
+	assignmentRules, err := cmapi_repository_rule.FindRules(cmdb)
+	if err != nil {
+		errorDatial := "FindRules error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	// Quarantine the device of an unsupported resource type instead of failing the upsert,
+	// and promote the device if it is quarantined and its resource type has become supported
+	quarantined, err := getQuarantinedDeviceList(cmdb.Tx)
+	if err != nil {
+		errorDatial := "getQuarantinedDeviceList error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+	quarantine := newUpsertQuarantinePlan(id, requestResources.resource, quarantined)
+	err = applyQuarantinePlan(cmdb.Tx, quarantine)
+	if err != nil {
+		errorDatial := "applyQuarantinePlan error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	// As in the hardware sync, the resource of the same device ID as a quarantined device is put in the NotDetected state
+	scope := syncScope{deviceIDs: []string{id}}
+	result, err := registerResources(cmdb.Tx, existsResources, existsNodes, existsSwitches, existsChassis, &resourceRegister{resource: quarantine.supported}, assignmentRules, scope)
+	if err != nil {
+		// Only the errors of the device in the request are responded with 400 Bad Request
+		status, res := syncErrorResponse(funcName, "registerResources error", err)
+		c.JSON(status, res)
+		return
+	}
+	result.addQuarantinePlan(quarantine)
+
+	err = enqueueDomainEvents(cmdb.Tx, newUpsertDeviceEvents(id, result))
+	if err != nil {
+		errorDatial := "enqueueDomainEvents error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	err = cmdb.CmDbCommit()
+	if err != nil {
+		errorDatial := "CmDbCommit error"
+		common.Log.Error(fmt.Sprintf("%s %s : %s", funcName, errorDatial, err.Error()), false)
+		c.JSON(http.StatusInternalServerError, convertErrorResponse(http.StatusInternalServerError, errorDatial))
+		return
+	}
+
+	upsertResult := getUpsertDeviceResult(id, result)
+	res := map[string]any{
+		"deviceID": id,
+		"result":   upsertResult,
+	}
+	status := http.StatusOK
+	if upsertResult == upsertDeviceResultAdded {
+		status = http.StatusCreated
+	}
+
+	logResponseBody(res)
+	common.Log.Info(fmt.Sprintf("%s[%s] completed successfully.", c.Request.URL.Path, c.Request.Method))
+
+	c.JSON(status, res)
+}
+
+// newUpsertQuarantinePlan decides whether to quarantine the device of the upsert, or to promote it if it is quarantined (see newQuarantinePlan).
+// Only the quarantined device of the same device ID is promoted, so that the other quarantined devices are not registered by the upsert.
+func newUpsertQuarantinePlan(deviceID string, requestResources []map[string]any, quarantined []quarantinedDevice) quarantinePlan {
+	quarantined = slices.DeleteFunc(slices.Clone(quarantined), func(device quarantinedDevice) bool {
+		return device.deviceID != deviceID
+	})
+	return newQuarantinePlan(requestResources, quarantined)
+}
+
+// getUpsertDeviceResult returns whether the device was added, updated, unchanged, re-detected or quarantined by the upsert.
+func getUpsertDeviceResult(deviceID string, result syncResult) string {
+	switch {
+	case slices.Contains(result.quarantinedDeviceIDs, deviceID):
+		return upsertDeviceResultQuarantined
+	case slices.Contains(result.addedDeviceIDs, deviceID):
+		return upsertDeviceResultAdded
+	case slices.Contains(result.redetectedDeviceIDs, deviceID):
+		return upsertDeviceResultRedetected
+	case slices.Contains(result.unchangedDeviceIDs, deviceID):
+		return upsertDeviceResultUnchanged
+	default:
+		return upsertDeviceResultUpdated
+	}
+}
+
+// newUpsertDeviceEvents creates the domain events of the upsert of a single device.
+// In addition to the events of the hardware sync, resource.updated is published when an existing device is updated.
+// No event is published for an unchanged device, and only resource.quarantined is published for a quarantined device.
+func newUpsertDeviceEvents(deviceID string, result syncResult) []domainEvent {
+	events := []domainEvent{}
+	if upsertResult := getUpsertDeviceResult(deviceID, result); !slices.Contains([]string{upsertDeviceResultAdded, upsertDeviceResultUnchanged, upsertDeviceResultQuarantined}, upsertResult) {
+		data := map[string]any{"deviceID": deviceID, "redetected": upsertResult == upsertDeviceResultRedetected}
+		events = append(events, newDomainEvent(domainEventResourceUpdated, deviceID, data))
+	}
+	return append(events, result.toDomainEvents()...)
+}
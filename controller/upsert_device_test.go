@@ -0,0 +1,184 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUpsertDevice(t *testing.T) {
+	t.Skip("not test")
+}
+
+func Test_getUpsertDeviceResult(t *testing.T) {
+	tests := []struct {
+		name   string
+		result func() syncResult
+		want   string
+	}{
+		{
+			name: "Normal case: Added",
+			result: func() syncResult {
+				sr := newSyncResult()
+				sr.addedDeviceIDs = []string{"res101"}
+				return sr
+			},
+			want: "added",
+		},
+		{
+			name: "Normal case: Re-detected",
+			result: func() syncResult {
+				sr := newSyncResult()
+				sr.redetectedDeviceIDs = []string{"res101"}
+				return sr
+			},
+			want: "redetected",
+		},
+		{
+			name: "Normal case: Updated",
+			result: func() syncResult {
+				sr := newSyncResult()
+				sr.updatedDeviceIDs = []string{"res101"}
+				return sr
+			},
+			want: "updated",
+		},
+		{
+			name: "Normal case: Unchanged",
+			result: func() syncResult {
+				sr := newSyncResult()
+				sr.unchangedDeviceIDs = []string{"res101"}
+				return sr
+			},
+			want: "unchanged",
+		},
+		{
+			name: "Normal case: Quarantined",
+			result: func() syncResult {
+				sr := newSyncResult()
+				sr.quarantinedDeviceIDs = []string{"res101"}
+				sr.notDetectedDeviceIDs = []string{"res101"}
+				return sr
+			},
+			want: "quarantined",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getUpsertDeviceResult("res101", tt.result()); got != tt.want {
+				t.Errorf("getUpsertDeviceResult() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newUpsertDeviceEvents(t *testing.T) {
+	added := newSyncResult()
+	added.addedDeviceIDs = []string{"res101"}
+	added.createdNodeIDs = []string{"res101"}
+
+	redetected := newSyncResult()
+	redetected.redetectedDeviceIDs = []string{"res101"}
+	redetected.removedNodeIDs = []string{"node001"}
+
+	unchanged := newSyncResult()
+	unchanged.unchangedDeviceIDs = []string{"res101"}
+
+	quarantined := newSyncResult()
+	quarantined.quarantinedDeviceIDs = []string{"res101"}
+
+	tests := []struct {
+		name   string
+		result syncResult
+		want   []domainEvent
+	}{
+		{
+			name:   "Normal case: Added device",
+			result: added,
+			want: []domainEvent{
+				newDomainEvent(domainEventResourceCreated, "res101", map[string]any{"deviceID": "res101"}),
+				newDomainEvent(domainEventNodeComposed, "res101", map[string]any{"nodeID": "res101"}),
+			},
+		},
+		{
+			name:   "Normal case: Re-detected device",
+			result: redetected,
+			want: []domainEvent{
+				newDomainEvent(domainEventResourceUpdated, "res101", map[string]any{"deviceID": "res101", "redetected": true}),
+				newDomainEvent(domainEventNodeDecomposed, "node001", map[string]any{"nodeID": "node001"}),
+			},
+		},
+		{
+			name:   "Normal case: Unchanged device",
+			result: unchanged,
+			want:   []domainEvent{},
+		},
+		{
+			name:   "Normal case: Quarantined device",
+			result: quarantined,
+			want: []domainEvent{
+				newDomainEvent(domainEventResourceQuarantined, "res101", map[string]any{"deviceID": "res101"}),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newUpsertDeviceEvents("res101", tt.result); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newUpsertDeviceEvents() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newUpsertQuarantinePlan(t *testing.T) {
+	quarantined := []quarantinedDevice{
+		{deviceID: "gpu01", resourceType: GPU, payload: map[string]any{"deviceID": "gpu01", "type": GPU}},
+		{deviceID: "gpu02", resourceType: GPU, payload: map[string]any{"deviceID": "gpu02", "type": GPU}},
+	}
+	dpu := map[string]any{"deviceID": "gpu01", "type": "DPU"}
+	gpu := map[string]any{"deviceID": "gpu01", "type": GPU}
+	tests := []struct {
+		name             string
+		requestResources []map[string]any
+		want             quarantinePlan
+	}{
+		{
+			name:             "Normal case: The device of an unsupported resource type is quarantined",
+			requestResources: []map[string]any{dpu},
+			want: quarantinePlan{
+				supported:  []map[string]any{},
+				quarantine: []map[string]any{dpu},
+				promote:    []string{},
+			},
+		},
+		{
+			name:             "Normal case: Only the quarantined device of the same device ID is promoted",
+			requestResources: []map[string]any{gpu},
+			want: quarantinePlan{
+				supported:  []map[string]any{gpu},
+				quarantine: []map[string]any{},
+				promote:    []string{"gpu01"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newUpsertQuarantinePlan("gpu01", tt.requestResources, quarantined); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newUpsertQuarantinePlan() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -25,7 +25,8 @@ import (
 // ResourceAvailableFilter is a struct that holds the filter criteria for resource availability.
 // It contains a slice of resource group IDs that are targeted for search.
 type ResourceAvailableFilter struct {
-	TargetResourceGroupIDs []string // TargetResourceGroupIDs is a slice of resource group IDs to be targeted for search.
+	TargetResourceGroupIDs []string           // TargetResourceGroupIDs is a slice of resource group IDs to be targeted for search.
+	Seen                   ResourceSeenFilter // Seen is the ranges of the times when the resources were first seen, last seen and not detected since.
 }
 
 // NewResourceAvailableFilter creates a new instance of resourceAvailableFilter.
@@ -51,6 +52,7 @@ func NewResourceAvailableFilter(resourceGroupIDs []string) ResourceAvailableFilt
 // This function checks for the following conditions:
 // - If NotDetected is true, it filters out records where 'detected' is false.
 // - If Available is true, it ensures that the 'device' and 'annotation' maps exist and that the 'available' status within 'annotation' matches the 'status' in 'device'.
+// - It checks if the times of the record are in the ranges of Seen.
 // - It also checks if the record belongs to any of the TargetResourceGroupIDs, if specified.
 //
 // Arguments:
@@ -85,6 +87,10 @@ func (raf ResourceAvailableFilter) FilterByCondition(record map[string]any, reco
 		return false
 	}
 
+	if !raf.Seen.FilterByCondition(record) {
+		return false
+	}
+
 	if len(raf.TargetResourceGroupIDs) > 0 {
 		resourceGroupIDs := record["resourceGroupIDs"].([]string)
 		for _, resourceGroupID := range resourceGroupIDs {
@@ -17,6 +17,7 @@ package resource_filter
 import (
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestNewResourceAvailableFilter(t *testing.T) {
@@ -31,12 +32,12 @@ func TestNewResourceAvailableFilter(t *testing.T) {
 		{
 			"Normal case: Create an instance of the ResourceAvailableFilter structure (arguments: empty array)",
 			args{[]string{}},
-			ResourceAvailableFilter{[]string{}},
+			ResourceAvailableFilter{TargetResourceGroupIDs: []string{}},
 		},
 		{
 			"Normal case: Create an instance of the ResourceAvailableFilter structure (arguments: non-empty array)",
 			args{[]string{"aa", "bb"}},
-			ResourceAvailableFilter{[]string{"aa", "bb"}},
+			ResourceAvailableFilter{TargetResourceGroupIDs: []string{"aa", "bb"}},
 		},
 	}
 	for _, tt := range tests {
@@ -161,3 +162,30 @@ func TestResourceAvailableFilter_FilterByCondition(t *testing.T) {
 		})
 	}
 }
+
+func Test_ResourceAvailableFilter_FilterByCondition_seen(t *testing.T) {
+	record := map[string]any{
+		"device":           map[string]any{"status": map[string]any{"state": "Enabled", "health": "OK"}},
+		"annotation":       map[string]any{"available": true},
+		"detected":         true,
+		"resourceGroupIDs": []string{"aaa"},
+		"firstSeenAt":      "2025-06-05T00:00:00Z",
+	}
+	tests := []struct {
+		name string
+		seen ResourceSeenFilter
+		want bool
+	}{
+		{"Normal case: if the time is in the range of Seen, the result is true", ResourceSeenFilter{FirstSeenAt: TimeRange{After: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)}}, true},
+		{"Normal case: if the time is out of the range of Seen, the result is false", ResourceSeenFilter{FirstSeenAt: TimeRange{Before: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)}}, false},
+		{"Normal case: if the time is unknown and Seen restricts it, the result is false", ResourceSeenFilter{LastSeenAt: TimeRange{After: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raf := ResourceAvailableFilter{TargetResourceGroupIDs: []string{"aaa"}, Seen: tt.seen}
+			if got := raf.FilterByCondition(record); got != tt.want {
+				t.Errorf("ResourceAvailableFilter.FilterByCondition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
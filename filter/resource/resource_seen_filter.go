@@ -0,0 +1,100 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package resource_filter
+
+import (
+	"time"
+)
+
+// TimeRange is a range of time used as a filter criterion.
+// After is inclusive and Before is exclusive. A zero time does not restrict that side of the range.
+type TimeRange struct {
+	After  time.Time
+	Before time.Time
+}
+
+// IsZero reports whether the range restricts neither side.
+func (tr TimeRange) IsZero() bool {
+	return tr.After.IsZero() && tr.Before.IsZero()
+}
+
+// contains reports whether the time in ISO 8601 is in the range. A value that is not a valid time is never in the range.
+func (tr TimeRange) contains(value string) bool {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return false
+	}
+	if !tr.After.IsZero() && t.Before(tr.After) {
+		return false
+	}
+	if !tr.Before.IsZero() && !t.Before(tr.Before) {
+		return false
+	}
+	return true
+}
+
+// ResourceSeenFilter is a struct that holds the filter criteria for the times when the resources were first seen,
+// last seen and not detected since.
+type ResourceSeenFilter struct {
+	FirstSeenAt      TimeRange // FirstSeenAt is the range of the time when the resource was first seen.
+	LastSeenAt       TimeRange // LastSeenAt is the range of the time when the resource was last seen.
+	NotDetectedSince TimeRange // NotDetectedSince is the range of the time since when the resource is not detected.
+}
+
+// NewResourceSeenFilter creates a new instance of ResourceSeenFilter with the ranges of the times.
+//
+// Parameters:
+//
+//	firstSeenAt - the range of the time when the resource was first seen.
+//	lastSeenAt - the range of the time when the resource was last seen.
+//	notDetectedSince - the range of the time since when the resource is not detected.
+//
+// Returns:
+//
+//	A new instance of ResourceSeenFilter.
+func NewResourceSeenFilter(firstSeenAt TimeRange, lastSeenAt TimeRange, notDetectedSince TimeRange) ResourceSeenFilter {
+	return ResourceSeenFilter{
+		FirstSeenAt:      firstSeenAt,
+		LastSeenAt:       lastSeenAt,
+		NotDetectedSince: notDetectedSince,
+	}
+}
+
+// FilterByCondition evaluates if a given record matches the ranges set in the ResourceSeenFilter.
+// A range that restricts neither side matches any record, and a record whose time is unknown does not match a range
+// that restricts it. For example, a detected resource never matches a range of the time since when it is not detected.
+//
+// Arguments:
+// record: The record to evaluate, expected to be a map with keys like 'firstSeenAt', 'lastSeenAt' and 'notDetectedSince'.
+// recordOption: Optional parameters for future use.
+//
+// Returns:
+// A boolean indicating if the record matches the filter conditions.
+func (rsf ResourceSeenFilter) FilterByCondition(record map[string]any, recordOption ...any) bool {
+	for key, tr := range map[string]TimeRange{
+		"firstSeenAt":      rsf.FirstSeenAt,
+		"lastSeenAt":       rsf.LastSeenAt,
+		"notDetectedSince": rsf.NotDetectedSince,
+	} {
+		if tr.IsZero() {
+			continue
+		}
+		value, _ := record[key].(string)
+		if !tr.contains(value) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,97 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package resource_filter
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewResourceSeenFilter(t *testing.T) {
+	firstSeenAt := TimeRange{After: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)}
+	notDetectedSince := TimeRange{Before: time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)}
+	want := ResourceSeenFilter{FirstSeenAt: firstSeenAt, NotDetectedSince: notDetectedSince}
+	if got := NewResourceSeenFilter(firstSeenAt, TimeRange{}, notDetectedSince); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewResourceSeenFilter() = %v, want %v", got, want)
+	}
+}
+
+func TestTimeRange_contains(t *testing.T) {
+	tr := TimeRange{
+		After:  time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+		Before: time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC),
+	}
+	tests := []struct {
+		name  string
+		value string
+		want  bool
+	}{
+		{"Normal case: The start of the range is included", "2025-06-01T00:00:00Z", true},
+		{"Normal case: A time in another time zone is compared in UTC", "2025-06-09T23:00:00-01:00", false},
+		{"Normal case: The end of the range is excluded", "2025-06-10T00:00:00Z", false},
+		{"Normal case: A time before the range is excluded", "2025-05-31T23:59:59Z", false},
+		{"Error case: An unknown time is excluded", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tr.contains(tt.value); got != tt.want {
+				t.Errorf("contains(%v) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceSeenFilter_FilterByCondition(t *testing.T) {
+	since := time.Date(2025, 6, 5, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		filter ResourceSeenFilter
+		record map[string]any
+		want   bool
+	}{
+		{
+			"Normal case: The filter without ranges matches any record",
+			ResourceSeenFilter{},
+			map[string]any{"detected": true},
+			true,
+		},
+		{
+			"Normal case: The record first seen in the range matches",
+			ResourceSeenFilter{FirstSeenAt: TimeRange{After: since}},
+			map[string]any{"firstSeenAt": "2025-06-05T00:00:00Z", "lastSeenAt": "2025-06-06T00:00:00Z"},
+			true,
+		},
+		{
+			"Normal case: The record last seen out of the range does not match",
+			ResourceSeenFilter{FirstSeenAt: TimeRange{After: since}, LastSeenAt: TimeRange{Before: since}},
+			map[string]any{"firstSeenAt": "2025-06-05T00:00:00Z", "lastSeenAt": "2025-06-06T00:00:00Z"},
+			false,
+		},
+		{
+			"Normal case: The detected record does not match the range of the time since when it is not detected",
+			ResourceSeenFilter{NotDetectedSince: TimeRange{Before: since}},
+			map[string]any{"firstSeenAt": "2025-06-01T00:00:00Z", "lastSeenAt": "2025-06-06T00:00:00Z"},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.FilterByCondition(tt.record); got != tt.want {
+				t.Errorf("FilterByCondition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -21,7 +21,8 @@ import (
 // ResourceUnusedFilter is a struct that holds the filter criteria for resources that are unused.
 // It contains a slice of resource group IDs that are targeted for the search.
 type ResourceUnusedFilter struct {
-	TargetResourceGroupIDs []string // TargetResourceGroupIDs is a slice of resource group IDs to be targeted for search.
+	TargetResourceGroupIDs []string           // TargetResourceGroupIDs is a slice of resource group IDs to be targeted for search.
+	Seen                   ResourceSeenFilter // Seen is the ranges of the times when the resources were first seen, last seen and not detected since.
 }
 
 // NewResourceUnusedFilter creates a new instance of resourceUnusedFilter.
@@ -47,7 +48,8 @@ func NewResourceUnusedFilter(resourceGroupIDs []string) ResourceUnusedFilter {
 // 2. The "device" field must contain a "status" map with "state" as "Enabled" and "health" as "OK".
 // 3. The "annotation" field must contain an "available" boolean set to true.
 // 4. The links array within the device field should be empty.
-// 5. If TargetResourceGroupIDs is not empty, the record's "resourceGroupIDs" must contain at least one of the target IDs.
+// 5. The times of the record must be in the ranges of Seen.
+// 6. If TargetResourceGroupIDs is not empty, the record's "resourceGroupIDs" must contain at least one of the target IDs.
 //
 // Parameters:
 //
@@ -80,6 +82,10 @@ func (ruf ResourceUnusedFilter) FilterByCondition(record map[string]any, recordO
 		return false
 	}
 
+	if !ruf.Seen.FilterByCondition(record) {
+		return false
+	}
+
 	if len(ruf.TargetResourceGroupIDs) > 0 {
 		resourceGroupIDs := record["resourceGroupIDs"].([]string)
 		for _, resourceGroupID := range resourceGroupIDs {
@@ -15,10 +15,21 @@
 package main
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+
 	logger "github.com/project-cdim/cdim-go-logger"
 
 	"github.com/project-cdim/configuration-manager/common"
 	"github.com/project-cdim/configuration-manager/controller"
+	"github.com/project-cdim/configuration-manager/database"
+	"github.com/project-cdim/configuration-manager/outbox"
+	"github.com/project-cdim/configuration-manager/publisher"
+	"github.com/project-cdim/configuration-manager/resourcetype"
+	"github.com/project-cdim/configuration-manager/syncjob"
+	"github.com/project-cdim/configuration-manager/webhook"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -32,11 +43,144 @@ const urlBaseV1 = "/" + common.ProjectName + "/api/v1"
 // Audit Trail Logger
 var log, _ = logger.New(logger_common.Option{Tag: logger_common.TAG_TRAIL})
 
+// The tables of the outbox, the change log, the webhooks and the sync jobs, created at startup before they are used.
+// They are placed in the public schema explicitly, because the search path of the connection starts with ag_catalog.
+// The request body of a sync job is kept until the job is finished, so that a job interrupted by a restart can be run again.
+const sqlCreateTables string = `
+CREATE TABLE IF NOT EXISTS public.configuration_manager_outbox (
+	id text PRIMARY KEY,
+	pubsub_name text NOT NULL,
+	topic text NOT NULL,
+	event_type text NOT NULL,
+	payload jsonb NOT NULL,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	attempts integer NOT NULL DEFAULT 0,
+	next_attempt_at timestamptz NOT NULL DEFAULT now(),
+	delivered_at timestamptz,
+	last_error text NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS configuration_manager_outbox_pending_idx
+	ON public.configuration_manager_outbox (next_attempt_at) WHERE delivered_at IS NULL;
+CREATE TABLE IF NOT EXISTS public.configuration_manager_changes (
+	seq bigserial PRIMARY KEY,
+	id text NOT NULL,
+	type text NOT NULL,
+	subject text NOT NULL,
+	data jsonb NOT NULL,
+	created_at timestamptz NOT NULL DEFAULT now()
+);
+CREATE TABLE IF NOT EXISTS public.configuration_manager_webhooks (
+	id text PRIMARY KEY,
+	url text NOT NULL,
+	event_types jsonb NOT NULL,
+	secret text NOT NULL,
+	created_at timestamptz NOT NULL DEFAULT now()
+);
+CREATE TABLE IF NOT EXISTS public.configuration_manager_webhook_deliveries (
+	id text PRIMARY KEY,
+	webhook_id text NOT NULL REFERENCES public.configuration_manager_webhooks (id) ON DELETE CASCADE,
+	event_id text NOT NULL,
+	event_type text NOT NULL,
+	payload jsonb NOT NULL,
+	status text NOT NULL DEFAULT 'pending',
+	attempts integer NOT NULL DEFAULT 0,
+	next_attempt_at timestamptz NOT NULL DEFAULT now(),
+	last_attempt_at timestamptz,
+	last_status_code integer,
+	last_error text,
+	delivered_at timestamptz,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	UNIQUE (webhook_id, event_id)
+);
+CREATE INDEX IF NOT EXISTS configuration_manager_webhook_deliveries_pending
+	ON public.configuration_manager_webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE TABLE IF NOT EXISTS public.configuration_manager_sync_jobs (
+	id text PRIMARY KEY,
+	status text NOT NULL DEFAULT 'queued',
+	parameters jsonb NOT NULL,
+	content bytea,
+	processed integer NOT NULL DEFAULT 0,
+	attempts integer NOT NULL DEFAULT 0,
+	status_code integer,
+	result jsonb,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	started_at timestamptz,
+	heartbeat_at timestamptz,
+	finished_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS configuration_manager_sync_jobs_unfinished
+	ON public.configuration_manager_sync_jobs (created_at) WHERE status IN ('queued', 'running')
+`
+
 func main() {
+	// Stop rather than run without the resource types added by the configuration
+	if err := resourcetype.ConfigurationError(); err != nil {
+		common.Log.Error(fmt.Sprintf("resource type configuration error : %s", err.Error()))
+		os.Exit(1)
+	}
+	ensureResourceTypeLabels()
+
+	// The background workers share one connection pool opened at startup, and begin only a transaction per poll
+	cmdb := database.NewCmDb()
+	if err := cmdb.CmDbConnection(); err != nil {
+		common.Log.Error(fmt.Sprintf("database connection error : %s", err.Error()))
+		os.Exit(1)
+	}
+	db := cmdb.Db
+	// Stop rather than run the workers and the API without their tables
+	if err := migrateTables(db); err != nil {
+		common.Log.Error(fmt.Sprintf("table migration error : %s", err.Error()))
+		os.Exit(1)
+	}
+
+	// Start the relay publishing the events written to the outbox with the publisher selected by the configuration
+	pub, err := publisher.New(publisher.LoadConfig(os.Getenv))
+	if err != nil {
+		common.Log.Error(fmt.Sprintf("event publisher configuration error : %s", err.Error()))
+		os.Exit(1)
+	}
+	// The webhooks receive the same events as the publisher, and are delivered by their own worker
+	relay := outbox.NewRelay(db, publisher.NewMultiPublisher(pub, webhook.NewDispatcher(db)))
+	go relay.Run(context.Background())
+	webhookWorker := webhook.NewWorker(db)
+	go webhookWorker.Run(context.Background())
+	// The hardware syncs requested asynchronously are run by the sync job worker
+	syncJobWorker := syncjob.NewWorker(db, controller.RunSyncJob)
+	go syncJobWorker.Run(context.Background())
+
 	engine := SetupEngine()
 	engine.Run(":8080")
 }
 
+// ensureResourceTypeLabels creates the labels of the registered resource types missing in the graph.
+// A failure is only logged, because the labels of the built-in types are created when the graph is initialized.
+func ensureResourceTypeLabels() {
+	cmdb := database.NewCmDb()
+	if err := cmdb.CmDbConnection(); err != nil {
+		common.Log.Error(fmt.Sprintf("resource type labels were not ensured : %s", err.Error()))
+		return
+	}
+	defer cmdb.CmDbDisconnection()
+
+	created, err := resourcetype.EnsureLabels(cmdb.Db, resourcetype.Registered())
+	if err != nil {
+		common.Log.Error(fmt.Sprintf("resource type labels were not ensured : %s", err.Error()))
+		return
+	}
+	if len(created) > 0 {
+		common.Log.Info(fmt.Sprintf("resource type labels created : %v", created))
+	}
+}
+
+// migrateTables creates the tables of the outbox, the change log, the webhooks and the sync jobs missing in the database.
+func migrateTables(db *sql.DB) error {
+	common.Log.Debug(fmt.Sprintf("query: %s", sqlCreateTables))
+	if _, err := db.Exec(sqlCreateTables); err != nil {
+		return err
+	}
+	return nil
+}
+
 // SetupEngine initializes and returns a new instance of the gin Engine. This function configures
 // the engine with essential middleware, including a custom logging middleware for audit trails,
 // and CORS support using the default configuration. It also sets up a versioned API route group
@@ -80,6 +224,12 @@ func SetupEngine() *gin.Engine {
 		// Retrieve a list of resources unused for configuration design from the configuration management database
 		v1.GET("/resources/unused", controller.GetUnusedResourceList)
 
+		// Retrieve a list of resources not detected for longer than the retention policy allows
+		v1.GET("/resources/purge-candidates", controller.GetPurgeCandidateList)
+
+		// Physically delete a specific resource from the configuration management database
+		v1.DELETE("/resources/:id", controller.DeleteResource)
+
 		// Update the additional information of a specific resource
 		v1.PUT("/resources/:id/annotation", controller.UpdateAnnotation)
 
@@ -113,11 +263,110 @@ func SetupEngine() *gin.Engine {
 		// Retrieve a specific CXL switch from the configuration management database
 		v1.GET("/cxlswitches/:id", controller.GetCxlSwitch)
 
+		// Retrieve the changes recorded in the change log after a sequence number
+		v1.GET("/changes", controller.GetChangeList)
+
+		// Stream the changes recorded in the change log as Server-Sent Events
+		v1.GET("/changes/stream", controller.StreamChanges)
+
+		// Retrieve a list of all webhooks
+		v1.GET("/webhooks", controller.GetWebhookList)
+
+		// Register a webhook receiving the events by HTTP callbacks
+		v1.POST("/webhooks", controller.CreateWebhook)
+
+		// Retrieve the deliveries given up after the maximum number of attempts over all webhooks
+		v1.GET("/webhooks/dead-letters", controller.GetWebhookDeadLetterList)
+
+		// Delete a specific webhook and its deliveries
+		v1.DELETE("/webhooks/:id", controller.DeleteWebhook)
+
+		// Retrieve the deliveries of the events to a specific webhook
+		v1.GET("/webhooks/:id/deliveries", controller.GetWebhookDeliveryList)
+
+		// Retrieve a list of all units with their annotations and the resources contained in each unit
+		v1.GET("/units", controller.GetUnitList)
+
+		// Retrieve a specific unit from the configuration management database
+		v1.GET("/units/:id", controller.GetUnit)
+
+		// Update the annotation of a specific unit and the resources contained in it
+		v1.PUT("/units/:id/annotation", controller.UpdateUnitAnnotation)
+
+		// Retrieve a list of all racks with the number of chassis and the summary of mounted devices
+		v1.GET("/racks", controller.GetRackList)
+
 		// Retrieve a specific rack from the configuration management database
 		v1.GET("/racks/:id", controller.GetRack)
 
+		// Register a new rack in the configuration management database
+		v1.POST("/racks", controller.CreateRack)
+
+		// Update the information of a specific rack
+		v1.PUT("/racks/:id", controller.UpdateRack)
+
+		// Delete a specific rack that has no chassis attached
+		v1.DELETE("/racks/:id", controller.DeleteRack)
+
+		// Retrieve a list of all chassis and their mounted devices from the configuration management database
+		v1.GET("/chassis", controller.GetChassisList)
+
+		// Retrieve a specific chassis and its mounted devices from the configuration management database
+		v1.GET("/chassis/:id", controller.GetChassis)
+
+		// Register a new chassis in the configuration management database
+		v1.POST("/chassis", controller.CreateChassis)
+
+		// Update the information of a specific chassis
+		v1.PUT("/chassis/:id", controller.UpdateChassis)
+
+		// Delete a specific chassis that has no devices mounted
+		v1.DELETE("/chassis/:id", controller.DeleteChassis)
+
+		// Attach a chassis to a rack at the specified unit position
+		v1.PUT("/chassis/:id/rack", controller.AttachChassis)
+
+		// Detach a chassis from the rack
+		v1.DELETE("/chassis/:id/rack", controller.DetachChassis)
+
+		// Mount resources and CXL switches in a chassis
+		v1.PUT("/chassis/:id/mounts", controller.MountChassisDevices)
+
+		// Unmount a resource or a CXL switch from a chassis
+		v1.DELETE("/chassis/:id/mounts/:deviceID", controller.UnmountChassisDevice)
+
+		// Retrieve a list of all assignment rules in evaluation order
+		v1.GET("/assignment-rules", controller.GetAssignmentRuleList)
+
+		// Register a new assignment rule that decides the resource group of newly discovered resources
+		v1.POST("/assignment-rules", controller.CreateAssignmentRule)
+
+		// Evaluate the assignment rules against a sample device without registering it
+		v1.POST("/assignment-rules/test", controller.EvaluateAssignmentRules)
+
+		// Retrieve a specific assignment rule
+		v1.GET("/assignment-rules/:id", controller.GetAssignmentRule)
+
+		// Update a specific assignment rule
+		v1.PUT("/assignment-rules/:id", controller.UpdateAssignmentRule)
+
+		// Delete a specific assignment rule
+		v1.DELETE("/assignment-rules/:id", controller.DeleteAssignmentRule)
+
 		// Register multiple device information in the configuration management database
 		v1.POST("/devices", controller.RegisterDevice)
+
+		// Get the devices of unsupported resource types quarantined by the hardware sync
+		v1.GET("/devices/quarantine", controller.GetQuarantinedDeviceList)
+
+		// Register or update a single device without the full hardware sync
+		v1.PUT("/devices/:id", controller.UpsertDevice)
+
+		// Put a single device in the NotDetected state, or delete it physically with purge=true
+		v1.DELETE("/devices/:id", controller.DeleteDevice)
+
+		// Retrieve the status, progress and result of a hardware sync requested asynchronously
+		v1.GET("/sync-jobs/:id", controller.GetSyncJob)
 	}
 
 	return engine
@@ -30,8 +30,8 @@ import (
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
 
-	"github.com/project-cdim/configuration-manager/controller"
 	"github.com/project-cdim/configuration-manager/database"
+	"github.com/project-cdim/configuration-manager/publisher"
 
 	dapr "github.com/dapr/go-sdk/client"
 )
@@ -98,13 +98,13 @@ func TestMain(m *testing.M) {
 		database.GetSecretCmdb = originalGetSecretCmdb
 	}()
 
-	// Mock the controller.DaprClientFactory function to return a no-op client
-	originalDaprClientFactory := controller.DaprClientFactory
-	controller.DaprClientFactory = func() (controller.DaprClient, error) {
+	// Mock the publisher.DaprClientFactory function to return a no-op client
+	originalDaprClientFactory := publisher.DaprClientFactory
+	publisher.DaprClientFactory = func() (publisher.DaprClient, error) {
 		return &daprClientMock{}, nil
 	}
 	defer func() {
-		controller.DaprClientFactory = originalDaprClientFactory
+		publisher.DaprClientFactory = originalDaprClientFactory
 	}()
 
 	// Run tests
@@ -60,11 +60,13 @@ func TestChassisList_ToObject(t *testing.T) {
 						map[string]any{"id": "aaa"},
 						resource_model.NewResourceList(),
 						cxlswitch_model.NewCXLSwitchList(),
+						"",
 					},
 					{
 						map[string]any{"id": "bbb"},
 						resource_model.NewResourceList(),
 						cxlswitch_model.NewCXLSwitchList(),
+						"",
 					},
 				},
 			},
@@ -87,16 +89,19 @@ func TestChassisList_ToObject(t *testing.T) {
 						map[string]any{"aaa": "bbb"},
 						resource_model.NewResourceList(),
 						cxlswitch_model.NewCXLSwitchList(),
+						"",
 					},
 					{
 						map[string]any{"id": ""},
 						resource_model.NewResourceList(),
 						cxlswitch_model.NewCXLSwitchList(),
+						"",
 					},
 					{
 						map[string]any{"id": "ccc"},
 						resource_model.NewResourceList(),
 						cxlswitch_model.NewCXLSwitchList(),
+						"",
 					},
 				},
 			},
@@ -20,10 +20,12 @@ import (
 )
 
 // Chassis is a Chassis structure.
+// RackID is the ID of the rack to which the chassis is attached. It is set only when the chassis is retrieved on its own.
 type Chassis struct {
 	Properties  map[string]any
 	Resources   resource_model.ResourceList
 	CXLSwitches cxlswitch_model.CXLSwitchList
+	RackID      string
 }
 
 // NewChassis is a constructor for the Chassis structure.
@@ -41,6 +43,7 @@ func NewChassis() Chassis {
 		Properties:  map[string]any{},
 		Resources:   resource_model.ResourceList{},
 		CXLSwitches: cxlswitch_model.CXLSwitchList{},
+		RackID:      "",
 	}
 }
 
@@ -68,6 +71,7 @@ func (c *Chassis) Validate() bool {
 // Upon successful validation, it proceeds to construct a map (`res`) initialized with the Chassis's properties.
 // It then aggregates resources from both CXLSwitches and Resources, appending them into a single slice.
 // This aggregated resources slice is then added to the `res` map under the "resources" key.
+// If the chassis holds the ID of the rack to which it is attached, it is added under the "rackID" key.
 // The resulting map, which now includes the Chassis's properties and its aggregated resources, is returned.
 //
 // Returns:
@@ -85,5 +89,9 @@ func (c *Chassis) ToObject() map[string]any {
 
 	res["resources"] = resources
 
+	if len(c.RackID) > 0 {
+		res["rackID"] = c.RackID
+	}
+
 	return res
 }
@@ -34,6 +34,7 @@ func TestNewChassis(t *testing.T) {
 				map[string]any{},
 				resource_model.ResourceList{},
 				cxlswitch_model.CXLSwitchList{},
+				"",
 			},
 		},
 	}
@@ -104,6 +105,7 @@ func TestChassis_ToObject(t *testing.T) {
 		Properties  map[string]any
 		Resources   resource_model.ResourceList
 		CXLSwitches cxlswitch_model.CXLSwitchList
+		RackID      string
 	}
 	tests := []struct {
 		name   string
@@ -116,18 +118,34 @@ func TestChassis_ToObject(t *testing.T) {
 				map[string]any{"id": "test"},
 				createResourceList(),
 				createCXLSwitchList(),
+				"",
 			},
 			map[string]any{
 				"id":        "test",
 				"resources": createResources(),
 			},
 		},
+		{
+			"Normal case: Successfully convert Chassis struct with the rack ID to map",
+			fields{
+				map[string]any{"id": "test"},
+				createResourceList(),
+				createCXLSwitchList(),
+				"rack01",
+			},
+			map[string]any{
+				"id":        "test",
+				"resources": createResources(),
+				"rackID":    "rack01",
+			},
+		},
 		{
 			"Error case: Return nil for a Chassis struct without an id",
 			fields{
 				map[string]any{"aaa": "bbb"},
 				createResourceList(),
 				createCXLSwitchList(),
+				"",
 			},
 			nil,
 		},
@@ -138,6 +156,7 @@ func TestChassis_ToObject(t *testing.T) {
 				Properties:  tt.fields.Properties,
 				Resources:   tt.fields.Resources,
 				CXLSwitches: tt.fields.CXLSwitches,
+				RackID:      tt.fields.RackID,
 			}
 			if got := c.ToObject(); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("Chassis.ToObject() = %v, want %v", got, tt.want)
@@ -0,0 +1,184 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package chassis_model
+
+import (
+	"fmt"
+	"math"
+	"unicode/utf8"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/model"
+)
+
+// PropertyKeys is the list of properties of a rack or a chassis that can be specified by a client.
+// The other properties of the vertex, such as the unit position in the rack, are managed by other APIs.
+var PropertyKeys = []string{"name", "description", "height"}
+
+// ValidateProperty checks the validity of the provided rack or chassis property map.
+// It ensures that:
+//   - "id", if present, is a string that satisfies model.ValidateID,
+//   - "name", if present, is a string with a length between 1 and 64 characters,
+//   - "description", if present, is a string with a length of up to 256 characters,
+//   - "height", if present, is a positive integer representing the height in rack units.
+//
+// Parameters:
+//   - property: map[string]any - A map containing the property fields to validate.
+//
+// Returns:
+//   - bool: true if the property is valid, false otherwise.
+func ValidateProperty(property map[string]any) bool {
+	if id, ok := property["id"]; ok {
+		idStr, ok := id.(string)
+		if !ok || !model.ValidateID(idStr) {
+			common.Log.Warn(fmt.Sprintf("id is not a valid string. id(%v)", id))
+			return false
+		}
+	}
+
+	if name, ok := property["name"]; ok {
+		nameStr, ok := name.(string)
+		if !ok {
+			common.Log.Warn("name is not a string")
+			return false
+		}
+		nameLen := utf8.RuneCountInString(nameStr)
+		if nameLen < 1 || nameLen > 64 {
+			common.Log.Warn(fmt.Sprintf("name length is invalid. length(%v)", nameLen))
+			return false
+		}
+	}
+
+	if description, ok := property["description"]; ok {
+		descriptionStr, ok := description.(string)
+		if !ok {
+			common.Log.Warn("description is not a string")
+			return false
+		}
+		descLen := utf8.RuneCountInString(descriptionStr)
+		if descLen > 256 {
+			common.Log.Warn(fmt.Sprintf("description length is invalid. length(%v)", descLen))
+			return false
+		}
+	}
+
+	if height, ok := property["height"]; ok {
+		heightInt, ok := toInt64(height)
+		if !ok || heightInt < 1 {
+			common.Log.Warn(fmt.Sprintf("height is not a positive integer. height(%v)", height))
+			return false
+		}
+	}
+
+	return true
+}
+
+// ValidateAttachmentProperty checks the validity of the request to attach a chassis to a rack.
+// It ensures that "rackID" is a non-empty string and "unitPosition" is a positive integer.
+//
+// Parameters:
+//   - property: map[string]any - A map containing the property fields to validate.
+//
+// Returns:
+//   - bool: true if the property is valid, false otherwise.
+func ValidateAttachmentProperty(property map[string]any) bool {
+	rackID, ok := property["rackID"].(string)
+	if !ok || len(rackID) == 0 {
+		common.Log.Warn("rackID is not a string or empty")
+		return false
+	}
+
+	unitPosition, ok := toInt64(property["unitPosition"])
+	if !ok || unitPosition < 1 {
+		common.Log.Warn(fmt.Sprintf("unitPosition is not a positive integer. unitPosition(%v)", property["unitPosition"]))
+		return false
+	}
+
+	return true
+}
+
+// ValidateMountProperty checks the validity of the request to mount devices in a chassis.
+// It ensures that "mounts" is a non-empty array, and that each element specifies exactly one of
+// "deviceID" (a resource) and "cxlSwitchID" (a CXL switch) as a non-empty string.
+// "slot", if present, must be a non-empty string of up to 64 characters.
+//
+// Parameters:
+//   - property: map[string]any - A map containing the property fields to validate.
+//
+// Returns:
+//   - bool: true if the property is valid, false otherwise.
+func ValidateMountProperty(property map[string]any) bool {
+	mounts, ok := property["mounts"].([]any)
+	if !ok || len(mounts) == 0 {
+		common.Log.Warn("mounts is not an array or empty")
+		return false
+	}
+
+	for i, mount := range mounts {
+		mountMap, ok := mount.(map[string]any)
+		if !ok {
+			common.Log.Warn(fmt.Sprintf("mounts[%d] is not a map", i))
+			return false
+		}
+
+		deviceID, hasDeviceID := mountMap["deviceID"]
+		cxlSwitchID, hasCXLSwitchID := mountMap["cxlSwitchID"]
+		if hasDeviceID == hasCXLSwitchID {
+			common.Log.Warn(fmt.Sprintf("mounts[%d] must have either deviceID or cxlSwitchID", i))
+			return false
+		}
+		id := deviceID
+		if hasCXLSwitchID {
+			id = cxlSwitchID
+		}
+		if idStr, ok := id.(string); !ok || len(idStr) == 0 {
+			common.Log.Warn(fmt.Sprintf("mounts[%d] has an ID that is not a string or empty", i))
+			return false
+		}
+
+		if slot, ok := mountMap["slot"]; ok {
+			slotStr, ok := slot.(string)
+			if !ok {
+				common.Log.Warn(fmt.Sprintf("mounts[%d].slot is not a string", i))
+				return false
+			}
+			slotLen := utf8.RuneCountInString(slotStr)
+			if slotLen < 1 || slotLen > 64 {
+				common.Log.Warn(fmt.Sprintf("mounts[%d].slot length is invalid. length(%v)", i, slotLen))
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// toInt64 converts an integral JSON or database number to int64.
+// It returns false if the value is not a number or has a fractional part.
+func toInt64(value any) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, false
+		}
+		return int64(v), true
+	default:
+		return 0, false
+	}
+}
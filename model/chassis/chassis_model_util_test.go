@@ -0,0 +1,122 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package chassis_model
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateProperty(t *testing.T) {
+	tests := []struct {
+		name     string
+		property map[string]any
+		want     bool
+	}{
+		{"Normal case: No properties are specified", map[string]any{}, true},
+		{"Normal case: All properties are specified", map[string]any{"id": "chassis-01", "name": "chassis 1", "description": "1st chassis", "height": float64(2)}, true},
+		{"Error case: id contains an invalid character", map[string]any{"id": "chassis'01"}, false},
+		{"Error case: id is not a string", map[string]any{"id": float64(1)}, false},
+		{"Error case: name is empty", map[string]any{"name": ""}, false},
+		{"Error case: name is too long", map[string]any{"name": strings.Repeat("a", 65)}, false},
+		{"Error case: description is too long", map[string]any{"description": strings.Repeat("a", 257)}, false},
+		{"Error case: height is zero", map[string]any{"height": float64(0)}, false},
+		{"Error case: height is fractional", map[string]any{"height": 1.5}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidateProperty(tt.property); got != tt.want {
+				t.Errorf("ValidateProperty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAttachmentProperty(t *testing.T) {
+	tests := []struct {
+		name     string
+		property map[string]any
+		want     bool
+	}{
+		{"Normal case: rackID and unitPosition are specified", map[string]any{"rackID": "rack-01", "unitPosition": float64(1)}, true},
+		{"Error case: rackID is not specified", map[string]any{"unitPosition": float64(1)}, false},
+		{"Error case: rackID is empty", map[string]any{"rackID": "", "unitPosition": float64(1)}, false},
+		{"Error case: unitPosition is not specified", map[string]any{"rackID": "rack-01"}, false},
+		{"Error case: unitPosition is zero", map[string]any{"rackID": "rack-01", "unitPosition": float64(0)}, false},
+		{"Error case: unitPosition is a string", map[string]any{"rackID": "rack-01", "unitPosition": "1"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidateAttachmentProperty(tt.property); got != tt.want {
+				t.Errorf("ValidateAttachmentProperty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateMountProperty(t *testing.T) {
+	tests := []struct {
+		name     string
+		property map[string]any
+		want     bool
+	}{
+		{
+			"Normal case: Resources and CXL switches are specified",
+			map[string]any{"mounts": []any{
+				map[string]any{"deviceID": "cpu0", "slot": "1"},
+				map[string]any{"cxlSwitchID": "switch0"},
+			}},
+			true,
+		},
+		{"Error case: mounts is not specified", map[string]any{}, false},
+		{"Error case: mounts is empty", map[string]any{"mounts": []any{}}, false},
+		{"Error case: An element is not a map", map[string]any{"mounts": []any{"cpu0"}}, false},
+		{"Error case: Neither deviceID nor cxlSwitchID is specified", map[string]any{"mounts": []any{map[string]any{"slot": "1"}}}, false},
+		{"Error case: Both deviceID and cxlSwitchID are specified", map[string]any{"mounts": []any{map[string]any{"deviceID": "cpu0", "cxlSwitchID": "switch0"}}}, false},
+		{"Error case: deviceID is empty", map[string]any{"mounts": []any{map[string]any{"deviceID": ""}}}, false},
+		{"Error case: slot is not a string", map[string]any{"mounts": []any{map[string]any{"deviceID": "cpu0", "slot": float64(1)}}}, false},
+		{"Error case: slot is empty", map[string]any{"mounts": []any{map[string]any{"deviceID": "cpu0", "slot": ""}}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidateMountProperty(tt.property); got != tt.want {
+				t.Errorf("ValidateMountProperty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToInt64(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  any
+		want   int64
+		wantOk bool
+	}{
+		{"Normal case: int", 3, 3, true},
+		{"Normal case: int64", int64(4), 4, true},
+		{"Normal case: integral float64", float64(5), 5, true},
+		{"Error case: fractional float64", 5.5, 0, false},
+		{"Error case: string", "5", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := toInt64(tt.value)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("toInt64() = (%v, %v), want (%v, %v)", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package chassis_model
+
+// Attachment represents the placement of a chassis in a rack.
+//
+// Fields:
+// - ChassisID: The ID of the chassis to be attached.
+// - Properties: A map containing "rackID" and "unitPosition" (the lowest rack unit occupied by the chassis).
+type Attachment struct {
+	ChassisID  string
+	Properties map[string]any
+}
+
+// NewAttachment creates and returns a new Attachment instance for the specified chassis.
+func NewAttachment(chassisID string, properties map[string]any) Attachment {
+	return Attachment{
+		ChassisID:  chassisID,
+		Properties: properties,
+	}
+}
+
+// Validate reports whether the chassis ID is specified and the properties are valid.
+func (a *Attachment) Validate() bool {
+	return len(a.ChassisID) > 0 && ValidateAttachmentProperty(a.Properties)
+}
+
+// RackID returns the ID of the rack to which the chassis is attached.
+func (a *Attachment) RackID() string {
+	rackID, _ := a.Properties["rackID"].(string)
+	return rackID
+}
+
+// UnitPosition returns the unit position of the chassis in the rack.
+// The value is stored as float64 when it comes from a request body.
+func (a *Attachment) UnitPosition() int64 {
+	unitPosition, _ := toInt64(a.Properties["unitPosition"])
+	return unitPosition
+}
+
+// ToObject converts the Attachment struct to a map representation.
+// It returns nil if the Attachment is not valid.
+// The returned map contains "chassisID", "rackID" and "unitPosition".
+func (a *Attachment) ToObject() map[string]any {
+	if !a.Validate() {
+		return nil
+	}
+
+	return map[string]any{
+		"chassisID":    a.ChassisID,
+		"rackID":       a.RackID(),
+		"unitPosition": a.UnitPosition(),
+	}
+}
+
+// Mount represents a device to be mounted in a chassis.
+// Exactly one of DeviceID (a resource) and CXLSwitchID (a CXL switch) is set.
+// Slot is the optional position of the device in the chassis.
+type Mount struct {
+	DeviceID    string
+	CXLSwitchID string
+	Slot        string
+}
+
+// ToObject converts the Mount struct to a map representation, omitting the empty fields.
+func (m *Mount) ToObject() map[string]any {
+	res := map[string]any{}
+	if len(m.DeviceID) > 0 {
+		res["deviceID"] = m.DeviceID
+	}
+	if len(m.CXLSwitchID) > 0 {
+		res["cxlSwitchID"] = m.CXLSwitchID
+	}
+	if len(m.Slot) > 0 {
+		res["slot"] = m.Slot
+	}
+	return res
+}
+
+// MountList represents the devices to be mounted in a chassis.
+type MountList struct {
+	ChassisID string
+	Mounts    []Mount
+}
+
+// NewMountList creates a MountList for the specified chassis from the request properties.
+// The properties are expected to have been validated by ValidateMountProperty; invalid elements are skipped.
+func NewMountList(chassisID string, properties map[string]any) MountList {
+	ml := MountList{
+		ChassisID: chassisID,
+		Mounts:    []Mount{},
+	}
+
+	mounts, _ := properties["mounts"].([]any)
+	for _, mount := range mounts {
+		mountMap, ok := mount.(map[string]any)
+		if !ok {
+			continue
+		}
+		m := Mount{}
+		m.DeviceID, _ = mountMap["deviceID"].(string)
+		m.CXLSwitchID, _ = mountMap["cxlSwitchID"].(string)
+		m.Slot, _ = mountMap["slot"].(string)
+		ml.Mounts = append(ml.Mounts, m)
+	}
+
+	return ml
+}
+
+// Validate reports whether the chassis ID is specified and at least one device is to be mounted.
+func (ml *MountList) Validate() bool {
+	return len(ml.ChassisID) > 0 && len(ml.Mounts) > 0
+}
+
+// ToObject converts the MountList struct to a map representation.
+// It returns nil if the MountList is not valid.
+// The returned map contains "chassisID" and "mounts".
+func (ml *MountList) ToObject() map[string]any {
+	if !ml.Validate() {
+		return nil
+	}
+
+	mounts := []map[string]any{}
+	for _, mount := range ml.Mounts {
+		mounts = append(mounts, mount.ToObject())
+	}
+
+	return map[string]any{
+		"chassisID": ml.ChassisID,
+		"mounts":    mounts,
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package chassis_model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAttachment_ToObject(t *testing.T) {
+	tests := []struct {
+		name       string
+		attachment Attachment
+		want       map[string]any
+	}{
+		{
+			"Normal case: The unit position is converted to int64",
+			NewAttachment("chassis-01", map[string]any{"rackID": "rack-01", "unitPosition": float64(5)}),
+			map[string]any{"chassisID": "chassis-01", "rackID": "rack-01", "unitPosition": int64(5)},
+		},
+		{
+			"Error case: Return nil if the chassis ID is empty",
+			NewAttachment("", map[string]any{"rackID": "rack-01", "unitPosition": float64(5)}),
+			nil,
+		},
+		{
+			"Error case: Return nil if the unit position is invalid",
+			NewAttachment("chassis-01", map[string]any{"rackID": "rack-01", "unitPosition": float64(-1)}),
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.attachment.ToObject(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Attachment.ToObject() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewMountList(t *testing.T) {
+	properties := map[string]any{"mounts": []any{
+		map[string]any{"deviceID": "cpu0", "slot": "1"},
+		map[string]any{"cxlSwitchID": "switch0"},
+		"invalid",
+	}}
+	want := MountList{
+		ChassisID: "chassis-01",
+		Mounts: []Mount{
+			{DeviceID: "cpu0", Slot: "1"},
+			{CXLSwitchID: "switch0"},
+		},
+	}
+	if got := NewMountList("chassis-01", properties); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewMountList() = %v, want %v", got, want)
+	}
+}
+
+func TestMountList_ToObject(t *testing.T) {
+	tests := []struct {
+		name      string
+		mountList MountList
+		want      map[string]any
+	}{
+		{
+			"Normal case: Empty fields are omitted",
+			MountList{
+				ChassisID: "chassis-01",
+				Mounts: []Mount{
+					{DeviceID: "cpu0", Slot: "1"},
+					{CXLSwitchID: "switch0"},
+				},
+			},
+			map[string]any{
+				"chassisID": "chassis-01",
+				"mounts": []map[string]any{
+					{"deviceID": "cpu0", "slot": "1"},
+					{"cxlSwitchID": "switch0"},
+				},
+			},
+		},
+		{
+			"Error case: Return nil if no devices are specified",
+			MountList{ChassisID: "chassis-01", Mounts: []Mount{}},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.mountList.ToObject(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MountList.ToObject() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package chassis_model
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/model"
+)
+
+// derivedKeys is the list of elements of a retrieved chassis that are not properties of the Chassis vertex.
+var derivedKeys = []string{"resources", "rackID"}
+
+// ChassisVertex represents the properties of a Chassis vertex to be registered or updated.
+//
+// Fields:
+// - Id: The identifier of the chassis. It is generated when the chassis is created without an ID.
+// - Properties: A map containing the properties that can be specified by a client (see PropertyKeys).
+// - Preserved: A map containing the properties managed by other APIs, such as the unit position in the rack.
+// - CreatedAt: The timestamp when the chassis was created.
+// - UpdatedAt: The timestamp when the chassis was last updated.
+type ChassisVertex struct {
+	Id         string
+	Properties map[string]any
+	Preserved  map[string]any
+	CreatedAt  string
+	UpdatedAt  string
+}
+
+// NewChassisVertex creates and returns a new ChassisVertex instance with default values.
+func NewChassisVertex() ChassisVertex {
+	return ChassisVertex{
+		Id:         "",
+		Properties: map[string]any{},
+		Preserved:  map[string]any{},
+		CreatedAt:  "",
+		UpdatedAt:  "",
+	}
+}
+
+// NewChassisVertexWithCreateTimeStampsNow creates a new ChassisVertex instance for registration.
+// The ID is taken from the "id" element of the properties if specified, and the timestamps are set to the current time.
+//
+// Parameters:
+//   - properties: A map containing the properties specified in the request.
+//
+// Returns:
+//
+//	A new ChassisVertex instance with the specified properties and current creation timestamps.
+func NewChassisVertexWithCreateTimeStampsNow(properties map[string]any) ChassisVertex {
+	cv := NewChassisVertex()
+	cv.Id, _ = properties["id"].(string)
+	now := model.CurrentTimeISO8601()
+	cv.CreatedAt = now
+	cv.UpdatedAt = now
+	cv.Properties = PickProperties(properties)
+	return cv
+}
+
+// NewChassisVertexForUpdate creates a new ChassisVertex instance for updating purposes.
+// It keeps the ID, the creation timestamp and the properties not specifiable by a client of the chassis
+// stored in the database, refreshes the update timestamp, and replaces the client properties.
+// If the stored chassis has no creation timestamp, the current time is used instead.
+//
+// Parameters:
+//   - chassisFromDb: A map containing the existing chassis data from the database.
+//   - properties: A map containing the properties to be updated.
+//
+// Returns:
+//   - ChassisVertex: A new ChassisVertex instance with updated properties and timestamps.
+func NewChassisVertexForUpdate(chassisFromDb map[string]any, properties map[string]any) ChassisVertex {
+	cv := NewChassisVertex()
+	cv.Id, _ = chassisFromDb["id"].(string)
+	cv.UpdatedAt = model.CurrentTimeISO8601()
+	cv.CreatedAt, _ = chassisFromDb["createdAt"].(string)
+	if len(cv.CreatedAt) == 0 {
+		cv.CreatedAt = cv.UpdatedAt
+	}
+	cv.Preserved = PreservedProperties(chassisFromDb, derivedKeys)
+	cv.Properties = PickProperties(properties)
+	return cv
+}
+
+// Validate checks the ID, properties and timestamps of the ChassisVertex object.
+// An empty ID is allowed because it is generated on registration.
+func (cv *ChassisVertex) Validate() bool {
+	if len(cv.Id) > 0 && !model.ValidateID(cv.Id) {
+		common.Log.Warn(fmt.Sprintf("id is invalid. id(%v)", cv.Id))
+		return false
+	}
+
+	if !ValidateProperty(cv.Properties) {
+		return false
+	}
+
+	if !model.ValidateISO8601(cv.CreatedAt) {
+		common.Log.Warn(fmt.Sprintf("createdAt is not ISO8601. createdAt(%v)", cv.CreatedAt))
+		return false
+	}
+
+	if !model.ValidateISO8601(cv.UpdatedAt) {
+		common.Log.Warn(fmt.Sprintf("updatedAt is not ISO8601. updatedAt(%v)", cv.UpdatedAt))
+		return false
+	}
+
+	return true
+}
+
+// ToObject converts the ChassisVertex struct to the property map of the Chassis vertex.
+// It returns nil if the ChassisVertex is not valid.
+// The returned map contains the preserved properties, the client properties, "id", "createdAt" and "updatedAt".
+func (cv *ChassisVertex) ToObject() map[string]any {
+	if !cv.Validate() {
+		return nil
+	}
+
+	return ComposeVertexProperties(cv.Id, cv.Preserved, cv.Properties, cv.CreatedAt, cv.UpdatedAt)
+}
+
+// PickProperties returns a copy of the rack or chassis properties that can be specified by a client.
+func PickProperties(properties map[string]any) map[string]any {
+	res := map[string]any{}
+	for _, key := range PropertyKeys {
+		if value, ok := properties[key]; ok {
+			res[key] = value
+		}
+	}
+	return res
+}
+
+// PreservedProperties returns a copy of the properties of a retrieved rack or chassis, excluding
+// the elements that are not properties of the vertex (derived), the properties that can be specified
+// by a client, and the identifier and timestamps.
+// The result is kept as it is when the rack or chassis is updated.
+//
+// Parameters:
+//   - fromDb: A map containing the existing rack or chassis data from the database.
+//   - derived: The keys of the elements that are not properties of the vertex.
+//
+// Returns:
+//   - map[string]any: The properties to be preserved.
+func PreservedProperties(fromDb map[string]any, derived []string) map[string]any {
+	res := map[string]any{}
+	for key, value := range fromDb {
+		if slices.Contains(derived, key) || slices.Contains(PropertyKeys, key) {
+			continue
+		}
+		if key == "id" || key == "createdAt" || key == "updatedAt" {
+			continue
+		}
+		res[key] = value
+	}
+	return res
+}
+
+// ComposeVertexProperties merges the properties of a rack or chassis vertex into a single map.
+// The client properties take precedence over the preserved properties.
+func ComposeVertexProperties(id string, preserved map[string]any, properties map[string]any, createdAt string, updatedAt string) map[string]any {
+	res := map[string]any{}
+	for key, value := range preserved {
+		res[key] = value
+	}
+	for key, value := range properties {
+		res[key] = value
+	}
+	res["id"] = id
+	res["createdAt"] = createdAt
+	res["updatedAt"] = updatedAt
+	return res
+}
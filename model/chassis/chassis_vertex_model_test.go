@@ -0,0 +1,163 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package chassis_model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewChassisVertex(t *testing.T) {
+	want := ChassisVertex{
+		Id:         "",
+		Properties: map[string]any{},
+		Preserved:  map[string]any{},
+		CreatedAt:  "",
+		UpdatedAt:  "",
+	}
+	if got := NewChassisVertex(); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewChassisVertex() = %v, want %v", got, want)
+	}
+}
+
+func TestNewChassisVertexWithCreateTimeStampsNow(t *testing.T) {
+	tests := []struct {
+		name           string
+		properties     map[string]any
+		wantId         string
+		wantProperties map[string]any
+	}{
+		{
+			"Normal case: The ID is taken from the properties and unknown properties are dropped",
+			map[string]any{"id": "chassis-01", "name": "chassis 1", "unitPosition": float64(3)},
+			"chassis-01",
+			map[string]any{"name": "chassis 1"},
+		},
+		{
+			"Normal case: The ID is empty if it is not specified",
+			map[string]any{"height": float64(2)},
+			"",
+			map[string]any{"height": float64(2)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewChassisVertexWithCreateTimeStampsNow(tt.properties)
+			if got.Id != tt.wantId || !reflect.DeepEqual(got.Properties, tt.wantProperties) {
+				t.Errorf("NewChassisVertexWithCreateTimeStampsNow() = %v, want id %v and properties %v", got, tt.wantId, tt.wantProperties)
+			}
+			if got.CreatedAt == "" || got.CreatedAt != got.UpdatedAt {
+				t.Errorf("NewChassisVertexWithCreateTimeStampsNow() timestamps = (%v, %v)", got.CreatedAt, got.UpdatedAt)
+			}
+		})
+	}
+}
+
+func TestNewChassisVertexForUpdate(t *testing.T) {
+	chassisFromDb := map[string]any{
+		"id":           "chassis-01",
+		"name":         "old name",
+		"unitPosition": int64(3),
+		"createdAt":    "2025-01-01T00:00:00Z",
+		"updatedAt":    "2025-01-01T00:00:00Z",
+		"rackID":       "rack-01",
+		"resources":    []any{},
+	}
+	got := NewChassisVertexForUpdate(chassisFromDb, map[string]any{"description": "new"})
+
+	if got.Id != "chassis-01" || got.CreatedAt != "2025-01-01T00:00:00Z" {
+		t.Errorf("NewChassisVertexForUpdate() = %v", got)
+	}
+	if want := map[string]any{"unitPosition": int64(3)}; !reflect.DeepEqual(got.Preserved, want) {
+		t.Errorf("NewChassisVertexForUpdate() Preserved = %v, want %v", got.Preserved, want)
+	}
+	if want := map[string]any{"description": "new"}; !reflect.DeepEqual(got.Properties, want) {
+		t.Errorf("NewChassisVertexForUpdate() Properties = %v, want %v", got.Properties, want)
+	}
+
+	got = NewChassisVertexForUpdate(map[string]any{"id": "chassis-02"}, map[string]any{})
+	if got.CreatedAt != got.UpdatedAt {
+		t.Errorf("NewChassisVertexForUpdate() CreatedAt = %v, want %v", got.CreatedAt, got.UpdatedAt)
+	}
+}
+
+func TestChassisVertex_ToObject(t *testing.T) {
+	tests := []struct {
+		name string
+		cv   ChassisVertex
+		want map[string]any
+	}{
+		{
+			"Normal case: The preserved and client properties are merged",
+			ChassisVertex{
+				Id:         "chassis-01",
+				Properties: map[string]any{"name": "new name"},
+				Preserved:  map[string]any{"name": "old name", "unitPosition": int64(3)},
+				CreatedAt:  "2025-01-01T00:00:00Z",
+				UpdatedAt:  "2025-01-02T00:00:00Z",
+			},
+			map[string]any{
+				"id":           "chassis-01",
+				"name":         "new name",
+				"unitPosition": int64(3),
+				"createdAt":    "2025-01-01T00:00:00Z",
+				"updatedAt":    "2025-01-02T00:00:00Z",
+			},
+		},
+		{
+			"Error case: Return nil if the ID is invalid",
+			ChassisVertex{
+				Id:         "chassis 01",
+				Properties: map[string]any{},
+				Preserved:  map[string]any{},
+				CreatedAt:  "2025-01-01T00:00:00Z",
+				UpdatedAt:  "2025-01-01T00:00:00Z",
+			},
+			nil,
+		},
+		{
+			"Error case: Return nil if the timestamp is not ISO8601",
+			ChassisVertex{
+				Id:         "chassis-01",
+				Properties: map[string]any{},
+				Preserved:  map[string]any{},
+				CreatedAt:  "2025/01/01",
+				UpdatedAt:  "2025-01-01T00:00:00Z",
+			},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cv.ToObject(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ChassisVertex.ToObject() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPreservedProperties(t *testing.T) {
+	fromDb := map[string]any{
+		"id":        "rack-01",
+		"name":      "rack 1",
+		"location":  "room A",
+		"createdAt": "2025-01-01T00:00:00Z",
+		"chassis":   []any{},
+	}
+	want := map[string]any{"location": "room A"}
+	if got := PreservedProperties(fromDb, []string{"chassis"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("PreservedProperties() = %v, want %v", got, want)
+	}
+}
@@ -14,7 +14,10 @@
         
 package model
 
-import "time"
+import (
+	"regexp"
+	"time"
+)
 
 // CurrentTimeISO8601 returns the current time in UTC formatted according to ISO 8601 standard.
 // The format used is: YYYY-MM-DDThh:mm:ssZ.
@@ -35,3 +38,13 @@ func ValidateISO8601(s string) bool {
 	_, err := time.Parse(layout, s)
 	return err == nil
 }
+
+// idPattern is the format of an ID that a client is allowed to specify for a vertex.
+// It starts with an alphanumeric character and consists of up to 128 alphanumeric characters, '.', '_', ':' and '-'.
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._:-]{0,127}$`)
+
+// ValidateID checks if the provided string can be used as an ID specified by a client.
+// The characters are restricted so that the ID can be embedded in a Cypher query as it is.
+func ValidateID(s string) bool {
+	return idPattern.MatchString(s)
+}
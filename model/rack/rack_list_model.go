@@ -0,0 +1,53 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package rack_model
+
+import (
+	"fmt"
+
+	"github.com/project-cdim/configuration-manager/common"
+)
+
+// RackList is a list of Rack.
+type RackList struct {
+	Racks []Rack
+}
+
+// NewRackList creates and returns a new instance of RackList with an empty slice of Rack.
+func NewRackList() RackList {
+	return RackList{
+		Racks: []Rack{},
+	}
+}
+
+// ToObject creates and returns a map array in which each rack is represented with its properties,
+// the number of chassis and the summary of the devices.
+// Invalid racks are not included in the result.
+//
+// Returns:
+//
+//	[]map[string]any: A slice of map objects, each representing a validated Rack.
+func (rl *RackList) ToObject() []map[string]any {
+	res := []map[string]any{}
+	for _, rack := range rl.Racks {
+		if rack.Validate() {
+			res = append(res, rack.ToObjectWithSummary())
+		} else {
+			common.Log.Warn(fmt.Sprintf("Not added to list. rack(%v)", rack))
+		}
+	}
+
+	return res
+}
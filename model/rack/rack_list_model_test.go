@@ -0,0 +1,69 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package rack_model
+
+import (
+	"reflect"
+	"testing"
+
+	chassis_model "github.com/project-cdim/configuration-manager/model/chassis"
+)
+
+func TestNewRackList(t *testing.T) {
+	want := RackList{[]Rack{}}
+	if got := NewRackList(); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewRackList() = %v, want %v", got, want)
+	}
+}
+
+func TestRackList_ToObject(t *testing.T) {
+	tests := []struct {
+		name  string
+		racks []Rack
+		want  []map[string]any
+	}{
+		{
+			"Normal case: Convert the racks excluding invalid racks",
+			[]Rack{
+				{Properties: map[string]any{"id": "rack01"}, Chassis: chassis_model.NewChassisList()},
+				{Properties: map[string]any{"id": ""}, Chassis: chassis_model.NewChassisList()},
+			},
+			[]map[string]any{
+				{
+					"id":           "rack01",
+					"chassisCount": 0,
+					"summary": map[string]any{
+						"resourceCount":            0,
+						"notDetectedResourceCount": 0,
+						"cxlSwitchCount":           0,
+					},
+				},
+			},
+		},
+		{
+			"Normal case: No racks",
+			[]Rack{},
+			[]map[string]any{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rl := &RackList{Racks: tt.racks}
+			if got := rl.ToObject(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RackList.ToObject() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -78,3 +78,69 @@ func (r *Rack) ToObject() map[string]any {
 
 	return res
 }
+
+// Summary creates and returns the counts of the devices mounted in the chassis attached to the rack.
+//
+// The returned map contains the following keys:
+//   - "resourceCount": The number of resources mounted in the rack.
+//   - "notDetectedResourceCount": The number of resources that were not detected in the last hardware sync.
+//   - "cxlSwitchCount": The number of CXL switches mounted in the rack.
+//
+// Returns:
+//
+//	map[string]any: A map of the counts.
+func (r *Rack) Summary() map[string]any {
+	resourceCount := 0
+	notDetectedResourceCount := 0
+	cxlSwitchCount := 0
+	for _, chassis := range r.Chassis.Chassis {
+		for _, resource := range chassis.Resources.Resources {
+			if !resource.Validate() {
+				continue
+			}
+			resourceCount++
+			if !resource.Detected {
+				notDetectedResourceCount++
+			}
+		}
+		for _, cxlswitch := range chassis.CXLSwitches.CXLSwitches {
+			if cxlswitch.Validate() {
+				cxlSwitchCount++
+			}
+		}
+	}
+
+	return map[string]any{
+		"resourceCount":            resourceCount,
+		"notDetectedResourceCount": notDetectedResourceCount,
+		"cxlSwitchCount":           cxlSwitchCount,
+	}
+}
+
+// ToObjectWithSummary creates and returns a map with the properties of the rack, the number of chassis attached
+// to the rack and the summary of the devices, instead of the chassis themselves.
+//
+// Returns:
+//
+//	map[string]any: A map representation of the Rack with "chassisCount" and "summary", or nil if the Rack is invalid.
+func (r *Rack) ToObjectWithSummary() map[string]any {
+	if !r.Validate() {
+		return nil
+	}
+
+	res := map[string]any{}
+	for key, value := range r.Properties {
+		res[key] = value
+	}
+
+	chassisCount := 0
+	for _, chassis := range r.Chassis.Chassis {
+		if chassis.Validate() {
+			chassisCount++
+		}
+	}
+	res["chassisCount"] = chassisCount
+	res["summary"] = r.Summary()
+
+	return res
+}
@@ -19,6 +19,8 @@ import (
 	"testing"
 
 	chassis_model "github.com/project-cdim/configuration-manager/model/chassis"
+	cxlswitch_model "github.com/project-cdim/configuration-manager/model/cxlswitch"
+	resource_model "github.com/project-cdim/configuration-manager/model/resource"
 )
 
 func TestNewRack(t *testing.T) {
@@ -130,3 +132,96 @@ func TestRack_ToObject(t *testing.T) {
 		})
 	}
 }
+
+func createChassisListForSummary() chassis_model.ChassisList {
+	chassis := chassis_model.NewChassis()
+	chassis.Properties = map[string]any{"id": "chassis01"}
+	chassis.Resources = resource_model.ResourceList{
+		Resources: []resource_model.Resource{
+			{Device: map[string]any{"deviceID": "cpu01"}, Detected: true},
+			{Device: map[string]any{"deviceID": "mem01"}, Detected: false},
+		},
+	}
+	chassis.CXLSwitches = cxlswitch_model.CXLSwitchList{
+		CXLSwitches: []cxlswitch_model.CXLSwitch{
+			{Properties: map[string]any{"id": "sw01"}},
+		},
+	}
+	emptyChassis := chassis_model.NewChassis()
+	emptyChassis.Properties = map[string]any{"id": "chassis02"}
+	return chassis_model.ChassisList{Chassis: []chassis_model.Chassis{chassis, emptyChassis}}
+}
+
+func TestRack_Summary(t *testing.T) {
+	tests := []struct {
+		name    string
+		chassis chassis_model.ChassisList
+		want    map[string]any
+	}{
+		{
+			"Normal case: Count the resources and CXL switches mounted in the rack",
+			createChassisListForSummary(),
+			map[string]any{
+				"resourceCount":            2,
+				"notDetectedResourceCount": 1,
+				"cxlSwitchCount":           1,
+			},
+		},
+		{
+			"Normal case: A rack without chassis",
+			chassis_model.NewChassisList(),
+			map[string]any{
+				"resourceCount":            0,
+				"notDetectedResourceCount": 0,
+				"cxlSwitchCount":           0,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Rack{Properties: map[string]any{"id": "rack01"}, Chassis: tt.chassis}
+			if got := r.Summary(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Rack.Summary() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRack_ToObjectWithSummary(t *testing.T) {
+	tests := []struct {
+		name       string
+		properties map[string]any
+		want       map[string]any
+	}{
+		{
+			"Normal case: Convert Rack struct to map with the number of chassis and the summary",
+			map[string]any{"id": "rack01", "name": "rack 01"},
+			map[string]any{
+				"id":           "rack01",
+				"name":         "rack 01",
+				"chassisCount": 2,
+				"summary": map[string]any{
+					"resourceCount":            2,
+					"notDetectedResourceCount": 1,
+					"cxlSwitchCount":           1,
+				},
+			},
+		},
+		{
+			"Error case: Return nil for a Rack struct without an id",
+			map[string]any{"name": "rack 01"},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Rack{Properties: tt.properties, Chassis: createChassisListForSummary()}
+			if got := r.ToObjectWithSummary(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Rack.ToObjectWithSummary() = %v, want %v", got, tt.want)
+			}
+			if _, ok := tt.properties["chassisCount"]; ok {
+				t.Errorf("Rack.ToObjectWithSummary() modified the properties of the rack")
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package rack_model
+
+import (
+	chassis_model "github.com/project-cdim/configuration-manager/model/chassis"
+)
+
+// ValidateProperty checks the validity of the provided rack property map.
+// A rack has the same properties that can be specified by a client as a chassis,
+// so the validation is delegated to chassis_model.ValidateProperty.
+//
+// Parameters:
+//   - property: map[string]any - A map containing the property fields to validate.
+//
+// Returns:
+//   - bool: true if the property is valid, false otherwise.
+func ValidateProperty(property map[string]any) bool {
+	return chassis_model.ValidateProperty(property)
+}
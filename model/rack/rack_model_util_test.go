@@ -0,0 +1,37 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package rack_model
+
+import (
+	"testing"
+)
+
+func TestValidateProperty(t *testing.T) {
+	tests := []struct {
+		name     string
+		property map[string]any
+		want     bool
+	}{
+		{"Normal case: The properties are valid", map[string]any{"id": "rack-01", "name": "rack 1", "height": float64(42)}, true},
+		{"Error case: height is negative", map[string]any{"height": float64(-42)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidateProperty(tt.property); got != tt.want {
+				t.Errorf("ValidateProperty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
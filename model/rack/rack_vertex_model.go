@@ -0,0 +1,132 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package rack_model
+
+import (
+	"fmt"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/model"
+	chassis_model "github.com/project-cdim/configuration-manager/model/chassis"
+)
+
+// derivedKeys is the list of elements of a retrieved rack that are not properties of the Rack vertex.
+var derivedKeys = []string{"chassis", "chassisCount", "summary"}
+
+// RackVertex represents the properties of a Rack vertex to be registered or updated.
+//
+// Fields:
+// - Id: The identifier of the rack. It is generated when the rack is created without an ID.
+// - Properties: A map containing the properties that can be specified by a client (see chassis_model.PropertyKeys).
+// - Preserved: A map containing the properties that were stored in the vertex by other means.
+// - CreatedAt: The timestamp when the rack was created.
+// - UpdatedAt: The timestamp when the rack was last updated.
+type RackVertex struct {
+	Id         string
+	Properties map[string]any
+	Preserved  map[string]any
+	CreatedAt  string
+	UpdatedAt  string
+}
+
+// NewRackVertex creates and returns a new RackVertex instance with default values.
+func NewRackVertex() RackVertex {
+	return RackVertex{
+		Id:         "",
+		Properties: map[string]any{},
+		Preserved:  map[string]any{},
+		CreatedAt:  "",
+		UpdatedAt:  "",
+	}
+}
+
+// NewRackVertexWithCreateTimeStampsNow creates a new RackVertex instance for registration.
+// The ID is taken from the "id" element of the properties if specified, and the timestamps are set to the current time.
+//
+// Parameters:
+//   - properties: A map containing the properties specified in the request.
+//
+// Returns:
+//
+//	A new RackVertex instance with the specified properties and current creation timestamps.
+func NewRackVertexWithCreateTimeStampsNow(properties map[string]any) RackVertex {
+	rv := NewRackVertex()
+	rv.Id, _ = properties["id"].(string)
+	now := model.CurrentTimeISO8601()
+	rv.CreatedAt = now
+	rv.UpdatedAt = now
+	rv.Properties = chassis_model.PickProperties(properties)
+	return rv
+}
+
+// NewRackVertexForUpdate creates a new RackVertex instance for updating purposes.
+// It keeps the ID, the creation timestamp and the properties not specifiable by a client of the rack
+// stored in the database, refreshes the update timestamp, and replaces the client properties.
+// If the stored rack has no creation timestamp, the current time is used instead.
+//
+// Parameters:
+//   - rackFromDb: A map containing the existing rack data from the database.
+//   - properties: A map containing the properties to be updated.
+//
+// Returns:
+//   - RackVertex: A new RackVertex instance with updated properties and timestamps.
+func NewRackVertexForUpdate(rackFromDb map[string]any, properties map[string]any) RackVertex {
+	rv := NewRackVertex()
+	rv.Id, _ = rackFromDb["id"].(string)
+	rv.UpdatedAt = model.CurrentTimeISO8601()
+	rv.CreatedAt, _ = rackFromDb["createdAt"].(string)
+	if len(rv.CreatedAt) == 0 {
+		rv.CreatedAt = rv.UpdatedAt
+	}
+	rv.Preserved = chassis_model.PreservedProperties(rackFromDb, derivedKeys)
+	rv.Properties = chassis_model.PickProperties(properties)
+	return rv
+}
+
+// Validate checks the ID, properties and timestamps of the RackVertex object.
+// An empty ID is allowed because it is generated on registration.
+func (rv *RackVertex) Validate() bool {
+	if len(rv.Id) > 0 && !model.ValidateID(rv.Id) {
+		common.Log.Warn(fmt.Sprintf("id is invalid. id(%v)", rv.Id))
+		return false
+	}
+
+	if !ValidateProperty(rv.Properties) {
+		return false
+	}
+
+	if !model.ValidateISO8601(rv.CreatedAt) {
+		common.Log.Warn(fmt.Sprintf("createdAt is not ISO8601. createdAt(%v)", rv.CreatedAt))
+		return false
+	}
+
+	if !model.ValidateISO8601(rv.UpdatedAt) {
+		common.Log.Warn(fmt.Sprintf("updatedAt is not ISO8601. updatedAt(%v)", rv.UpdatedAt))
+		return false
+	}
+
+	return true
+}
+
+// ToObject converts the RackVertex struct to the property map of the Rack vertex.
+// It returns nil if the RackVertex is not valid.
+// The returned map contains the preserved properties, the client properties, "id", "createdAt" and "updatedAt".
+func (rv *RackVertex) ToObject() map[string]any {
+	if !rv.Validate() {
+		return nil
+	}
+
+	return chassis_model.ComposeVertexProperties(rv.Id, rv.Preserved, rv.Properties, rv.CreatedAt, rv.UpdatedAt)
+}
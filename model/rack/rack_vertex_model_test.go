@@ -0,0 +1,111 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package rack_model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewRackVertex(t *testing.T) {
+	want := RackVertex{
+		Id:         "",
+		Properties: map[string]any{},
+		Preserved:  map[string]any{},
+		CreatedAt:  "",
+		UpdatedAt:  "",
+	}
+	if got := NewRackVertex(); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewRackVertex() = %v, want %v", got, want)
+	}
+}
+
+func TestNewRackVertexWithCreateTimeStampsNow(t *testing.T) {
+	got := NewRackVertexWithCreateTimeStampsNow(map[string]any{"id": "rack-01", "height": float64(42), "chassis": []any{}})
+	if got.Id != "rack-01" {
+		t.Errorf("NewRackVertexWithCreateTimeStampsNow() Id = %v, want %v", got.Id, "rack-01")
+	}
+	if want := map[string]any{"height": float64(42)}; !reflect.DeepEqual(got.Properties, want) {
+		t.Errorf("NewRackVertexWithCreateTimeStampsNow() Properties = %v, want %v", got.Properties, want)
+	}
+	if got.CreatedAt == "" || got.CreatedAt != got.UpdatedAt {
+		t.Errorf("NewRackVertexWithCreateTimeStampsNow() timestamps = (%v, %v)", got.CreatedAt, got.UpdatedAt)
+	}
+}
+
+func TestNewRackVertexForUpdate(t *testing.T) {
+	rackFromDb := map[string]any{
+		"id":        "rack-01",
+		"name":      "old name",
+		"location":  "room A",
+		"createdAt": "2025-01-01T00:00:00Z",
+		"chassis":   []any{},
+	}
+	got := NewRackVertexForUpdate(rackFromDb, map[string]any{"name": "new name"})
+
+	if got.Id != "rack-01" || got.CreatedAt != "2025-01-01T00:00:00Z" {
+		t.Errorf("NewRackVertexForUpdate() = %v", got)
+	}
+	if want := map[string]any{"location": "room A"}; !reflect.DeepEqual(got.Preserved, want) {
+		t.Errorf("NewRackVertexForUpdate() Preserved = %v, want %v", got.Preserved, want)
+	}
+	if want := map[string]any{"name": "new name"}; !reflect.DeepEqual(got.Properties, want) {
+		t.Errorf("NewRackVertexForUpdate() Properties = %v, want %v", got.Properties, want)
+	}
+}
+
+func TestRackVertex_ToObject(t *testing.T) {
+	tests := []struct {
+		name string
+		rv   RackVertex
+		want map[string]any
+	}{
+		{
+			"Normal case: The preserved and client properties are merged",
+			RackVertex{
+				Id:         "rack-01",
+				Properties: map[string]any{"height": float64(42)},
+				Preserved:  map[string]any{"location": "room A"},
+				CreatedAt:  "2025-01-01T00:00:00Z",
+				UpdatedAt:  "2025-01-02T00:00:00Z",
+			},
+			map[string]any{
+				"id":        "rack-01",
+				"height":    float64(42),
+				"location":  "room A",
+				"createdAt": "2025-01-01T00:00:00Z",
+				"updatedAt": "2025-01-02T00:00:00Z",
+			},
+		},
+		{
+			"Error case: Return nil if the properties are invalid",
+			RackVertex{
+				Id:         "rack-01",
+				Properties: map[string]any{"height": float64(0)},
+				Preserved:  map[string]any{},
+				CreatedAt:  "2025-01-01T00:00:00Z",
+				UpdatedAt:  "2025-01-01T00:00:00Z",
+			},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rv.ToObject(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RackVertex.ToObject() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
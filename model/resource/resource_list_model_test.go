@@ -59,6 +59,11 @@ func TestResourceList_ToObject(t *testing.T) {
 						[]string{"00001"},
 						[]string{"node001"},
 						false,
+						"",
+						"",
+						"",
+						"",
+						0,
 					},
 					{
 						map[string]any{"deviceID": "002"},
@@ -66,6 +71,11 @@ func TestResourceList_ToObject(t *testing.T) {
 						[]string{"00002"},
 						[]string{"node002"},
 						true,
+						"",
+						"",
+						"",
+						"",
+						0,
 					},
 				},
 			},
@@ -96,6 +106,11 @@ func TestResourceList_ToObject(t *testing.T) {
 						[]string{"00001"},
 						[]string{"node001"},
 						false,
+						"",
+						"",
+						"",
+						"",
+						0,
 					},
 					{
 						map[string]any{},
@@ -103,6 +118,11 @@ func TestResourceList_ToObject(t *testing.T) {
 						[]string{},
 						[]string{},
 						false,
+						"",
+						"",
+						"",
+						"",
+						0,
 					},
 				},
 			},
@@ -148,6 +168,11 @@ func TestResourceList_ToObject4Node(t *testing.T) {
 						[]string{"00001"},
 						[]string{},
 						false,
+						"",
+						"",
+						"",
+						"",
+						0,
 					},
 					{
 						map[string]any{"deviceID": "002"},
@@ -155,6 +180,11 @@ func TestResourceList_ToObject4Node(t *testing.T) {
 						[]string{"00002"},
 						[]string{},
 						true,
+						"",
+						"",
+						"",
+						"",
+						0,
 					},
 				},
 			},
@@ -183,6 +213,11 @@ func TestResourceList_ToObject4Node(t *testing.T) {
 						[]string{"00001"},
 						[]string{},
 						false,
+						"",
+						"",
+						"",
+						"",
+						0,
 					},
 					{
 						map[string]any{},
@@ -190,6 +225,11 @@ func TestResourceList_ToObject4Node(t *testing.T) {
 						[]string{},
 						[]string{},
 						false,
+						"",
+						"",
+						"",
+						"",
+						0,
 					},
 				},
 			},
@@ -234,6 +274,11 @@ func TestResourceList_ToObject4Unused(t *testing.T) {
 						[]string{"00001"},
 						[]string{},
 						false,
+						"",
+						"",
+						"",
+						"",
+						0,
 					},
 					{
 						map[string]any{"deviceID": "002"},
@@ -241,6 +286,11 @@ func TestResourceList_ToObject4Unused(t *testing.T) {
 						[]string{"00002"},
 						[]string{},
 						true,
+						"",
+						"",
+						"",
+						"",
+						0,
 					},
 				},
 			},
@@ -267,6 +317,11 @@ func TestResourceList_ToObject4Unused(t *testing.T) {
 						[]string{"00001"},
 						[]string{},
 						false,
+						"",
+						"",
+						"",
+						"",
+						0,
 					},
 					{
 						map[string]any{},
@@ -274,6 +329,11 @@ func TestResourceList_ToObject4Unused(t *testing.T) {
 						[]string{},
 						[]string{},
 						false,
+						"",
+						"",
+						"",
+						"",
+						0,
 					},
 				},
 			},
@@ -18,13 +18,40 @@ import (
 	annotation_model "github.com/project-cdim/configuration-manager/model/annotation"
 )
 
+// Names of the properties of the resource Vertex that hold the times when the resource was first and last seen by the hardware sync.
+// They are maintained by the hardware sync apart from the device information reported by it.
+const (
+	FirstSeenAtKey = "firstSeenAt"
+	LastSeenAtKey  = "lastSeenAt"
+)
+
+// Names of the properties of the resource Vertex that hold the hardware syncs that missed the resource while it is kept detected.
+// They are maintained by the hardware sync, and are removed when the resource is reported again.
+const (
+	PendingMissesKey    = "pendingMisses"
+	PendingMissSinceKey = "pendingMissSince"
+)
+
+// Name of the property of the resource Vertex that holds the content hash of the device information reported by the hardware sync.
+// It is maintained by the hardware sync to skip the writes of the devices that have not changed.
+const ContentHashKey = "contentHash"
+
 // Resource is a resource structure.
+// UnitID is the ID of the unit that contains the resource. It is set only when the resource is retrieved via the resource API.
+// FirstSeenAt, LastSeenAt and NotDetectedSince are times in ISO 8601, and are empty if unknown.
+// NotDetectedSince is set only when the resource in the NotDetected state is retrieved via the resource API.
+// PendingMisses is the number of consecutive hardware syncs that missed the resource without putting it in the NotDetected state.
 type Resource struct {
 	Device           map[string]any
 	Annotation       annotation_model.Annotation
 	ResourceGroupIDs []string
 	NodeIDs          []string
 	Detected         bool
+	UnitID           string
+	FirstSeenAt      string
+	LastSeenAt       string
+	NotDetectedSince string
+	PendingMisses    int64
 }
 
 // NewResource is the constructor for the Resource structure.
@@ -44,6 +71,11 @@ func NewResource() Resource {
 		ResourceGroupIDs: []string{},
 		NodeIDs:          []string{},
 		Detected:         false,
+		UnitID:           "",
+		FirstSeenAt:      "",
+		LastSeenAt:       "",
+		NotDetectedSince: "",
+		PendingMisses:    0,
 	}
 }
 
@@ -69,6 +101,9 @@ func (r *Resource) Validate() bool {
 // This method first validates the Resource instance. If the instance is not valid, it returns nil.
 // Upon successful validation, it constructs a map (`res`) initialized with the Resource's device information,
 // annotations (formatted specifically for Resource), resource group IDs, node IDs, and detection status.
+// If the resource holds the ID of the unit that contains it, it is added under the "unitID" key.
+// The times when the resource was first seen, last seen and not detected since are added under the "firstSeenAt", "lastSeenAt"
+// and "notDetectedSince" keys if they are known, and the number of pending misses is added under the "pendingMisses" key if there are any.
 // The resulting map is returned and includes all necessary information about the Resource.
 //
 // Returns:
@@ -80,13 +115,31 @@ func (r *Resource) ToObject() map[string]any {
 		return nil
 	}
 
-	return map[string]any{
+	res := map[string]any{
 		"device":           r.Device,
 		"annotation":       r.Annotation.ToObject(),
 		"resourceGroupIDs": r.ResourceGroupIDs,
 		"nodeIDs":          r.NodeIDs,
 		"detected":         r.Detected,
 	}
+
+	if len(r.UnitID) > 0 {
+		res["unitID"] = r.UnitID
+	}
+	for key, value := range map[string]string{
+		FirstSeenAtKey:     r.FirstSeenAt,
+		LastSeenAtKey:      r.LastSeenAt,
+		"notDetectedSince": r.NotDetectedSince,
+	} {
+		if len(value) > 0 {
+			res[key] = value
+		}
+	}
+	if r.PendingMisses > 0 {
+		res[PendingMissesKey] = r.PendingMisses
+	}
+
+	return res
 }
 
 // ToObject4Node creates for nodeObject and returns a map with elements
@@ -117,7 +170,8 @@ func (r *Resource) ToObject4Node() map[string]any {
 
 // ToObject4Unused converts the Resource instance into a map representation suitable for unusing operations.
 // It first validates the Resource instance, and if validation fails, it returns nil.
-// If validation succeeds, it returns a map containing the device, annotation, and resourceGroupIDs fields.
+// If validation succeeds, it returns a map containing the device, annotation, and resourceGroupIDs fields,
+// and the unitID field if the resource holds the ID of the unit that contains it.
 //
 // Returns:
 //
@@ -127,9 +181,15 @@ func (r *Resource) ToObject4Unused() map[string]any {
 		return nil
 	}
 
-	return map[string]any{
+	res := map[string]any{
 		"device":           r.Device,
 		"annotation":       r.Annotation.ToObject(),
 		"resourceGroupIDs": r.ResourceGroupIDs,
 	}
+
+	if len(r.UnitID) > 0 {
+		res["unitID"] = r.UnitID
+	}
+
+	return res
 }
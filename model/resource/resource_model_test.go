@@ -28,7 +28,7 @@ func TestNewResource(t *testing.T) {
 	}{
 		{
 			"Normal Case: Generates an instance of the Resource struct",
-			Resource{map[string]any{}, annotation_model.Annotation{Properties: map[string]any{}}, []string{}, []string{}, false},
+			Resource{map[string]any{}, annotation_model.Annotation{Properties: map[string]any{}}, []string{}, []string{}, false, "", "", "", "", 0},
 		},
 	}
 	for _, tt := range tests {
@@ -99,6 +99,11 @@ func TestResource_ToObject(t *testing.T) {
 		ResourceGroupIDs []string
 		NodeIDs          []string
 		Detected         bool
+		UnitID           string
+		FirstSeenAt      string
+		LastSeenAt       string
+		NotDetectedSince string
+		PendingMisses    int64
 	}
 	tests := []struct {
 		name   string
@@ -113,6 +118,11 @@ func TestResource_ToObject(t *testing.T) {
 				[]string{"00001"},
 				[]string{"node001"},
 				true,
+				"",
+				"",
+				"",
+				"",
+				0,
 			},
 			map[string]any{
 				"device":           map[string]any{"deviceID": "001"},
@@ -122,6 +132,77 @@ func TestResource_ToObject(t *testing.T) {
 				"detected":         true,
 			},
 		},
+		{
+			"Normal Case: Adds the unitID if the resource is contained in a unit",
+			fields{
+				map[string]any{"deviceID": "001"},
+				annotation_model.Annotation{Properties: map[string]any{"available": true}},
+				[]string{"00001"},
+				[]string{"node001"},
+				true,
+				"001",
+				"",
+				"",
+				"",
+				0,
+			},
+			map[string]any{
+				"device":           map[string]any{"deviceID": "001"},
+				"annotation":       map[string]any{"available": true},
+				"resourceGroupIDs": []string{"00001"},
+				"nodeIDs":          []string{"node001"},
+				"detected":         true,
+				"unitID":           "001",
+			},
+		},
+		{
+			"Normal Case: Adds the times when the resource was first seen, last seen and not detected since if they are known",
+			fields{
+				map[string]any{"deviceID": "001"},
+				annotation_model.Annotation{Properties: map[string]any{"available": true}},
+				[]string{"00001"},
+				[]string{},
+				false,
+				"",
+				"2025-06-01T00:00:00Z",
+				"2025-06-10T00:00:00Z",
+				"2025-06-11T00:00:00Z",
+				0,
+			},
+			map[string]any{
+				"device":           map[string]any{"deviceID": "001"},
+				"annotation":       map[string]any{"available": true},
+				"resourceGroupIDs": []string{"00001"},
+				"nodeIDs":          []string{},
+				"detected":         false,
+				"firstSeenAt":      "2025-06-01T00:00:00Z",
+				"lastSeenAt":       "2025-06-10T00:00:00Z",
+				"notDetectedSince": "2025-06-11T00:00:00Z",
+			},
+		},
+		{
+			"Normal Case: Adds the number of pending misses if there are any",
+			fields{
+				map[string]any{"deviceID": "001"},
+				annotation_model.Annotation{Properties: map[string]any{"available": true}},
+				[]string{"00001"},
+				[]string{},
+				true,
+				"",
+				"",
+				"",
+				"",
+				2,
+			},
+			map[string]any{
+				"device":           map[string]any{"deviceID": "001"},
+				"annotation":       map[string]any{"available": true},
+				"resourceGroupIDs": []string{"00001"},
+				"nodeIDs":          []string{},
+				"detected":         true,
+				"pendingMisses":    int64(2),
+			},
+		},
 		{
 			"Normal Case: Returns nil for an empty Resource struct",
 			fields{
@@ -130,6 +211,11 @@ func TestResource_ToObject(t *testing.T) {
 				[]string{},
 				[]string{},
 				false,
+				"",
+				"",
+				"",
+				"",
+				0,
 			},
 			nil,
 		},
@@ -142,6 +228,11 @@ func TestResource_ToObject(t *testing.T) {
 				ResourceGroupIDs: tt.fields.ResourceGroupIDs,
 				NodeIDs:          tt.fields.NodeIDs,
 				Detected:         tt.fields.Detected,
+				UnitID:           tt.fields.UnitID,
+				FirstSeenAt:      tt.fields.FirstSeenAt,
+				LastSeenAt:       tt.fields.LastSeenAt,
+				NotDetectedSince: tt.fields.NotDetectedSince,
+				PendingMisses:    tt.fields.PendingMisses,
 			}
 			if got := r.ToObject(); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("Resource.ToObject() = %v, want %v", got, tt.want)
@@ -214,6 +305,7 @@ func TestResource_ToObject4Unused(t *testing.T) {
 		ResourceGroupIDs []string
 		NodeIDs          []string
 		Detected         bool
+		UnitID           string
 	}
 	tests := []struct {
 		name   string
@@ -228,11 +320,29 @@ func TestResource_ToObject4Unused(t *testing.T) {
 				[]string{"00001"},
 				[]string{},
 				true,
+				"",
+			},
+			map[string]any{
+				"device":           map[string]any{"deviceID": "001"},
+				"annotation":       map[string]any{"available": true},
+				"resourceGroupIDs": []string{"00001"},
+			},
+		},
+		{
+			"Normal Case: Adds the unitID if the resource is contained in a unit",
+			fields{
+				map[string]any{"deviceID": "001"},
+				annotation_model.Annotation{Properties: map[string]any{"available": true}},
+				[]string{"00001"},
+				[]string{},
+				true,
+				"001",
 			},
 			map[string]any{
 				"device":           map[string]any{"deviceID": "001"},
 				"annotation":       map[string]any{"available": true},
 				"resourceGroupIDs": []string{"00001"},
+				"unitID":           "001",
 			},
 		},
 		{
@@ -243,6 +353,7 @@ func TestResource_ToObject4Unused(t *testing.T) {
 				[]string{},
 				[]string{},
 				false,
+				"",
 			},
 			nil,
 		},
@@ -255,6 +366,7 @@ func TestResource_ToObject4Unused(t *testing.T) {
 				ResourceGroupIDs: tt.fields.ResourceGroupIDs,
 				NodeIDs:          tt.fields.NodeIDs,
 				Detected:         tt.fields.Detected,
+				UnitID:           tt.fields.UnitID,
 			}
 			if got := r.ToObject4Unused(); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("Resource.ToObject4Unused() = %v, want %v", got, tt.want)
@@ -0,0 +1,90 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package rule_model
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/project-cdim/configuration-manager/common"
+)
+
+// AssignmentRuleList represents a list of assignment rules.
+// It contains a slice of AssignmentRule objects.
+type AssignmentRuleList struct {
+	Rules []AssignmentRule
+}
+
+// NewAssignmentRuleList creates and returns a new AssignmentRuleList instance with an empty list of Rules.
+func NewAssignmentRuleList() AssignmentRuleList {
+	return AssignmentRuleList{
+		Rules: []AssignmentRule{},
+	}
+}
+
+// Sort sorts the rules in evaluation order, that is, in ascending order of priority.
+// Rules with the same priority are ordered by ID so that the evaluation order is deterministic.
+func (rl *AssignmentRuleList) Sort() {
+	sort.SliceStable(rl.Rules, func(i, j int) bool {
+		pi, pj := rl.Rules[i].Priority(), rl.Rules[j].Priority()
+		if pi != pj {
+			return pi < pj
+		}
+		return rl.Rules[i].Id < rl.Rules[j].Id
+	})
+}
+
+// Resolve evaluates the rules in order of priority and returns the first rule that the device matches.
+// Invalid rules are skipped.
+//
+// Parameters:
+//   - dc: The attributes of the device to be evaluated.
+//
+// Returns:
+//   - *AssignmentRule: The matched rule, or nil if no rule matches.
+func (rl *AssignmentRuleList) Resolve(dc DeviceContext) *AssignmentRule {
+	rl.Sort()
+	for i := range rl.Rules {
+		rule := &rl.Rules[i]
+		if !rule.Validate() {
+			common.Log.Warn(fmt.Sprintf("Skip invalid assignment rule. rule(%v)", rule))
+			continue
+		}
+		if rule.Match(dc) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// ToObject converts the AssignmentRuleList to a slice of maps, where each map represents a rule.
+// Only valid rules (those that pass validation) are included in the result, in evaluation order.
+//
+// Returns:
+//
+//	[]map[string]any: A slice of maps, each containing the data of a valid rule.
+func (rl *AssignmentRuleList) ToObject() []map[string]any {
+	rl.Sort()
+	res := []map[string]any{}
+	for _, rule := range rl.Rules {
+		if rule.Validate() {
+			res = append(res, rule.ToObject())
+		} else {
+			common.Log.Warn(fmt.Sprintf("Not added to list. rule(%v)", rule))
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,117 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package rule_model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newTestRule(id string, priority float64, conditions map[string]any) AssignmentRule {
+	return AssignmentRule{
+		Id: id,
+		Properties: map[string]any{
+			"name":            id,
+			"priority":        priority,
+			"resourceGroupID": "group-" + id,
+			"conditions":      conditions,
+		},
+		CreatedAt: "2021-01-01T00:00:00Z",
+		UpdatedAt: "2021-01-01T00:00:00Z",
+	}
+}
+
+func TestNewAssignmentRuleList(t *testing.T) {
+	want := AssignmentRuleList{[]AssignmentRule{}}
+	if got := NewAssignmentRuleList(); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewAssignmentRuleList() = %v, want %v", got, want)
+	}
+}
+
+func TestAssignmentRuleList_Resolve(t *testing.T) {
+	tests := []struct {
+		name   string
+		rules  []AssignmentRule
+		dc     DeviceContext
+		wantID string
+	}{
+		{
+			"Normal case: The matched rule with the smallest priority is returned",
+			[]AssignmentRule{
+				newTestRule("rule03", 30, map[string]any{"type": "CPU"}),
+				newTestRule("rule01", 10, map[string]any{"type": "memory"}),
+				newTestRule("rule02", 20, map[string]any{"type": "CPU"}),
+			},
+			DeviceContext{Type: "CPU", Device: map[string]any{}},
+			"rule02",
+		},
+		{
+			"Normal case: Rules with the same priority are evaluated in order of ID",
+			[]AssignmentRule{
+				newTestRule("rule02", 10, map[string]any{"type": "CPU"}),
+				newTestRule("rule01", 10, map[string]any{"type": "CPU"}),
+			},
+			DeviceContext{Type: "CPU", Device: map[string]any{}},
+			"rule01",
+		},
+		{
+			"Normal case: Invalid rules are skipped",
+			[]AssignmentRule{
+				newTestRule("rule01", 10, map[string]any{"unknown": "CPU"}),
+				newTestRule("rule02", 20, map[string]any{"type": "CPU"}),
+			},
+			DeviceContext{Type: "CPU", Device: map[string]any{}},
+			"rule02",
+		},
+		{
+			"Normal case: No rule matches",
+			[]AssignmentRule{
+				newTestRule("rule01", 10, map[string]any{"type": "memory"}),
+			},
+			DeviceContext{Type: "CPU", Device: map[string]any{}},
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rl := AssignmentRuleList{Rules: tt.rules}
+			got := rl.Resolve(tt.dc)
+			gotID := ""
+			if got != nil {
+				gotID = got.Id
+			}
+			if gotID != tt.wantID {
+				t.Errorf("AssignmentRuleList.Resolve() = %v, want %v", gotID, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestAssignmentRuleList_ToObject(t *testing.T) {
+	rl := AssignmentRuleList{
+		Rules: []AssignmentRule{
+			newTestRule("rule02", 20, map[string]any{"type": "CPU"}),
+			newTestRule("rule01", 10, map[string]any{"type": "CPU"}),
+			newTestRule("rule03", 30, map[string]any{}),
+		},
+	}
+	got := rl.ToObject()
+	if len(got) != 2 {
+		t.Fatalf("AssignmentRuleList.ToObject() length = %v, want 2", len(got))
+	}
+	if got[0]["id"] != "rule01" || got[1]["id"] != "rule02" {
+		t.Errorf("AssignmentRuleList.ToObject() order = %v, %v", got[0]["id"], got[1]["id"])
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package rule_model
+
+import (
+	"fmt"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/model"
+)
+
+// AssignmentRule represents a rule that decides the resource group of a newly discovered device.
+//
+// Fields:
+// - Id: A unique identifier for the rule.
+// - Properties: A map containing name, description, priority, resourceGroupID and conditions.
+// - CreatedAt: The timestamp when the rule was created.
+// - UpdatedAt: The timestamp when the rule was last updated.
+type AssignmentRule struct {
+	Id         string
+	Properties map[string]any
+	CreatedAt  string
+	UpdatedAt  string
+}
+
+// NewAssignmentRule creates and returns a new AssignmentRule instance with default values.
+func NewAssignmentRule() AssignmentRule {
+	return AssignmentRule{
+		Id:         "",
+		Properties: map[string]any{},
+		CreatedAt:  "",
+		UpdatedAt:  "",
+	}
+}
+
+// NewAssignmentRuleWithCreateTimeStampsNow creates a new AssignmentRule instance, sets its creation timestamps
+// to the current time, and assigns the provided properties to the rule.
+//
+// Parameters:
+//   - properties: A map containing the properties to be assigned to the new rule.
+//
+// Returns:
+//
+//	A new AssignmentRule instance with the specified properties and current creation timestamps.
+func NewAssignmentRuleWithCreateTimeStampsNow(properties map[string]any) AssignmentRule {
+	r := NewAssignmentRule()
+	now := model.CurrentTimeISO8601()
+	r.CreatedAt = now
+	r.UpdatedAt = now
+	r.Properties = properties
+	return r
+}
+
+// NewAssignmentRuleForUpdate creates a new AssignmentRule instance for updating purposes.
+// It keeps the ID and creation timestamp of the rule stored in the database, refreshes the update
+// timestamp, and assigns the provided properties.
+//
+// Parameters:
+//   - ruleFromDb: A map containing the existing rule data from the database.
+//   - properties: A map containing the properties to be updated.
+//
+// Returns:
+//   - AssignmentRule: A new AssignmentRule instance with updated properties and timestamps.
+func NewAssignmentRuleForUpdate(ruleFromDb map[string]any, properties map[string]any) AssignmentRule {
+	r := NewAssignmentRule()
+	r.Id = ruleFromDb["id"].(string)
+	r.CreatedAt = ruleFromDb["createdAt"].(string)
+	r.UpdatedAt = model.CurrentTimeISO8601()
+	r.Properties = properties
+	return r
+}
+
+// NewAssignmentRuleFromDb creates an AssignmentRule from the properties of an AssignmentRules vertex.
+//
+// Parameters:
+//   - props: The properties of the vertex.
+//
+// Returns:
+//   - AssignmentRule: The rule assembled from the vertex properties.
+func NewAssignmentRuleFromDb(props map[string]any) AssignmentRule {
+	r := NewAssignmentRule()
+	r.Id, _ = props["id"].(string)
+	r.CreatedAt, _ = props["createdAt"].(string)
+	r.UpdatedAt, _ = props["updatedAt"].(string)
+	r.Properties = props
+	return r
+}
+
+// Validate checks the properties and timestamps of the AssignmentRule object.
+// It returns true if all validations pass, otherwise it returns false.
+func (r *AssignmentRule) Validate() bool {
+	if !ValidateProperty(r.Properties) {
+		return false
+	}
+
+	if !model.ValidateISO8601(r.CreatedAt) {
+		common.Log.Warn(fmt.Sprintf("createdAt is not ISO8601. createdAt(%v)", r.CreatedAt))
+		return false
+	}
+
+	if !model.ValidateISO8601(r.UpdatedAt) {
+		common.Log.Warn(fmt.Sprintf("updatedAt is not ISO8601. updatedAt(%v)", r.UpdatedAt))
+		return false
+	}
+
+	return true
+}
+
+// Priority returns the evaluation priority of the rule. Rules with a smaller value are evaluated first.
+// The value is stored as float64 when it comes from a request body and as int64 when it comes from the database.
+func (r *AssignmentRule) Priority() int64 {
+	priority, _ := toInt64(r.Properties["priority"])
+	return priority
+}
+
+// ResourceGroupID returns the ID of the resource group that a matching device is assigned to.
+func (r *AssignmentRule) ResourceGroupID() string {
+	resourceGroupID, _ := r.Properties["resourceGroupID"].(string)
+	return resourceGroupID
+}
+
+// Match reports whether the device described by dc satisfies every condition of the rule.
+// A condition that is not specified in the rule is not evaluated.
+//
+// Parameters:
+//   - dc: The attributes of the device to be evaluated.
+//
+// Returns:
+//   - bool: true if all conditions of the rule are satisfied, otherwise false.
+func (r *AssignmentRule) Match(dc DeviceContext) bool {
+	conditions, ok := r.Properties["conditions"].(map[string]any)
+	if !ok {
+		return false
+	}
+	return matchConditions(conditions, dc)
+}
+
+// ToObject converts the AssignmentRule struct to a map representation.
+// It returns nil if the AssignmentRule is not valid.
+// The returned map contains the following keys:
+// - "id", "name", "description", "priority", "resourceGroupID", "conditions", "createdAt", "updatedAt"
+func (r *AssignmentRule) ToObject() map[string]any {
+	if !r.Validate() {
+		return nil
+	}
+
+	description, ok := r.Properties["description"].(string)
+	if !ok {
+		description = ""
+	}
+
+	return map[string]any{
+		"id":              r.Id,
+		"name":            r.Properties["name"].(string),
+		"description":     description,
+		"priority":        r.Priority(),
+		"resourceGroupID": r.ResourceGroupID(),
+		"conditions":      r.Properties["conditions"].(map[string]any),
+		"createdAt":       r.CreatedAt,
+		"updatedAt":       r.UpdatedAt,
+	}
+}
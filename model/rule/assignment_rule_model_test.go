@@ -0,0 +1,304 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package rule_model
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/project-cdim/configuration-manager/model"
+)
+
+func validRuleProperties() map[string]any {
+	return map[string]any{
+		"name":            "rule01",
+		"description":     "This is rule01",
+		"priority":        float64(10),
+		"resourceGroupID": "10000000-0000-7000-8000-000000000001",
+		"conditions": map[string]any{
+			"type":  "CPU",
+			"model": "Xeon*",
+		},
+	}
+}
+
+func TestNewAssignmentRule(t *testing.T) {
+	tests := []struct {
+		name string
+		want AssignmentRule
+	}{
+		{
+			"Normal case: Create an instance of the AssignmentRule struct",
+			AssignmentRule{
+				Id:         "",
+				Properties: map[string]any{},
+				CreatedAt:  "",
+				UpdatedAt:  "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewAssignmentRule(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewAssignmentRule() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewAssignmentRuleWithCreateTimeStampsNow(t *testing.T) {
+	tests := []struct {
+		name       string
+		properties map[string]any
+	}{
+		{
+			"Normal case: Create an instance of the AssignmentRule struct with the current time",
+			validRuleProperties(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewAssignmentRuleWithCreateTimeStampsNow(tt.properties)
+			if got.Id != "" {
+				t.Errorf("NewAssignmentRuleWithCreateTimeStampsNow() Id = %v", got.Id)
+			}
+			if !reflect.DeepEqual(got.Properties, tt.properties) {
+				t.Errorf("NewAssignmentRuleWithCreateTimeStampsNow() Properties = %v, want %v", got.Properties, tt.properties)
+			}
+			if !model.ValidateISO8601(got.CreatedAt) {
+				t.Errorf("NewAssignmentRuleWithCreateTimeStampsNow() CreatedAt = %v", got.CreatedAt)
+			}
+			if got.CreatedAt != got.UpdatedAt {
+				t.Errorf("NewAssignmentRuleWithCreateTimeStampsNow() CreatedAt = %v, UpdatedAt = %v", got.CreatedAt, got.UpdatedAt)
+			}
+		})
+	}
+}
+
+func TestNewAssignmentRuleForUpdate(t *testing.T) {
+	tests := []struct {
+		name       string
+		ruleFromDb map[string]any
+		properties map[string]any
+	}{
+		{
+			"Normal case: Keep the ID and the creation time of the existing rule",
+			map[string]any{
+				"id":        "rule01",
+				"createdAt": "2021-01-01T00:00:00Z",
+			},
+			validRuleProperties(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewAssignmentRuleForUpdate(tt.ruleFromDb, tt.properties)
+			if got.Id != tt.ruleFromDb["id"] {
+				t.Errorf("NewAssignmentRuleForUpdate() Id = %v, want %v", got.Id, tt.ruleFromDb["id"])
+			}
+			if got.CreatedAt != tt.ruleFromDb["createdAt"] {
+				t.Errorf("NewAssignmentRuleForUpdate() CreatedAt = %v, want %v", got.CreatedAt, tt.ruleFromDb["createdAt"])
+			}
+			if !model.ValidateISO8601(got.UpdatedAt) {
+				t.Errorf("NewAssignmentRuleForUpdate() UpdatedAt = %v", got.UpdatedAt)
+			}
+			if !reflect.DeepEqual(got.Properties, tt.properties) {
+				t.Errorf("NewAssignmentRuleForUpdate() Properties = %v, want %v", got.Properties, tt.properties)
+			}
+		})
+	}
+}
+
+func TestNewAssignmentRuleFromDb(t *testing.T) {
+	props := validRuleProperties()
+	props["id"] = "rule01"
+	props["createdAt"] = "2021-01-01T00:00:00Z"
+	props["updatedAt"] = "2021-01-02T00:00:00Z"
+	tests := []struct {
+		name  string
+		props map[string]any
+		want  AssignmentRule
+	}{
+		{
+			"Normal case: Create an instance of the AssignmentRule struct from vertex properties",
+			props,
+			AssignmentRule{
+				Id:         "rule01",
+				Properties: props,
+				CreatedAt:  "2021-01-01T00:00:00Z",
+				UpdatedAt:  "2021-01-02T00:00:00Z",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewAssignmentRuleFromDb(tt.props); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewAssignmentRuleFromDb() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssignmentRule_Validate(t *testing.T) {
+	withProperty := func(key string, value any) map[string]any {
+		props := validRuleProperties()
+		if value == nil {
+			delete(props, key)
+		} else {
+			props[key] = value
+		}
+		return props
+	}
+	tests := []struct {
+		name      string
+		props     map[string]any
+		createdAt string
+		want      bool
+	}{
+		{"Normal case: All properties are valid", validRuleProperties(), "2021-01-01T00:00:00Z", true},
+		{"Normal case: description is omitted", withProperty("description", nil), "2021-01-01T00:00:00Z", true},
+		{"Error case: name is missing", withProperty("name", nil), "2021-01-01T00:00:00Z", false},
+		{"Error case: name is empty", withProperty("name", ""), "2021-01-01T00:00:00Z", false},
+		{"Error case: priority is negative", withProperty("priority", float64(-1)), "2021-01-01T00:00:00Z", false},
+		{"Error case: priority is not an integer", withProperty("priority", 1.5), "2021-01-01T00:00:00Z", false},
+		{"Error case: resourceGroupID is empty", withProperty("resourceGroupID", ""), "2021-01-01T00:00:00Z", false},
+		{"Error case: conditions is empty", withProperty("conditions", map[string]any{}), "2021-01-01T00:00:00Z", false},
+		{"Error case: conditions contains an unknown item", withProperty("conditions", map[string]any{"vendor": "x"}), "2021-01-01T00:00:00Z", false},
+		{"Error case: nodeIDPattern is not a valid regular expression", withProperty("conditions", map[string]any{"nodeIDPattern": "("}), "2021-01-01T00:00:00Z", false},
+		{"Error case: model is not a valid pattern", withProperty("conditions", map[string]any{"model": "["}), "2021-01-01T00:00:00Z", false},
+		{"Error case: properties is not a map", withProperty("conditions", map[string]any{"properties": "x"}), "2021-01-01T00:00:00Z", false},
+		{"Error case: createdAt is not ISO8601", validRuleProperties(), "2021/01/01", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := AssignmentRule{Id: "rule01", Properties: tt.props, CreatedAt: tt.createdAt, UpdatedAt: "2021-01-01T00:00:00Z"}
+			if got := r.Validate(); got != tt.want {
+				t.Errorf("AssignmentRule.Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssignmentRule_Match(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions any
+		dc         DeviceContext
+		want       bool
+	}{
+		{
+			"Normal case: All conditions match",
+			map[string]any{"type": "CPU", "model": "Xeon*", "nodeIDPattern": "^node-0[0-9]$", "chassisID": "ch01", "rackID": "rack01", "cxlSwitchID": "sw01", "properties": map[string]any{"status": map[string]any{"health": "OK"}}},
+			DeviceContext{Type: "CPU", NodeID: "node-01", ChassisID: "ch01", RackID: "rack01", CXLSwitchID: "sw01", Device: map[string]any{"model": "Xeon Gold", "status": map[string]any{"health": "OK"}}},
+			true,
+		},
+		{
+			"Normal case: type does not match",
+			map[string]any{"type": "memory"},
+			DeviceContext{Type: "CPU", Device: map[string]any{}},
+			false,
+		},
+		{
+			"Normal case: model does not match",
+			map[string]any{"model": "Xeon*"},
+			DeviceContext{Type: "CPU", Device: map[string]any{"model": "EPYC"}},
+			false,
+		},
+		{
+			"Normal case: The device has no node while nodeIDPattern is specified",
+			map[string]any{"nodeIDPattern": ".*"},
+			DeviceContext{Type: "CPU", Device: map[string]any{}},
+			false,
+		},
+		{
+			"Normal case: A property does not exist in the device",
+			map[string]any{"properties": map[string]any{"status": map[string]any{"state": "Enabled"}}},
+			DeviceContext{Type: "CPU", Device: map[string]any{"status": map[string]any{"health": "OK"}}},
+			false,
+		},
+		{
+			"Normal case: A numeric property matches",
+			map[string]any{"properties": map[string]any{"totalCores": float64(16)}},
+			DeviceContext{Type: "CPU", Device: map[string]any{"totalCores": float64(16)}},
+			true,
+		},
+		{
+			"Error case: conditions is not a map",
+			"CPU",
+			DeviceContext{Type: "CPU", Device: map[string]any{}},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := AssignmentRule{Properties: map[string]any{"conditions": tt.conditions}}
+			if got := r.Match(tt.dc); got != tt.want {
+				t.Errorf("AssignmentRule.Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssignmentRule_ToObject(t *testing.T) {
+	noDescription := validRuleProperties()
+	delete(noDescription, "description")
+	tests := []struct {
+		name  string
+		props map[string]any
+		want  map[string]any
+	}{
+		{
+			"Normal case: Convert a valid rule to map",
+			validRuleProperties(),
+			map[string]any{
+				"id":              "rule01",
+				"name":            "rule01",
+				"description":     "This is rule01",
+				"priority":        int64(10),
+				"resourceGroupID": "10000000-0000-7000-8000-000000000001",
+				"conditions":      map[string]any{"type": "CPU", "model": "Xeon*"},
+				"createdAt":       "2021-01-01T00:00:00Z",
+				"updatedAt":       "2021-01-01T00:00:00Z",
+			},
+		},
+		{
+			"Normal case: An omitted description is converted to an empty string",
+			noDescription,
+			map[string]any{
+				"id":              "rule01",
+				"name":            "rule01",
+				"description":     "",
+				"priority":        int64(10),
+				"resourceGroupID": "10000000-0000-7000-8000-000000000001",
+				"conditions":      map[string]any{"type": "CPU", "model": "Xeon*"},
+				"createdAt":       "2021-01-01T00:00:00Z",
+				"updatedAt":       "2021-01-01T00:00:00Z",
+			},
+		},
+		{
+			"Error case: An invalid rule is converted to nil",
+			map[string]any{},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := AssignmentRule{Id: "rule01", Properties: tt.props, CreatedAt: "2021-01-01T00:00:00Z", UpdatedAt: "2021-01-01T00:00:00Z"}
+			if got := r.ToObject(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AssignmentRule.ToObject() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package rule_model
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/project-cdim/configuration-manager/common"
+)
+
+// Condition item names of an assignment rule
+const (
+	ConditionType          = "type"          // Exact match with the "type" of the device
+	ConditionModel         = "model"         // Shell pattern match with the "model" of the device
+	ConditionChassisID     = "chassisID"     // Exact match with the chassis in which the device is mounted
+	ConditionRackID        = "rackID"        // Exact match with the rack to which the chassis of the device is attached
+	ConditionNodeIDPattern = "nodeIDPattern" // Regular expression match with the node ID of the device
+	ConditionCXLSwitchID   = "cxlSwitchID"   // Exact match with the CXL switch to which the device is connected
+	ConditionProperties    = "properties"    // Exact match with arbitrary device properties specified in the same structure as the device
+)
+
+// conditionKeys is the list of condition item names that can be specified in an assignment rule.
+var conditionKeys = []string{
+	ConditionType,
+	ConditionModel,
+	ConditionChassisID,
+	ConditionRackID,
+	ConditionNodeIDPattern,
+	ConditionCXLSwitchID,
+	ConditionProperties,
+}
+
+// DeviceContext holds the attributes of a device that are evaluated by assignment rules.
+type DeviceContext struct {
+	DeviceID    string
+	Type        string
+	NodeID      string
+	CXLSwitchID string
+	ChassisID   string
+	RackID      string
+	Device      map[string]any
+}
+
+// ValidateProperty checks the validity of the provided assignment rule property map.
+// It ensures that:
+//   - "name" is a string with a length between 1 and 64 characters,
+//   - "description", if present, is a string with a length of up to 256 characters,
+//   - "priority" is a non-negative integer,
+//   - "resourceGroupID" is a non-empty string,
+//   - "conditions" is a map that contains at least one known condition and no unknown conditions.
+//
+// Parameters:
+//   - property: map[string]any - A map containing the property fields to validate.
+//
+// Returns:
+//   - bool: true if the property is valid, false otherwise.
+func ValidateProperty(property map[string]any) bool {
+	name, ok := property["name"].(string)
+	if !ok {
+		common.Log.Warn("name is not a string")
+		return false
+	}
+	nameLen := utf8.RuneCountInString(name)
+	if nameLen < 1 || nameLen > 64 {
+		common.Log.Warn(fmt.Sprintf("name length is invalid. length(%v)", nameLen))
+		return false
+	}
+
+	if description, ok := property["description"]; ok {
+		descriptionStr, ok := description.(string)
+		if !ok {
+			common.Log.Warn("description is not a string")
+			return false
+		}
+		descLen := utf8.RuneCountInString(descriptionStr)
+		if descLen > 256 {
+			common.Log.Warn(fmt.Sprintf("description length is invalid. length(%v)", descLen))
+			return false
+		}
+	}
+
+	priority, ok := toInt64(property["priority"])
+	if !ok || priority < 0 {
+		common.Log.Warn(fmt.Sprintf("priority is not a non-negative integer. priority(%v)", property["priority"]))
+		return false
+	}
+
+	resourceGroupID, ok := property["resourceGroupID"].(string)
+	if !ok || len(resourceGroupID) == 0 {
+		common.Log.Warn("resourceGroupID is not a string or empty")
+		return false
+	}
+
+	conditions, ok := property["conditions"].(map[string]any)
+	if !ok {
+		common.Log.Warn("conditions is not a map")
+		return false
+	}
+
+	return validateConditions(conditions)
+}
+
+// validateConditions checks that the conditions map contains at least one condition,
+// that every key is a known condition item, and that every value has the expected type.
+func validateConditions(conditions map[string]any) bool {
+	if len(conditions) == 0 {
+		common.Log.Warn("conditions contains no elements")
+		return false
+	}
+
+	for key, value := range conditions {
+		switch key {
+		case ConditionType, ConditionModel, ConditionChassisID, ConditionRackID, ConditionCXLSwitchID:
+			if str, ok := value.(string); !ok || len(str) == 0 {
+				common.Log.Warn(fmt.Sprintf("conditions/%s is not a string or empty", key))
+				return false
+			}
+			if key == ConditionModel {
+				if _, err := path.Match(value.(string), ""); err != nil {
+					common.Log.Warn(fmt.Sprintf("conditions/%s is not a valid pattern. value(%v)", key, value))
+					return false
+				}
+			}
+		case ConditionNodeIDPattern:
+			pattern, ok := value.(string)
+			if !ok || len(pattern) == 0 {
+				common.Log.Warn(fmt.Sprintf("conditions/%s is not a string or empty", key))
+				return false
+			}
+			if _, err := regexp.Compile(pattern); err != nil {
+				common.Log.Warn(fmt.Sprintf("conditions/%s is not a valid regular expression. value(%v)", key, value))
+				return false
+			}
+		case ConditionProperties:
+			properties, ok := value.(map[string]any)
+			if !ok || len(properties) == 0 {
+				common.Log.Warn(fmt.Sprintf("conditions/%s is not a map or empty", key))
+				return false
+			}
+		default:
+			common.Log.Warn(fmt.Sprintf("conditions contains an unknown item. key(%v), known(%v)", key, conditionKeys))
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchConditions reports whether the device described by dc satisfies every condition.
+func matchConditions(conditions map[string]any, dc DeviceContext) bool {
+	if len(conditions) == 0 {
+		return false
+	}
+
+	for key, value := range conditions {
+		switch key {
+		case ConditionType:
+			if value != dc.Type {
+				return false
+			}
+		case ConditionModel:
+			model, ok := dc.Device["model"].(string)
+			if !ok {
+				return false
+			}
+			pattern, _ := value.(string)
+			if matched, err := path.Match(pattern, model); err != nil || !matched {
+				return false
+			}
+		case ConditionChassisID:
+			if value != dc.ChassisID {
+				return false
+			}
+		case ConditionRackID:
+			if value != dc.RackID {
+				return false
+			}
+		case ConditionNodeIDPattern:
+			pattern, _ := value.(string)
+			if len(dc.NodeID) == 0 {
+				return false
+			}
+			if matched, err := regexp.MatchString(pattern, dc.NodeID); err != nil || !matched {
+				return false
+			}
+		case ConditionCXLSwitchID:
+			if value != dc.CXLSwitchID {
+				return false
+			}
+		case ConditionProperties:
+			properties, ok := value.(map[string]any)
+			if !ok || !matchProperties(properties, dc.Device) {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchProperties reports whether every property in want exists in device with the same value.
+// Nested maps are compared recursively, so only the properties specified in want are evaluated.
+// Other values are compared by their string representation, which absorbs the difference between
+// numbers decoded from a request body and numbers read from the database.
+func matchProperties(want map[string]any, device map[string]any) bool {
+	for key, wantValue := range want {
+		gotValue, ok := device[key]
+		if !ok {
+			return false
+		}
+		if wantMap, ok := wantValue.(map[string]any); ok {
+			gotMap, ok := gotValue.(map[string]any)
+			if !ok || !matchProperties(wantMap, gotMap) {
+				return false
+			}
+			continue
+		}
+		if fmt.Sprint(gotValue) != fmt.Sprint(wantValue) {
+			return false
+		}
+	}
+	return true
+}
+
+// toInt64 converts an integral JSON or database number to int64.
+// It returns false if the value is not a number or has a fractional part.
+func toInt64(value any) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, false
+		}
+		return int64(v), true
+	default:
+		return 0, false
+	}
+}
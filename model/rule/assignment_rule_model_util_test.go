@@ -0,0 +1,72 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package rule_model
+
+import (
+	"testing"
+)
+
+func TestValidateProperty(t *testing.T) {
+	t.Skip("not test because it is tested within AssignmentRule.Validate")
+}
+
+func TestMatchProperties(t *testing.T) {
+	device := map[string]any{
+		"model":  "Xeon Gold",
+		"status": map[string]any{"health": "OK", "state": "Enabled"},
+	}
+	tests := []struct {
+		name string
+		want map[string]any
+		ok   bool
+	}{
+		{"Normal case: Top level property matches", map[string]any{"model": "Xeon Gold"}, true},
+		{"Normal case: Nested property matches", map[string]any{"status": map[string]any{"health": "OK"}}, true},
+		{"Normal case: Nested property does not match", map[string]any{"status": map[string]any{"health": "Critical"}}, false},
+		{"Normal case: Property does not exist", map[string]any{"baseSpeedMHz": float64(2000)}, false},
+		{"Normal case: Device property is not a map", map[string]any{"model": map[string]any{"name": "Xeon"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchProperties(tt.want, device); got != tt.ok {
+				t.Errorf("matchProperties() = %v, want %v", got, tt.ok)
+			}
+		})
+	}
+}
+
+func TestToInt64(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  any
+		want   int64
+		wantOk bool
+	}{
+		{"Normal case: int", 3, 3, true},
+		{"Normal case: int64", int64(4), 4, true},
+		{"Normal case: integral float64", float64(5), 5, true},
+		{"Error case: fractional float64", 5.5, 0, false},
+		{"Error case: string", "5", 0, false},
+		{"Error case: nil", nil, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := toInt64(tt.value)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("toInt64() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package unit_model
+
+import (
+	"fmt"
+
+	"github.com/project-cdim/configuration-manager/common"
+)
+
+// UnitList is a list of Unit.
+type UnitList struct {
+	Units []Unit
+}
+
+// NewUnitList creates and returns a new instance of UnitList.
+//
+// This function initializes a UnitList struct with an empty slice of Unit.
+//
+// Returns:
+//
+//	UnitList: A new instance of UnitList with an empty slice of Unit.
+func NewUnitList() UnitList {
+	return UnitList{
+		Units: []Unit{},
+	}
+}
+
+// ToObject creates and returns a map array with elements of id, annotation, and resources from a unit list.
+//
+// This method iterates over each Unit in the UnitList, validates it, and then converts it into a map object.
+// Invalid units are not added to the list.
+//
+// Returns:
+//
+//	[]map[string]any: A slice of map objects, each representing a validated Unit.
+func (ul *UnitList) ToObject() []map[string]any {
+	res := []map[string]any{}
+	for _, unit := range ul.Units {
+		if unit.Validate() {
+			res = append(res, unit.ToObject())
+		} else {
+			common.Log.Warn(fmt.Sprintf("Not added to list. unit(%v)", unit))
+		}
+	}
+
+	return res
+}
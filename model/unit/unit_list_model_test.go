@@ -0,0 +1,64 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package unit_model
+
+import (
+	"reflect"
+	"testing"
+
+	annotation_model "github.com/project-cdim/configuration-manager/model/annotation"
+	resource_model "github.com/project-cdim/configuration-manager/model/resource"
+)
+
+func TestNewUnitList(t *testing.T) {
+	want := UnitList{[]Unit{}}
+	if got := NewUnitList(); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewUnitList() = %v, want %v", got, want)
+	}
+}
+
+func TestUnitList_ToObject(t *testing.T) {
+	tests := []struct {
+		name  string
+		units []Unit
+		want  []map[string]any
+	}{
+		{
+			"Normal case: When UnitList struct includes an abnormal Unit struct (no device ID), convert to map excluding the abnormal Unit struct",
+			[]Unit{
+				{"cpu01", annotation_model.Annotation{Properties: map[string]any{"available": true}}, resource_model.NewResourceList()},
+				{"", annotation_model.Annotation{Properties: map[string]any{"available": true}}, resource_model.NewResourceList()},
+				{"cpu02", annotation_model.Annotation{Properties: map[string]any{"available": false}}, resource_model.NewResourceList()},
+			},
+			[]map[string]any{
+				{"id": "cpu01", "annotation": map[string]any{"available": true}, "resources": []map[string]any{}},
+				{"id": "cpu02", "annotation": map[string]any{"available": false}, "resources": []map[string]any{}},
+			},
+		},
+		{
+			"Normal case: An empty UnitList struct is converted to an empty slice",
+			[]Unit{},
+			[]map[string]any{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ul := &UnitList{Units: tt.units}
+			if got := ul.ToObject(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UnitList.ToObject() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package unit_model
+
+import (
+	annotation_model "github.com/project-cdim/configuration-manager/model/annotation"
+	resource_model "github.com/project-cdim/configuration-manager/model/resource"
+)
+
+// Unit is a Unit structure.
+// A unit is a set of resources that are handled together, such as a CPU and its non-removable devices.
+// DeviceID is the deviceID of the resource that represents the unit, and it is used as the ID of the unit.
+type Unit struct {
+	DeviceID   string
+	Annotation annotation_model.Annotation
+	Resources  resource_model.ResourceList
+}
+
+// NewUnit is the constructor for the Unit structure.
+//
+// This function initializes a Unit struct with all elements having empty values.
+// It is useful for creating a Unit instance ready to be populated with its annotation and resources.
+//
+// Returns:
+//
+//	Unit: A new instance of Unit with an empty device ID, an empty annotation, and an empty ResourceList.
+func NewUnit() Unit {
+	return Unit{
+		DeviceID:   "",
+		Annotation: annotation_model.NewAnnotation(),
+		Resources:  resource_model.ResourceList{},
+	}
+}
+
+// Validate reports whether the receiver Unit is valid.
+//
+// This method checks the validity of the Unit instance by verifying that the device ID is not empty.
+//
+// Returns:
+//
+//	bool: True if the Unit has a device ID, false otherwise.
+func (u *Unit) Validate() bool {
+	return len(u.DeviceID) > 0
+}
+
+// ToObject creates and returns a map with elements of id, annotation, and resources.
+//
+// This method first validates the Unit instance. If the instance is not valid, it returns nil.
+// Upon successful validation, it constructs a map with the device ID of the unit under the "id" key,
+// the annotation of the unit, and the resources contained in the unit.
+//
+// Returns:
+//
+//	map[string]any: A map representation of the Unit, or nil if the Unit is invalid.
+func (u *Unit) ToObject() map[string]any {
+	if !u.Validate() {
+		return nil
+	}
+
+	return map[string]any{
+		"id":         u.DeviceID,
+		"annotation": u.Annotation.ToObject(),
+		"resources":  u.Resources.ToObject(),
+	}
+}
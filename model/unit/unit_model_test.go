@@ -0,0 +1,115 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package unit_model
+
+import (
+	"reflect"
+	"testing"
+
+	annotation_model "github.com/project-cdim/configuration-manager/model/annotation"
+	resource_model "github.com/project-cdim/configuration-manager/model/resource"
+)
+
+func TestNewUnit(t *testing.T) {
+	tests := []struct {
+		name string
+		want Unit
+	}{
+		{
+			"Normal case: Create an instance of the Unit struct",
+			Unit{
+				"",
+				annotation_model.Annotation{Properties: map[string]any{}},
+				resource_model.ResourceList{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewUnit(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewUnit() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnit_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		deviceID string
+		want     bool
+	}{
+		{"Normal case: The unit has a device ID", "cpu01", true},
+		{"Error case: The unit has no device ID", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewUnit()
+			u.DeviceID = tt.deviceID
+			if got := u.Validate(); got != tt.want {
+				t.Errorf("Unit.Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnit_ToObject(t *testing.T) {
+	resource := resource_model.Resource{
+		Device:           map[string]any{"deviceID": "cpu01", "type": "CPU"},
+		Annotation:       annotation_model.Annotation{Properties: map[string]any{"available": true}},
+		ResourceGroupIDs: []string{"group01"},
+		NodeIDs:          []string{"node01"},
+		Detected:         true,
+	}
+	tests := []struct {
+		name string
+		unit Unit
+		want map[string]any
+	}{
+		{
+			"Normal case: The unit is converted to a map with its annotation and resources",
+			Unit{
+				"cpu01",
+				annotation_model.Annotation{Properties: map[string]any{"available": false}},
+				resource_model.ResourceList{Resources: []resource_model.Resource{resource}},
+			},
+			map[string]any{
+				"id":         "cpu01",
+				"annotation": map[string]any{"available": false},
+				"resources": []map[string]any{
+					{
+						"device":           map[string]any{"deviceID": "cpu01", "type": "CPU"},
+						"annotation":       map[string]any{"available": true},
+						"resourceGroupIDs": []string{"group01"},
+						"nodeIDs":          []string{"node01"},
+						"detected":         true,
+					},
+				},
+			},
+		},
+		{
+			"Normal case: Returns nil for a unit without a device ID",
+			NewUnit(),
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.unit.ToObject(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Unit.ToObject() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package outbox
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/project-cdim/configuration-manager/common"
+)
+
+// The events are inserted in one statement from the arrays of their fields.
+const sqlInsertOutboxEvents string = `
+INSERT INTO public.configuration_manager_outbox (id, pubsub_name, topic, event_type, payload)
+SELECT event.id, event.pubsub_name, event.topic, event.event_type, event.payload::jsonb
+FROM unnest($1::text[], $2::text[], $3::text[], $4::text[], $5::text[]) AS event(id, pubsub_name, topic, event_type, payload)
+`
+
+// Pending events are claimed by putting off their next attempt until the end of the lease $2,
+// so that the relays of the other replicas skip them while they are being published without holding their locks.
+const sqlClaimPendingOutboxEvents string = `
+WITH claimed AS (
+	UPDATE public.configuration_manager_outbox
+	SET next_attempt_at = $2
+	WHERE id IN (
+		SELECT id
+		FROM public.configuration_manager_outbox
+		WHERE delivered_at IS NULL AND next_attempt_at <= now()
+		ORDER BY created_at, id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING id, pubsub_name, topic, event_type, payload, attempts, created_at
+)
+SELECT id, pubsub_name, topic, event_type, payload, attempts
+FROM claimed
+ORDER BY created_at, id
+`
+
+const sqlUpdateOutboxEventDelivered string = `
+UPDATE public.configuration_manager_outbox
+SET attempts = attempts + 1, delivered_at = now(), last_error = ''
+WHERE id = $1 AND delivered_at IS NULL
+`
+
+const sqlUpdateOutboxEventFailed string = `
+UPDATE public.configuration_manager_outbox
+SET attempts = attempts + 1, next_attempt_at = $2, last_error = $3
+WHERE id = $1 AND delivered_at IS NULL
+`
+
+// Event is an event to be published through the outbox.
+// ID is used as the ID of the CloudEvent so that subscribers can detect redelivered events.
+type Event struct {
+	ID         string
+	PubsubName string
+	Topic      string
+	Type       string
+	Payload    map[string]any
+}
+
+// Record is an event stored in the outbox and waiting to be published.
+// Payload holds the JSON encoded payload, and Attempts is the number of times the publication has failed.
+type Record struct {
+	ID         string
+	PubsubName string
+	Topic      string
+	Type       string
+	Payload    []byte
+	Attempts   int
+}
+
+// Enqueue writes the events to the outbox in the transaction tx.
+// The events are published by the relay only after the transaction is committed together with the change they describe.
+func Enqueue(tx *sql.Tx, events ...Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(events))
+	pubsubNames := make([]string, 0, len(events))
+	topics := make([]string, 0, len(events))
+	types := make([]string, 0, len(events))
+	payloads := make([]string, 0, len(events))
+	for _, event := range events {
+		payload, err := json.Marshal(event.Payload)
+		if err != nil {
+			common.Log.Error(fmt.Sprintf("outbox event marshal error [id : %s] : %s", event.ID, err.Error()))
+			return err
+		}
+		ids = append(ids, event.ID)
+		pubsubNames = append(pubsubNames, event.PubsubName)
+		topics = append(topics, event.Topic)
+		types = append(types, event.Type)
+		payloads = append(payloads, string(payload))
+	}
+
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %v, param2: %v, param3: %v, param4: %v", sqlInsertOutboxEvents, ids, pubsubNames, topics, types))
+	if _, err := tx.Exec(sqlInsertOutboxEvents, pq.Array(ids), pq.Array(pubsubNames), pq.Array(topics), pq.Array(types), pq.Array(payloads)); err != nil {
+		common.Log.Error(err.Error())
+		return err
+	}
+	return nil
+}
+
+// claimPending claims up to limit events whose next attempt is due until leaseUntil, and returns them in the order they were enqueued.
+// The claim is committed by itself, and the events become due again at leaseUntil unless they are marked before.
+func claimPending(db *sql.DB, limit int, leaseUntil time.Time) ([]Record, error) {
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %d, param2: %s", sqlClaimPendingOutboxEvents, limit, leaseUntil))
+	rows, err := db.Query(sqlClaimPendingOutboxEvents, limit, leaseUntil)
+	if err != nil {
+		common.Log.Error(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := []Record{}
+	for rows.Next() {
+		var record Record
+		if err := rows.Scan(&record.ID, &record.PubsubName, &record.Topic, &record.Type, &record.Payload, &record.Attempts); err != nil {
+			common.Log.Error(err.Error())
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		common.Log.Error(err.Error())
+		return nil, err
+	}
+	return records, nil
+}
+
+// markDelivered marks the event as delivered so that it is not published again.
+func markDelivered(db *sql.DB, id string) error {
+	if _, err := db.Exec(sqlUpdateOutboxEventDelivered, id); err != nil {
+		common.Log.Error(err.Error())
+		return err
+	}
+	return nil
+}
+
+// markFailed records the failure of the publication and schedules the next attempt at nextAttemptAt.
+func markFailed(db *sql.DB, id string, nextAttemptAt time.Time, lastError string) error {
+	if _, err := db.Exec(sqlUpdateOutboxEventFailed, id, nextAttemptAt, lastError); err != nil {
+		common.Log.Error(err.Error())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/publisher"
+)
+
+const (
+	defaultRelayInterval  = 1 * time.Second  // Interval to poll the outbox
+	defaultRelayBatchSize = 100              // Maximum number of events published in a poll
+	defaultBaseBackoff    = 1 * time.Second  // Delay before the first retry
+	defaultMaxBackoff     = 5 * time.Minute  // Upper limit of the delay between retries
+	defaultPublishTimeout = 10 * time.Second // Timeout of the publication of an event
+	defaultLease          = 5 * time.Minute  // Time for which the claimed events are skipped by the other relays while they are published
+)
+
+// Relay publishes the events written to the outbox and marks them as delivered.
+// A failed publication is retried with an exponential backoff, so events are delivered at least once in the order they were enqueued,
+// except while an earlier event is waiting for its retry.
+type Relay struct {
+	db             *sql.DB
+	publisher      publisher.Publisher
+	interval       time.Duration
+	batchSize      int
+	baseBackoff    time.Duration
+	maxBackoff     time.Duration
+	publishTimeout time.Duration
+	lease          time.Duration
+}
+
+// NewRelay creates a Relay publishing the events with pub.
+// The outbox is polled through db, the connection pool shared with the other background workers, which is not closed by the Relay.
+func NewRelay(db *sql.DB, pub publisher.Publisher) Relay {
+	return Relay{
+		db:             db,
+		publisher:      pub,
+		interval:       defaultRelayInterval,
+		batchSize:      defaultRelayBatchSize,
+		baseBackoff:    defaultBaseBackoff,
+		maxBackoff:     defaultMaxBackoff,
+		publishTimeout: defaultPublishTimeout,
+		lease:          defaultLease,
+	}
+}
+
+// Run polls the outbox and publishes the pending events until ctx is cancelled.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		if _, err := r.relayOnce(ctx); err != nil {
+			common.Log.Warn(fmt.Sprintf("outbox relay error : %s", err.Error()))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// relayOnce claims the pending events, publishes them and returns the number of delivered events.
+// No transaction is kept open while the events are published: the claim and the result of each publication are committed by themselves.
+func (r *Relay) relayOnce(ctx context.Context) (int, error) {
+	leaseUntil := time.Now().Add(r.lease)
+	records, err := claimPending(r.db, r.batchSize, leaseUntil)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, record := range records {
+		// The rest of the events are left to the next poll after the lease, because another relay may have claimed them
+		if !time.Now().Before(leaseUntil) {
+			break
+		}
+		publishCtx, cancel := context.WithTimeout(ctx, r.publishTimeout)
+		err := r.publisher.Publish(publishCtx, record.toMessage())
+		cancel()
+		if err != nil {
+			common.Log.Warn(fmt.Sprintf("outbox event publish error [id : %s, type : %s, attempts : %d] : %s", record.ID, record.Type, record.Attempts+1, err.Error()))
+			if err := markFailed(r.db, record.ID, time.Now().Add(r.backoff(record.Attempts+1)), err.Error()); err != nil {
+				return delivered, err
+			}
+			continue
+		}
+		if err := markDelivered(r.db, record.ID); err != nil {
+			return delivered, err
+		}
+		delivered++
+	}
+	return delivered, nil
+}
+
+// backoff returns the delay before the next attempt after the publication has failed attempts times.
+// The delay doubles on every failure, starting from baseBackoff and capped at maxBackoff.
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.baseBackoff
+	for i := 1; i < attempts && delay < r.maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, r.maxBackoff)
+}
+
+// toMessage converts the record into the message to publish.
+func (r *Record) toMessage() publisher.Message {
+	return publisher.Message{
+		ID:         r.ID,
+		PubsubName: r.PubsubName,
+		Topic:      r.Topic,
+		Type:       r.Type,
+		Payload:    r.Payload,
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package outbox
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/project-cdim/configuration-manager/publisher"
+)
+
+func TestNewRelay(t *testing.T) {
+	db := &sql.DB{}
+	pub := publisher.NewDaprPublisher()
+	got := NewRelay(db, pub)
+	if got.db != db {
+		t.Errorf("NewRelay() db = %v, want %v", got.db, db)
+	}
+	if got.publisher != pub {
+		t.Errorf("NewRelay() publisher = %v, want %v", got.publisher, pub)
+	}
+	if got.interval != defaultRelayInterval || got.batchSize != defaultRelayBatchSize || got.baseBackoff != defaultBaseBackoff || got.maxBackoff != defaultMaxBackoff || got.lease != defaultLease {
+		t.Errorf("NewRelay() = %+v, want the default settings", got)
+	}
+}
+
+func TestRelay_Run(t *testing.T) {
+	t.Skip("not test")
+}
+
+func TestRelay_relayOnce(t *testing.T) {
+	t.Skip("not test")
+}
+
+func TestRelay_backoff(t *testing.T) {
+	relay := Relay{baseBackoff: 1 * time.Second, maxBackoff: 10 * time.Second}
+	tests := []struct {
+		name     string
+		attempts int
+		want     time.Duration
+	}{
+		{name: "Normal case: The first retry waits for the base backoff", attempts: 1, want: 1 * time.Second},
+		{name: "Normal case: The delay doubles on every failure", attempts: 2, want: 2 * time.Second},
+		{name: "Normal case: The delay doubles on every failure", attempts: 4, want: 8 * time.Second},
+		{name: "Normal case: The delay is capped at the max backoff", attempts: 5, want: 10 * time.Second},
+		{name: "Normal case: The delay is capped at the max backoff", attempts: 100, want: 10 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := relay.backoff(tt.attempts); got != tt.want {
+				t.Errorf("backoff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecord_toMessage(t *testing.T) {
+	record := Record{
+		ID:         "event001",
+		PubsubName: "pubsub",
+		Topic:      "topic",
+		Type:       "resource.created",
+		Payload:    []byte(`{"id":"event001"}`),
+		Attempts:   3,
+	}
+	want := publisher.Message{
+		ID:         "event001",
+		PubsubName: "pubsub",
+		Topic:      "topic",
+		Type:       "resource.created",
+		Payload:    []byte(`{"id":"event001"}`),
+	}
+	if got := record.toMessage(); !reflect.DeepEqual(got, want) {
+		t.Errorf("toMessage() = %v, want %v", got, want)
+	}
+}
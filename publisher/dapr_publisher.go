@@ -0,0 +1,69 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package publisher
+
+import (
+	"context"
+
+	dapr "github.com/dapr/go-sdk/client"
+)
+
+// DaprClient is an interface that defines the methods for publishing events to Dapr.
+// This interface is used to enable dependency injection for testing purposes.
+type DaprClient interface {
+	PublishEvent(ctx context.Context, pubsubName, topicName string, data interface{}, opts ...dapr.PublishEventOption) error
+}
+
+// DaprClientImpl is a wrapper around the official Dapr client.
+// This is the production implementation.
+type DaprClientImpl struct {
+	client dapr.Client
+}
+
+// PublishEvent publishes an event to the specified pubsub and topic using the Dapr client.
+func (d *DaprClientImpl) PublishEvent(ctx context.Context, pubsubName, topicName string, data interface{}, opts ...dapr.PublishEventOption) error {
+	return d.client.PublishEvent(ctx, pubsubName, topicName, data, opts...)
+}
+
+// DaprClientFactory is a function type that creates a new DaprClient.
+// This can be replaced with a mock implementation during testing.
+var DaprClientFactory = func() (DaprClient, error) {
+	client, err := dapr.NewClient()
+	if err != nil {
+		return nil, err
+	}
+	return &DaprClientImpl{client: client}, nil
+}
+
+// DaprPublisher publishes events to the Dapr pub/sub component and the topic of the message.
+type DaprPublisher struct{}
+
+// NewDaprPublisher creates a DaprPublisher.
+func NewDaprPublisher() *DaprPublisher {
+	return &DaprPublisher{}
+}
+
+// Publish publishes the message through the DaprClient. The ID and the type of the message are used for the CloudEvent.
+func (dp *DaprPublisher) Publish(ctx context.Context, message Message) error {
+	client, err := DaprClientFactory()
+	if err != nil {
+		return err
+	}
+	opts := []dapr.PublishEventOption{
+		dapr.PublishEventWithContentType("application/json"),
+		dapr.PublishEventWithMetadata(map[string]string{"cloudevent.id": message.ID, "cloudevent.type": message.Type}),
+	}
+	return client.PublishEvent(ctx, message.PubsubName, message.Topic, message.Payload, opts...)
+}
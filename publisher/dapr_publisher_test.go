@@ -0,0 +1,99 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package publisher
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	dapr "github.com/dapr/go-sdk/client"
+)
+
+// Structure for recording the events published through the DaprClient
+type publishedEvent struct {
+	pubsubName string
+	topicName  string
+	data       any
+}
+
+type recordingDaprClient struct {
+	events []publishedEvent
+	err    error
+}
+
+func (r *recordingDaprClient) PublishEvent(ctx context.Context, pubsubName, topicName string, data interface{}, opts ...dapr.PublishEventOption) error {
+	r.events = append(r.events, publishedEvent{pubsubName: pubsubName, topicName: topicName, data: data})
+	return r.err
+}
+
+func TestDaprPublisher_Publish(t *testing.T) {
+	message := Message{
+		ID:         "event001",
+		PubsubName: "pubsub",
+		Topic:      "topic",
+		Type:       "resource.created",
+		Payload:    []byte(`{"id":"event001"}`),
+	}
+	tests := []struct {
+		name       string
+		factoryErr error
+		publishErr error
+		wantErr    bool
+		wantEvents []publishedEvent
+	}{
+		{
+			name:       "Normal case: The event is published to the pub/sub and the topic of the message",
+			wantErr:    false,
+			wantEvents: []publishedEvent{{pubsubName: "pubsub", topicName: "topic", data: []byte(`{"id":"event001"}`)}},
+		},
+		{
+			name:       "Error case: The publication fails",
+			publishErr: errors.New("publish error"),
+			wantErr:    true,
+			wantEvents: []publishedEvent{{pubsubName: "pubsub", topicName: "topic", data: []byte(`{"id":"event001"}`)}},
+		},
+		{
+			name:       "Error case: The client cannot be created",
+			factoryErr: errors.New("factory error"),
+			wantErr:    true,
+			wantEvents: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &recordingDaprClient{err: tt.publishErr}
+			originalFactory := DaprClientFactory
+			DaprClientFactory = func() (DaprClient, error) {
+				if tt.factoryErr != nil {
+					return nil, tt.factoryErr
+				}
+				return client, nil
+			}
+			defer func() {
+				DaprClientFactory = originalFactory
+			}()
+
+			err := NewDaprPublisher().Publish(context.Background(), message)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Publish() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(client.events, tt.wantEvents) {
+				t.Errorf("Publish() events = %v, want %v", client.events, tt.wantEvents)
+			}
+		})
+	}
+}
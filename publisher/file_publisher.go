@@ -0,0 +1,74 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+)
+
+// FilePublisher appends events to a file in the JSON Lines format, one event per line.
+// It is intended for development and testing without a message broker.
+type FilePublisher struct {
+	path string
+	mu   sync.Mutex
+}
+
+// Structure of a line written by the FilePublisher
+type fileRecord struct {
+	ID         string          `json:"id"`
+	PubsubName string          `json:"pubsubName"`
+	Topic      string          `json:"topic"`
+	Type       string          `json:"type"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// NewFilePublisher creates a FilePublisher appending the events to the file at path.
+func NewFilePublisher(path string) (*FilePublisher, error) {
+	if len(path) == 0 {
+		return nil, errors.New("file path of the event publisher is empty")
+	}
+	return &FilePublisher{path: path}, nil
+}
+
+// Publish appends the message to the file. The file is created if it does not exist.
+func (fp *FilePublisher) Publish(ctx context.Context, message Message) error {
+	line, err := json.Marshal(fileRecord{
+		ID:         message.ID,
+		PubsubName: message.PubsubName,
+		Topic:      message.Topic,
+		Type:       message.Type,
+		Payload:    json.RawMessage(message.Payload),
+	})
+	if err != nil {
+		return err
+	}
+
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	file, err := os.OpenFile(fp.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,66 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package publisher
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewFilePublisher(t *testing.T) {
+	if _, err := NewFilePublisher(""); err == nil {
+		t.Errorf("NewFilePublisher() error = nil, want error")
+	}
+	if _, err := NewFilePublisher("events.jsonl"); err != nil {
+		t.Errorf("NewFilePublisher() error = %v", err)
+	}
+}
+
+func TestFilePublisher_Publish(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	fp, err := NewFilePublisher(path)
+	if err != nil {
+		t.Fatalf("NewFilePublisher() error = %v", err)
+	}
+
+	messages := []Message{
+		{ID: "event001", PubsubName: "pubsub", Topic: "topic", Type: "resource.created", Payload: []byte(`{"id":"event001"}`)},
+		{ID: "event002", PubsubName: "pubsub", Topic: "topic", Type: "resource.notDetected", Payload: []byte(`{"id":"event002"}`)},
+	}
+	for _, message := range messages {
+		if err := fp.Publish(context.Background(), message); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	want := `{"id":"event001","pubsubName":"pubsub","topic":"topic","type":"resource.created","payload":{"id":"event001"}}` + "\n" +
+		`{"id":"event002","pubsubName":"pubsub","topic":"topic","type":"resource.notDetected","payload":{"id":"event002"}}` + "\n"
+	if string(got) != want {
+		t.Errorf("Publish() file = %v, want %v", string(got), want)
+	}
+}
+
+func TestFilePublisher_Publish_invalidPayload(t *testing.T) {
+	fp, _ := NewFilePublisher(filepath.Join(t.TempDir(), "events.jsonl"))
+	if err := fp.Publish(context.Background(), Message{ID: "event001", Payload: []byte(`{`)}); err == nil {
+		t.Errorf("Publish() error = nil, want error")
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package publisher
+
+import (
+	"context"
+	"errors"
+)
+
+// MultiPublisher publishes each event to all of its publishers.
+// An error is returned if any of them fails, so the event is retried on all the publishers;
+// the publishers are expected to tolerate events delivered more than once.
+type MultiPublisher struct {
+	publishers []Publisher
+}
+
+// NewMultiPublisher creates a MultiPublisher publishing the events to publishers in order.
+func NewMultiPublisher(publishers ...Publisher) *MultiPublisher {
+	return &MultiPublisher{publishers: publishers}
+}
+
+// Publish publishes the message to all the publishers, and returns the errors of the failed publishers joined.
+func (mp *MultiPublisher) Publish(ctx context.Context, message Message) error {
+	var errs []error
+	for _, publisher := range mp.publishers {
+		if err := publisher.Publish(ctx, message); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package publisher
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type recordingPublisher struct {
+	published []string
+	err       error
+}
+
+func (rp *recordingPublisher) Publish(ctx context.Context, message Message) error {
+	rp.published = append(rp.published, message.ID)
+	return rp.err
+}
+
+func TestMultiPublisher_Publish(t *testing.T) {
+	tests := []struct {
+		name    string
+		errs    []error
+		wantErr bool
+	}{
+		{
+			name:    "Normal case: All the publishers succeed",
+			errs:    []error{nil, nil},
+			wantErr: false,
+		},
+		{
+			name:    "Error case: One of the publishers fails",
+			errs:    []error{errors.New("publish error"), nil},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publishers := []Publisher{}
+			recorders := []*recordingPublisher{}
+			for _, err := range tt.errs {
+				recorder := &recordingPublisher{err: err}
+				recorders = append(recorders, recorder)
+				publishers = append(publishers, recorder)
+			}
+			mp := NewMultiPublisher(publishers...)
+			if err := mp.Publish(context.Background(), Message{ID: "event001"}); (err != nil) != tt.wantErr {
+				t.Errorf("Publish() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			// Every publisher receives the message even if an earlier one fails
+			for i, recorder := range recorders {
+				if !reflect.DeepEqual(recorder.published, []string{"event001"}) {
+					t.Errorf("Publish() publisher[%d] received %v, want [event001]", i, recorder.published)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package publisher
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want Config
+	}{
+		{
+			name: "Normal case: Default configuration",
+			env:  map[string]string{},
+			want: Config{Backend: "dapr", WebhookURL: "", NatsURL: "nats://localhost:4222", FilePath: "events.jsonl"},
+		},
+		{
+			name: "Normal case: All settings are configured",
+			env: map[string]string{
+				"CM_EVENT_PUBLISHER":   " Webhook ",
+				"CM_EVENT_WEBHOOK_URL": "http://localhost:8000/events",
+				"CM_EVENT_NATS_URL":    "nats://nats:4222",
+				"CM_EVENT_FILE_PATH":   "/tmp/events.jsonl",
+			},
+			want: Config{Backend: "webhook", WebhookURL: "http://localhost:8000/events", NatsURL: "nats://nats:4222", FilePath: "/tmp/events.jsonl"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := LoadConfig(func(key string) string { return tt.env[key] })
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   Config
+		wantType Publisher
+		wantErr  bool
+	}{
+		{
+			name:     "Normal case: dapr",
+			config:   Config{Backend: "dapr"},
+			wantType: &DaprPublisher{},
+			wantErr:  false,
+		},
+		{
+			name:     "Normal case: webhook",
+			config:   Config{Backend: "webhook", WebhookURL: "http://localhost:8000/events"},
+			wantType: &WebhookPublisher{},
+			wantErr:  false,
+		},
+		{
+			name:     "Normal case: nats",
+			config:   Config{Backend: "nats", NatsURL: "nats://localhost:4222"},
+			wantType: &NatsPublisher{},
+			wantErr:  false,
+		},
+		{
+			name:     "Normal case: file",
+			config:   Config{Backend: "file", FilePath: "events.jsonl"},
+			wantType: &FilePublisher{},
+			wantErr:  false,
+		},
+		{
+			name:    "Error case: The webhook URL is not configured",
+			config:  Config{Backend: "webhook"},
+			wantErr: true,
+		},
+		{
+			name:    "Error case: Unknown backend",
+			config:  Config{Backend: "kafka"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && reflect.TypeOf(got) != reflect.TypeOf(tt.wantType) {
+				t.Errorf("New() = %T, want %T", got, tt.wantType)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package publisher
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// CloudEventSource is the source of the CloudEvents sent by the service
+const CloudEventSource = "configuration-manager"
+
+const defaultWebhookTimeout = 10 * time.Second
+
+// WebhookPublisher POSTs events to a URL as CloudEvents in the binary content mode:
+// the payload is sent as the body, and the attributes of the event are sent as ce-* headers.
+// The topic of the message is sent in the ce-topic extension attribute.
+type WebhookPublisher struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookPublisher creates a WebhookPublisher POSTing the events to webhookURL.
+// It returns an error if webhookURL is not an absolute HTTP(S) URL.
+func NewWebhookPublisher(webhookURL string) (*WebhookPublisher, error) {
+	parsed, err := url.Parse(webhookURL)
+	if err != nil {
+		return nil, err
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || len(parsed.Host) == 0 {
+		return nil, fmt.Errorf("invalid webhook URL: %q", webhookURL)
+	}
+	return &WebhookPublisher{
+		url:    webhookURL,
+		client: &http.Client{Timeout: defaultWebhookTimeout},
+	}, nil
+}
+
+// Publish POSTs the message to the URL. A response other than 2xx is treated as an error.
+func (wp *WebhookPublisher) Publish(ctx context.Context, message Message) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wp.url, bytes.NewReader(message.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("ce-specversion", "1.0")
+	req.Header.Set("ce-id", message.ID)
+	req.Header.Set("ce-type", message.Type)
+	req.Header.Set("ce-source", CloudEventSource)
+	req.Header.Set("ce-topic", message.Topic)
+
+	resp, err := wp.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Read the body so that the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.New("webhook returned " + resp.Status)
+	}
+	return nil
+}
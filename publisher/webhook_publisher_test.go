@@ -0,0 +1,111 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package publisher
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewWebhookPublisher(t *testing.T) {
+	tests := []struct {
+		name       string
+		webhookURL string
+		wantErr    bool
+	}{
+		{name: "Normal case: http", webhookURL: "http://localhost:8000/events", wantErr: false},
+		{name: "Normal case: https", webhookURL: "https://example.com/events", wantErr: false},
+		{name: "Error case: Empty URL", webhookURL: "", wantErr: true},
+		{name: "Error case: Relative URL", webhookURL: "/events", wantErr: true},
+		{name: "Error case: Unsupported scheme", webhookURL: "ftp://localhost/events", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewWebhookPublisher(tt.webhookURL)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewWebhookPublisher() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWebhookPublisher_Publish(t *testing.T) {
+	message := Message{
+		ID:         "event001",
+		PubsubName: "pubsub",
+		Topic:      "configuration_manager.resource",
+		Type:       "resource.created",
+		Payload:    []byte(`{"id":"event001"}`),
+	}
+	tests := []struct {
+		name       string
+		statusCode int
+		wantErr    bool
+	}{
+		{name: "Normal case: 2xx response", statusCode: http.StatusNoContent, wantErr: false},
+		{name: "Error case: Non-2xx response", statusCode: http.StatusServiceUnavailable, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotHeader http.Header
+			var gotBody string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotHeader = r.Header.Clone()
+				body, _ := io.ReadAll(r.Body)
+				gotBody = string(body)
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+
+			wp, err := NewWebhookPublisher(server.URL)
+			if err != nil {
+				t.Fatalf("NewWebhookPublisher() error = %v", err)
+			}
+			err = wp.Publish(context.Background(), message)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Publish() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if gotBody != `{"id":"event001"}` {
+				t.Errorf("Publish() body = %v", gotBody)
+			}
+			wantHeader := map[string]string{
+				"Content-Type":   "application/json",
+				"Ce-Specversion": "1.0",
+				"Ce-Id":          "event001",
+				"Ce-Type":        "resource.created",
+				"Ce-Source":      "configuration-manager",
+				"Ce-Topic":       "configuration_manager.resource",
+			}
+			for key, want := range wantHeader {
+				if got := gotHeader.Get(key); got != want {
+					t.Errorf("Publish() header %s = %v, want %v", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestWebhookPublisher_Publish_connectionError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	wp, _ := NewWebhookPublisher(server.URL)
+	server.Close()
+
+	if err := wp.Publish(context.Background(), Message{ID: "event001", Payload: []byte(`{}`)}); err == nil {
+		t.Errorf("Publish() error = nil, want error")
+	}
+}
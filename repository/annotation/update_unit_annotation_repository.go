@@ -0,0 +1,85 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package annotation_repository
+
+import (
+	"fmt"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+	"github.com/project-cdim/configuration-manager/model"
+)
+
+// cypher query to update the annotation of a unit
+const updateUnitAnnotation string = `
+	MATCH (:Unit {deviceID: '%s'})-[:Have]->(van:Annotation)
+	SET van = %s
+`
+
+// cypher query to update the annotations of the resources contained in a unit
+const updateUnitMemberAnnotation string = `
+	MATCH (:Unit {deviceID: '%s'})-[:Contain]->(vrs)-[:Have]->(van:Annotation)
+	WHERE %s
+	SET van = %s
+`
+
+// UpdateUnitAnnotationRepository is a struct that holds the ID of the unit to be updated.
+// It is used to update the annotations of the unit and the resources contained in it.
+type UpdateUnitAnnotationRepository struct {
+	unitID string
+}
+
+// NewUpdateUnitAnnotationRepository creates a new UpdateUnitAnnotationRepository with the given unit ID.
+// It returns an UpdateUnitAnnotationRepository instance.
+func NewUpdateUnitAnnotationRepository(unitID string) UpdateUnitAnnotationRepository {
+	return UpdateUnitAnnotationRepository{
+		unitID: unitID,
+	}
+}
+
+// Set updates the annotation of the unit and the annotations of all resources contained in the unit
+// to the provided model, so that the members of a unit always share the same annotation.
+// Both updates are executed in the transaction held by cmdb.
+//
+// Parameters:
+//   - cmdb: A database connection implementing the database.CmDb interface.
+//   - model: A model implementing the model.CmModelMapper interface, representing the annotation data.
+//
+// Returns:
+//   - A map[string]any representing the updated annotation object, or nil if an error occurs.
+//   - An error if any operation fails during the update process.
+func (uuar *UpdateUnitAnnotationRepository) Set(cmdb database.CmDb, model model.CmModelMapper) (map[string]any, error) {
+	annotationObject := model.ToObject()
+
+	cypherProperty, err := common.Map2CypherProperty(annotationObject)
+	if err != nil {
+		return nil, err
+	}
+
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s", updateUnitAnnotation, uuar.unitID, cypherProperty))
+	_, err = cmdb.CmDbExecCypher(0, updateUnitAnnotation, uuar.unitID, cypherProperty)
+	if err != nil {
+		return nil, err
+	}
+
+	whereClauses := updateAnnotationConstructsWhereClause()
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s, param3: %s", updateUnitMemberAnnotation, uuar.unitID, whereClauses, cypherProperty))
+	_, err = cmdb.CmDbExecCypher(0, updateUnitMemberAnnotation, uuar.unitID, whereClauses, cypherProperty)
+	if err != nil {
+		return nil, err
+	}
+
+	return annotationObject, nil
+}
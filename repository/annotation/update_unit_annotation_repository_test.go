@@ -0,0 +1,32 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package annotation_repository
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewUpdateUnitAnnotationRepository(t *testing.T) {
+	repo := NewUpdateUnitAnnotationRepository("cpu01")
+
+	if !reflect.DeepEqual(repo.unitID, "cpu01") {
+		t.Errorf("NewUpdateUnitAnnotationRepository().unitID = %v, want %v", repo.unitID, "cpu01")
+	}
+}
+
+func TestUpdateUnitAnnotationRepository_Set(t *testing.T) {
+	t.Skip("not test")
+}
@@ -0,0 +1,233 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package chassis_repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+	"github.com/project-cdim/configuration-manager/model"
+	cmapi_repository "github.com/project-cdim/configuration-manager/repository"
+
+	"github.com/apache/age/drivers/golang/age"
+)
+
+// ErrUnitConflict is returned when a chassis does not fit in the rack at the specified unit position.
+var ErrUnitConflict = errors.New("the chassis does not fit in the rack at the unit position")
+
+// getRackUnits is cypher query to retrieve a rack and the chassis attached to it.
+const getRackUnits = `
+	MATCH (vrc:Rack {id: '%s'})
+	OPTIONAL MATCH (vrc)-[:Attach]->(vch:Chassis)
+	RETURN
+		vrc,
+		CASE WHEN vch IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE vch END
+`
+
+const getRackUnitsColumnCount = 2
+const (
+	getRackUnitsIndexRack = iota
+	getRackUnitsIndexChassis
+)
+
+// getChassisVertex is cypher query to retrieve the Chassis vertex only.
+const getChassisVertex = `
+	MATCH (vch:Chassis {id: '%s'})
+	RETURN vch
+`
+
+// deleteAttachEdge is cypher query to detach a chassis from the rack.
+const deleteAttachEdge = `
+	MATCH (:Rack)-[eat:Attach]->(vch:Chassis {id: '%s'})
+	DELETE eat
+`
+
+// countAttachEdge is cypher query to count the Attach edges to a chassis.
+const countAttachEdge = `
+	MATCH (:Rack)-[eat:Attach]->(:Chassis {id: '%s'})
+	RETURN COUNT(eat)
+`
+
+// createAttachEdge is cypher query to attach a chassis to a rack at the unit position.
+// The unit position is also held by the chassis so that the chassis can be sorted in the rack.
+const createAttachEdge = `
+	MATCH (vrc:Rack {id: '%s'}), (vch:Chassis {id: '%s'})
+	CREATE (vrc)-[:Attach {unitPosition: %d}]->(vch)
+	SET vch.unitPosition = %d
+`
+
+// removeUnitPosition is cypher query to remove the unit position from a detached chassis.
+const removeUnitPosition = `
+	MATCH (vch:Chassis {id: '%s'})
+	REMOVE vch.unitPosition
+`
+
+// AttachChassisRepository is a repository for attaching a chassis to a rack.
+type AttachChassisRepository struct{}
+
+// NewAttachChassisRepository creates a new instance of AttachChassisRepository.
+func NewAttachChassisRepository() AttachChassisRepository {
+	return AttachChassisRepository{}
+}
+
+// Set attaches the chassis to the rack at the unit position given by the model (chassis_model.Attachment).
+// If the chassis is already attached to a rack, it is moved.
+// It returns an error wrapping cmapi_repository.ErrNotFound if the chassis or the rack does not exist,
+// and ErrUnitConflict if the chassis exceeds the height of the rack or overlaps with another chassis.
+//
+// Parameters:
+//
+//	cmdb - The database connection object.
+//	model  - The model mapper to convert the attachment to an object.
+//
+// Returns:
+//
+//	A map representing the attachment, or an error if the operation fails.
+func (acr *AttachChassisRepository) Set(cmdb database.CmDb, model model.CmModelMapper) (map[string]any, error) {
+	attachment := model.ToObject()
+	if attachment == nil {
+		return nil, errors.New("AttachChassisRepository.Set : invalid attachment")
+	}
+	chassisID := attachment["chassisID"].(string)
+	rackID := attachment["rackID"].(string)
+	unitPosition := attachment["unitPosition"].(int64)
+
+	chassisProps, err := findChassisVertex(cmdb, chassisID)
+	if err != nil {
+		return nil, err
+	}
+	if chassisProps == nil {
+		return nil, fmt.Errorf("%w: chassis(%s)", cmapi_repository.ErrNotFound, chassisID)
+	}
+
+	rackProps, attached, err := findRackUnits(cmdb, rackID, chassisID)
+	if err != nil {
+		return nil, err
+	}
+	if rackProps == nil {
+		return nil, fmt.Errorf("%w: rack(%s)", cmapi_repository.ErrNotFound, rackID)
+	}
+	if !fitsInRack(rackProps, attached, chassisProps, unitPosition) {
+		return nil, fmt.Errorf("%w: rack(%s), unitPosition(%d)", ErrUnitConflict, rackID, unitPosition)
+	}
+
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s", deleteAttachEdge, chassisID))
+	if _, err := cmdb.CmDbExecCypher(0, deleteAttachEdge, chassisID); err != nil {
+		return nil, err
+	}
+
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s, param3: %d", createAttachEdge, rackID, chassisID, unitPosition))
+	if _, err := cmdb.CmDbExecCypher(0, createAttachEdge, rackID, chassisID, unitPosition, unitPosition); err != nil {
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// findChassisVertex returns the properties of the Chassis vertex, or nil if it does not exist.
+func findChassisVertex(cmdb database.CmDb, chassisID string) (map[string]any, error) {
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s", getChassisVertex, chassisID))
+	cypherCursor, err := cmdb.CmDbExecCypher(1, getChassisVertex, chassisID)
+	if err != nil {
+		return nil, err
+	}
+	defer cypherCursor.Close()
+
+	var res map[string]any
+	for cypherCursor.Next() {
+		row, err := cypherCursor.GetRow()
+		if err != nil {
+			common.Log.Error(err.Error())
+			return nil, err
+		}
+		res = row[0].(*age.Vertex).Props()
+	}
+
+	return res, nil
+}
+
+// findRackUnits returns the properties of the rack and of the chassis attached to it, excluding the specified chassis.
+// The rack properties are nil if the rack does not exist.
+func findRackUnits(cmdb database.CmDb, rackID string, excludeChassisID string) (map[string]any, []map[string]any, error) {
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s", getRackUnits, rackID))
+	cypherCursor, err := cmdb.CmDbExecCypher(getRackUnitsColumnCount, getRackUnits, rackID)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer cypherCursor.Close()
+
+	var rackProps map[string]any
+	attached := []map[string]any{}
+	for cypherCursor.Next() {
+		row, err := cypherCursor.GetRow()
+		if err != nil {
+			common.Log.Error(err.Error())
+			return nil, nil, err
+		}
+		rackProps = row[getRackUnitsIndexRack].(*age.Vertex).Props()
+		chassisProps := row[getRackUnitsIndexChassis].(*age.Vertex).Props()
+		if id, ok := chassisProps["id"].(string); ok && id != excludeChassisID {
+			attached = append(attached, chassisProps)
+		}
+	}
+
+	return rackProps, attached, nil
+}
+
+// DetachChassisRepository is a repository for detaching a chassis from the rack.
+type DetachChassisRepository struct {
+	ChassisID string
+}
+
+// NewDetachChassisRepository creates a new instance of DetachChassisRepository with the specified chassisID.
+func NewDetachChassisRepository(chassisID string) DetachChassisRepository {
+	return DetachChassisRepository{
+		ChassisID: chassisID,
+	}
+}
+
+// Delete detaches the chassis from the rack and removes its unit position.
+// It returns an error wrapping cmapi_repository.ErrNotFound if the chassis is not attached to any rack.
+//
+// Parameters:
+//
+//	cmdb - An instance of the CmDb database interface.
+//
+// Returns:
+//
+//	error - An error object if the operation fails, otherwise nil.
+func (dcr *DetachChassisRepository) Delete(cmdb database.CmDb) error {
+	cnt, err := cmapi_repository.CountRecords(cmdb, countAttachEdge, dcr.ChassisID)
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return fmt.Errorf("%w: attachment of chassis(%s)", cmapi_repository.ErrNotFound, dcr.ChassisID)
+	}
+
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s", deleteAttachEdge, dcr.ChassisID))
+	if _, err := cmdb.CmDbExecCypher(0, deleteAttachEdge, dcr.ChassisID); err != nil {
+		return err
+	}
+
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s", removeUnitPosition, dcr.ChassisID))
+	if _, err := cmdb.CmDbExecCypher(0, removeUnitPosition, dcr.ChassisID); err != nil {
+		return err
+	}
+
+	return nil
+}
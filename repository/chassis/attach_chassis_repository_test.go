@@ -0,0 +1,50 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package chassis_repository
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewAttachChassisRepository(t *testing.T) {
+	want := AttachChassisRepository{}
+	if got := NewAttachChassisRepository(); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewAttachChassisRepository() = %v, want %v", got, want)
+	}
+}
+
+func TestAttachChassisRepository_Set(t *testing.T) {
+	t.Skip("not test")
+}
+
+func TestFindChassisVertex(t *testing.T) {
+	t.Skip("not test")
+}
+
+func TestFindRackUnits(t *testing.T) {
+	t.Skip("not test")
+}
+
+func TestNewDetachChassisRepository(t *testing.T) {
+	want := DetachChassisRepository{"chassis-01"}
+	if got := NewDetachChassisRepository("chassis-01"); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewDetachChassisRepository() = %v, want %v", got, want)
+	}
+}
+
+func TestDetachChassisRepository_Delete(t *testing.T) {
+	t.Skip("not test")
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package chassis_repository
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+	"github.com/project-cdim/configuration-manager/filter"
+	chassis_model "github.com/project-cdim/configuration-manager/model/chassis"
+
+	"github.com/apache/age/drivers/golang/age"
+)
+
+// getChassisList is cypher query to retrieve all chassis.
+const getChassisList string = `
+	MATCH (vch:Chassis)
+	OPTIONAL MATCH (vrc:Rack)-[:Attach]->(vch)
+	OPTIONAL MATCH (vch)-[:Mount]->(vrs)
+	OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
+	OPTIONAL MATCH (vnd)-[:Compose]->(vrs)
+	OPTIONAL MATCH (vrs)-[:Have]->(van:Annotation)
+	OPTIONAL MATCH (vrs)-[endt:NotDetected]->(:NotDetectedDevice)
+	WITH vrc, vch, vrs, van, vrsg, vnd, endt
+	RETURN
+		CASE WHEN vrc IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE vrc END,
+		vch,
+		CASE WHEN vrs IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE vrs END,
+		CASE WHEN van IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE van END,
+		COLLECT(vrsg.id),
+		COLLECT(vnd.id),
+		CASE WHEN endt IS NULL THEN true ELSE false END
+`
+
+// ChassisListRepository is a repository structure for getting all chassis.
+type ChassisListRepository struct {
+	Detail bool
+}
+
+// NewChassisListRepository creates and returns a ChassisListRepository object.
+// The detail flag indicates whether to retrieve the detailed information of the mounted resources.
+func NewChassisListRepository(detail bool) ChassisListRepository {
+	return ChassisListRepository{
+		Detail: detail,
+	}
+}
+
+// FindList retrieves all chassis with the resources and CXL switches mounted in them, and the racks to which they are attached.
+// The chassis are sorted by ID, and only the chassis that satisfy the filter are returned.
+func (clr *ChassisListRepository) FindList(cmdb database.CmDb, filter filter.CmFilter) ([]map[string]any, error) {
+	common.Log.Debug(fmt.Sprintf("query: %s", getChassisList))
+	cypherCursor, err := cmdb.CmDbExecCypher(getChassisColumnCount, getChassisList)
+	if err != nil {
+		return nil, err
+	}
+	defer cypherCursor.Close()
+
+	records := [][]age.Entity{}
+	for cypherCursor.Next() {
+		row, err := cypherCursor.GetRow()
+		if err != nil {
+			common.Log.Error(err.Error())
+			return nil, err
+		}
+		records = append(records, row)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return compareByChassis(records, getChassisIndexChassis, getChassisIndexDevice, i, j)
+	})
+
+	chassisList := chassis_model.NewChassisList()
+	for _, chassis := range ComposeChassisList(records, chassisRecordIndex, clr.Detail) {
+		if filter.FilterByCondition(chassis.ToObject()) {
+			chassisList.Chassis = append(chassisList.Chassis, chassis)
+		}
+	}
+
+	return chassisList.ToObject(), nil
+}
@@ -0,0 +1,120 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package chassis_repository
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+	"github.com/project-cdim/configuration-manager/filter"
+
+	"github.com/apache/age/drivers/golang/age"
+)
+
+// getChassis is cypher query to retrieve a specific chassis.
+const getChassis string = `
+	MATCH (vch:Chassis{id: '%s'})
+	OPTIONAL MATCH (vrc:Rack)-[:Attach]->(vch)
+	OPTIONAL MATCH (vch)-[:Mount]->(vrs)
+	OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
+	OPTIONAL MATCH (vnd)-[:Compose]->(vrs)
+	OPTIONAL MATCH (vrs)-[:Have]->(van:Annotation)
+	OPTIONAL MATCH (vrs)-[endt:NotDetected]->(:NotDetectedDevice)
+	WITH vrc, vch, vrs, van, vrsg, vnd, endt
+	RETURN
+		CASE WHEN vrc IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE vrc END,
+		vch,
+		CASE WHEN vrs IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE vrs END,
+		CASE WHEN van IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE van END,
+		COLLECT(vrsg.id),
+		COLLECT(vnd.id),
+		CASE WHEN endt IS NULL THEN true ELSE false END
+`
+
+const getChassisColumnCount = 7
+const (
+	getChassisIndexRack = iota
+	getChassisIndexChassis
+	getChassisIndexDevice
+	getChassisIndexAnnotation
+	getChassisIndexResourceGroupIDs
+	getChassisIndexNodeIDs
+	getChassisIndexNotDetected
+)
+
+// chassisRecordIndex is the column positions of the getChassis and getChassisList query results.
+var chassisRecordIndex = ChassisRecordIndex{
+	Rack:             getChassisIndexRack,
+	Chassis:          getChassisIndexChassis,
+	Device:           getChassisIndexDevice,
+	Annotation:       getChassisIndexAnnotation,
+	ResourceGroupIDs: getChassisIndexResourceGroupIDs,
+	NodeIDs:          getChassisIndexNodeIDs,
+	NotDetected:      getChassisIndexNotDetected,
+}
+
+// ChassisRepository is a repository structure for getting a specific chassis.
+type ChassisRepository struct {
+	ChassisID string
+	Detail    bool
+}
+
+// NewChassisRepository creates and returns a ChassisRepository object that holds the argument chassisID.
+// The detail flag indicates whether to retrieve the detailed information of the mounted resources.
+func NewChassisRepository(chassisID string, detail bool) ChassisRepository {
+	return ChassisRepository{
+		ChassisID: chassisID,
+		Detail:    detail,
+	}
+}
+
+// Find retrieves a chassis with the resources and CXL switches mounted in it, and the rack to which it is attached.
+// It returns nil if the chassis does not exist or does not satisfy the filter.
+func (cr *ChassisRepository) Find(cmdb database.CmDb, filter filter.CmFilter) (map[string]any, error) {
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s", getChassis, cr.ChassisID))
+	cypherCursor, err := cmdb.CmDbExecCypher(getChassisColumnCount, getChassis, cr.ChassisID)
+	if err != nil {
+		return nil, err
+	}
+	defer cypherCursor.Close()
+
+	records := [][]age.Entity{}
+	for cypherCursor.Next() {
+		row, err := cypherCursor.GetRow()
+		if err != nil {
+			common.Log.Error(err.Error())
+			return nil, err
+		}
+		records = append(records, row)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return compareByChassis(records, getChassisIndexChassis, getChassisIndexDevice, i, j)
+	})
+
+	chassisList := ComposeChassisList(records, chassisRecordIndex, cr.Detail)
+	if len(chassisList) == 0 {
+		return nil, nil
+	}
+
+	res := chassisList[0].ToObject()
+	if res == nil || !filter.FilterByCondition(res) {
+		return nil, nil
+	}
+
+	return res, nil
+}
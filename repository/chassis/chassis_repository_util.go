@@ -0,0 +1,182 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package chassis_repository
+
+import (
+	"strings"
+
+	chassis_model "github.com/project-cdim/configuration-manager/model/chassis"
+	cxlswitch_model "github.com/project-cdim/configuration-manager/model/cxlswitch"
+	resource_repository "github.com/project-cdim/configuration-manager/repository/resource"
+
+	"github.com/apache/age/drivers/golang/age"
+)
+
+// ChassisRecordIndex holds the column positions of a Cypher query result that traverses
+// the Attach and Mount edges (Rack -> Chassis -> Device).
+// Rack is set to -1 if the rack to which the chassis is attached should not be held in the chassis.
+type ChassisRecordIndex struct {
+	Rack             int
+	Chassis          int
+	Device           int
+	Annotation       int
+	ResourceGroupIDs int
+	NodeIDs          int
+	NotDetected      int
+}
+
+// ComposeChassisList assembles Chassis structures from the records of a Cypher query result.
+// The records must be sorted so that the records of the same chassis are consecutive.
+// Records without a chassis are skipped. CXL switches mounted in a chassis are held as CXL switches,
+// and other devices are held as resources composed in the same way as the other repositories.
+//
+// Parameters:
+//   - records: The records of the Cypher query result.
+//   - idx: The column positions of the records.
+//   - detail: Whether to include the detailed device information of the resources.
+//
+// Returns:
+//   - []chassis_model.Chassis: The assembled chassis in the order of the records.
+func ComposeChassisList(records [][]age.Entity, idx ChassisRecordIndex, detail bool) []chassis_model.Chassis {
+	res := []chassis_model.Chassis{}
+	preChassisID := ""
+	for _, row := range records {
+		chassisProps := row[idx.Chassis].(*age.Vertex).Props()
+		chassisID, ok := chassisProps["id"].(string)
+		if !ok {
+			continue
+		}
+
+		if len(res) == 0 || preChassisID != chassisID {
+			chassis := chassis_model.NewChassis()
+			chassis.Properties = chassisProps
+			if idx.Rack >= 0 {
+				chassis.RackID, _ = row[idx.Rack].(*age.Vertex).Props()["id"].(string)
+			}
+			res = append(res, chassis)
+			preChassisID = chassisID
+		}
+		chassis := &res[len(res)-1]
+
+		deviceVertex := row[idx.Device].(*age.Vertex)
+		if len(deviceVertex.Props()) <= 0 {
+			continue
+		}
+		if deviceVertex.Label() == "CXLswitch" {
+			cxlswitch := cxlswitch_model.NewCXLSwitch()
+			cxlswitch.Properties = deviceVertex.Props()
+			if cxlswitch.Validate() {
+				chassis.CXLSwitches.CXLSwitches = append(chassis.CXLSwitches.CXLSwitches, cxlswitch)
+			}
+		} else {
+			resource := resource_repository.ComposeResource(
+				deviceVertex,
+				row[idx.Annotation].(*age.Vertex),
+				row[idx.ResourceGroupIDs].(*age.SimpleEntity),
+				row[idx.NodeIDs].(*age.SimpleEntity),
+				row[idx.NotDetected].(*age.SimpleEntity).AsBool(),
+				detail,
+			)
+			if resource.Validate() {
+				chassis.Resources.Resources = append(chassis.Resources.Resources, resource)
+			}
+		}
+	}
+
+	return res
+}
+
+// compareByChassis sorts the contents of a Cypher query execution result based on the following criteria:
+// - First sort key: Chassis' id (string, empty string if absent, ascending order)
+// - Second sort key: Chassis > Device's type (string, empty string if absent, ascending order)
+// - Third sort key: Chassis > Device's deviceID (string, empty string if absent, ascending order)
+// - Fourth sort key: Chassis > Device's id (string, empty string if absent, ascending order)
+func compareByChassis(records [][]age.Entity, chassisIdx, deviceIdx, i, j int) bool {
+	chassisID1, _ := records[i][chassisIdx].(*age.Vertex).Props()["id"].(string)
+	chassisID2, _ := records[j][chassisIdx].(*age.Vertex).Props()["id"].(string)
+	if chassisID1 != chassisID2 {
+		return strings.Compare(chassisID1, chassisID2) < 0
+	}
+
+	devProp1 := records[i][deviceIdx].(*age.Vertex).Props()
+	devProp2 := records[j][deviceIdx].(*age.Vertex).Props()
+	for _, key := range []string{"type", "deviceID", "id"} {
+		value1, _ := devProp1[key].(string)
+		value2, _ := devProp2[key].(string)
+		if value1 != value2 {
+			return strings.Compare(value1, value2) < 0
+		}
+	}
+
+	return false
+}
+
+// unitRange returns the lowest and highest rack units occupied by a chassis placed at the unit position.
+// A chassis without a height is regarded as occupying a single unit.
+func unitRange(chassisProps map[string]any, unitPosition int64) (int64, int64) {
+	height := propInt64(chassisProps, "height", 1)
+	if height < 1 {
+		height = 1
+	}
+	return unitPosition, unitPosition + height - 1
+}
+
+// fitsInRack reports whether a chassis can be placed at the unit position of a rack.
+// The chassis must not exceed the height of the rack, if the rack has one, and must not overlap
+// with the chassis already attached to the rack. Attached chassis without a unit position are ignored.
+//
+// Parameters:
+//   - rackProps: The properties of the rack.
+//   - attached: The properties of the other chassis attached to the rack.
+//   - chassisProps: The properties of the chassis to be placed.
+//   - unitPosition: The unit position at which the chassis is to be placed.
+//
+// Returns:
+//   - bool: true if the chassis can be placed, false otherwise.
+func fitsInRack(rackProps map[string]any, attached []map[string]any, chassisProps map[string]any, unitPosition int64) bool {
+	lowest, highest := unitRange(chassisProps, unitPosition)
+
+	if rackHeight := propInt64(rackProps, "height", 0); rackHeight > 0 && highest > rackHeight {
+		return false
+	}
+
+	for _, other := range attached {
+		otherPosition := propInt64(other, "unitPosition", 0)
+		if otherPosition < 1 {
+			continue
+		}
+		otherLowest, otherHighest := unitRange(other, otherPosition)
+		if lowest <= otherHighest && otherLowest <= highest {
+			return false
+		}
+	}
+
+	return true
+}
+
+// propInt64 returns the integral property of a vertex, or defaultValue if it does not exist or is not an integer.
+func propInt64(props map[string]any, key string, defaultValue int64) int64 {
+	switch v := props[key].(type) {
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	case float64:
+		if v == float64(int64(v)) {
+			return int64(v)
+		}
+	}
+	return defaultValue
+}
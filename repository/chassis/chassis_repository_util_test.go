@@ -0,0 +1,185 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package chassis_repository
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/apache/age/drivers/golang/age"
+)
+
+func createChassisRecord(rackID string, chassisID string, label string, device map[string]any) []age.Entity {
+	rack := map[string]any{}
+	if len(rackID) > 0 {
+		rack["id"] = rackID
+	}
+	chassis := map[string]any{}
+	if len(chassisID) > 0 {
+		chassis["id"] = chassisID
+	}
+	return []age.Entity{
+		age.NewVertex(1, "Rack", rack),
+		age.NewVertex(2, "Chassis", chassis),
+		age.NewVertex(3, label, device),
+		age.NewVertex(4, "Annotation", map[string]any{"available": true}),
+		age.NewSimpleEntity([]any{"group01"}),
+		age.NewSimpleEntity([]any{}),
+		age.NewSimpleEntity(true),
+	}
+}
+
+func TestComposeChassisList(t *testing.T) {
+	records := [][]age.Entity{
+		createChassisRecord("rack01", "chassis01", "CPU", map[string]any{"deviceID": "cpu01", "type": "CPU"}),
+		createChassisRecord("rack01", "chassis01", "CXLswitch", map[string]any{"id": "sw01"}),
+		createChassisRecord("", "chassis02", "dummy", map[string]any{}),
+		createChassisRecord("", "", "dummy", map[string]any{}),
+	}
+	tests := []struct {
+		name        string
+		idx         ChassisRecordIndex
+		wantRackIDs []string
+	}{
+		{
+			"Normal case: The rack of each chassis is held",
+			chassisRecordIndex,
+			[]string{"rack01", ""},
+		},
+		{
+			"Normal case: The rack of each chassis is not held",
+			ChassisRecordIndex{-1, 1, 2, 3, 4, 5, 6},
+			[]string{"", ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ComposeChassisList(records, tt.idx, false)
+			if len(got) != 2 {
+				t.Fatalf("ComposeChassisList() length = %v, want 2", len(got))
+			}
+			gotRackIDs := []string{got[0].RackID, got[1].RackID}
+			if !reflect.DeepEqual(gotRackIDs, tt.wantRackIDs) {
+				t.Errorf("ComposeChassisList() rackIDs = %v, want %v", gotRackIDs, tt.wantRackIDs)
+			}
+			if len(got[0].Resources.Resources) != 1 || len(got[0].CXLSwitches.CXLSwitches) != 1 {
+				t.Errorf("ComposeChassisList() chassis01 = %v", got[0])
+			}
+			if len(got[1].Resources.Resources) != 0 || len(got[1].CXLSwitches.CXLSwitches) != 0 {
+				t.Errorf("ComposeChassisList() chassis02 = %v", got[1])
+			}
+		})
+	}
+}
+
+func Test_compareByChassis(t *testing.T) {
+	tests := []struct {
+		name    string
+		records [][]age.Entity
+		want    bool
+	}{
+		{
+			"Normal case: Compares the first sort key, chassis id",
+			[][]age.Entity{
+				createChassisRecord("", "chassis01", "CPU", map[string]any{"deviceID": "cpu02", "type": "CPU"}),
+				createChassisRecord("", "chassis02", "CPU", map[string]any{"deviceID": "cpu01", "type": "CPU"}),
+			},
+			true,
+		},
+		{
+			"Normal case: With the same chassis, compares the second sort key, type",
+			[][]age.Entity{
+				createChassisRecord("", "chassis01", "Memory", map[string]any{"deviceID": "mem01", "type": "memory"}),
+				createChassisRecord("", "chassis01", "CPU", map[string]any{"deviceID": "cpu01", "type": "CPU"}),
+			},
+			false,
+		},
+		{
+			"Normal case: With the same chassis and type, compares the third sort key, deviceID",
+			[][]age.Entity{
+				createChassisRecord("", "chassis01", "CPU", map[string]any{"deviceID": "cpu01", "type": "CPU"}),
+				createChassisRecord("", "chassis01", "CPU", map[string]any{"deviceID": "cpu02", "type": "CPU"}),
+			},
+			true,
+		},
+		{
+			"Normal case: With the same chassis and device, compares the fourth sort key, id",
+			[][]age.Entity{
+				createChassisRecord("", "chassis01", "CXLswitch", map[string]any{"id": "sw02"}),
+				createChassisRecord("", "chassis01", "CXLswitch", map[string]any{"id": "sw01"}),
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compareByChassis(tt.records, getChassisIndexChassis, getChassisIndexDevice, 0, 1); got != tt.want {
+				t.Errorf("compareByChassis() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFitsInRack(t *testing.T) {
+	rack := map[string]any{"id": "rack-01", "height": int64(10)}
+	attached := []map[string]any{
+		{"id": "chassis-02", "unitPosition": int64(1), "height": int64(2)},
+		{"id": "chassis-03", "unitPosition": int64(6)},
+		{"id": "chassis-04"},
+	}
+	tests := []struct {
+		name         string
+		rack         map[string]any
+		chassis      map[string]any
+		unitPosition int64
+		want         bool
+	}{
+		{"Normal case: The chassis fits between the other chassis", rack, map[string]any{"height": float64(3)}, 3, true},
+		{"Normal case: A chassis without a height occupies a single unit", rack, map[string]any{}, 10, true},
+		{"Normal case: A rack without a height has no upper limit", map[string]any{"id": "rack-01"}, map[string]any{}, 100, true},
+		{"Error case: The chassis overlaps with the chassis below", rack, map[string]any{}, 2, false},
+		{"Error case: The chassis overlaps with the chassis above", rack, map[string]any{"height": int64(4)}, 3, false},
+		{"Error case: The chassis exceeds the height of the rack", rack, map[string]any{"height": int64(2)}, 10, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fitsInRack(tt.rack, attached, tt.chassis, tt.unitPosition); got != tt.want {
+				t.Errorf("fitsInRack() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPropInt64(t *testing.T) {
+	props := map[string]any{"int64": int64(2), "float": float64(3), "fraction": 1.5, "string": "4"}
+	tests := []struct {
+		name string
+		key  string
+		want int64
+	}{
+		{"Normal case: int64", "int64", 2},
+		{"Normal case: integral float64", "float", 3},
+		{"Normal case: fractional float64 returns the default value", "fraction", -1},
+		{"Normal case: string returns the default value", "string", -1},
+		{"Normal case: missing key returns the default value", "missing", -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := propInt64(props, tt.key, -1); got != tt.want {
+				t.Errorf("propInt64() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package chassis_repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+	"github.com/project-cdim/configuration-manager/model"
+	cmapi_repository "github.com/project-cdim/configuration-manager/repository"
+)
+
+// DB_Chassis is the label of the Chassis vertex.
+const DB_Chassis = "Chassis"
+
+const (
+	mergeChassis = `
+		MERGE (vch:Chassis {id: '%s'})
+		SET vch = %s
+`
+	mergeChassisColumnCount = 0
+)
+
+// CreateChassisRepository is a repository for creating chassis.
+type CreateChassisRepository struct{}
+
+// NewCreateChassisRepository creates a new instance of CreateChassisRepository.
+// It returns an empty CreateChassisRepository struct.
+func NewCreateChassisRepository() CreateChassisRepository {
+	return CreateChassisRepository{}
+}
+
+// Set creates a new chassis in the database using the provided CmDb and CmModelMapper.
+// If the model has no ID, a unique ID is generated. If a chassis with the specified ID already exists,
+// it returns an error wrapping cmapi_repository.ErrAlreadyExists.
+//
+// Parameters:
+//
+//	cmdb - The database connection object.
+//	model  - The model mapper to convert the model to an object.
+//
+// Returns:
+//
+//	A map representing the created chassis object, or an error if the operation fails.
+func (ccr *CreateChassisRepository) Set(cmdb database.CmDb, model model.CmModelMapper) (map[string]any, error) {
+	chassisObject := model.ToObject()
+	if chassisObject == nil {
+		return nil, errors.New("CreateChassisRepository.Set : invalid chassis")
+	}
+
+	id, _ := chassisObject["id"].(string)
+	if len(id) == 0 {
+		generated, err := cmapi_repository.GenerateVertexID(cmdb, DB_Chassis)
+		if err != nil {
+			return nil, err
+		}
+		id = generated
+		chassisObject["id"] = id
+	} else {
+		exists, err := cmapi_repository.ExistsVertex(cmdb, DB_Chassis, id)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, fmt.Errorf("%w: chassis(%s)", cmapi_repository.ErrAlreadyExists, id)
+		}
+	}
+
+	if err := mergeChassisVertex(cmdb, id, chassisObject); err != nil {
+		return nil, err
+	}
+
+	return chassisObject, nil
+}
+
+// mergeChassisVertex replaces the properties of the Chassis vertex with the specified id, creating it if necessary.
+func mergeChassisVertex(cmdb database.CmDb, id string, chassisObject map[string]any) error {
+	property, err := common.Map2CypherProperty(chassisObject)
+	if err != nil {
+		return err
+	}
+
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s", mergeChassis, id, property))
+	_, err = cmdb.CmDbExecCypher(mergeChassisColumnCount, mergeChassis, id, property)
+	return err
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package chassis_repository
+
+import (
+	"fmt"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+)
+
+// deleteChassis is cypher query to delete a chassis with the Attach edge from the rack.
+const deleteChassis = `
+	MATCH (vch:Chassis {id: '%s'})
+	DETACH DELETE vch
+`
+
+// DeleteChassisRepository represents a repository for deleting a chassis.
+type DeleteChassisRepository struct {
+	ChassisID string
+}
+
+// NewDeleteChassisRepository creates a new instance of DeleteChassisRepository with the specified chassisID.
+func NewDeleteChassisRepository(chassisID string) DeleteChassisRepository {
+	return DeleteChassisRepository{
+		ChassisID: chassisID,
+	}
+}
+
+// Delete removes the chassis and its edges from the database.
+// The caller is responsible for confirming that no devices are mounted in the chassis.
+//
+// Parameters:
+//
+//	cmdb - An instance of the CmDb database interface.
+//
+// Returns:
+//
+//	error - An error object if the deletion fails, otherwise nil.
+func (dcr *DeleteChassisRepository) Delete(cmdb database.CmDb) error {
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s", deleteChassis, dcr.ChassisID))
+	_, err := cmdb.CmDbExecCypher(0, deleteChassis, dcr.ChassisID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
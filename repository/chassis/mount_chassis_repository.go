@@ -0,0 +1,197 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package chassis_repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+	"github.com/project-cdim/configuration-manager/model"
+	cmapi_repository "github.com/project-cdim/configuration-manager/repository"
+)
+
+// Cypher patterns of the devices that can be mounted in a chassis.
+// A resource is identified by its deviceID and a CXL switch by its id.
+const (
+	mountTargetResource  = `(vrs {deviceID: '%s'})`
+	mountTargetCXLSwitch = `(vrs:CXLswitch {id: '%s'})`
+)
+
+// countMountTarget is cypher query to count the devices that match a mount target pattern.
+const countMountTarget = `
+	MATCH %s
+	RETURN COUNT(vrs)
+`
+
+// deleteMountEdge is cypher query to unmount a device from any chassis.
+const deleteMountEdge = `
+	MATCH (:Chassis)-[emt:Mount]->%s
+	DELETE emt
+`
+
+// createMountEdge is cypher query to mount a device in a chassis.
+const createMountEdge = `
+	MATCH (vch:Chassis {id: '%s'}), %s
+	CREATE (vch)-[:Mount %s]->(vrs)
+`
+
+// countChassisMountEdge is cypher query to count the Mount edges from a chassis to a device.
+const countChassisMountEdge = `
+	MATCH (:Chassis {id: '%s'})-[emt:Mount]->%s
+	RETURN COUNT(emt)
+`
+
+// deleteChassisMountEdge is cypher query to unmount a device from a chassis.
+const deleteChassisMountEdge = `
+	MATCH (:Chassis {id: '%s'})-[emt:Mount]->%s
+	DELETE emt
+`
+
+// mountTarget returns the Cypher pattern of the device to be mounted.
+func mountTarget(mount map[string]any) (string, string) {
+	if cxlSwitchID, ok := mount["cxlSwitchID"].(string); ok {
+		return fmt.Sprintf(mountTargetCXLSwitch, cxlSwitchID), cxlSwitchID
+	}
+	deviceID, _ := mount["deviceID"].(string)
+	return fmt.Sprintf(mountTargetResource, deviceID), deviceID
+}
+
+// MountChassisRepository is a repository for mounting resources and CXL switches in a chassis.
+type MountChassisRepository struct{}
+
+// NewMountChassisRepository creates a new instance of MountChassisRepository.
+func NewMountChassisRepository() MountChassisRepository {
+	return MountChassisRepository{}
+}
+
+// Set mounts the devices given by the model (chassis_model.MountList) in the chassis.
+// A device that is already mounted in another chassis is moved, and the slot of a device that is
+// already mounted in the chassis is replaced.
+// It returns an error wrapping cmapi_repository.ErrNotFound if the chassis or any of the devices does not exist,
+// in which case nothing is mounted because the transaction is rolled back.
+//
+// Parameters:
+//
+//	cmdb - The database connection object.
+//	model  - The model mapper to convert the mount list to an object.
+//
+// Returns:
+//
+//	A map representing the mounted devices, or an error if the operation fails.
+func (mcr *MountChassisRepository) Set(cmdb database.CmDb, model model.CmModelMapper) (map[string]any, error) {
+	mountList := model.ToObject()
+	if mountList == nil {
+		return nil, errors.New("MountChassisRepository.Set : invalid mount list")
+	}
+	chassisID := mountList["chassisID"].(string)
+
+	exists, err := cmapi_repository.ExistsVertex(cmdb, DB_Chassis, chassisID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: chassis(%s)", cmapi_repository.ErrNotFound, chassisID)
+	}
+
+	for _, mount := range mountList["mounts"].([]map[string]any) {
+		target, id := mountTarget(mount)
+
+		cnt, err := cmapi_repository.CountRecords(cmdb, countMountTarget, target)
+		if err != nil {
+			return nil, err
+		}
+		if cnt == 0 {
+			return nil, fmt.Errorf("%w: device(%s)", cmapi_repository.ErrNotFound, id)
+		}
+
+		common.Log.Debug(fmt.Sprintf("query: %s, param1: %s", deleteMountEdge, target))
+		if _, err := cmdb.CmDbExecCypher(0, deleteMountEdge, target); err != nil {
+			return nil, err
+		}
+
+		edgeProperty := map[string]any{}
+		if slot, ok := mount["slot"]; ok {
+			edgeProperty["slot"] = slot
+		}
+		property, err := common.Map2CypherProperty(edgeProperty)
+		if err != nil {
+			return nil, err
+		}
+
+		common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s, param3: %s", createMountEdge, chassisID, target, property))
+		if _, err := cmdb.CmDbExecCypher(0, createMountEdge, chassisID, target, property); err != nil {
+			return nil, err
+		}
+	}
+
+	return mountList, nil
+}
+
+// UnmountChassisRepository is a repository for unmounting a device from a chassis.
+// DeviceID is the deviceID of a resource or the id of a CXL switch.
+type UnmountChassisRepository struct {
+	ChassisID string
+	DeviceID  string
+}
+
+// NewUnmountChassisRepository creates a new instance of UnmountChassisRepository.
+func NewUnmountChassisRepository(chassisID string, deviceID string) UnmountChassisRepository {
+	return UnmountChassisRepository{
+		ChassisID: chassisID,
+		DeviceID:  deviceID,
+	}
+}
+
+// Delete unmounts the device from the chassis.
+// It returns an error wrapping cmapi_repository.ErrNotFound if the device is not mounted in the chassis.
+//
+// Parameters:
+//
+//	cmdb - An instance of the CmDb database interface.
+//
+// Returns:
+//
+//	error - An error object if the operation fails, otherwise nil.
+func (ucr *UnmountChassisRepository) Delete(cmdb database.CmDb) error {
+	targets := []string{
+		fmt.Sprintf(mountTargetResource, ucr.DeviceID),
+		fmt.Sprintf(mountTargetCXLSwitch, ucr.DeviceID),
+	}
+
+	total := 0
+	for _, target := range targets {
+		cnt, err := cmapi_repository.CountRecords(cmdb, countChassisMountEdge, ucr.ChassisID, target)
+		if err != nil {
+			return err
+		}
+		if cnt == 0 {
+			continue
+		}
+		total += cnt
+
+		common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s", deleteChassisMountEdge, ucr.ChassisID, target))
+		if _, err := cmdb.CmDbExecCypher(0, deleteChassisMountEdge, ucr.ChassisID, target); err != nil {
+			return err
+		}
+	}
+
+	if total == 0 {
+		return fmt.Errorf("%w: device(%s) in chassis(%s)", cmapi_repository.ErrNotFound, ucr.DeviceID, ucr.ChassisID)
+	}
+
+	return nil
+}
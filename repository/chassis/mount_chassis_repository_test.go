@@ -0,0 +1,62 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package chassis_repository
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMountTarget(t *testing.T) {
+	tests := []struct {
+		name        string
+		mount       map[string]any
+		wantPattern string
+		wantID      string
+	}{
+		{"Normal case: A resource is matched by deviceID", map[string]any{"deviceID": "cpu0"}, "(vrs {deviceID: 'cpu0'})", "cpu0"},
+		{"Normal case: A CXL switch is matched by id", map[string]any{"cxlSwitchID": "switch0", "slot": "1"}, "(vrs:CXLswitch {id: 'switch0'})", "switch0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotPattern, gotID := mountTarget(tt.mount)
+			if gotPattern != tt.wantPattern || gotID != tt.wantID {
+				t.Errorf("mountTarget() = (%v, %v), want (%v, %v)", gotPattern, gotID, tt.wantPattern, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestNewMountChassisRepository(t *testing.T) {
+	want := MountChassisRepository{}
+	if got := NewMountChassisRepository(); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewMountChassisRepository() = %v, want %v", got, want)
+	}
+}
+
+func TestMountChassisRepository_Set(t *testing.T) {
+	t.Skip("not test")
+}
+
+func TestNewUnmountChassisRepository(t *testing.T) {
+	want := UnmountChassisRepository{"chassis-01", "cpu0"}
+	if got := NewUnmountChassisRepository("chassis-01", "cpu0"); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewUnmountChassisRepository() = %v, want %v", got, want)
+	}
+}
+
+func TestUnmountChassisRepository_Delete(t *testing.T) {
+	t.Skip("not test")
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package chassis_repository
+
+import (
+	"errors"
+
+	"github.com/project-cdim/configuration-manager/database"
+	"github.com/project-cdim/configuration-manager/model"
+)
+
+// UpdateChassisRepository is a repository that handles the update operations for chassis.
+type UpdateChassisRepository struct{}
+
+// NewUpdateChassisRepository creates a new instance of UpdateChassisRepository.
+// It returns an empty UpdateChassisRepository struct.
+func NewUpdateChassisRepository() UpdateChassisRepository {
+	return UpdateChassisRepository{}
+}
+
+// Set replaces the properties of the chassis in the database with the object converted from the model.
+// The Attach and Mount edges of the chassis are kept.
+//
+// Parameters:
+//
+//	cmdb - The database connection object.
+//	model  - The model mapper to convert the chassis model to an object.
+//
+// Returns:
+//
+//	A map representing the updated chassis object and an error if any occurred during the process.
+func (ucr *UpdateChassisRepository) Set(cmdb database.CmDb, model model.CmModelMapper) (map[string]any, error) {
+	chassisObject := model.ToObject()
+	if chassisObject == nil {
+		return nil, errors.New("UpdateChassisRepository.Set : invalid chassis")
+	}
+
+	id, _ := chassisObject["id"].(string)
+	if err := mergeChassisVertex(cmdb, id, chassisObject); err != nil {
+		return nil, err
+	}
+
+	return chassisObject, nil
+}
@@ -15,6 +15,7 @@
 package repository
 
 import (
+	"database/sql"
 	"fmt"
 
 	"github.com/project-cdim/configuration-manager/common"
@@ -120,6 +121,22 @@ func RelayFind(repo RepositoryFinder, filter filter.CmFilter) (map[string]any, e
 //   - map[string]any: The result of setting the configuration model.
 //   - error: An error if any occurred during the process.
 func RelaySet(repo RepositorySetter, model model.CmModelMapper) (map[string]any, error) {
+	return RelaySetWithHook(repo, model, nil)
+}
+
+// RelaySetWithHook works in the same way as RelaySet, and additionally calls afterSet with the transaction and the result
+// of setting the configuration model before committing, so that other data such as events can be written atomically with the change.
+// If afterSet returns an error, the transaction is rolled back. afterSet may be nil.
+//
+// Parameters:
+//   - repo: RepositorySetter interface for setting the configuration model.
+//   - model: model.CmModelMapper containing the configuration data.
+//   - afterSet: Function called in the transaction after the configuration model is set.
+//
+// Returns:
+//   - map[string]any: The result of setting the configuration model.
+//   - error: An error if any occurred during the process.
+func RelaySetWithHook(repo RepositorySetter, model model.CmModelMapper, afterSet func(tx *sql.Tx, res map[string]any) error) (map[string]any, error) {
 	cmdb := database.NewCmDb()
 
 	err := cmdb.CmDbBeginTransaction()
@@ -134,6 +151,14 @@ func RelaySet(repo RepositorySetter, model model.CmModelMapper) (map[string]any,
 		return nil, err
 	}
 
+	if afterSet != nil {
+		err = afterSet(cmdb.Tx, res)
+		if err != nil {
+			cmdb.CmDbRollback()
+			return nil, err
+		}
+	}
+
 	err = cmdb.CmDbCommit()
 	if err != nil {
 		return nil, err
@@ -151,6 +176,19 @@ func RelaySet(repo RepositorySetter, model model.CmModelMapper) (map[string]any,
 // Returns:
 //   - error: An error if any operation fails during the process, including starting, committing, or rolling back the transaction. Returns nil if the deletion is successful.
 func RelayDelete(repo RepositoryDeleter) error {
+	return RelayDeleteWithHook(repo, nil)
+}
+
+// RelayDeleteWithHook works in the same way as RelayDelete, and additionally calls afterDelete with the transaction before committing.
+// If afterDelete returns an error, the transaction is rolled back. afterDelete may be nil.
+//
+// Parameters:
+//   - repo: A RepositoryDeleter interface that provides the Delete method.
+//   - afterDelete: Function called in the transaction after the deletion.
+//
+// Returns:
+//   - error: An error if any operation fails during the process. Returns nil if the deletion is successful.
+func RelayDeleteWithHook(repo RepositoryDeleter, afterDelete func(tx *sql.Tx) error) error {
 	cmdb := database.NewCmDb()
 
 	err := cmdb.CmDbBeginTransaction()
@@ -165,6 +203,14 @@ func RelayDelete(repo RepositoryDeleter) error {
 		return err
 	}
 
+	if afterDelete != nil {
+		err = afterDelete(cmdb.Tx)
+		if err != nil {
+			cmdb.CmDbRollback()
+			return err
+		}
+	}
+
 	err = cmdb.CmDbCommit()
 	if err != nil {
 		return err
@@ -33,6 +33,18 @@ func TestRelaySet(t *testing.T) {
 	t.Skip("not test")
 }
 
+func TestRelaySetWithHook(t *testing.T) {
+	t.Skip("not test")
+}
+
+func TestRelayDelete(t *testing.T) {
+	t.Skip("not test")
+}
+
+func TestRelayDeleteWithHook(t *testing.T) {
+	t.Skip("not test")
+}
+
 func Test_ExtractEntityString(t *testing.T) {
 	type args struct {
 		entity *age.SimpleEntity
@@ -0,0 +1,103 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+
+	"github.com/apache/age/drivers/golang/age"
+	"github.com/google/uuid"
+)
+
+// ErrNotFound is returned by a repository when a vertex that the operation refers to does not exist.
+var ErrNotFound = errors.New("the target vertex did not exist")
+
+// ErrAlreadyExists is returned by a repository when a vertex with the specified ID already exists.
+var ErrAlreadyExists = errors.New("the vertex already exists")
+
+// countVertexByID is cypher query to count the vertices of a label with the specified id.
+const countVertexByID = `
+	MATCH (v:%s {id: '%s'})
+	RETURN COUNT(v)
+`
+
+// CountRecords executes a Cypher query that returns a single count column and returns the count.
+//
+// Parameters:
+//   - cmdb: An instance of the CmDb database.
+//   - query: The Cypher query whose result is a single row with a single count column.
+//   - params: The parameters to be embedded in the query.
+//
+// Returns:
+//   - int: The count, or 0 if the query returned no rows.
+//   - error: An error if the query failed.
+func CountRecords(cmdb database.CmDb, query string, params ...any) (int, error) {
+	common.Log.Debug(fmt.Sprintf("query: %s, params: %v", query, params))
+	cypherCursor, err := cmdb.CmDbExecCypher(1, query, params...)
+	if err != nil {
+		return 0, err
+	}
+	defer cypherCursor.Close()
+
+	cnt := 0
+	for cypherCursor.Next() {
+		row, err := cypherCursor.GetRow()
+		if err != nil {
+			common.Log.Error(err.Error())
+			return 0, err
+		}
+		cnt += int(row[0].(*age.SimpleEntity).AsInt64())
+	}
+
+	return cnt, nil
+}
+
+// ExistsVertex reports whether a vertex of the label with the specified id exists.
+func ExistsVertex(cmdb database.CmDb, label string, id string) (bool, error) {
+	cnt, err := CountRecords(cmdb, countVertexByID, label, id)
+	if err != nil {
+		return false, err
+	}
+	return cnt > 0, nil
+}
+
+// GenerateVertexID generates a unique ID for a vertex of the label using UUID version 7.
+// It attempts to generate a unique ID up to 10 times, checking for duplicates in the database.
+//
+// Parameters:
+//   - cmdb: An instance of the CmDb database.
+//   - label: The label of the vertex to be created.
+//
+// Returns:
+//   - A unique ID as a string, or an error if a unique ID could not be generated.
+func GenerateVertexID(cmdb database.CmDb, label string) (string, error) {
+	for i := 0; i < 10; i++ {
+		id, _ := uuid.NewV7()
+
+		exists, err := ExistsVertex(cmdb, label, id.String())
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return id.String(), nil
+		}
+	}
+
+	return "", fmt.Errorf("GenerateVertexID : An ID was generated in UUID format for %s, but duplicates continued to occur", label)
+}
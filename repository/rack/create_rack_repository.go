@@ -0,0 +1,100 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package rack_repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+	"github.com/project-cdim/configuration-manager/model"
+	cmapi_repository "github.com/project-cdim/configuration-manager/repository"
+)
+
+// DB_Rack is the label of the Rack vertex.
+const DB_Rack = "Rack"
+
+const (
+	mergeRack = `
+		MERGE (vrc:Rack {id: '%s'})
+		SET vrc = %s
+`
+	mergeRackColumnCount = 0
+)
+
+// CreateRackRepository is a repository for creating racks.
+type CreateRackRepository struct{}
+
+// NewCreateRackRepository creates a new instance of CreateRackRepository.
+// It returns an empty CreateRackRepository struct.
+func NewCreateRackRepository() CreateRackRepository {
+	return CreateRackRepository{}
+}
+
+// Set creates a new rack in the database using the provided CmDb and CmModelMapper.
+// If the model has no ID, a unique ID is generated. If a rack with the specified ID already exists,
+// it returns an error wrapping cmapi_repository.ErrAlreadyExists.
+//
+// Parameters:
+//
+//	cmdb - The database connection object.
+//	model  - The model mapper to convert the model to an object.
+//
+// Returns:
+//
+//	A map representing the created rack object, or an error if the operation fails.
+func (crr *CreateRackRepository) Set(cmdb database.CmDb, model model.CmModelMapper) (map[string]any, error) {
+	rackObject := model.ToObject()
+	if rackObject == nil {
+		return nil, errors.New("CreateRackRepository.Set : invalid rack")
+	}
+
+	id, _ := rackObject["id"].(string)
+	if len(id) == 0 {
+		generated, err := cmapi_repository.GenerateVertexID(cmdb, DB_Rack)
+		if err != nil {
+			return nil, err
+		}
+		id = generated
+		rackObject["id"] = id
+	} else {
+		exists, err := cmapi_repository.ExistsVertex(cmdb, DB_Rack, id)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, fmt.Errorf("%w: rack(%s)", cmapi_repository.ErrAlreadyExists, id)
+		}
+	}
+
+	if err := mergeRackVertex(cmdb, id, rackObject); err != nil {
+		return nil, err
+	}
+
+	return rackObject, nil
+}
+
+// mergeRackVertex replaces the properties of the Rack vertex with the specified id, creating it if necessary.
+func mergeRackVertex(cmdb database.CmDb, id string, rackObject map[string]any) error {
+	property, err := common.Map2CypherProperty(rackObject)
+	if err != nil {
+		return err
+	}
+
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s", mergeRack, id, property))
+	_, err = cmdb.CmDbExecCypher(mergeRackColumnCount, mergeRack, id, property)
+	return err
+}
@@ -0,0 +1,59 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package rack_repository
+
+import (
+	"fmt"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+)
+
+const deleteRack = `
+	MATCH (vrc:Rack {id: '%s'})
+	DELETE vrc
+`
+
+// DeleteRackRepository represents a repository for deleting a rack.
+type DeleteRackRepository struct {
+	RackID string
+}
+
+// NewDeleteRackRepository creates a new instance of DeleteRackRepository with the specified rackID.
+func NewDeleteRackRepository(rackID string) DeleteRackRepository {
+	return DeleteRackRepository{
+		RackID: rackID,
+	}
+}
+
+// Delete removes the rack from the database.
+// The caller is responsible for confirming that no chassis are attached to the rack.
+//
+// Parameters:
+//
+//	cmdb - An instance of the CmDb database interface.
+//
+// Returns:
+//
+//	error - An error object if the deletion fails, otherwise nil.
+func (drr *DeleteRackRepository) Delete(cmdb database.CmDb) error {
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s", deleteRack, drr.RackID))
+	_, err := cmdb.CmDbExecCypher(0, deleteRack, drr.RackID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
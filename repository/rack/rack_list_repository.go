@@ -0,0 +1,97 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package rack_repository
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+	"github.com/project-cdim/configuration-manager/filter"
+	rack_model "github.com/project-cdim/configuration-manager/model/rack"
+	chassis_repository "github.com/project-cdim/configuration-manager/repository/chassis"
+
+	"github.com/apache/age/drivers/golang/age"
+)
+
+// getRackList is cypher query to retrieve all racks.
+// The columns are the same as getRack.
+const getRackList string = `
+	MATCH (vrc:Rack)
+	OPTIONAL MATCH (vrc)-[:Attach]->(vch)
+	OPTIONAL MATCH (vch)-[:Mount]->(vrs)
+	OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
+	OPTIONAL MATCH (vnd)-[:Compose]->(vrs)
+	OPTIONAL MATCH (vrs)-[:Have]->(van:Annotation)
+	OPTIONAL MATCH (vrs)-[endt:NotDetected]->(:NotDetectedDevice)
+	WITH vrc, vch, vrs, van, vrsg, vnd, endt
+	RETURN
+		vrc,
+		CASE WHEN vch IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE vch END,
+		CASE WHEN vrs IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE vrs END,
+		CASE WHEN van IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE van END,
+		COLLECT(vrsg.id),
+		COLLECT(vnd.id),
+		CASE WHEN endt IS NULL THEN true ELSE false END
+`
+
+// RackListRepository is a repository structure for getting all racks.
+type RackListRepository struct{}
+
+// NewRackListRepository creates and returns a RackListRepository object.
+func NewRackListRepository() RackListRepository {
+	return RackListRepository{}
+}
+
+// FindList retrieves all racks sorted by ID. Each rack is returned with its properties,
+// the number of chassis attached to it and the summary of the devices mounted in the chassis.
+// The filter is applied to each chassis in the same way as RackRepository.
+func (rlr *RackListRepository) FindList(cmdb database.CmDb, filter filter.CmFilter) ([]map[string]any, error) {
+	common.Log.Debug(fmt.Sprintf("query: %s", getRackList))
+	cypherCursor, err := cmdb.CmDbExecCypher(getRackColumnCount, getRackList)
+	if err != nil {
+		return nil, err
+	}
+	defer cypherCursor.Close()
+
+	records := [][]age.Entity{}
+	for cypherCursor.Next() {
+		row, err := cypherCursor.GetRow()
+		if err != nil {
+			common.Log.Error(err.Error())
+			return nil, err
+		}
+		records = append(records, row)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return compareByRackList(records, getRackIndexRack, getRackIndexChassis, getRackIndexDevice, i, j)
+	})
+
+	rackList := rack_model.NewRackList()
+	for _, rackRecords := range splitRecordsByRack(records, getRackIndexRack) {
+		rack := rack_model.NewRack()
+		rack.Properties = rackRecords[0][getRackIndexRack].(*age.Vertex).Props()
+		for _, chassis := range chassis_repository.ComposeChassisList(rackRecords, rackRecordIndex, false) {
+			if filter.FilterByCondition(chassis.ToObject()) {
+				rack.Chassis.Chassis = append(rack.Chassis.Chassis, chassis)
+			}
+		}
+		rackList.Racks = append(rackList.Racks, rack)
+	}
+
+	return rackList.ToObject(), nil
+}
@@ -21,11 +21,8 @@ import (
 	"github.com/project-cdim/configuration-manager/common"
 	"github.com/project-cdim/configuration-manager/database"
 	"github.com/project-cdim/configuration-manager/filter"
-	chassis_model "github.com/project-cdim/configuration-manager/model/chassis"
-	cxlswitch_model "github.com/project-cdim/configuration-manager/model/cxlswitch"
 	rack_model "github.com/project-cdim/configuration-manager/model/rack"
-	resource_model "github.com/project-cdim/configuration-manager/model/resource"
-	resource_repository "github.com/project-cdim/configuration-manager/repository/resource"
+	chassis_repository "github.com/project-cdim/configuration-manager/repository/chassis"
 
 	"github.com/apache/age/drivers/golang/age"
 )
@@ -61,6 +58,18 @@ const (
 	getRackIndexNotDetected
 )
 
+// rackRecordIndex is the column positions of the getRack and getRackList query results.
+// The rack is not held in each chassis because the chassis are nested in the rack.
+var rackRecordIndex = chassis_repository.ChassisRecordIndex{
+	Rack:             -1,
+	Chassis:          getRackIndexChassis,
+	Device:           getRackIndexDevice,
+	Annotation:       getRackIndexAnnotation,
+	ResourceGroupIDs: getRackIndexResourceGroupIDs,
+	NodeIDs:          getRackIndexNodeIDs,
+	NotDetected:      getRackIndexNotDetected,
+}
+
 // RackRepository is a repository structure for getting a specific rack.
 type RackRepository struct {
 	RackID string
@@ -103,64 +112,13 @@ func (rr *RackRepository) Find(cmdb database.CmDb, filter filter.CmFilter) (map[
 	})
 
 	rack := rack_model.NewRack()
-	chassis := chassis_model.NewChassis()
-	resources := resource_model.NewResourceList()
-	cxlswitches := cxlswitch_model.NewCXLSwitchList()
-	preChassisID := ""
-	for _, row := range records {
-		if len(rack.Properties) <= 0 {
-			rack.Properties = row[getRackIndexRack].(*age.Vertex).Props()
-		}
-
-		chassisWork := row[getRackIndexChassis].(*age.Vertex).Props()
-		chassisID, ok := chassisWork["id"].(string)
-		if !ok {
-			continue
-		}
-
-		if len(preChassisID) > 0 && preChassisID != chassisID {
-			chassis.Resources = resources
-			chassis.CXLSwitches = cxlswitches
-			if filter.FilterByCondition(chassis.ToObject()) {
-				rack.Chassis.Chassis = append(rack.Chassis.Chassis, chassis)
-			}
-			chassis = chassis_model.NewChassis()
-			resources = resource_model.NewResourceList()
-			cxlswitches = cxlswitch_model.NewCXLSwitchList()
-		}
-
-		chassis.Properties = chassisWork
-
-		deviceVertex := row[getRackIndexDevice].(*age.Vertex)
-		if len(deviceVertex.Props()) > 0 {
-			if deviceVertex.Label() == "CXLswitch" {
-				cxlswitch := cxlswitch_model.NewCXLSwitch()
-				cxlswitch.Properties = deviceVertex.Props()
-				if cxlswitch.Validate() {
-					cxlswitches.CXLSwitches = append(cxlswitches.CXLSwitches, cxlswitch)
-				}
-			} else {
-				resource := resource_repository.ComposeResource(
-					deviceVertex,
-					row[getRackIndexAnnotation].(*age.Vertex),
-					row[getRackIndexResourceGroupIDs].(*age.SimpleEntity),
-					row[getRackIndexNodeIDs].(*age.SimpleEntity),
-					row[getRackIndexNotDetected].(*age.SimpleEntity).AsBool(),
-					rr.Detail,
-				)
-				if resource.Validate() {
-					resources.Resources = append(resources.Resources, resource)
-				}
-			}
-		}
-
-		preChassisID = chassisID
+	if len(records) > 0 {
+		rack.Properties = records[0][getRackIndexRack].(*age.Vertex).Props()
 	}
-
-	chassis.Resources = resources
-	chassis.CXLSwitches = cxlswitches
-	if filter.FilterByCondition(chassis.ToObject()) {
-		rack.Chassis.Chassis = append(rack.Chassis.Chassis, chassis)
+	for _, chassis := range chassis_repository.ComposeChassisList(records, rackRecordIndex, rr.Detail) {
+		if filter.FilterByCondition(chassis.ToObject()) {
+			rack.Chassis.Chassis = append(rack.Chassis.Chassis, chassis)
+		}
 	}
 
 	return rack.ToObject(), nil
@@ -21,7 +21,8 @@ import (
 )
 
 // compareByRack sorts the contents of a Cypher query execution result based on the following criteria:
-// - First sort key: Rack > Chassis' unitPosition (numeric, -1 if absent, ascending order)
+// - First sort key: Rack > Chassis' unitPosition (numeric, -1 if absent, ascending order),
+//   and Rack > Chassis' id (string, empty string if absent, ascending order) to keep the records of the same chassis consecutive
 // - Second sort key: Rack > Chassis > Device's type (string, empty string if absent, ascending order)
 // - Third sort key: Rack > Chassis > Device's deviceID (string, empty string if absent, ascending order)
 // - Fourth sort key: Rack > Chassis > Device's id (string, empty string if absent, ascending order)
@@ -47,6 +48,12 @@ func compareByRack(records [][]age.Entity, chassisIdx, deviceIdx, i, j int) bool
 		return position1 < position2
 	}
 
+	chassisID1, _ := chassisProp1["id"].(string)
+	chassisID2, _ := chassisProp2["id"].(string)
+	if chassisID1 != chassisID2 {
+		return strings.Compare(chassisID1, chassisID2) < 0
+	}
+
 	devProp1 := row1[deviceIdx].(*age.Vertex).Props()
 	devProp2 := row2[deviceIdx].(*age.Vertex).Props()
 
@@ -90,3 +97,31 @@ func compareByRack(records [][]age.Entity, chassisIdx, deviceIdx, i, j int) bool
 
 	return strings.Compare(id1, id2) < 0
 }
+
+// compareByRackList sorts the contents of a Cypher query execution result of multiple racks.
+// The records are sorted by the rack's id first, and then by the same criteria as compareByRack.
+func compareByRackList(records [][]age.Entity, rackIdx, chassisIdx, deviceIdx, i, j int) bool {
+	rackID1, _ := records[i][rackIdx].(*age.Vertex).Props()["id"].(string)
+	rackID2, _ := records[j][rackIdx].(*age.Vertex).Props()["id"].(string)
+	if rackID1 != rackID2 {
+		return strings.Compare(rackID1, rackID2) < 0
+	}
+
+	return compareByRack(records, chassisIdx, deviceIdx, i, j)
+}
+
+// splitRecordsByRack splits the sorted records into groups of consecutive records of the same rack.
+func splitRecordsByRack(records [][]age.Entity, rackIdx int) [][][]age.Entity {
+	res := [][][]age.Entity{}
+	preRackID := ""
+	for _, row := range records {
+		rackID, _ := row[rackIdx].(*age.Vertex).Props()["id"].(string)
+		if len(res) == 0 || rackID != preRackID {
+			res = append(res, [][]age.Entity{})
+			preRackID = rackID
+		}
+		res[len(res)-1] = append(res[len(res)-1], row)
+	}
+
+	return res
+}
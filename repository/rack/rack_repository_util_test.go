@@ -276,3 +276,71 @@ func Test_compareByRack(t *testing.T) {
 		})
 	}
 }
+
+func createRackRecord(rackID string, chassisID string, unitPosition int64, deviceID string) []age.Entity {
+	return []age.Entity{
+		age.NewVertex(1, "Rack", map[string]any{"id": rackID}),
+		age.NewVertex(2, "Chassis", map[string]any{"id": chassisID, "unitPosition": unitPosition}),
+		age.NewVertex(3, "CPU", map[string]any{"deviceID": deviceID, "type": "CPU"}),
+	}
+}
+
+func Test_compareByRack_chassisID(t *testing.T) {
+	records := [][]age.Entity{
+		createRackRecord("rack01", "chassis02", 1, "cpu01"),
+		createRackRecord("rack01", "chassis01", 1, "cpu02"),
+	}
+	if got := compareByRack(records, 1, 2, 0, 1); got != false {
+		t.Errorf("compareByRack() = %v, want false", got)
+	}
+	if got := compareByRack(records, 1, 2, 1, 0); got != true {
+		t.Errorf("compareByRack() = %v, want true", got)
+	}
+}
+
+func Test_compareByRackList(t *testing.T) {
+	tests := []struct {
+		name    string
+		records [][]age.Entity
+		want    bool
+	}{
+		{
+			"Normal case: Compares the rack id first",
+			[][]age.Entity{
+				createRackRecord("rack02", "chassis01", 1, "cpu01"),
+				createRackRecord("rack01", "chassis01", 2, "cpu01"),
+			},
+			false,
+		},
+		{
+			"Normal case: With the same rack, compares in the same way as compareByRack",
+			[][]age.Entity{
+				createRackRecord("rack01", "chassis01", 1, "cpu01"),
+				createRackRecord("rack01", "chassis02", 2, "cpu01"),
+			},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compareByRackList(tt.records, 0, 1, 2, 0, 1); got != tt.want {
+				t.Errorf("compareByRackList() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_splitRecordsByRack(t *testing.T) {
+	records := [][]age.Entity{
+		createRackRecord("rack01", "chassis01", 1, "cpu01"),
+		createRackRecord("rack01", "chassis01", 1, "cpu02"),
+		createRackRecord("rack02", "chassis02", 1, "cpu03"),
+	}
+	got := splitRecordsByRack(records, 0)
+	if len(got) != 2 || len(got[0]) != 2 || len(got[1]) != 1 {
+		t.Errorf("splitRecordsByRack() = %v", got)
+	}
+	if got := splitRecordsByRack([][]age.Entity{}, 0); len(got) != 0 {
+		t.Errorf("splitRecordsByRack() = %v, want empty", got)
+	}
+}
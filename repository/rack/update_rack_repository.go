@@ -0,0 +1,56 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package rack_repository
+
+import (
+	"errors"
+
+	"github.com/project-cdim/configuration-manager/database"
+	"github.com/project-cdim/configuration-manager/model"
+)
+
+// UpdateRackRepository is a repository that handles the update operations for racks.
+type UpdateRackRepository struct{}
+
+// NewUpdateRackRepository creates a new instance of UpdateRackRepository.
+// It returns an empty UpdateRackRepository struct.
+func NewUpdateRackRepository() UpdateRackRepository {
+	return UpdateRackRepository{}
+}
+
+// Set replaces the properties of the rack in the database with the object converted from the model.
+// The Attach edges of the rack are kept.
+//
+// Parameters:
+//
+//	cmdb - The database connection object.
+//	model  - The model mapper to convert the rack model to an object.
+//
+// Returns:
+//
+//	A map representing the updated rack object and an error if any occurred during the process.
+func (urr *UpdateRackRepository) Set(cmdb database.CmDb, model model.CmModelMapper) (map[string]any, error) {
+	rackObject := model.ToObject()
+	if rackObject == nil {
+		return nil, errors.New("UpdateRackRepository.Set : invalid rack")
+	}
+
+	id, _ := rackObject["id"].(string)
+	if err := mergeRackVertex(cmdb, id, rackObject); err != nil {
+		return nil, err
+	}
+
+	return rackObject, nil
+}
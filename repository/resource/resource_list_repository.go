@@ -24,26 +24,16 @@ import (
 	"github.com/project-cdim/configuration-manager/filter"
 	resource_filter "github.com/project-cdim/configuration-manager/filter/resource"
 	resource_model "github.com/project-cdim/configuration-manager/model/resource"
+	cmapi_repository "github.com/project-cdim/configuration-manager/repository"
+	"github.com/project-cdim/configuration-manager/resourcetype"
 
 	"github.com/apache/age/drivers/golang/age"
 )
 
-// ResourceTypeList is a list of resource types.
+// ResourceTypeList is a list of the labels of the resource types in the registry.
 // Note: The type has been changed from [...]string to []any to allow expanding
 // this list as variadic arguments when needed.
-var ResourceTypeList = []any{
-	"CPU",
-	"Accelerator",
-	"DSP",
-	"FPGA",
-	"GPU",
-	"UnknownProcessor",
-	"Memory",
-	"Storage",
-	"NetworkInterface",
-	"GraphicController",
-	"VirtualMedia",
-}
+var ResourceTypeList = resourcetype.Registered().Labels()
 
 // Cypher query fragment to retrieve a specific resource
 const queryResourceList_match_return string = `
@@ -52,11 +42,14 @@ OPTIONAL MATCH (vrs)-[:Have]->(van)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
 OPTIONAL MATCH (vrs)-[endt:NotDetected]->(: NotDetectedDevice)
 OPTIONAL MATCH (vnd)-[:Compose]->(vrs)
+OPTIONAL MATCH (vut:Unit)-[:Contain]->(vrs)
 RETURN vrs,
 	CASE WHEN van IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE van END,
 	COLLECT(vrsg.id),
 	COLLECT(vnd.id),
-	CASE WHEN endt IS NULL THEN true ELSE false END`
+	CASE WHEN endt IS NULL THEN true ELSE false END,
+	CASE WHEN vut IS NULL THEN "" ELSE vut.deviceID END,
+	CASE WHEN endt.since IS NULL THEN "" ELSE endt.since END`
 
 // Due to the relationships of the data registered in the DB, both UNION and UNION ALL return the same data. Therefore, considering the search speed efficiency, UNION ALL is used.
 const queryResourceList_unionall string = `
@@ -73,25 +66,32 @@ func getQueryResourceList() string {
 	return strings.Join(items, queryResourceList_unionall)
 }
 
-const getResourceListColumnCount = 5
+const getResourceListColumnCount = 7
 const (
 	getResourceListIndexResource = iota
 	getResourceListIndexAnnotation
 	getResourceListIndexResourceGroupIDs
 	getResourceListIndexNodeIDs
 	getResourceListIndexNotDetected
+	getResourceListIndexUnitID
+	getResourceListIndexNotDetectedSince
 )
 
 // ResourceListRepository is a repository structure for getting resource lists.
+// SortKey and Descending specify the order of the list (see SortKeyList). The default is the ascending order of deviceID,
+// and the lists of the available and unused resources are sorted by resourceType and deviceID for the same values.
 type ResourceListRepository struct {
-	Detail bool
+	Detail     bool
+	SortKey    string
+	Descending bool
 }
 
 // NewResourceListRepository creates and returns a ResourceListRepository object that holds the arguments detail.
 // This constructor function initializes a ResourceListRepository with a detail flag indicating whether to retrieve detailed resource information.
 func NewResourceListRepository(detail bool) ResourceListRepository {
 	return ResourceListRepository{
-		Detail: detail,
+		Detail:  detail,
+		SortKey: SortKeyDeviceID,
 	}
 }
 
@@ -125,6 +125,8 @@ func (rlr *ResourceListRepository) FindList(cmdb database.CmDb, filter filter.Cm
 			row[getResourceListIndexNotDetected].(*age.SimpleEntity).AsBool(),
 			rlr.Detail,
 		)
+		resource.UnitID = cmapi_repository.ExtractEntityString(row[getResourceListIndexUnitID].(*age.SimpleEntity))
+		resource.NotDetectedSince = cmapi_repository.ExtractEntityString(row[getResourceListIndexNotDetectedSince].(*age.SimpleEntity))
 		if filter.FilterByCondition(resource.ToObject()) {
 			// Append a single record of search results to the variable resources (information of search results)
 			resourceList.Resources = append(resourceList.Resources, resource)
@@ -133,18 +135,22 @@ func (rlr *ResourceListRepository) FindList(cmdb database.CmDb, filter filter.Cm
 
 	switch filter.(type) {
 	case resource_filter.ResourceAvailableFilter:
-		// sort by resourceType and deviceID
+		// sort by resourceType and deviceID, and then by the sort key
 		sortResourceList(resourceList.Resources)
+		sortResourceListByKey(resourceList.Resources, rlr.SortKey, rlr.Descending)
 		return resourceList.ToObject(), nil
 	case resource_filter.ResourceUnusedFilter:
-		// sort by resourceType and deviceID
+		// sort by resourceType and deviceID, and then by the sort key
 		sortResourceList(resourceList.Resources)
+		sortResourceListByKey(resourceList.Resources, rlr.SortKey, rlr.Descending)
 		return resourceList.ToObject4Unused(), nil
 	default:
 		// sort by deviceID
 		sort.Slice(resourceList.Resources, func(i, j int) bool {
 			return strings.Compare(resourceList.Resources[j].Device["deviceID"].(string), resourceList.Resources[i].Device["deviceID"].(string)) > 0
 		})
+		// sort by the sort key, keeping the order of deviceID for the same values
+		sortResourceListByKey(resourceList.Resources, rlr.SortKey, rlr.Descending)
 		return resourceList.ToObject(), nil
 	}
 }
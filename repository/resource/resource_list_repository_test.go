@@ -32,7 +32,8 @@ func TestNewResourceListRepository(t *testing.T) {
 			"Normal case: Creates an instance of the ResourceListRepository structure (argument: true)",
 			args{true},
 			ResourceListRepository{
-				true,
+				Detail:  true,
+				SortKey: "deviceID",
 			},
 		},
 	}
@@ -74,118 +75,151 @@ OPTIONAL MATCH (vrs)-[:Have]->(van)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
 OPTIONAL MATCH (vrs)-[endt:NotDetected]->(: NotDetectedDevice)
 OPTIONAL MATCH (vnd)-[:Compose]->(vrs)
+OPTIONAL MATCH (vut:Unit)-[:Contain]->(vrs)
 RETURN vrs,
 	CASE WHEN van IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE van END,
 	COLLECT(vrsg.id),
 	COLLECT(vnd.id),
-	CASE WHEN endt IS NULL THEN true ELSE false END
+	CASE WHEN endt IS NULL THEN true ELSE false END,
+	CASE WHEN vut IS NULL THEN "" ELSE vut.deviceID END,
+	CASE WHEN endt.since IS NULL THEN "" ELSE endt.since END
 UNION ALL
 MATCH (vrs:%s)
 OPTIONAL MATCH (vrs)-[:Have]->(van)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
 OPTIONAL MATCH (vrs)-[endt:NotDetected]->(: NotDetectedDevice)
 OPTIONAL MATCH (vnd)-[:Compose]->(vrs)
+OPTIONAL MATCH (vut:Unit)-[:Contain]->(vrs)
 RETURN vrs,
 	CASE WHEN van IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE van END,
 	COLLECT(vrsg.id),
 	COLLECT(vnd.id),
-	CASE WHEN endt IS NULL THEN true ELSE false END
+	CASE WHEN endt IS NULL THEN true ELSE false END,
+	CASE WHEN vut IS NULL THEN "" ELSE vut.deviceID END,
+	CASE WHEN endt.since IS NULL THEN "" ELSE endt.since END
 UNION ALL
 MATCH (vrs:%s)
 OPTIONAL MATCH (vrs)-[:Have]->(van)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
 OPTIONAL MATCH (vrs)-[endt:NotDetected]->(: NotDetectedDevice)
 OPTIONAL MATCH (vnd)-[:Compose]->(vrs)
+OPTIONAL MATCH (vut:Unit)-[:Contain]->(vrs)
 RETURN vrs,
 	CASE WHEN van IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE van END,
 	COLLECT(vrsg.id),
 	COLLECT(vnd.id),
-	CASE WHEN endt IS NULL THEN true ELSE false END
+	CASE WHEN endt IS NULL THEN true ELSE false END,
+	CASE WHEN vut IS NULL THEN "" ELSE vut.deviceID END,
+	CASE WHEN endt.since IS NULL THEN "" ELSE endt.since END
 UNION ALL
 MATCH (vrs:%s)
 OPTIONAL MATCH (vrs)-[:Have]->(van)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
 OPTIONAL MATCH (vrs)-[endt:NotDetected]->(: NotDetectedDevice)
 OPTIONAL MATCH (vnd)-[:Compose]->(vrs)
+OPTIONAL MATCH (vut:Unit)-[:Contain]->(vrs)
 RETURN vrs,
 	CASE WHEN van IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE van END,
 	COLLECT(vrsg.id),
 	COLLECT(vnd.id),
-	CASE WHEN endt IS NULL THEN true ELSE false END
+	CASE WHEN endt IS NULL THEN true ELSE false END,
+	CASE WHEN vut IS NULL THEN "" ELSE vut.deviceID END,
+	CASE WHEN endt.since IS NULL THEN "" ELSE endt.since END
 UNION ALL
 MATCH (vrs:%s)
 OPTIONAL MATCH (vrs)-[:Have]->(van)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
 OPTIONAL MATCH (vrs)-[endt:NotDetected]->(: NotDetectedDevice)
 OPTIONAL MATCH (vnd)-[:Compose]->(vrs)
+OPTIONAL MATCH (vut:Unit)-[:Contain]->(vrs)
 RETURN vrs,
 	CASE WHEN van IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE van END,
 	COLLECT(vrsg.id),
 	COLLECT(vnd.id),
-	CASE WHEN endt IS NULL THEN true ELSE false END
+	CASE WHEN endt IS NULL THEN true ELSE false END,
+	CASE WHEN vut IS NULL THEN "" ELSE vut.deviceID END,
+	CASE WHEN endt.since IS NULL THEN "" ELSE endt.since END
 UNION ALL
 MATCH (vrs:%s)
 OPTIONAL MATCH (vrs)-[:Have]->(van)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
 OPTIONAL MATCH (vrs)-[endt:NotDetected]->(: NotDetectedDevice)
 OPTIONAL MATCH (vnd)-[:Compose]->(vrs)
+OPTIONAL MATCH (vut:Unit)-[:Contain]->(vrs)
 RETURN vrs,
 	CASE WHEN van IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE van END,
 	COLLECT(vrsg.id),
 	COLLECT(vnd.id),
-	CASE WHEN endt IS NULL THEN true ELSE false END
+	CASE WHEN endt IS NULL THEN true ELSE false END,
+	CASE WHEN vut IS NULL THEN "" ELSE vut.deviceID END,
+	CASE WHEN endt.since IS NULL THEN "" ELSE endt.since END
 UNION ALL
 MATCH (vrs:%s)
 OPTIONAL MATCH (vrs)-[:Have]->(van)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
 OPTIONAL MATCH (vrs)-[endt:NotDetected]->(: NotDetectedDevice)
 OPTIONAL MATCH (vnd)-[:Compose]->(vrs)
+OPTIONAL MATCH (vut:Unit)-[:Contain]->(vrs)
 RETURN vrs,
 	CASE WHEN van IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE van END,
 	COLLECT(vrsg.id),
 	COLLECT(vnd.id),
-	CASE WHEN endt IS NULL THEN true ELSE false END
+	CASE WHEN endt IS NULL THEN true ELSE false END,
+	CASE WHEN vut IS NULL THEN "" ELSE vut.deviceID END,
+	CASE WHEN endt.since IS NULL THEN "" ELSE endt.since END
 UNION ALL
 MATCH (vrs:%s)
 OPTIONAL MATCH (vrs)-[:Have]->(van)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
 OPTIONAL MATCH (vrs)-[endt:NotDetected]->(: NotDetectedDevice)
 OPTIONAL MATCH (vnd)-[:Compose]->(vrs)
+OPTIONAL MATCH (vut:Unit)-[:Contain]->(vrs)
 RETURN vrs,
 	CASE WHEN van IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE van END,
 	COLLECT(vrsg.id),
 	COLLECT(vnd.id),
-	CASE WHEN endt IS NULL THEN true ELSE false END
+	CASE WHEN endt IS NULL THEN true ELSE false END,
+	CASE WHEN vut IS NULL THEN "" ELSE vut.deviceID END,
+	CASE WHEN endt.since IS NULL THEN "" ELSE endt.since END
 UNION ALL
 MATCH (vrs:%s)
 OPTIONAL MATCH (vrs)-[:Have]->(van)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
 OPTIONAL MATCH (vrs)-[endt:NotDetected]->(: NotDetectedDevice)
 OPTIONAL MATCH (vnd)-[:Compose]->(vrs)
+OPTIONAL MATCH (vut:Unit)-[:Contain]->(vrs)
 RETURN vrs,
 	CASE WHEN van IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE van END,
 	COLLECT(vrsg.id),
 	COLLECT(vnd.id),
-	CASE WHEN endt IS NULL THEN true ELSE false END
+	CASE WHEN endt IS NULL THEN true ELSE false END,
+	CASE WHEN vut IS NULL THEN "" ELSE vut.deviceID END,
+	CASE WHEN endt.since IS NULL THEN "" ELSE endt.since END
 UNION ALL
 MATCH (vrs:%s)
 OPTIONAL MATCH (vrs)-[:Have]->(van)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
 OPTIONAL MATCH (vrs)-[endt:NotDetected]->(: NotDetectedDevice)
 OPTIONAL MATCH (vnd)-[:Compose]->(vrs)
+OPTIONAL MATCH (vut:Unit)-[:Contain]->(vrs)
 RETURN vrs,
 	CASE WHEN van IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE van END,
 	COLLECT(vrsg.id),
 	COLLECT(vnd.id),
-	CASE WHEN endt IS NULL THEN true ELSE false END
+	CASE WHEN endt IS NULL THEN true ELSE false END,
+	CASE WHEN vut IS NULL THEN "" ELSE vut.deviceID END,
+	CASE WHEN endt.since IS NULL THEN "" ELSE endt.since END
 UNION ALL
 MATCH (vrs:%s)
 OPTIONAL MATCH (vrs)-[:Have]->(van)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
 OPTIONAL MATCH (vrs)-[endt:NotDetected]->(: NotDetectedDevice)
 OPTIONAL MATCH (vnd)-[:Compose]->(vrs)
+OPTIONAL MATCH (vut:Unit)-[:Contain]->(vrs)
 RETURN vrs,
 	CASE WHEN van IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE van END,
 	COLLECT(vrsg.id),
 	COLLECT(vnd.id),
-	CASE WHEN endt IS NULL THEN true ELSE false END`
+	CASE WHEN endt IS NULL THEN true ELSE false END,
+	CASE WHEN vut IS NULL THEN "" ELSE vut.deviceID END,
+	CASE WHEN endt.since IS NULL THEN "" ELSE endt.since END`
@@ -23,6 +23,7 @@ import (
 	"github.com/project-cdim/configuration-manager/filter"
 
 	resource_model "github.com/project-cdim/configuration-manager/model/resource"
+	cmapi_repository "github.com/project-cdim/configuration-manager/repository"
 
 	"github.com/apache/age/drivers/golang/age"
 )
@@ -34,11 +35,14 @@ OPTIONAL MATCH (vrs)-[:Have]->(van)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
 OPTIONAL MATCH (vrs)-[endt:NotDetected]->(:NotDetectedDevice)
 OPTIONAL MATCH (vnd)-[:Compose]->(vrs)
+OPTIONAL MATCH (vut:Unit)-[:Contain]->(vrs)
 RETURN vrs,
 	CASE WHEN van IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE van END,
 	COLLECT(vrsg.id),
 	COLLECT(vnd.id),
-	CASE WHEN endt IS NULL THEN true ELSE false END`
+	CASE WHEN endt IS NULL THEN true ELSE false END,
+	CASE WHEN vut IS NULL THEN "" ELSE vut.deviceID END,
+	CASE WHEN endt.since IS NULL THEN "" ELSE endt.since END`
 
 // Due to the relationships of the data registered in the DB, both UNION and UNION ALL return the same data. Therefore, considering the search speed efficiency, UNION ALL is used.
 const queryResource_unionall string = `
@@ -69,13 +73,15 @@ func getQueryResourceParam(deviceID string) []any {
 	return items
 }
 
-const getResourceColumnCount = 5
+const getResourceColumnCount = 7
 const (
 	getResourceIndexResource = iota
 	getResourceIndexAnnotation
 	getResourceIndexResourceGroupIDs
 	getResourceIndexNodeIDs
 	getResourceIndexNotDetected
+	getResourceIndexUnitID
+	getResourceIndexNotDetectedSince
 )
 
 // ResourceListRepository is a repository structure for getting a specific resource.
@@ -119,6 +125,8 @@ func (rr *ResourceRepository) Find(cmdb database.CmDb, filter filter.CmFilter) (
 			row[getResourceIndexNotDetected].(*age.SimpleEntity).AsBool(),
 			true,
 		)
+		resourceWork.UnitID = cmapi_repository.ExtractEntityString(row[getResourceIndexUnitID].(*age.SimpleEntity))
+		resourceWork.NotDetectedSince = cmapi_repository.ExtractEntityString(row[getResourceIndexNotDetectedSince].(*age.SimpleEntity))
 		if filter.FilterByCondition(resourceWork.ToObject()) {
 			resource = resourceWork
 		}
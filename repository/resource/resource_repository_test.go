@@ -155,118 +155,151 @@ OPTIONAL MATCH (vrs)-[:Have]->(van)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
 OPTIONAL MATCH (vrs)-[endt:NotDetected]->(:NotDetectedDevice)
 OPTIONAL MATCH (vnd)-[:Compose]->(vrs)
+OPTIONAL MATCH (vut:Unit)-[:Contain]->(vrs)
 RETURN vrs,
 	CASE WHEN van IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE van END,
 	COLLECT(vrsg.id),
 	COLLECT(vnd.id),
-	CASE WHEN endt IS NULL THEN true ELSE false END
+	CASE WHEN endt IS NULL THEN true ELSE false END,
+	CASE WHEN vut IS NULL THEN "" ELSE vut.deviceID END,
+	CASE WHEN endt.since IS NULL THEN "" ELSE endt.since END
 UNION ALL
 MATCH (vrs:%s{deviceID: '%s'})
 OPTIONAL MATCH (vrs)-[:Have]->(van)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
 OPTIONAL MATCH (vrs)-[endt:NotDetected]->(:NotDetectedDevice)
 OPTIONAL MATCH (vnd)-[:Compose]->(vrs)
+OPTIONAL MATCH (vut:Unit)-[:Contain]->(vrs)
 RETURN vrs,
 	CASE WHEN van IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE van END,
 	COLLECT(vrsg.id),
 	COLLECT(vnd.id),
-	CASE WHEN endt IS NULL THEN true ELSE false END
+	CASE WHEN endt IS NULL THEN true ELSE false END,
+	CASE WHEN vut IS NULL THEN "" ELSE vut.deviceID END,
+	CASE WHEN endt.since IS NULL THEN "" ELSE endt.since END
 UNION ALL
 MATCH (vrs:%s{deviceID: '%s'})
 OPTIONAL MATCH (vrs)-[:Have]->(van)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
 OPTIONAL MATCH (vrs)-[endt:NotDetected]->(:NotDetectedDevice)
 OPTIONAL MATCH (vnd)-[:Compose]->(vrs)
+OPTIONAL MATCH (vut:Unit)-[:Contain]->(vrs)
 RETURN vrs,
 	CASE WHEN van IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE van END,
 	COLLECT(vrsg.id),
 	COLLECT(vnd.id),
-	CASE WHEN endt IS NULL THEN true ELSE false END
+	CASE WHEN endt IS NULL THEN true ELSE false END,
+	CASE WHEN vut IS NULL THEN "" ELSE vut.deviceID END,
+	CASE WHEN endt.since IS NULL THEN "" ELSE endt.since END
 UNION ALL
 MATCH (vrs:%s{deviceID: '%s'})
 OPTIONAL MATCH (vrs)-[:Have]->(van)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
 OPTIONAL MATCH (vrs)-[endt:NotDetected]->(:NotDetectedDevice)
 OPTIONAL MATCH (vnd)-[:Compose]->(vrs)
+OPTIONAL MATCH (vut:Unit)-[:Contain]->(vrs)
 RETURN vrs,
 	CASE WHEN van IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE van END,
 	COLLECT(vrsg.id),
 	COLLECT(vnd.id),
-	CASE WHEN endt IS NULL THEN true ELSE false END
+	CASE WHEN endt IS NULL THEN true ELSE false END,
+	CASE WHEN vut IS NULL THEN "" ELSE vut.deviceID END,
+	CASE WHEN endt.since IS NULL THEN "" ELSE endt.since END
 UNION ALL
 MATCH (vrs:%s{deviceID: '%s'})
 OPTIONAL MATCH (vrs)-[:Have]->(van)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
 OPTIONAL MATCH (vrs)-[endt:NotDetected]->(:NotDetectedDevice)
 OPTIONAL MATCH (vnd)-[:Compose]->(vrs)
+OPTIONAL MATCH (vut:Unit)-[:Contain]->(vrs)
 RETURN vrs,
 	CASE WHEN van IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE van END,
 	COLLECT(vrsg.id),
 	COLLECT(vnd.id),
-	CASE WHEN endt IS NULL THEN true ELSE false END
+	CASE WHEN endt IS NULL THEN true ELSE false END,
+	CASE WHEN vut IS NULL THEN "" ELSE vut.deviceID END,
+	CASE WHEN endt.since IS NULL THEN "" ELSE endt.since END
 UNION ALL
 MATCH (vrs:%s{deviceID: '%s'})
 OPTIONAL MATCH (vrs)-[:Have]->(van)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
 OPTIONAL MATCH (vrs)-[endt:NotDetected]->(:NotDetectedDevice)
 OPTIONAL MATCH (vnd)-[:Compose]->(vrs)
+OPTIONAL MATCH (vut:Unit)-[:Contain]->(vrs)
 RETURN vrs,
 	CASE WHEN van IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE van END,
 	COLLECT(vrsg.id),
 	COLLECT(vnd.id),
-	CASE WHEN endt IS NULL THEN true ELSE false END
+	CASE WHEN endt IS NULL THEN true ELSE false END,
+	CASE WHEN vut IS NULL THEN "" ELSE vut.deviceID END,
+	CASE WHEN endt.since IS NULL THEN "" ELSE endt.since END
 UNION ALL
 MATCH (vrs:%s{deviceID: '%s'})
 OPTIONAL MATCH (vrs)-[:Have]->(van)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
 OPTIONAL MATCH (vrs)-[endt:NotDetected]->(:NotDetectedDevice)
 OPTIONAL MATCH (vnd)-[:Compose]->(vrs)
+OPTIONAL MATCH (vut:Unit)-[:Contain]->(vrs)
 RETURN vrs,
 	CASE WHEN van IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE van END,
 	COLLECT(vrsg.id),
 	COLLECT(vnd.id),
-	CASE WHEN endt IS NULL THEN true ELSE false END
+	CASE WHEN endt IS NULL THEN true ELSE false END,
+	CASE WHEN vut IS NULL THEN "" ELSE vut.deviceID END,
+	CASE WHEN endt.since IS NULL THEN "" ELSE endt.since END
 UNION ALL
 MATCH (vrs:%s{deviceID: '%s'})
 OPTIONAL MATCH (vrs)-[:Have]->(van)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
 OPTIONAL MATCH (vrs)-[endt:NotDetected]->(:NotDetectedDevice)
 OPTIONAL MATCH (vnd)-[:Compose]->(vrs)
+OPTIONAL MATCH (vut:Unit)-[:Contain]->(vrs)
 RETURN vrs,
 	CASE WHEN van IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE van END,
 	COLLECT(vrsg.id),
 	COLLECT(vnd.id),
-	CASE WHEN endt IS NULL THEN true ELSE false END
+	CASE WHEN endt IS NULL THEN true ELSE false END,
+	CASE WHEN vut IS NULL THEN "" ELSE vut.deviceID END,
+	CASE WHEN endt.since IS NULL THEN "" ELSE endt.since END
 UNION ALL
 MATCH (vrs:%s{deviceID: '%s'})
 OPTIONAL MATCH (vrs)-[:Have]->(van)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
 OPTIONAL MATCH (vrs)-[endt:NotDetected]->(:NotDetectedDevice)
 OPTIONAL MATCH (vnd)-[:Compose]->(vrs)
+OPTIONAL MATCH (vut:Unit)-[:Contain]->(vrs)
 RETURN vrs,
 	CASE WHEN van IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE van END,
 	COLLECT(vrsg.id),
 	COLLECT(vnd.id),
-	CASE WHEN endt IS NULL THEN true ELSE false END
+	CASE WHEN endt IS NULL THEN true ELSE false END,
+	CASE WHEN vut IS NULL THEN "" ELSE vut.deviceID END,
+	CASE WHEN endt.since IS NULL THEN "" ELSE endt.since END
 UNION ALL
 MATCH (vrs:%s{deviceID: '%s'})
 OPTIONAL MATCH (vrs)-[:Have]->(van)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
 OPTIONAL MATCH (vrs)-[endt:NotDetected]->(:NotDetectedDevice)
 OPTIONAL MATCH (vnd)-[:Compose]->(vrs)
+OPTIONAL MATCH (vut:Unit)-[:Contain]->(vrs)
 RETURN vrs,
 	CASE WHEN van IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE van END,
 	COLLECT(vrsg.id),
 	COLLECT(vnd.id),
-	CASE WHEN endt IS NULL THEN true ELSE false END
+	CASE WHEN endt IS NULL THEN true ELSE false END,
+	CASE WHEN vut IS NULL THEN "" ELSE vut.deviceID END,
+	CASE WHEN endt.since IS NULL THEN "" ELSE endt.since END
 UNION ALL
 MATCH (vrs:%s{deviceID: '%s'})
 OPTIONAL MATCH (vrs)-[:Have]->(van)
 OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
 OPTIONAL MATCH (vrs)-[endt:NotDetected]->(:NotDetectedDevice)
 OPTIONAL MATCH (vnd)-[:Compose]->(vrs)
+OPTIONAL MATCH (vut:Unit)-[:Contain]->(vrs)
 RETURN vrs,
 	CASE WHEN van IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE van END,
 	COLLECT(vrsg.id),
 	COLLECT(vnd.id),
-	CASE WHEN endt IS NULL THEN true ELSE false END`
+	CASE WHEN endt IS NULL THEN true ELSE false END,
+	CASE WHEN vut IS NULL THEN "" ELSE vut.deviceID END,
+	CASE WHEN endt.since IS NULL THEN "" ELSE endt.since END`
@@ -15,6 +15,7 @@
 package resource_repository
 
 import (
+	"slices"
 	"sort"
 	"strings"
 
@@ -31,18 +32,7 @@ import (
 func ComposeResource(resVertex *age.Vertex, annotationVertex *age.Vertex, resourceGroupIDs *age.SimpleEntity, nodeIDs *age.SimpleEntity, detected bool, detail bool) resource_model.Resource {
 	resource := resource_model.NewResource()
 
-	// Retrieve the 'available' information from the resource annotation, defaulting to true (available for use in the design proposal) if not present
-	annotationProp := annotationVertex.Props()
-	available := true
-	if _, ok := annotationProp["available"]; ok {
-		switch annotationProp["available"].(type) {
-		case bool:
-			available = annotationProp["available"].(bool)
-		}
-	}
-	annotation := annotation_model.Annotation{
-		Properties: map[string]any{"available": available},
-	}
+	annotation := ComposeAnnotation(annotationVertex)
 
 	// Retrieve the Property information from the resource Vertex data (Properties are obtained in map format)
 	device := resVertex.Props()
@@ -50,6 +40,17 @@ func ComposeResource(resVertex *age.Vertex, annotationVertex *age.Vertex, resour
 		// Return an empty model if there are no Properties
 		return resource
 	}
+	// The times when the resource was first and last seen are held in the resource Vertex apart from the device information
+	resource.FirstSeenAt, _ = device[resource_model.FirstSeenAtKey].(string)
+	resource.LastSeenAt, _ = device[resource_model.LastSeenAtKey].(string)
+	delete(device, resource_model.FirstSeenAtKey)
+	delete(device, resource_model.LastSeenAtKey)
+	// The hardware syncs that missed the resource while it is kept detected are also held apart from the device information
+	resource.PendingMisses, _ = device[resource_model.PendingMissesKey].(int64)
+	delete(device, resource_model.PendingMissesKey)
+	delete(device, resource_model.PendingMissSinceKey)
+	// The content hash is maintained by the hardware sync and is not a part of the device information
+	delete(device, resource_model.ContentHashKey)
 	if !detail {
 		device = extractPrimaryDeviceProp(device)
 	}
@@ -70,6 +71,23 @@ func ComposeResource(resVertex *age.Vertex, annotationVertex *age.Vertex, resour
 	return resource
 }
 
+// ComposeAnnotation assembles and returns Annotation information from an Annotation vertex of search results.
+// The 'available' information is retrieved from the annotation, defaulting to true (available for use in the design proposal) if not present.
+// It is shared by the resources and the units, which hold their annotations in the same form.
+func ComposeAnnotation(annotationVertex *age.Vertex) annotation_model.Annotation {
+	annotationProp := annotationVertex.Props()
+	available := true
+	if _, ok := annotationProp["available"]; ok {
+		switch annotationProp["available"].(type) {
+		case bool:
+			available = annotationProp["available"].(bool)
+		}
+	}
+	return annotation_model.Annotation{
+		Properties: map[string]any{"available": available},
+	}
+}
+
 // extractPrimaryDeviceProp returns only the primary properties of a device.
 func extractPrimaryDeviceProp(prop map[string]any) map[string]any {
 	res := map[string]any{}
@@ -83,6 +101,58 @@ func extractPrimaryDeviceProp(prop map[string]any) map[string]any {
 	return res
 }
 
+// Keys to sort the resource list by
+const (
+	SortKeyDeviceID         = "deviceID"
+	SortKeyFirstSeenAt      = "firstSeenAt"
+	SortKeyLastSeenAt       = "lastSeenAt"
+	SortKeyNotDetectedSince = "notDetectedSince"
+)
+
+// SortKeyList is a list of the keys to sort the resource list by.
+var SortKeyList = []string{
+	SortKeyDeviceID,
+	SortKeyFirstSeenAt,
+	SortKeyLastSeenAt,
+	SortKeyNotDetectedSince,
+}
+
+// sortResourceListByKey stably sorts the resources sorted by deviceID by the sort key.
+// The times are compared as ISO 8601 strings in UTC, and the resources whose times are unknown are placed last in either order.
+// For deviceID, the resources are only reversed in the descending order.
+func sortResourceListByKey(resources []resource_model.Resource, key string, descending bool) {
+	if key == SortKeyDeviceID || len(key) == 0 {
+		if descending {
+			slices.Reverse(resources)
+		}
+		return
+	}
+
+	valueOf := func(resource resource_model.Resource) string {
+		switch key {
+		case SortKeyFirstSeenAt:
+			return resource.FirstSeenAt
+		case SortKeyLastSeenAt:
+			return resource.LastSeenAt
+		case SortKeyNotDetectedSince:
+			return resource.NotDetectedSince
+		}
+		return ""
+	}
+	slices.SortStableFunc(resources, func(a, b resource_model.Resource) int {
+		valueA, valueB := valueOf(a), valueOf(b)
+		switch {
+		case len(valueA) == 0 || len(valueB) == 0:
+			// The unknown times are placed last
+			return len(valueB) - len(valueA)
+		case descending:
+			return strings.Compare(valueB, valueA)
+		default:
+			return strings.Compare(valueA, valueB)
+		}
+	})
+}
+
 // Sort by resourceType and deviceID
 func sortResourceList(resources []resource_model.Resource) {
 	// Sort from the less priority sort key
@@ -317,6 +317,75 @@ func TestComposeResource(t *testing.T) {
 				Detected:         true,
 			},
 		},
+		{
+			"Normal case: The times when the resource was first and last seen are separated from the device information",
+			args{
+				age.NewVertex(10, "label10", map[string]any{
+					"deviceID": "id10", "type": "CPU", "firstSeenAt": "2025-06-01T00:00:00Z", "lastSeenAt": "2025-06-10T00:00:00Z",
+				}),
+				age.NewVertex(20, "label20", map[string]any{
+					"available": true,
+				}),
+				age.NewSimpleEntity([]any{}),
+				age.NewSimpleEntity([]any{}),
+				true,
+				false,
+			},
+			resource_model.Resource{
+				Device:           map[string]any{"deviceID": "id10", "type": "CPU"},
+				Annotation:       annotation_model.Annotation{Properties: map[string]any{"available": true}},
+				ResourceGroupIDs: []string{},
+				NodeIDs:          []string{},
+				Detected:         true,
+				FirstSeenAt:      "2025-06-01T00:00:00Z",
+				LastSeenAt:       "2025-06-10T00:00:00Z",
+			},
+		},
+		{
+			"Normal case: The pending misses of the resource are separated from the device information",
+			args{
+				age.NewVertex(10, "label10", map[string]any{
+					"deviceID": "id10", "type": "CPU", "pendingMisses": int64(2), "pendingMissSince": "2025-06-10T00:00:00Z",
+				}),
+				age.NewVertex(20, "label20", map[string]any{
+					"available": true,
+				}),
+				age.NewSimpleEntity([]any{}),
+				age.NewSimpleEntity([]any{}),
+				true,
+				true,
+			},
+			resource_model.Resource{
+				Device:           map[string]any{"deviceID": "id10", "type": "CPU"},
+				Annotation:       annotation_model.Annotation{Properties: map[string]any{"available": true}},
+				ResourceGroupIDs: []string{},
+				NodeIDs:          []string{},
+				Detected:         true,
+				PendingMisses:    2,
+			},
+		},
+		{
+			"Normal case: The content hash of the resource is not a part of the device information",
+			args{
+				age.NewVertex(10, "label10", map[string]any{
+					"deviceID": "id10", "type": "CPU", "contentHash": "sha256:0123",
+				}),
+				age.NewVertex(20, "label20", map[string]any{
+					"available": true,
+				}),
+				age.NewSimpleEntity([]any{}),
+				age.NewSimpleEntity([]any{}),
+				true,
+				true,
+			},
+			resource_model.Resource{
+				Device:           map[string]any{"deviceID": "id10", "type": "CPU"},
+				Annotation:       annotation_model.Annotation{Properties: map[string]any{"available": true}},
+				ResourceGroupIDs: []string{},
+				NodeIDs:          []string{},
+				Detected:         true,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -327,6 +396,37 @@ func TestComposeResource(t *testing.T) {
 	}
 }
 
+func TestComposeAnnotation(t *testing.T) {
+	tests := []struct {
+		name             string
+		annotationVertex *age.Vertex
+		want             annotation_model.Annotation
+	}{
+		{
+			"Normal case: The available element of the annotationVertex is returned",
+			age.NewVertex(20, "Annotation", map[string]any{"available": false}),
+			annotation_model.Annotation{Properties: map[string]any{"available": false}},
+		},
+		{
+			"Normal case: If the annotationVertex does not have an available element, available defaults to true",
+			age.NewVertex(-1, "dummy", map[string]any{}),
+			annotation_model.Annotation{Properties: map[string]any{"available": true}},
+		},
+		{
+			"Normal case: If the available element is not a bool, available defaults to true",
+			age.NewVertex(20, "Annotation", map[string]any{"available": "false"}),
+			annotation_model.Annotation{Properties: map[string]any{"available": true}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ComposeAnnotation(tt.annotationVertex); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ComposeAnnotation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_extractPrimaryDeviceProp(t *testing.T) {
 	type args struct {
 		prop map[string]any
@@ -419,3 +519,38 @@ func Test_sortResourceList(t *testing.T) {
 		})
 	}
 }
+
+func Test_sortResourceListByKey(t *testing.T) {
+	newResources := func() []resource_model.Resource {
+		return []resource_model.Resource{
+			{Device: map[string]any{"deviceID": "id01"}, FirstSeenAt: "2025-06-03T00:00:00Z"},
+			{Device: map[string]any{"deviceID": "id02"}},
+			{Device: map[string]any{"deviceID": "id03"}, FirstSeenAt: "2025-06-01T00:00:00Z"},
+			{Device: map[string]any{"deviceID": "id04"}, FirstSeenAt: "2025-06-03T00:00:00Z"},
+		}
+	}
+	tests := []struct {
+		name       string
+		key        string
+		descending bool
+		want       []string
+	}{
+		{"Normal case: Sort by deviceID in the ascending order", "deviceID", false, []string{"id01", "id02", "id03", "id04"}},
+		{"Normal case: Sort by deviceID in the descending order", "deviceID", true, []string{"id04", "id03", "id02", "id01"}},
+		{"Normal case: Sort by firstSeenAt in the ascending order", "firstSeenAt", false, []string{"id03", "id01", "id04", "id02"}},
+		{"Normal case: Sort by firstSeenAt in the descending order, placing the unknown time last", "firstSeenAt", true, []string{"id01", "id04", "id03", "id02"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resources := newResources()
+			sortResourceListByKey(resources, tt.key, tt.descending)
+			got := []string{}
+			for _, resource := range resources {
+				got = append(got, resource.Device["deviceID"].(string))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sortResourceListByKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package rule_repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+	"github.com/project-cdim/configuration-manager/model"
+
+	"github.com/apache/age/drivers/golang/age"
+	"github.com/google/uuid"
+)
+
+const (
+	getAssignmentRuleCount = `
+		MATCH (vr:AssignmentRules {id: '%s'})
+		return COUNT(vr)
+`
+	getAssignmentRuleCountColumnCount = 1
+)
+
+const (
+	mergeAssignmentRule = `
+		MERGE (vr:AssignmentRules {id: '%s'})
+		SET vr = %s
+`
+	mergeAssignmentRuleColumnCount = 0
+)
+
+// CreateRuleRepository is a repository for creating assignment rules.
+type CreateRuleRepository struct{}
+
+// NewCreateRuleRepository creates a new instance of CreateRuleRepository.
+// It returns an empty CreateRuleRepository struct.
+func NewCreateRuleRepository() CreateRuleRepository {
+	return CreateRuleRepository{}
+}
+
+// Set creates a new assignment rule in the database using the provided CmDb and CmModelMapper.
+// It generates a unique rule ID, converts the model to an object, and sets the ID.
+//
+// Parameters:
+//
+//	cmdb - The database connection object.
+//	model  - The model mapper to convert the model to an object.
+//
+// Returns:
+//
+//	A map representing the created rule object, or an error if the operation fails.
+func (crr *CreateRuleRepository) Set(cmdb database.CmDb, model model.CmModelMapper) (map[string]any, error) {
+	id, err := generateAssignmentRuleID(cmdb)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleObject := model.ToObject()
+	if ruleObject == nil {
+		return nil, errors.New("CreateRuleRepository.Set : invalid assignment rule")
+	}
+	ruleObject["id"] = id
+
+	if err := mergeRule(cmdb, id, ruleObject); err != nil {
+		return nil, err
+	}
+
+	return ruleObject, nil
+}
+
+// mergeRule writes the rule object to the AssignmentRules vertex with the specified ID.
+func mergeRule(cmdb database.CmDb, id string, ruleObject map[string]any) error {
+	property, err := common.Map2CypherProperty(ruleObject)
+	if err != nil {
+		return err
+	}
+
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s", mergeAssignmentRule, id, property))
+	_, err = cmdb.CmDbExecCypher(mergeAssignmentRuleColumnCount, mergeAssignmentRule, id, property)
+	return err
+}
+
+// generateAssignmentRuleID generates a unique assignment rule ID using UUID version 7.
+// It attempts to generate a unique ID up to 10 times, checking for duplicates in the database.
+//
+// Parameters:
+//   - cmdb: An instance of the CmDb database.
+//
+// Returns:
+//   - A unique rule ID as a string, or an error if a unique ID could not be generated.
+func generateAssignmentRuleID(cmdb database.CmDb) (string, error) {
+	for i := 0; i < 10; i++ {
+		id, _ := uuid.NewV7()
+
+		common.Log.Debug(fmt.Sprintf("query: %s, param1: %s", getAssignmentRuleCount, id.String()))
+		cypherCursor, err := cmdb.CmDbExecCypher(getAssignmentRuleCountColumnCount, getAssignmentRuleCount, id.String())
+		if err != nil {
+			common.Log.Error(err.Error())
+			return "", err
+		}
+		defer cypherCursor.Close()
+
+		for cypherCursor.Next() {
+			row, err := cypherCursor.GetRow()
+			if err != nil {
+				common.Log.Error(err.Error())
+				return "", err
+			}
+
+			cntEntity := row[0].(*age.SimpleEntity)
+			if cntEntity.AsInt64() == 0 {
+				return id.String(), nil
+			}
+			break
+		}
+	}
+
+	return "", errors.New("generateAssignmentRuleID : An ID was generated in UUID format, but duplicates continued to occur")
+}
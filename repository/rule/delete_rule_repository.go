@@ -0,0 +1,66 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package rule_repository
+
+import (
+	"fmt"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+)
+
+const deleteAssignmentRule = `
+	MATCH (vr:AssignmentRules {id: '%s'})
+	DELETE vr
+`
+
+// DeleteRuleRepository represents a repository for deleting an assignment rule.
+// It contains the RuleID which identifies the rule to be deleted.
+type DeleteRuleRepository struct {
+	RuleID string
+}
+
+// NewDeleteRuleRepository creates a new instance of DeleteRuleRepository with the specified ruleID.
+//
+// Parameters:
+//   - ruleID: A string representing the unique identifier of the rule to be deleted.
+//
+// Returns:
+//
+//	A new instance of DeleteRuleRepository initialized with the provided ruleID.
+func NewDeleteRuleRepository(ruleID string) DeleteRuleRepository {
+	return DeleteRuleRepository{
+		RuleID: ruleID,
+	}
+}
+
+// Delete removes the assignment rule from the database.
+//
+// Parameters:
+//
+//	cmdb - An instance of the CmDb database interface.
+//
+// Returns:
+//
+//	error - An error object if the deletion fails, otherwise nil.
+func (drr *DeleteRuleRepository) Delete(cmdb database.CmDb) error {
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s", deleteAssignmentRule, drr.RuleID))
+	_, err := cmdb.CmDbExecCypher(0, deleteAssignmentRule, drr.RuleID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
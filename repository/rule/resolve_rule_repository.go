@@ -0,0 +1,79 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package rule_repository
+
+import (
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+	"github.com/project-cdim/configuration-manager/filter"
+	rule_model "github.com/project-cdim/configuration-manager/model/rule"
+)
+
+// ResolveRuleRepository is a repository that evaluates the assignment rules against a sample device
+// without registering the device.
+type ResolveRuleRepository struct {
+	Device rule_model.DeviceContext
+}
+
+// NewResolveRuleRepository creates a new instance of ResolveRuleRepository for the specified device.
+//
+// Parameters:
+//   - device: The attributes of the device to be evaluated.
+//
+// Returns:
+//
+//	A new instance of ResolveRuleRepository.
+func NewResolveRuleRepository(device rule_model.DeviceContext) ResolveRuleRepository {
+	return ResolveRuleRepository{
+		Device: device,
+	}
+}
+
+// Find evaluates the assignment rules in the same way as the hardware sync and returns the result.
+// The returned map contains the following keys:
+//   - "resourceGroupID": The resource group to which the device would be assigned.
+//   - "matchedRule": The matched rule, or nil if no rule matches.
+//   - "default": true if the default group would be applied because no rule matches.
+//
+// Parameters:
+//   - cmdb: An instance of the CmDb database connection.
+//   - filter: A CmFilter instance. It is not used in this repository.
+//
+// Returns:
+//   - A map containing the evaluation result.
+//   - An error if there is an issue executing the query or processing the results.
+func (rrr *ResolveRuleRepository) Find(cmdb database.CmDb, filter filter.CmFilter) (map[string]any, error) {
+	rules, storedRules, err := findApplicableRules(cmdb)
+	if err != nil {
+		return nil, err
+	}
+
+	matchedRule := rules.Resolve(rrr.Device)
+	if matchedRule == nil {
+		return map[string]any{
+			"resourceGroupID": common.DefaultGroupId,
+			"matchedRule":     nil,
+			"default":         true,
+		}, nil
+	}
+
+	// The response is unquoted by the caller, so the rule is returned as stored in the database
+	storedRule := storedRules[matchedRule.Id]
+	return map[string]any{
+		"resourceGroupID": storedRule.ResourceGroupID(),
+		"matchedRule":     storedRule.ToObject(),
+		"default":         false,
+	}, nil
+}
@@ -0,0 +1,144 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package rule_repository
+
+import (
+	"fmt"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+	"github.com/project-cdim/configuration-manager/filter"
+	rule_model "github.com/project-cdim/configuration-manager/model/rule"
+
+	"github.com/apache/age/drivers/golang/age"
+)
+
+const getAssignmentRuleList string = `
+MATCH (vr:AssignmentRules)
+OPTIONAL MATCH (vrsg:ResourceGroups)
+WHERE vrsg.id = vr.resourceGroupID
+RETURN
+	vr,
+	CASE WHEN vrsg IS NULL THEN false ELSE true END`
+
+const getAssignmentRuleListColumnCount = 2
+const (
+	getAssignmentRuleListIndexRule = iota
+	getAssignmentRuleListIndexGroupExists
+)
+
+// RuleListRepository is a repository that manages the list of assignment rules.
+type RuleListRepository struct{}
+
+// NewRuleListRepository creates a new instance of RuleListRepository.
+// It returns an empty RuleListRepository struct.
+func NewRuleListRepository() RuleListRepository {
+	return RuleListRepository{}
+}
+
+// FindList retrieves the list of assignment rules from the database based on the provided filter.
+// The rules are returned in evaluation order.
+//
+// Parameters:
+//   - cmdb: An instance of the CmDb database connection.
+//   - filter: A CmFilter instance used to filter the rules.
+//
+// Returns:
+//   - A slice of maps containing rule data if successful.
+//   - An error if there is an issue executing the query or processing the results.
+func (rlr *RuleListRepository) FindList(cmdb database.CmDb, filter filter.CmFilter) ([]map[string]any, error) {
+	rules, _, err := findRules(cmdb)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []map[string]any{}
+	for _, rule := range rules.ToObject() {
+		if filter.FilterByCondition(rule) {
+			res = append(res, rule)
+		}
+	}
+
+	return res, nil
+}
+
+// FindRules retrieves the assignment rules to be evaluated for newly discovered devices.
+// Rules whose target resource group no longer exists are excluded, so that a device is never assigned
+// to a missing group. The string values of the rules are unquoted before they are returned.
+//
+// Parameters:
+//   - cmdb: An instance of the CmDb database connection.
+//
+// Returns:
+//   - rule_model.AssignmentRuleList: The list of applicable rules in evaluation order.
+//   - error: An error if there is an issue executing the query or processing the results.
+func FindRules(cmdb database.CmDb) (rule_model.AssignmentRuleList, error) {
+	rules, _, err := findApplicableRules(cmdb)
+	return rules, err
+}
+
+// findApplicableRules retrieves the rules whose target resource group exists.
+// It returns the rules with unquoted string values, which are used for evaluation, together with
+// the rules as stored in the database indexed by ID, which are used for responses relayed by the Relay* functions.
+func findApplicableRules(cmdb database.CmDb) (rule_model.AssignmentRuleList, map[string]rule_model.AssignmentRule, error) {
+	rules, groupExists, err := findRules(cmdb)
+	if err != nil {
+		return rule_model.NewAssignmentRuleList(), nil, err
+	}
+
+	res := rule_model.NewAssignmentRuleList()
+	stored := map[string]rule_model.AssignmentRule{}
+	for i, rule := range rules.Rules {
+		if !groupExists[i] {
+			common.Log.Warn(fmt.Sprintf("The resource group of the assignment rule does not exist. rule(%s), resourceGroupID(%s)", rule.Id, rule.ResourceGroupID()))
+			continue
+		}
+		unquoted, err := common.UnquoteRecursive(rule.Properties)
+		if err != nil {
+			return rule_model.NewAssignmentRuleList(), nil, err
+		}
+		unquotedRule := rule_model.NewAssignmentRuleFromDb(unquoted.(map[string]any))
+		res.Rules = append(res.Rules, unquotedRule)
+		stored[unquotedRule.Id] = rule
+	}
+	res.Sort()
+
+	return res, stored, nil
+}
+
+// findRules executes the query for the assignment rules and returns them together with
+// a flag for each rule that indicates whether its target resource group exists.
+func findRules(cmdb database.CmDb) (rule_model.AssignmentRuleList, []bool, error) {
+	common.Log.Debug(fmt.Sprintf("query: %s", getAssignmentRuleList))
+	cypherCursor, err := cmdb.CmDbExecCypher(getAssignmentRuleListColumnCount, getAssignmentRuleList)
+	if err != nil {
+		return rule_model.NewAssignmentRuleList(), nil, err
+	}
+	defer cypherCursor.Close()
+
+	rules := rule_model.NewAssignmentRuleList()
+	groupExists := []bool{}
+	for cypherCursor.Next() {
+		row, err := cypherCursor.GetRow()
+		if err != nil {
+			common.Log.Error(err.Error())
+			return rule_model.NewAssignmentRuleList(), nil, err
+		}
+		rules.Rules = append(rules.Rules, rule_model.NewAssignmentRuleFromDb(row[getAssignmentRuleListIndexRule].(*age.Vertex).Props()))
+		groupExists = append(groupExists, row[getAssignmentRuleListIndexGroupExists].(*age.SimpleEntity).AsBool())
+	}
+
+	return rules, groupExists, nil
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package rule_repository
+
+import (
+	"fmt"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+	"github.com/project-cdim/configuration-manager/filter"
+	rule_model "github.com/project-cdim/configuration-manager/model/rule"
+
+	"github.com/apache/age/drivers/golang/age"
+)
+
+const getAssignmentRule string = `
+MATCH (vr:AssignmentRules {id: '%s'})
+RETURN vr`
+
+const getAssignmentRuleColumnCount = 1
+
+// RuleRepository represents a repository for retrieving a single assignment rule.
+// It contains the RuleID which uniquely identifies the rule.
+type RuleRepository struct {
+	RuleID string
+}
+
+// NewRuleRepository creates a new instance of RuleRepository with the specified ruleID.
+//
+// Parameters:
+//   - ruleID: A string representing the unique identifier of the assignment rule.
+//
+// Returns:
+//
+//	A new instance of RuleRepository.
+func NewRuleRepository(ruleID string) RuleRepository {
+	return RuleRepository{
+		RuleID: ruleID,
+	}
+}
+
+// Find retrieves an assignment rule from the database based on the provided filter.
+//
+// Parameters:
+//   - cmdb: An instance of the CmDb database connection.
+//   - filter: A CmFilter instance to filter the results.
+//
+// Returns:
+//   - A map containing the rule data, or nil if the rule does not exist or does not satisfy the filter.
+//   - An error if any issues occur during the database query or data processing.
+func (rr *RuleRepository) Find(cmdb database.CmDb, filter filter.CmFilter) (map[string]any, error) {
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s", getAssignmentRule, rr.RuleID))
+	cypherCursor, err := cmdb.CmDbExecCypher(getAssignmentRuleColumnCount, getAssignmentRule, rr.RuleID)
+	if err != nil {
+		return nil, err
+	}
+	defer cypherCursor.Close()
+
+	rule := rule_model.NewAssignmentRule()
+	for cypherCursor.Next() {
+		row, err := cypherCursor.GetRow()
+		if err != nil {
+			common.Log.Error(err.Error())
+			return nil, err
+		}
+		rule = rule_model.NewAssignmentRuleFromDb(row[0].(*age.Vertex).Props())
+	}
+
+	res := rule.ToObject()
+	if res == nil || !filter.FilterByCondition(res) {
+		return nil, nil
+	}
+
+	return res, nil
+}
@@ -0,0 +1,55 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package rule_repository
+
+import (
+	"errors"
+
+	"github.com/project-cdim/configuration-manager/database"
+	"github.com/project-cdim/configuration-manager/model"
+)
+
+// UpdateRuleRepository is a repository that handles the update operations for assignment rules.
+type UpdateRuleRepository struct{}
+
+// NewUpdateRuleRepository creates a new instance of UpdateRuleRepository.
+// It returns an empty UpdateRuleRepository struct.
+func NewUpdateRuleRepository() UpdateRuleRepository {
+	return UpdateRuleRepository{}
+}
+
+// Set updates an assignment rule in the database using the provided CmDb and CmModelMapper.
+//
+// Parameters:
+//
+//	cmdb - The database connection object.
+//	model  - The model mapper to convert the rule model to an object.
+//
+// Returns:
+//
+//	A map representing the updated rule object and an error if any occurred during the process.
+func (urr *UpdateRuleRepository) Set(cmdb database.CmDb, model model.CmModelMapper) (map[string]any, error) {
+	ruleObject := model.ToObject()
+	if ruleObject == nil {
+		return nil, errors.New("UpdateRuleRepository.Set : invalid assignment rule")
+	}
+	id, _ := ruleObject["id"].(string)
+
+	if err := mergeRule(cmdb, id, ruleObject); err != nil {
+		return nil, err
+	}
+
+	return ruleObject, nil
+}
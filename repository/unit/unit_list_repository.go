@@ -0,0 +1,78 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package unit_repository
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+	"github.com/project-cdim/configuration-manager/filter"
+	unit_model "github.com/project-cdim/configuration-manager/model/unit"
+
+	"github.com/apache/age/drivers/golang/age"
+)
+
+// getUnitList is cypher query to retrieve all units.
+const getUnitList string = `
+	MATCH (vut:Unit)` + getUnit_optional_match_return
+
+// UnitListRepository is a repository structure for getting all units.
+type UnitListRepository struct {
+	Detail bool
+}
+
+// NewUnitListRepository creates and returns a UnitListRepository object.
+// The detail flag indicates whether to retrieve the detailed information of the contained resources.
+func NewUnitListRepository(detail bool) UnitListRepository {
+	return UnitListRepository{
+		Detail: detail,
+	}
+}
+
+// FindList retrieves all units with their annotations and the resources contained in them.
+// The units are sorted by ID, and only the units that satisfy the filter are returned.
+func (ulr *UnitListRepository) FindList(cmdb database.CmDb, filter filter.CmFilter) ([]map[string]any, error) {
+	common.Log.Debug(fmt.Sprintf("query: %s", getUnitList))
+	cypherCursor, err := cmdb.CmDbExecCypher(getUnitColumnCount, getUnitList)
+	if err != nil {
+		return nil, err
+	}
+	defer cypherCursor.Close()
+
+	records := [][]age.Entity{}
+	for cypherCursor.Next() {
+		row, err := cypherCursor.GetRow()
+		if err != nil {
+			common.Log.Error(err.Error())
+			return nil, err
+		}
+		records = append(records, row)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return compareByUnit(records, i, j)
+	})
+
+	unitList := unit_model.NewUnitList()
+	for _, unit := range composeUnitList(records, ulr.Detail) {
+		if filter.FilterByCondition(unit.ToObject()) {
+			unitList.Units = append(unitList.Units, unit)
+		}
+	}
+
+	return unitList.ToObject(), nil
+}
@@ -0,0 +1,82 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package unit_repository
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+	"github.com/project-cdim/configuration-manager/filter"
+
+	"github.com/apache/age/drivers/golang/age"
+)
+
+// getUnit is cypher query to retrieve a specific unit.
+const getUnit string = `
+	MATCH (vut:Unit {deviceID: '%s'})` + getUnit_optional_match_return
+
+// UnitRepository is a repository structure for getting a specific unit.
+type UnitRepository struct {
+	UnitID string
+	Detail bool
+}
+
+// NewUnitRepository creates and returns a UnitRepository object that holds the argument unitID.
+// The detail flag indicates whether to retrieve the detailed information of the contained resources.
+func NewUnitRepository(unitID string, detail bool) UnitRepository {
+	return UnitRepository{
+		UnitID: unitID,
+		Detail: detail,
+	}
+}
+
+// Find retrieves a unit with its annotation and the resources contained in it.
+// It returns nil if the unit does not exist or does not satisfy the filter.
+func (ur *UnitRepository) Find(cmdb database.CmDb, filter filter.CmFilter) (map[string]any, error) {
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s", getUnit, ur.UnitID))
+	cypherCursor, err := cmdb.CmDbExecCypher(getUnitColumnCount, getUnit, ur.UnitID)
+	if err != nil {
+		return nil, err
+	}
+	defer cypherCursor.Close()
+
+	records := [][]age.Entity{}
+	for cypherCursor.Next() {
+		row, err := cypherCursor.GetRow()
+		if err != nil {
+			common.Log.Error(err.Error())
+			return nil, err
+		}
+		records = append(records, row)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return compareByUnit(records, i, j)
+	})
+
+	units := composeUnitList(records, ur.Detail)
+	if len(units) == 0 {
+		return nil, nil
+	}
+
+	res := units[0].ToObject()
+	if res == nil || !filter.FilterByCondition(res) {
+		return nil, nil
+	}
+
+	return res, nil
+}
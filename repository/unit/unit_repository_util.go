@@ -0,0 +1,124 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package unit_repository
+
+import (
+	"strings"
+
+	unit_model "github.com/project-cdim/configuration-manager/model/unit"
+	resource_repository "github.com/project-cdim/configuration-manager/repository/resource"
+
+	"github.com/apache/age/drivers/golang/age"
+)
+
+// Cypher query fragment that traverses the Contain edges of units (Unit -> Resource).
+// The results are narrowed down by the MATCH clause on the Unit vertex placed before this fragment.
+const getUnit_optional_match_return string = `
+	OPTIONAL MATCH (vut)-[:Have]->(vuan:Annotation)
+	OPTIONAL MATCH (vut)-[:Contain]->(vrs)
+	OPTIONAL MATCH (vrsg)-[:Include]->(vrs)
+	OPTIONAL MATCH (vnd)-[:Compose]->(vrs)
+	OPTIONAL MATCH (vrs)-[:Have]->(van:Annotation)
+	OPTIONAL MATCH (vrs)-[endt:NotDetected]->(:NotDetectedDevice)
+	WITH vut, vuan, vrs, van, vrsg, vnd, endt
+	RETURN
+		vut,
+		CASE WHEN vuan IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE vuan END,
+		CASE WHEN vrs IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE vrs END,
+		CASE WHEN van IS NULL THEN {id:-1, label:"dummy", properties: {}}::vertex ELSE van END,
+		COLLECT(vrsg.id),
+		COLLECT(vnd.id),
+		CASE WHEN endt IS NULL THEN true ELSE false END
+`
+
+const getUnitColumnCount = 7
+const (
+	getUnitIndexUnit = iota
+	getUnitIndexUnitAnnotation
+	getUnitIndexResource
+	getUnitIndexAnnotation
+	getUnitIndexResourceGroupIDs
+	getUnitIndexNodeIDs
+	getUnitIndexNotDetected
+)
+
+// composeUnitList assembles Unit structures from the records of a Cypher query result.
+// The records must be sorted so that the records of the same unit are consecutive.
+// Records without a unit are skipped, and the resources contained in a unit are composed
+// in the same way as the other repositories.
+//
+// Parameters:
+//   - records: The records of the Cypher query result.
+//   - detail: Whether to include the detailed device information of the resources.
+//
+// Returns:
+//   - []unit_model.Unit: The assembled units in the order of the records.
+func composeUnitList(records [][]age.Entity, detail bool) []unit_model.Unit {
+	res := []unit_model.Unit{}
+	preDeviceID := ""
+	for _, row := range records {
+		deviceID, ok := row[getUnitIndexUnit].(*age.Vertex).Props()["deviceID"].(string)
+		if !ok {
+			continue
+		}
+
+		if len(res) == 0 || preDeviceID != deviceID {
+			unit := unit_model.NewUnit()
+			unit.DeviceID = deviceID
+			unit.Annotation = resource_repository.ComposeAnnotation(row[getUnitIndexUnitAnnotation].(*age.Vertex))
+			res = append(res, unit)
+			preDeviceID = deviceID
+		}
+		unit := &res[len(res)-1]
+
+		resource := resource_repository.ComposeResource(
+			row[getUnitIndexResource].(*age.Vertex),
+			row[getUnitIndexAnnotation].(*age.Vertex),
+			row[getUnitIndexResourceGroupIDs].(*age.SimpleEntity),
+			row[getUnitIndexNodeIDs].(*age.SimpleEntity),
+			row[getUnitIndexNotDetected].(*age.SimpleEntity).AsBool(),
+			detail,
+		)
+		if resource.Validate() {
+			unit.Resources.Resources = append(unit.Resources.Resources, resource)
+		}
+	}
+
+	return res
+}
+
+// compareByUnit sorts the contents of a Cypher query execution result based on the following criteria:
+// - First sort key: Unit's deviceID (string, empty string if absent, ascending order)
+// - Second sort key: Unit > Resource's type (string, empty string if absent, ascending order)
+// - Third sort key: Unit > Resource's deviceID (string, empty string if absent, ascending order)
+func compareByUnit(records [][]age.Entity, i, j int) bool {
+	unitID1, _ := records[i][getUnitIndexUnit].(*age.Vertex).Props()["deviceID"].(string)
+	unitID2, _ := records[j][getUnitIndexUnit].(*age.Vertex).Props()["deviceID"].(string)
+	if unitID1 != unitID2 {
+		return strings.Compare(unitID1, unitID2) < 0
+	}
+
+	resProp1 := records[i][getUnitIndexResource].(*age.Vertex).Props()
+	resProp2 := records[j][getUnitIndexResource].(*age.Vertex).Props()
+	for _, key := range []string{"type", "deviceID"} {
+		value1, _ := resProp1[key].(string)
+		value2, _ := resProp2[key].(string)
+		if value1 != value2 {
+			return strings.Compare(value1, value2) < 0
+		}
+	}
+
+	return false
+}
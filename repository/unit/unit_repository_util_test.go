@@ -0,0 +1,106 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package unit_repository
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/apache/age/drivers/golang/age"
+)
+
+func createUnitRecord(unitID string, available bool, device map[string]any) []age.Entity {
+	unit := map[string]any{}
+	if len(unitID) > 0 {
+		unit["deviceID"] = unitID
+	}
+	return []age.Entity{
+		age.NewVertex(1, "Unit", unit),
+		age.NewVertex(2, "Annotation", map[string]any{"available": available}),
+		age.NewVertex(3, "Resource", device),
+		age.NewVertex(4, "Annotation", map[string]any{"available": true}),
+		age.NewSimpleEntity([]any{"group01"}),
+		age.NewSimpleEntity([]any{"node01"}),
+		age.NewSimpleEntity(true),
+	}
+}
+
+func Test_composeUnitList(t *testing.T) {
+	records := [][]age.Entity{
+		createUnitRecord("cpu01", false, map[string]any{"deviceID": "cpu01", "type": "CPU"}),
+		createUnitRecord("cpu01", false, map[string]any{"deviceID": "mem01", "type": "memory"}),
+		createUnitRecord("cpu02", true, map[string]any{}),
+		createUnitRecord("", true, map[string]any{"deviceID": "mem02", "type": "memory"}),
+	}
+	want := []map[string]any{
+		{
+			"id":         "cpu01",
+			"annotation": map[string]any{"available": false},
+			"resources": []map[string]any{
+				{
+					"device":           map[string]any{"deviceID": "cpu01", "type": "CPU"},
+					"annotation":       map[string]any{"available": true},
+					"resourceGroupIDs": []string{"group01"},
+					"nodeIDs":          []string{"node01"},
+					"detected":         true,
+				},
+				{
+					"device":           map[string]any{"deviceID": "mem01", "type": "memory"},
+					"annotation":       map[string]any{"available": true},
+					"resourceGroupIDs": []string{"group01"},
+					"nodeIDs":          []string{"node01"},
+					"detected":         true,
+				},
+			},
+		},
+		{
+			"id":         "cpu02",
+			"annotation": map[string]any{"available": true},
+			"resources":  []map[string]any{},
+		},
+	}
+
+	got := []map[string]any{}
+	for _, unit := range composeUnitList(records, true) {
+		got = append(got, unit.ToObject())
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("composeUnitList() = %v, want %v", got, want)
+	}
+}
+
+func Test_compareByUnit(t *testing.T) {
+	records := [][]age.Entity{
+		createUnitRecord("cpu02", true, map[string]any{"deviceID": "cpu02", "type": "CPU"}),
+		createUnitRecord("cpu01", true, map[string]any{"deviceID": "mem01", "type": "memory"}),
+		createUnitRecord("cpu01", true, map[string]any{"deviceID": "cpu01", "type": "CPU"}),
+		createUnitRecord("cpu01", true, map[string]any{"deviceID": "mem00", "type": "memory"}),
+	}
+	want := []string{"cpu01/cpu01", "cpu01/mem00", "cpu01/mem01", "cpu02/cpu02"}
+
+	sort.Slice(records, func(i, j int) bool {
+		return compareByUnit(records, i, j)
+	})
+	got := []string{}
+	for _, row := range records {
+		unitID := row[getUnitIndexUnit].(*age.Vertex).Props()["deviceID"].(string)
+		deviceID := row[getUnitIndexResource].(*age.Vertex).Props()["deviceID"].(string)
+		got = append(got, unitID+"/"+deviceID)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("compareByUnit() sorted = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package resourcetype
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Environment variable to configure the path of the JSON file listing the resource types added to the built-in types
+const envResourceTypesFile = "CM_RESOURCE_TYPES_FILE"
+
+// Registry of the resource types used by the service, loaded from the configuration at startup.
+// If the configuration is invalid, only the built-in types are registered and the error is kept for ConfigurationError.
+var registered, errConfiguration = load(os.Getenv, os.ReadFile)
+
+// Registered returns the registry of the resource types used by the service.
+func Registered() *Registry {
+	return registered
+}
+
+// ConfigurationError returns the error in the configuration of the resource types, or nil if it is valid.
+func ConfigurationError() error {
+	return errConfiguration
+}
+
+// load creates the registry from the file configured by getenv, which is read by readFile.
+// The file is a JSON array of the types, e.g. [{"name": "DPU", "label": "DPU", "processor": true, "schema": "/etc/cm/DPU.json"}].
+// It returns the registry of the built-in types and the error if the file cannot be read or is invalid.
+func load(getenv func(string) string, readFile func(string) ([]byte, error)) (*Registry, error) {
+	builtin, err := NewRegistry(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	path := strings.TrimSpace(getenv(envResourceTypesFile))
+	if len(path) == 0 {
+		return builtin, nil
+	}
+	data, err := readFile(path)
+	if err != nil {
+		return builtin, fmt.Errorf("cannot read the resource types %s: %w", path, err)
+	}
+	var additional []Type
+	if err := json.Unmarshal(data, &additional); err != nil {
+		return builtin, fmt.Errorf("invalid resource types %s: %w", path, err)
+	}
+	registry, err := NewRegistry(additional)
+	if err != nil {
+		return builtin, fmt.Errorf("invalid resource types %s: %w", path, err)
+	}
+	return registry, nil
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package resourcetype
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func Test_load(t *testing.T) {
+	tests := []struct {
+		name       string
+		env        map[string]string
+		files      map[string]string
+		wantLabels []any
+		wantErr    bool
+	}{
+		{
+			name:       "Normal case: The file is not configured",
+			env:        map[string]string{},
+			wantLabels: []any{"CPU", "Accelerator", "DSP", "FPGA", "GPU", "UnknownProcessor", "Memory", "Storage", "NetworkInterface", "GraphicController", "VirtualMedia"},
+		},
+		{
+			name:       "Normal case: The types in the file are added",
+			env:        map[string]string{envResourceTypesFile: " /etc/cm/types.json "},
+			files:      map[string]string{"/etc/cm/types.json": `[{"name": "DPU", "label": "DPU", "processor": true}]`},
+			wantLabels: []any{"CPU", "Accelerator", "DSP", "FPGA", "GPU", "UnknownProcessor", "Memory", "Storage", "NetworkInterface", "GraphicController", "VirtualMedia", "DPU"},
+		},
+		{
+			name:       "Error case: The file cannot be read",
+			env:        map[string]string{envResourceTypesFile: "/etc/cm/types.json"},
+			wantLabels: []any{"CPU", "Accelerator", "DSP", "FPGA", "GPU", "UnknownProcessor", "Memory", "Storage", "NetworkInterface", "GraphicController", "VirtualMedia"},
+			wantErr:    true,
+		},
+		{
+			name:       "Error case: The file is not JSON",
+			env:        map[string]string{envResourceTypesFile: "/etc/cm/types.json"},
+			files:      map[string]string{"/etc/cm/types.json": `{"name": "DPU"`},
+			wantLabels: []any{"CPU", "Accelerator", "DSP", "FPGA", "GPU", "UnknownProcessor", "Memory", "Storage", "NetworkInterface", "GraphicController", "VirtualMedia"},
+			wantErr:    true,
+		},
+		{
+			name:       "Error case: The type in the file is invalid",
+			env:        map[string]string{envResourceTypesFile: "/etc/cm/types.json"},
+			files:      map[string]string{"/etc/cm/types.json": `[{"name": "DPU", "label": "Rack"}]`},
+			wantLabels: []any{"CPU", "Accelerator", "DSP", "FPGA", "GPU", "UnknownProcessor", "Memory", "Storage", "NetworkInterface", "GraphicController", "VirtualMedia"},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string { return tt.env[key] }
+			readFile := func(path string) ([]byte, error) {
+				data, ok := tt.files[path]
+				if !ok {
+					return nil, errors.New("no such file")
+				}
+				return []byte(data), nil
+			}
+			got, err := load(getenv, readFile)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if labels := got.Labels(); !reflect.DeepEqual(labels, tt.wantLabels) {
+				t.Errorf("load().Labels() = %v, want %v", labels, tt.wantLabels)
+			}
+		})
+	}
+}
+
+func TestRegistered(t *testing.T) {
+	if got := Registered(); got != registered {
+		t.Errorf("Registered() = %v, want %v", got, registered)
+	}
+}
+
+func TestConfigurationError(t *testing.T) {
+	if err := ConfigurationError(); err != errConfiguration {
+		t.Errorf("ConfigurationError() = %v, want %v", err, errConfiguration)
+	}
+}
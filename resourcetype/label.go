@@ -0,0 +1,87 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package resourcetype
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/database"
+)
+
+// SQL to select the labels of the vertices in the graph
+const sqlSelectVertexLabels = `
+SELECT l.name FROM ag_catalog.ag_label l
+	JOIN ag_catalog.ag_graph g ON l.graph = g.graphid
+	WHERE g.name = $1 AND l.kind = 'v'`
+
+// SQL to create the label of the vertices and the index of their properties, in the same way as the initialization of the graph
+const (
+	sqlCreateVertexLabel      = `SELECT ag_catalog.create_vlabel('%s', '%s')`
+	sqlCreateVertexLabelIndex = `CREATE INDEX IF NOT EXISTS %s_%s_idx ON %s."%s" USING gin (properties)`
+)
+
+// EnsureLabels creates the labels of the registered types that do not exist in the graph, such as those of the types
+// added by the configuration, and returns the created labels.
+func EnsureLabels(db *sql.DB, registry *Registry) ([]string, error) {
+	rows, err := db.Query(sqlSelectVertexLabels, database.GRAPH_NAME)
+	if err != nil {
+		common.Log.Error(err.Error())
+		return nil, err
+	}
+	existing := []string{}
+	for rows.Next() {
+		var label string
+		if err := rows.Scan(&label); err != nil {
+			rows.Close()
+			common.Log.Error(err.Error())
+			return nil, err
+		}
+		existing = append(existing, label)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		common.Log.Error(err.Error())
+		return nil, err
+	}
+
+	missing := missingLabels(registry, existing)
+	for _, label := range missing {
+		for _, query := range []string{
+			fmt.Sprintf(sqlCreateVertexLabel, database.GRAPH_NAME, label),
+			fmt.Sprintf(sqlCreateVertexLabelIndex, database.GRAPH_NAME, label, database.GRAPH_NAME, label),
+		} {
+			common.Log.Debug(fmt.Sprintf("query: %s", query))
+			if _, err := db.Exec(query); err != nil {
+				common.Log.Error(err.Error())
+				return nil, err
+			}
+		}
+	}
+	return missing, nil
+}
+
+// missingLabels returns the labels of the registered types that are not in the existing labels.
+func missingLabels(registry *Registry, existing []string) []string {
+	res := []string{}
+	for _, resourceType := range registry.types {
+		if !slices.Contains(existing, resourceType.Label) {
+			res = append(res, resourceType.Label)
+		}
+	}
+	return res
+}
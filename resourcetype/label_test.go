@@ -0,0 +1,36 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package resourcetype
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEnsureLabels(t *testing.T) {
+	t.Skip("not test")
+}
+
+func Test_missingLabels(t *testing.T) {
+	registry, err := NewRegistry([]Type{{Name: "DPU", Label: "DPU", Processor: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	existing := []string{"CPU", "Accelerator", "DSP", "FPGA", "GPU", "UnknownProcessor", "Memory", "Storage", "NetworkInterface", "GraphicController", "Node"}
+	want := []string{"VirtualMedia", "DPU"}
+	if got := missingLabels(registry, existing); !reflect.DeepEqual(got, want) {
+		t.Errorf("missingLabels() = %v, want %v", got, want)
+	}
+}
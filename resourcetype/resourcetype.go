@@ -0,0 +1,181 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+// Package resourcetype provides the registry of the hardware resource types managed by the service.
+// The registry holds the built-in types and the types added by the configuration, and every part of the service
+// that depends on the resource types, such as the labels of the resource vertices, refers to it.
+package resourcetype
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+)
+
+// Names of the built-in resource types in the JSON of the devices
+const (
+	CPU               = "CPU"
+	Accelerator       = "Accelerator"
+	DSP               = "DSP"
+	FPGA              = "FPGA"
+	GPU               = "GPU"
+	UnknownProcessor  = "UnknownProcessor"
+	Memory            = "memory"
+	Storage           = "storage"
+	NetworkInterface  = "networkInterface"
+	GraphicController = "graphicController"
+	VirtualMedia      = "virtualMedia"
+)
+
+// UnitRole defines how a resource forms a unit, which is the set of the resources attached and detached together.
+type UnitRole string
+
+const (
+	// The resource forms a unit together with its non-removable devices.
+	UnitRoleAnchor UnitRole = "anchor"
+	// The resource forms a unit by itself only if it has no non-removable devices.
+	UnitRoleStandalone UnitRole = "standalone"
+)
+
+// Type describes a hardware resource type.
+type Type struct {
+	Name       string   `json:"name"`       // Name of the type in the JSON of the devices
+	Label      string   `json:"label"`      // Label of the vertices of the resources
+	Processor  bool     `json:"processor"`  // Whether the resources are processors
+	NodeAnchor bool     `json:"nodeAnchor"` // Whether a resource is the node of its own, which the resources linked to it belong to
+	Unit       UnitRole `json:"unit"`       // How the resources form units. Defaults to anchor for processors and standalone for the others
+	Schema     string   `json:"schema"`     // Path of the JSON Schema file of the devices. The built-in types use the schemas shipped with the service
+}
+
+// builtinTypes are the resource types supported without the configuration, in the order of the resource queries.
+var builtinTypes = []Type{
+	{Name: CPU, Label: "CPU", Processor: true, NodeAnchor: true},
+	{Name: Accelerator, Label: "Accelerator", Processor: true},
+	{Name: DSP, Label: "DSP", Processor: true},
+	{Name: FPGA, Label: "FPGA", Processor: true},
+	{Name: GPU, Label: "GPU", Processor: true},
+	{Name: UnknownProcessor, Label: "UnknownProcessor", Processor: true},
+	{Name: Memory, Label: "Memory"},
+	{Name: Storage, Label: "Storage"},
+	{Name: NetworkInterface, Label: "NetworkInterface"},
+	{Name: GraphicController, Label: "GraphicController"},
+	{Name: VirtualMedia, Label: "VirtualMedia"},
+}
+
+// Labels of the vertices other than the resources, which the resource types cannot use
+var reservedLabels = []string{
+	"Annotation", "AssignmentRules", "Chassis", "CXLswitch", "Node", "NotDetectedDevice",
+	"QuarantinedDevice", "Rack", "ResourceGroups", "SyncSource", "Unit",
+}
+
+// The label is embedded in the queries, so it is limited to an identifier.
+var labelPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// Registry holds the resource types in the order of registration.
+type Registry struct {
+	types  []Type
+	byName map[string]int
+}
+
+// NewRegistry creates a registry of the built-in types and the additional types.
+// An additional type with the name of a built-in type replaces it. It returns an error if a type is invalid,
+// or if the types share a name or a label.
+func NewRegistry(additional []Type) (*Registry, error) {
+	registry := &Registry{types: []Type{}, byName: map[string]int{}}
+	for _, resourceType := range builtinTypes {
+		registry.byName[resourceType.Name] = len(registry.types)
+		registry.types = append(registry.types, resourceType.normalize())
+	}
+
+	added := map[string]bool{}
+	for _, resourceType := range additional {
+		if err := resourceType.validate(); err != nil {
+			return nil, err
+		}
+		if added[resourceType.Name] {
+			return nil, fmt.Errorf("duplicate resource type name: %s", resourceType.Name)
+		}
+		added[resourceType.Name] = true
+		if index, ok := registry.byName[resourceType.Name]; ok {
+			registry.types[index] = resourceType.normalize()
+		} else {
+			registry.byName[resourceType.Name] = len(registry.types)
+			registry.types = append(registry.types, resourceType.normalize())
+		}
+	}
+
+	labels := map[string]string{}
+	for _, resourceType := range registry.types {
+		if name, ok := labels[resourceType.Label]; ok {
+			return nil, fmt.Errorf("duplicate resource type label: %s is used by %s and %s", resourceType.Label, name, resourceType.Name)
+		}
+		labels[resourceType.Label] = resourceType.Name
+	}
+	return registry, nil
+}
+
+// validate checks the name, the label and the unit role of the type.
+func (t Type) validate() error {
+	if len(t.Name) == 0 {
+		return fmt.Errorf("resource type name is empty")
+	}
+	if !labelPattern.MatchString(t.Label) {
+		return fmt.Errorf("invalid resource type label: type(%s) label(%s)", t.Name, t.Label)
+	}
+	if slices.Contains(reservedLabels, t.Label) {
+		return fmt.Errorf("reserved resource type label: type(%s) label(%s)", t.Name, t.Label)
+	}
+	switch t.Unit {
+	case "", UnitRoleAnchor, UnitRoleStandalone:
+	default:
+		return fmt.Errorf("invalid resource type unit: type(%s) unit(%s)", t.Name, t.Unit)
+	}
+	return nil
+}
+
+// normalize fills the default unit role of the type.
+func (t Type) normalize() Type {
+	if len(t.Unit) == 0 {
+		if t.Processor {
+			t.Unit = UnitRoleAnchor
+		} else {
+			t.Unit = UnitRoleStandalone
+		}
+	}
+	return t
+}
+
+// Lookup returns the type of the name in the JSON of the devices, and whether it is registered.
+func (r *Registry) Lookup(name string) (Type, bool) {
+	index, ok := r.byName[name]
+	if !ok {
+		return Type{}, false
+	}
+	return r.types[index], true
+}
+
+// Types returns the registered types in the order of registration.
+func (r *Registry) Types() []Type {
+	return slices.Clone(r.types)
+}
+
+// Labels returns the labels of the registered types in the order of registration.
+// The type is []any so that the labels can be expanded as the variadic arguments of the queries.
+func (r *Registry) Labels() []any {
+	labels := []any{}
+	for _, resourceType := range r.types {
+		labels = append(labels, resourceType.Label)
+	}
+	return labels
+}
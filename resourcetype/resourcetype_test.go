@@ -0,0 +1,126 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package resourcetype
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewRegistry(t *testing.T) {
+	tests := []struct {
+		name       string
+		additional []Type
+		wantLabels []any
+		wantType   Type
+		wantErr    bool
+	}{
+		{
+			name:       "Normal case: Only the built-in types",
+			additional: nil,
+			wantLabels: []any{"CPU", "Accelerator", "DSP", "FPGA", "GPU", "UnknownProcessor", "Memory", "Storage", "NetworkInterface", "GraphicController", "VirtualMedia"},
+			wantType:   Type{Name: CPU, Label: "CPU", Processor: true, NodeAnchor: true, Unit: UnitRoleAnchor},
+		},
+		{
+			name:       "Normal case: The additional type is registered after the built-in types",
+			additional: []Type{{Name: "DPU", Label: "DPU", Processor: true}},
+			wantLabels: []any{"CPU", "Accelerator", "DSP", "FPGA", "GPU", "UnknownProcessor", "Memory", "Storage", "NetworkInterface", "GraphicController", "VirtualMedia", "DPU"},
+			wantType:   Type{Name: "DPU", Label: "DPU", Processor: true, Unit: UnitRoleAnchor},
+		},
+		{
+			name:       "Normal case: The additional type replaces the built-in type of the same name",
+			additional: []Type{{Name: Memory, Label: "Memory", Unit: UnitRoleAnchor}},
+			wantLabels: []any{"CPU", "Accelerator", "DSP", "FPGA", "GPU", "UnknownProcessor", "Memory", "Storage", "NetworkInterface", "GraphicController", "VirtualMedia"},
+			wantType:   Type{Name: Memory, Label: "Memory", Unit: UnitRoleAnchor},
+		},
+		{
+			name:       "Error case: The name is empty",
+			additional: []Type{{Label: "DPU"}},
+			wantErr:    true,
+		},
+		{
+			name:       "Error case: The label is not an identifier",
+			additional: []Type{{Name: "DPU", Label: "DPU'})"}},
+			wantErr:    true,
+		},
+		{
+			name:       "Error case: The label is reserved",
+			additional: []Type{{Name: "node", Label: "Node"}},
+			wantErr:    true,
+		},
+		{
+			name:       "Error case: The unit role is unknown",
+			additional: []Type{{Name: "DPU", Label: "DPU", Unit: "member"}},
+			wantErr:    true,
+		},
+		{
+			name:       "Error case: The names are duplicated",
+			additional: []Type{{Name: "DPU", Label: "DPU"}, {Name: "DPU", Label: "DPU2"}},
+			wantErr:    true,
+		},
+		{
+			name:       "Error case: The label is used by the built-in type",
+			additional: []Type{{Name: "cpu", Label: "CPU"}},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewRegistry(tt.additional)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewRegistry() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if labels := got.Labels(); !reflect.DeepEqual(labels, tt.wantLabels) {
+				t.Errorf("NewRegistry().Labels() = %v, want %v", labels, tt.wantLabels)
+			}
+			if resourceType, ok := got.Lookup(tt.wantType.Name); !ok || !reflect.DeepEqual(resourceType, tt.wantType) {
+				t.Errorf("NewRegistry().Lookup() = %v, %v, want %v", resourceType, ok, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestRegistry_Lookup(t *testing.T) {
+	registry, err := NewRegistry(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := registry.Lookup(Storage); !ok || !reflect.DeepEqual(got, Type{Name: Storage, Label: "Storage", Unit: UnitRoleStandalone}) {
+		t.Errorf("Registry.Lookup() = %v, %v", got, ok)
+	}
+	if got, ok := registry.Lookup("DPU"); ok {
+		t.Errorf("Registry.Lookup() = %v, %v, want not found", got, ok)
+	}
+}
+
+func TestRegistry_Types(t *testing.T) {
+	registry, err := NewRegistry(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := registry.Types()
+	if len(got) != len(builtinTypes) {
+		t.Fatalf("Registry.Types() = %v, want %d types", got, len(builtinTypes))
+	}
+	// The returned types are a copy of the registry
+	got[0].Label = "Changed"
+	if resourceType, _ := registry.Lookup(CPU); resourceType.Label != "CPU" {
+		t.Errorf("Registry.Types() is not a copy: %v", resourceType)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package schema
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Schemas of the devices reported by the hardware sync, shipped with the service.
+// Each file is named after the resource type of the devices it describes, except for the following shared schemas.
+//
+//go:embed resources/*.json
+var resourceSchemaFS embed.FS
+
+// Names of the schemas shared by the devices of all the resource types
+const (
+	// Schema of the properties every device must have, such as the device ID and the resource type
+	DeviceSchemaName = "device"
+	// Schema of the properties shared by the devices of all the resource types, such as the links and the location
+	CommonSchemaName = "common"
+)
+
+// LoadResourceSchemas loads the schemas of the devices shipped with the service.
+// The schemas are keyed by the names of the files without the extension, that is, DeviceSchemaName, CommonSchemaName or a resource type.
+func LoadResourceSchemas() (map[string]*Schema, error) {
+	entries, err := resourceSchemaFS.ReadDir("resources")
+	if err != nil {
+		return nil, err
+	}
+
+	schemas := map[string]*Schema{}
+	for _, entry := range entries {
+		data, err := resourceSchemaFS.ReadFile(path.Join("resources", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		schema, err := Parse(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schema %s: %w", entry.Name(), err)
+		}
+		schemas[strings.TrimSuffix(entry.Name(), path.Ext(entry.Name()))] = schema
+	}
+	return schemas, nil
+}
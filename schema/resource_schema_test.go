@@ -0,0 +1,43 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package schema
+
+import (
+	"testing"
+)
+
+func TestLoadResourceSchemas(t *testing.T) {
+	got, err := LoadResourceSchemas()
+	if err != nil {
+		t.Fatalf("LoadResourceSchemas() error = %v", err)
+	}
+	for _, name := range []string{
+		DeviceSchemaName, CommonSchemaName,
+		"CPU", "Accelerator", "DSP", "FPGA", "GPU", "UnknownProcessor",
+		"memory", "storage", "networkInterface", "graphicController", "virtualMedia",
+	} {
+		if _, ok := got[name]; !ok {
+			t.Errorf("LoadResourceSchemas() does not have the schema %s", name)
+		}
+	}
+
+	// Every resource type schema accepts a device of the type that has a status
+	for name, schema := range got {
+		device := map[string]any{"deviceID": "dev01", "type": name, "status": map[string]any{"state": "Enabled", "health": "OK"}}
+		if violations := schema.Validate(device); len(violations) > 0 {
+			t.Errorf("schema %s Validate() = %v, want no violations", name, violations)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+// Package schema validates JSON documents, such as the devices reported by the hardware sync, against JSON Schemas.
+// Only the subset of the JSON Schema keywords used by the schemas shipped with the service is supported:
+// type, enum, required, properties, additionalProperties (boolean), items, minItems, minLength, minimum and maximum.
+// The annotations such as title and description are ignored, and a schema with any other keyword is rejected by Parse,
+// so that a constraint of the schema is never silently left unchecked.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Types is the type keyword of a JSON Schema, which is either a type name or a list of type names.
+type Types []string
+
+// UnmarshalJSON accepts both a type name and a list of type names.
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*t = Types{name}
+		return nil
+	}
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return fmt.Errorf("type must be a string or an array of strings: %w", err)
+	}
+	*t = names
+	return nil
+}
+
+// Schema is a JSON Schema.
+type Schema struct {
+	Type                 Types              `json:"type"`
+	Enum                 []any              `json:"enum"`
+	Required             []string           `json:"required"`
+	Properties           map[string]*Schema `json:"properties"`
+	AdditionalProperties *bool              `json:"additionalProperties"`
+	Items                *Schema            `json:"items"`
+	MinItems             *int               `json:"minItems"`
+	MinLength            *int               `json:"minLength"`
+	Minimum              *float64           `json:"minimum"`
+	Maximum              *float64           `json:"maximum"`
+}
+
+// supportedKeywords are the keywords of the fields of Schema.
+var supportedKeywords = []string{
+	"type", "enum", "required", "properties", "additionalProperties", "items", "minItems", "minLength", "minimum", "maximum",
+}
+
+// annotationKeywords are the keywords that do not affect the validation, which are accepted and ignored.
+var annotationKeywords = []string{
+	"$schema", "$id", "$comment", "title", "description", "default", "examples", "deprecated", "readOnly", "writeOnly",
+}
+
+// UnmarshalJSON decodes the schema, and returns an error if the schema has a keyword that is not supported.
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	var keywords map[string]json.RawMessage
+	if err := json.Unmarshal(data, &keywords); err != nil {
+		return fmt.Errorf("schema must be an object: %w", err)
+	}
+	for _, keyword := range slices.Sorted(maps.Keys(keywords)) {
+		if !slices.Contains(supportedKeywords, keyword) && !slices.Contains(annotationKeywords, keyword) {
+			return fmt.Errorf("unsupported keyword: %s", keyword)
+		}
+	}
+
+	// Decode the fields without calling this method recursively
+	type plainSchema Schema
+	return json.Unmarshal(data, (*plainSchema)(s))
+}
+
+// Violation is a part of a document that does not satisfy the schema.
+// Pointer is the JSON pointer (RFC 6901) to the part in the document, which is empty for the whole document.
+type Violation struct {
+	Pointer string
+	Message string
+}
+
+// Parse parses a JSON Schema.
+func Parse(data []byte) (*Schema, error) {
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// Validate validates the document decoded by encoding/json, and returns every violation in the document.
+// The violations in the properties of an object are returned in the order of the property names.
+func (s *Schema) Validate(document any) []Violation {
+	violations := []Violation{}
+	s.validate("", document, &violations)
+	return violations
+}
+
+// validate appends the violations of the value at the pointer to violations.
+func (s *Schema) validate(pointer string, value any, violations *[]Violation) {
+	if len(s.Type) > 0 && !slices.ContainsFunc(s.Type, func(name string) bool { return isType(value, name) }) {
+		// The other keywords are meaningless for a value of a different type
+		*violations = append(*violations, Violation{pointer, fmt.Sprintf("must be of type %s", strings.Join(s.Type, " or "))})
+		return
+	}
+	if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(candidate any) bool { return equal(candidate, value) }) {
+		*violations = append(*violations, Violation{pointer, fmt.Sprintf("must be one of %v", s.Enum)})
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		s.validateObject(pointer, v, violations)
+	case []any:
+		if s.MinItems != nil && len(v) < *s.MinItems {
+			*violations = append(*violations, Violation{pointer, fmt.Sprintf("must have at least %d items", *s.MinItems)})
+		}
+		if s.Items != nil {
+			for index, item := range v {
+				s.Items.validate(fmt.Sprintf("%s/%d", pointer, index), item, violations)
+			}
+		}
+	case string:
+		if s.MinLength != nil && utf8.RuneCountInString(v) < *s.MinLength {
+			*violations = append(*violations, Violation{pointer, fmt.Sprintf("must be at least %d characters long", *s.MinLength)})
+		}
+	default:
+		if number, ok := toFloat(value); ok {
+			if s.Minimum != nil && number < *s.Minimum {
+				*violations = append(*violations, Violation{pointer, fmt.Sprintf("must be greater than or equal to %v", *s.Minimum)})
+			}
+			if s.Maximum != nil && number > *s.Maximum {
+				*violations = append(*violations, Violation{pointer, fmt.Sprintf("must be less than or equal to %v", *s.Maximum)})
+			}
+		}
+	}
+}
+
+// validateObject appends the violations of the properties of the object at the pointer to violations.
+func (s *Schema) validateObject(pointer string, object map[string]any, violations *[]Violation) {
+	for _, name := range s.Required {
+		if _, ok := object[name]; !ok {
+			*violations = append(*violations, Violation{pointer + "/" + escapePointerToken(name), "is required"})
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(object)) {
+		propertyPointer := pointer + "/" + escapePointerToken(name)
+		if property, ok := s.Properties[name]; ok {
+			property.validate(propertyPointer, object[name], violations)
+		} else if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+			*violations = append(*violations, Violation{propertyPointer, "is not allowed"})
+		}
+	}
+}
+
+// isType reports whether the value decoded by encoding/json is of the JSON Schema type.
+// Integers of Go are also accepted as numbers so that documents built in Go can be validated.
+func isType(value any, name string) bool {
+	switch name {
+	case "null":
+		return value == nil
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "number":
+		_, ok := toFloat(value)
+		return ok
+	case "integer":
+		number, ok := toFloat(value)
+		return ok && number == math.Trunc(number)
+	default:
+		return false
+	}
+}
+
+// toFloat converts a number decoded by encoding/json, or an integer of Go, into float64.
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		number, err := v.Float64()
+		return number, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// equal reports whether two values decoded by encoding/json are equal, comparing numbers by their values.
+func equal(a any, b any) bool {
+	if numberA, ok := toFloat(a); ok {
+		numberB, ok := toFloat(b)
+		return ok && numberA == numberB
+	}
+	switch a.(type) {
+	case map[string]any, []any:
+		return fmt.Sprint(a) == fmt.Sprint(b)
+	default:
+		return a == b
+	}
+}
+
+// escapePointerToken escapes a property name to be a reference token of a JSON pointer.
+func escapePointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
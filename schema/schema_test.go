@@ -0,0 +1,161 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTypes_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    Types
+		wantErr bool
+	}{
+		{"Normal case: A type name", `"string"`, Types{"string"}, false},
+		{"Normal case: A list of type names", `["string", "null"]`, Types{"string", "null"}, false},
+		{"Error case: Neither a type name nor a list of type names", `1`, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Types
+			err := got.UnmarshalJSON([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UnmarshalJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	for _, data := range []string{
+		`{"type": 1}`,
+		// The keywords not supported are rejected rather than ignored
+		`{"type": "string", "pattern": "^[0-9]+$"}`,
+		`{"type": "object", "properties": {"deviceID": {"type": "string", "maxLength": 8}}}`,
+		`{"type": "array", "items": {"oneOf": [{"type": "string"}]}}`,
+		`{"type": "object", "additionalProperties": {"type": "string"}}`,
+	} {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Errorf("Parse(%s) error = nil, want error", data)
+		}
+	}
+	// The annotations are accepted
+	if _, err := Parse([]byte(`{"$schema": "https://json-schema.org/draft/2020-12/schema", "title": "t", "description": "d", "type": "object"}`)); err != nil {
+		t.Errorf("Parse() error = %v, want nil", err)
+	}
+	got, err := Parse([]byte(`{"type": "object", "required": ["deviceID"]}`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if !reflect.DeepEqual(got.Type, Types{"object"}) || !reflect.DeepEqual(got.Required, []string{"deviceID"}) {
+		t.Errorf("Parse() = %v", got)
+	}
+}
+
+func TestSchema_Validate(t *testing.T) {
+	schema, err := Parse([]byte(`{
+		"type": "object",
+		"required": ["deviceID", "status"],
+		"properties": {
+			"deviceID": {"type": "string", "minLength": 1},
+			"status": {"type": "object", "properties": {"state": {"enum": ["Enabled", "Disabled"]}}},
+			"links": {"type": "array", "minItems": 1, "items": {"type": "object", "required": ["deviceID"]}},
+			"cores": {"type": "integer", "minimum": 1, "maximum": 128},
+			"speed": {"type": ["number", "null"]},
+			"a/b~c": {"type": "boolean"}
+		},
+		"additionalProperties": false
+	}`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		document any
+		want     []Violation
+	}{
+		{
+			"Normal case: The document satisfies the schema",
+			map[string]any{
+				"deviceID": "cpu01",
+				"status":   map[string]any{"state": "Enabled"},
+				"links":    []any{map[string]any{"deviceID": "mem01"}},
+				"cores":    float64(8),
+				"speed":    nil,
+			},
+			[]Violation{},
+		},
+		{
+			"Normal case: Integers of Go are accepted as numbers",
+			map[string]any{"deviceID": "cpu01", "status": map[string]any{}, "cores": 8, "speed": int64(2000)},
+			[]Violation{},
+		},
+		{
+			"Error case: The document is not of the type",
+			[]any{},
+			[]Violation{{"", "must be of type object"}},
+		},
+		{
+			"Error case: Every violation is returned in the order of the property names",
+			map[string]any{
+				"deviceID": "",
+				"links":    []any{"mem01", map[string]any{}},
+				"cores":    8.5,
+				"speed":    "fast",
+				"extra":    true,
+				"a/b~c":    "true",
+			},
+			[]Violation{
+				{"/status", "is required"},
+				{"/a~1b~0c", "must be of type boolean"},
+				{"/cores", "must be of type integer"},
+				{"/deviceID", "must be at least 1 characters long"},
+				{"/extra", "is not allowed"},
+				{"/links/0", "must be of type object"},
+				{"/links/1/deviceID", "is required"},
+				{"/speed", "must be of type number or null"},
+			},
+		},
+		{
+			"Error case: The values are out of the ranges",
+			map[string]any{
+				"deviceID": "cpu01",
+				"status":   map[string]any{"state": "Absent"},
+				"links":    []any{},
+				"cores":    float64(256),
+			},
+			[]Violation{
+				{"/cores", "must be less than or equal to 128"},
+				{"/links", "must have at least 1 items"},
+				{"/status/state", "must be one of [Enabled Disabled]"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := schema.Validate(tt.document); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,298 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package syncjob
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/project-cdim/configuration-manager/common"
+)
+
+const sqlInsertSyncJob string = `
+INSERT INTO public.configuration_manager_sync_jobs (id, parameters, content)
+VALUES ($1, $2, $3)
+RETURNING created_at
+`
+
+const sqlSelectSyncJob string = `
+SELECT id, status, parameters, processed, attempts, status_code, result, created_at, started_at, finished_at
+FROM public.configuration_manager_sync_jobs
+WHERE id = $1
+`
+
+// A running job whose heartbeat has stopped was interrupted, for example by a restart, and is claimed again
+const sqlSelectClaimableSyncJob string = `
+SELECT id, parameters, content, attempts
+FROM public.configuration_manager_sync_jobs
+WHERE status = 'queued' OR (status = 'running' AND heartbeat_at < $1)
+ORDER BY created_at, id
+LIMIT 1
+FOR UPDATE SKIP LOCKED
+`
+
+// The number of the attempts after the claim is the fencing token of the claim
+const sqlUpdateSyncJobRunning string = `
+UPDATE public.configuration_manager_sync_jobs
+SET status = 'running', processed = 0, attempts = attempts + 1, started_at = now(), heartbeat_at = now()
+WHERE id = $1
+RETURNING attempts
+`
+
+// The heartbeat and the finish only update the job still running under the claim whose token is $2,
+// so that a worker whose job has been claimed again by another worker does not overwrite it
+const sqlUpdateSyncJobHeartbeat string = `
+UPDATE public.configuration_manager_sync_jobs
+SET processed = $3, heartbeat_at = now()
+WHERE id = $1 AND attempts = $2 AND status = 'running'
+`
+
+const sqlUpdateSyncJobFinished string = `
+UPDATE public.configuration_manager_sync_jobs
+SET status = $3, processed = $4, status_code = $5, result = $6, content = NULL, finished_at = now()
+WHERE id = $1 AND attempts = $2 AND status = 'running'
+`
+
+// Statuses of a sync job
+const (
+	StatusQueued    = "queued"    // Waiting for the worker
+	StatusRunning   = "running"   // Being run by the worker
+	StatusSucceeded = "succeeded" // Finished with a successful response
+	StatusFailed    = "failed"    // Finished with an error response
+)
+
+// Executor is a database handle on which the sync jobs are read or written, satisfied by both *sql.DB and *sql.Tx.
+type Executor interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+// Parameters are the query parameters of the hardware sync run by a job.
+type Parameters struct {
+	DryRun       bool     `json:"dryRun"`
+	Partial      bool     `json:"partial"`
+	Streamed     bool     `json:"streamed"` // Whether the devices are streamed in the request body as NDJSON
+	Source       string   `json:"source,omitempty"`
+	ChassisIDs   []string `json:"chassisIDs,omitempty"`
+	CxlSwitchIDs []string `json:"cxlSwitchIDs,omitempty"`
+}
+
+// Job is the state of a hardware sync run asynchronously.
+type Job struct {
+	ID         string
+	Status     string
+	Parameters Parameters
+	Content    []byte // Request body of the hardware sync, only read when the job is claimed
+	Processed  int    // Number of the devices processed so far
+	Attempts   int
+	StatusCode int             // Status code of the response of the hardware sync, once it is finished
+	Result     json.RawMessage // Response body of the hardware sync, once it is finished
+	CreatedAt  string
+	StartedAt  string
+	FinishedAt string
+}
+
+// ToObject converts the job into a map for the response.
+// The response body of the finished hardware sync is returned as the result if it succeeded, or as the error if it failed.
+func (j *Job) ToObject() map[string]any {
+	res := map[string]any{
+		"id":        j.ID,
+		"status":    j.Status,
+		"dryRun":    j.Parameters.DryRun,
+		"processed": j.Processed,
+		"attempts":  j.Attempts,
+		"createdAt": j.CreatedAt,
+	}
+	if len(j.StartedAt) > 0 {
+		res["startedAt"] = j.StartedAt
+	}
+	if len(j.FinishedAt) > 0 {
+		res["finishedAt"] = j.FinishedAt
+		res["statusCode"] = j.StatusCode
+	}
+	switch j.Status {
+	case StatusSucceeded:
+		res["result"] = j.Result
+	case StatusFailed:
+		res["error"] = j.Result
+	}
+	return res
+}
+
+// formatTime formats the time in ISO 8601 in UTC.
+func formatTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05Z07:00")
+}
+
+// Enqueue registers a job of the hardware sync with the parameters and the request body, and returns the queued job.
+func Enqueue(executor Executor, parameters Parameters, content []byte) (Job, error) {
+	id, _ := uuid.NewV7()
+	job := Job{ID: id.String(), Status: StatusQueued, Parameters: parameters}
+	parametersJSON, err := json.Marshal(parameters)
+	if err != nil {
+		return job, err
+	}
+
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s, param3: (%d bytes)", sqlInsertSyncJob, job.ID, parametersJSON, len(content)))
+	rows, err := executor.Query(sqlInsertSyncJob, job.ID, parametersJSON, content)
+	if err != nil {
+		common.Log.Error(err.Error())
+		return job, err
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		var createdAt time.Time
+		if err := rows.Scan(&createdAt); err != nil {
+			common.Log.Error(err.Error())
+			return job, err
+		}
+		job.CreatedAt = formatTime(createdAt)
+	}
+	if err := rows.Err(); err != nil {
+		common.Log.Error(err.Error())
+		return job, err
+	}
+	return job, nil
+}
+
+// Find returns the job of id without its request body. The second return value is false if the job does not exist.
+func Find(executor Executor, id string) (Job, bool, error) {
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s", sqlSelectSyncJob, id))
+	rows, err := executor.Query(sqlSelectSyncJob, id)
+	if err != nil {
+		common.Log.Error(err.Error())
+		return Job{}, false, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			common.Log.Error(err.Error())
+			return Job{}, false, err
+		}
+		return Job{}, false, nil
+	}
+	var job Job
+	var parameters []byte
+	var statusCode sql.NullInt64
+	var result []byte
+	var createdAt time.Time
+	var startedAt, finishedAt sql.NullTime
+	if err := rows.Scan(&job.ID, &job.Status, &parameters, &job.Processed, &job.Attempts, &statusCode, &result, &createdAt, &startedAt, &finishedAt); err != nil {
+		common.Log.Error(err.Error())
+		return Job{}, false, err
+	}
+	if err := json.Unmarshal(parameters, &job.Parameters); err != nil {
+		common.Log.Error(err.Error())
+		return Job{}, false, err
+	}
+	job.StatusCode = int(statusCode.Int64)
+	job.Result = result
+	job.CreatedAt = formatTime(createdAt)
+	if startedAt.Valid {
+		job.StartedAt = formatTime(startedAt.Time)
+	}
+	if finishedAt.Valid {
+		job.FinishedAt = formatTime(finishedAt.Time)
+	}
+	return job, true, nil
+}
+
+// claim locks the oldest job that is queued, or running with its heartbeat older than expiredBefore, and marks it as running.
+// The Attempts of the returned job is the fencing token of the claim, given to heartbeat and finish.
+// The second return value is false if there is no such job.
+func claim(tx *sql.Tx, expiredBefore time.Time) (Job, bool, error) {
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s", sqlSelectClaimableSyncJob, formatTime(expiredBefore)))
+	rows, err := tx.Query(sqlSelectClaimableSyncJob, expiredBefore)
+	if err != nil {
+		common.Log.Error(err.Error())
+		return Job{}, false, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			common.Log.Error(err.Error())
+			return Job{}, false, err
+		}
+		return Job{}, false, nil
+	}
+	job := Job{Status: StatusRunning}
+	var parameters []byte
+	if err := rows.Scan(&job.ID, &parameters, &job.Content, &job.Attempts); err != nil {
+		common.Log.Error(err.Error())
+		return Job{}, false, err
+	}
+	if err := json.Unmarshal(parameters, &job.Parameters); err != nil {
+		common.Log.Error(err.Error())
+		return Job{}, false, err
+	}
+	rows.Close()
+
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s", sqlUpdateSyncJobRunning, job.ID))
+	if err := tx.QueryRow(sqlUpdateSyncJobRunning, job.ID).Scan(&job.Attempts); err != nil {
+		common.Log.Error(err.Error())
+		return Job{}, false, err
+	}
+	return job, true, nil
+}
+
+// heartbeat records the number of the devices processed by the running job of id, and extends its lease.
+// The first return value is false if the job is no longer running under the claim of the token attempts.
+func heartbeat(executor Executor, id string, attempts int, processed int) (bool, error) {
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %d, param3: %d", sqlUpdateSyncJobHeartbeat, id, attempts, processed))
+	res, err := executor.Exec(sqlUpdateSyncJobHeartbeat, id, attempts, processed)
+	if err != nil {
+		common.Log.Error(err.Error())
+		return false, err
+	}
+	return updated(res)
+}
+
+// finish records the number of the processed devices and the response of the hardware sync of the job of id, and discards its request body.
+// The job has succeeded if the status code is not an error.
+// The first return value is false if the job is no longer running under the claim of the token attempts, in which case nothing is recorded.
+func finish(executor Executor, id string, attempts int, processed int, statusCode int, result any) (bool, error) {
+	status := StatusSucceeded
+	if statusCode >= 400 {
+		status = StatusFailed
+	}
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return false, err
+	}
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %d, param3: %s, param4: %d, param5: %d, param6: %s", sqlUpdateSyncJobFinished, id, attempts, status, processed, statusCode, resultJSON))
+	res, err := executor.Exec(sqlUpdateSyncJobFinished, id, attempts, status, processed, statusCode, resultJSON)
+	if err != nil {
+		common.Log.Error(err.Error())
+		return false, err
+	}
+	return updated(res)
+}
+
+// updated returns whether the update of res has updated any row.
+func updated(res sql.Result) (bool, error) {
+	rows, err := res.RowsAffected()
+	if err != nil {
+		common.Log.Error(err.Error())
+		return false, err
+	}
+	return rows > 0, nil
+}
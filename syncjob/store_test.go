@@ -0,0 +1,104 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package syncjob
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestJob_ToObject(t *testing.T) {
+	result := json.RawMessage(`{"count":1,"deviceIDs":["dev01"]}`)
+	tests := []struct {
+		name string
+		job  Job
+		want map[string]any
+	}{
+		{
+			name: "Normal case: Queued job",
+			job:  Job{ID: "job001", Status: StatusQueued, CreatedAt: "2025-01-01T00:00:00Z"},
+			want: map[string]any{"id": "job001", "status": "queued", "dryRun": false, "processed": 0, "attempts": 0, "createdAt": "2025-01-01T00:00:00Z"},
+		},
+		{
+			name: "Normal case: Running job",
+			job:  Job{ID: "job001", Status: StatusRunning, Parameters: Parameters{DryRun: true}, Processed: 500, Attempts: 1, CreatedAt: "2025-01-01T00:00:00Z", StartedAt: "2025-01-01T00:00:01Z"},
+			want: map[string]any{"id": "job001", "status": "running", "dryRun": true, "processed": 500, "attempts": 1, "createdAt": "2025-01-01T00:00:00Z",
+				"startedAt": "2025-01-01T00:00:01Z"},
+		},
+		{
+			name: "Normal case: Succeeded job",
+			job: Job{ID: "job001", Status: StatusSucceeded, Processed: 1, Attempts: 1, StatusCode: 201, Result: result,
+				CreatedAt: "2025-01-01T00:00:00Z", StartedAt: "2025-01-01T00:00:01Z", FinishedAt: "2025-01-01T00:00:02Z"},
+			want: map[string]any{"id": "job001", "status": "succeeded", "dryRun": false, "processed": 1, "attempts": 1, "createdAt": "2025-01-01T00:00:00Z",
+				"startedAt": "2025-01-01T00:00:01Z", "finishedAt": "2025-01-01T00:00:02Z", "statusCode": 201, "result": result},
+		},
+		{
+			name: "Normal case: Failed job",
+			job: Job{ID: "job001", Status: StatusFailed, Attempts: 1, StatusCode: 400, Result: json.RawMessage(`{"code":"badRequest"}`),
+				CreatedAt: "2025-01-01T00:00:00Z", StartedAt: "2025-01-01T00:00:01Z", FinishedAt: "2025-01-01T00:00:02Z"},
+			want: map[string]any{"id": "job001", "status": "failed", "dryRun": false, "processed": 0, "attempts": 1, "createdAt": "2025-01-01T00:00:00Z",
+				"startedAt": "2025-01-01T00:00:01Z", "finishedAt": "2025-01-01T00:00:02Z", "statusCode": 400, "error": json.RawMessage(`{"code":"badRequest"}`)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.job.ToObject(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ToObject() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnqueue(t *testing.T) {
+	t.Skip("not test")
+}
+
+func TestFind(t *testing.T) {
+	t.Skip("not test")
+}
+
+func Test_claim(t *testing.T) {
+	t.Skip("not test")
+}
+
+func Test_heartbeat(t *testing.T) {
+	t.Skip("not test")
+}
+
+func Test_finish(t *testing.T) {
+	t.Skip("not test")
+}
+
+func Test_updated(t *testing.T) {
+	tests := []struct {
+		name string
+		res  sql.Result
+		want bool
+	}{
+		{name: "Normal case: The row is updated", res: driver.RowsAffected(1), want: true},
+		{name: "Normal case: No row is updated under another claim", res: driver.RowsAffected(0), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := updated(tt.res)
+			if err != nil || got != tt.want {
+				t.Errorf("updated() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package syncjob
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/project-cdim/configuration-manager/common"
+)
+
+const (
+	defaultWorkerInterval    = 1 * time.Second // Interval to poll the queued jobs
+	defaultHeartbeatInterval = 5 * time.Second // Interval to record the progress of the running job
+	defaultLease             = 1 * time.Minute // Time after the last heartbeat when a running job is regarded as interrupted
+	defaultMaxAttempts       = 3               // Number of attempts after which an interrupted job is failed instead of being run again
+)
+
+// ErrLeaseLost is the error of a job that has been claimed again by another worker, after its heartbeat stopped for longer than the lease.
+var ErrLeaseLost = errors.New("sync job is no longer running under this claim")
+
+// Finish records the status code and the body of the response of the hardware sync of a job in tx, the transaction of the hardware sync.
+// It returns ErrLeaseLost if the job has been claimed again by another worker, in which case the transaction must be rolled back.
+type Finish func(tx *sql.Tx, statusCode int, result any) error
+
+// Runner runs the hardware sync of the job, and returns the status code and the body of its response.
+// The number of the processed devices is reported with progress, which returns ErrLeaseLost to stop the hardware sync
+// once the job has been claimed again by another worker. Before the transaction of the hardware sync is committed,
+// its response is recorded with finish in the transaction, so that a committed hardware sync is never run again.
+type Runner func(job Job, progress func(processed int) error, finish Finish) (int, any)
+
+// Worker runs the queued jobs one at a time.
+// The progress of the running job is recorded as its heartbeat, and a job whose heartbeat has stopped, for example by a restart,
+// is run again by any worker. The hardware sync is run again from the beginning, because its transaction was not committed.
+// The number of the attempts is the fencing token of a claim: the heartbeats and the finish of a worker whose job has been claimed
+// again update nothing, and the worker stops the hardware sync.
+type Worker struct {
+	db                *sql.DB
+	run               Runner
+	interval          time.Duration
+	heartbeatInterval time.Duration
+	lease             time.Duration
+	maxAttempts       int
+	now               func() time.Time
+}
+
+// NewWorker creates a Worker running the jobs with run. The jobs are claimed and recorded through db,
+// the connection pool shared with the other background workers, which is not closed by the Worker.
+func NewWorker(db *sql.DB, run Runner) Worker {
+	return Worker{
+		db:                db,
+		run:               run,
+		interval:          defaultWorkerInterval,
+		heartbeatInterval: defaultHeartbeatInterval,
+		lease:             defaultLease,
+		maxAttempts:       defaultMaxAttempts,
+		now:               time.Now,
+	}
+}
+
+// Run polls the queued jobs and runs them until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		// The jobs are run one after another while there are queued ones
+		ran, err := w.runOnce()
+		if err != nil {
+			common.Log.Warn(fmt.Sprintf("sync job worker error : %s", err.Error()))
+		}
+		if ran && err == nil {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce claims a job and runs it. It returns false if there is no job to run.
+func (w *Worker) runOnce() (bool, error) {
+	tx, err := w.db.Begin()
+	if err != nil {
+		common.Log.Error(err.Error())
+		return false, err
+	}
+	// The rollback after the commit does nothing
+	defer tx.Rollback()
+
+	job, exists, err := claim(tx, w.now().Add(-w.lease))
+	if err != nil || !exists {
+		return false, err
+	}
+	if err := tx.Commit(); err != nil {
+		common.Log.Error(err.Error())
+		return false, err
+	}
+
+	if job.Attempts > w.maxAttempts {
+		common.Log.Error(fmt.Sprintf("sync job failed [id : %s, attempts : %d] : interrupted too many times", job.ID, job.Attempts))
+		_, err := finish(w.db, job.ID, job.Attempts, 0, http.StatusInternalServerError, interruptedResponse(job.Attempts-1))
+		return true, err
+	}
+	common.Log.Info(fmt.Sprintf("sync job started [id : %s, attempts : %d]", job.ID, job.Attempts))
+
+	var processed atomic.Int64
+	var leaseLost atomic.Bool
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		w.keepAlive(w.db, job, &processed, &leaseLost, stop)
+	}()
+	progress := func(n int) error {
+		processed.Store(int64(n))
+		if leaseLost.Load() {
+			return ErrLeaseLost
+		}
+		return nil
+	}
+	finishInTx := func(tx *sql.Tx, statusCode int, result any) error {
+		finished, err := finish(tx, job.ID, job.Attempts, int(processed.Load()), statusCode, result)
+		if err != nil {
+			return err
+		}
+		if !finished {
+			return ErrLeaseLost
+		}
+		return nil
+	}
+	statusCode, result := w.runJob(job, progress, finishInTx)
+	close(stop)
+	<-stopped
+
+	if leaseLost.Load() {
+		common.Log.Warn(fmt.Sprintf("sync job stopped [id : %s, attempts : %d] : %s", job.ID, job.Attempts, ErrLeaseLost.Error()))
+		return true, nil
+	}
+	common.Log.Info(fmt.Sprintf("sync job finished [id : %s, status code : %d, processed : %d]", job.ID, statusCode, processed.Load()))
+	// The response of a committed hardware sync has already been recorded in its transaction, in which case nothing is updated
+	_, err = finish(w.db, job.ID, job.Attempts, int(processed.Load()), statusCode, result)
+	return true, err
+}
+
+// runJob runs the job with the Runner. A panic of the Runner fails the job instead of stopping the worker.
+func (w *Worker) runJob(job Job, progress func(processed int) error, finish Finish) (statusCode int, result any) {
+	defer func() {
+		if r := recover(); r != nil {
+			common.Log.Error(fmt.Sprintf("sync job panicked [id : %s] : %v", job.ID, r))
+			statusCode = http.StatusInternalServerError
+			result = errorResponse(fmt.Sprintf("sync job panicked: %v", r))
+		}
+	}()
+	return w.run(job, progress, finish)
+}
+
+// keepAlive records the number of the processed devices as the heartbeat of the running job, until stop is closed
+// or the job is no longer running under its claim, which is reported with leaseLost.
+// A failed heartbeat is only logged, and the next one is tried at the next interval.
+func (w *Worker) keepAlive(executor Executor, job Job, processed *atomic.Int64, leaseLost *atomic.Bool, stop <-chan struct{}) {
+	ticker := time.NewTicker(w.heartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			alive, err := heartbeat(executor, job.ID, job.Attempts, int(processed.Load()))
+			if err != nil {
+				common.Log.Warn(fmt.Sprintf("sync job heartbeat error [id : %s] : %s", job.ID, err.Error()))
+				continue
+			}
+			if !alive {
+				leaseLost.Store(true)
+				return
+			}
+		}
+	}
+}
+
+// interruptedResponse returns the error response of a job interrupted the number of times.
+func interruptedResponse(interrupted int) map[string]any {
+	return errorResponse(fmt.Sprintf("sync job was interrupted %d times", interrupted))
+}
+
+// errorResponse returns the same body as the 500 Internal Server Error response of the API, with details.
+func errorResponse(details string) map[string]any {
+	return map[string]any{
+		"code":    "internalServerError",
+		"message": "Internal Server Error. Contact the administrator.",
+		"details": details,
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package syncjob
+
+import (
+	"database/sql"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewWorker(t *testing.T) {
+	db := &sql.DB{}
+	w := NewWorker(db, func(job Job, progress func(processed int) error, finish Finish) (int, any) {
+		return http.StatusCreated, nil
+	})
+	if w.db != db {
+		t.Errorf("NewWorker() db = %v, want %v", w.db, db)
+	}
+	if w.run == nil || w.interval != time.Second || w.heartbeatInterval != 5*time.Second || w.lease != time.Minute || w.maxAttempts != 3 {
+		t.Errorf("NewWorker() = %+v", w)
+	}
+}
+
+func TestWorker_Run(t *testing.T) {
+	t.Skip("not test")
+}
+
+func TestWorker_runOnce(t *testing.T) {
+	t.Skip("not test")
+}
+
+func TestWorker_runJob(t *testing.T) {
+	tests := []struct {
+		name           string
+		run            Runner
+		leaseLost      bool
+		wantStatusCode int
+		wantResult     any
+		wantProcessed  []int
+		wantFinished   []int // Status codes recorded with finish
+	}{
+		{
+			name: "Normal case: The runner returns the response",
+			run: func(job Job, progress func(processed int) error, finish Finish) (int, any) {
+				progress(500)
+				progress(800)
+				if err := finish(nil, http.StatusCreated, map[string]any{"count": 800}); err != nil {
+					return http.StatusInternalServerError, errorResponse(err.Error())
+				}
+				return http.StatusCreated, map[string]any{"count": 800}
+			},
+			wantStatusCode: http.StatusCreated,
+			wantResult:     map[string]any{"count": 800},
+			wantProcessed:  []int{500, 800},
+			wantFinished:   []int{http.StatusCreated},
+		},
+		{
+			name: "Error case: The runner stops when the job has been claimed again",
+			run: func(job Job, progress func(processed int) error, finish Finish) (int, any) {
+				if err := progress(500); err != nil {
+					return http.StatusInternalServerError, errorResponse(err.Error())
+				}
+				return http.StatusCreated, map[string]any{"count": 800}
+			},
+			leaseLost:      true,
+			wantStatusCode: http.StatusInternalServerError,
+			wantResult:     errorResponse(ErrLeaseLost.Error()),
+			wantProcessed:  []int{500},
+			wantFinished:   []int{},
+		},
+		{
+			name: "Error case: The runner panics",
+			run: func(job Job, progress func(processed int) error, finish Finish) (int, any) {
+				progress(500)
+				panic("unexpected")
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantResult:     errorResponse("sync job panicked: unexpected"),
+			wantProcessed:  []int{500},
+			wantFinished:   []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewWorker(nil, tt.run)
+			processed := []int{}
+			progress := func(n int) error {
+				processed = append(processed, n)
+				if tt.leaseLost {
+					return ErrLeaseLost
+				}
+				return nil
+			}
+			finished := []int{}
+			finish := func(tx *sql.Tx, statusCode int, result any) error {
+				finished = append(finished, statusCode)
+				return nil
+			}
+			statusCode, result := w.runJob(Job{ID: "job001"}, progress, finish)
+			if statusCode != tt.wantStatusCode {
+				t.Errorf("runJob() statusCode = %v, want %v", statusCode, tt.wantStatusCode)
+			}
+			if !reflect.DeepEqual(result, tt.wantResult) {
+				t.Errorf("runJob() result = %v, want %v", result, tt.wantResult)
+			}
+			if !reflect.DeepEqual(processed, tt.wantProcessed) {
+				t.Errorf("runJob() processed = %v, want %v", processed, tt.wantProcessed)
+			}
+			if !reflect.DeepEqual(finished, tt.wantFinished) {
+				t.Errorf("runJob() finished = %v, want %v", finished, tt.wantFinished)
+			}
+		})
+	}
+}
+
+func TestWorker_keepAlive(t *testing.T) {
+	t.Skip("not test")
+}
+
+func Test_interruptedResponse(t *testing.T) {
+	want := map[string]any{"code": "internalServerError", "message": "Internal Server Error. Contact the administrator.", "details": "sync job was interrupted 3 times"}
+	if got := interruptedResponse(3); !reflect.DeepEqual(got, want) {
+		t.Errorf("interruptedResponse() = %v, want %v", got, want)
+	}
+}
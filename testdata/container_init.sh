@@ -12,6 +12,7 @@ psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "$POSTGRES_DB" <<-E
 
     SELECT CREATE_VLABEL('cdim_graph', 'CXLswitch');
     SELECT CREATE_VLABEL('cdim_graph', 'Annotation');
+    -- Labels of the built-in resource types. The labels of the types added by CM_RESOURCE_TYPES_FILE are created at startup.
     SELECT CREATE_VLABEL('cdim_graph', 'CPU');
     SELECT CREATE_VLABEL('cdim_graph', 'Memory');
     SELECT CREATE_VLABEL('cdim_graph', 'Storage');
@@ -28,6 +29,9 @@ psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "$POSTGRES_DB" <<-E
     SELECT CREATE_VLABEL('cdim_graph', 'Chassis');
     SELECT CREATE_VLABEL('cdim_graph', 'NotDetectedDevice');
     SELECT CREATE_VLABEL('cdim_graph', 'ResourceGroups');
+    SELECT CREATE_VLABEL('cdim_graph', 'AssignmentRules');
+    SELECT CREATE_VLABEL('cdim_graph', 'SyncSource');
+    SELECT CREATE_VLABEL('cdim_graph', 'QuarantinedDevice');
 
     SELECT CREATE_ELABEL('cdim_graph', 'Connect');
     SELECT CREATE_ELABEL('cdim_graph', 'Compose');
@@ -36,6 +40,7 @@ psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "$POSTGRES_DB" <<-E
     SELECT CREATE_ELABEL('cdim_graph', 'Have');
     SELECT CREATE_ELABEL('cdim_graph', 'Include');
     SELECT CREATE_ELABEL('cdim_graph', 'NotDetected');
+    SELECT CREATE_ELABEL('cdim_graph', 'ReportedBy');
 
     CREATE INDEX cdim_graph_CXLswitch_idx ON cdim_graph."CXLswitch" USING gin (properties);
     CREATE INDEX cdim_graph_Annotation_idx ON cdim_graph."Annotation" USING gin (properties);
@@ -55,8 +60,75 @@ psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "$POSTGRES_DB" <<-E
     CREATE INDEX cdim_graph_Chassis_idx ON cdim_graph."Chassis" USING gin (properties);
     CREATE INDEX cdim_graph_NotDetectedDevice_idx ON cdim_graph."NotDetectedDevice" USING gin (properties);
     CREATE INDEX cdim_graph_ResourceGroups_idx ON cdim_graph."ResourceGroups" USING gin (properties);
+    CREATE INDEX cdim_graph_AssignmentRules_idx ON cdim_graph."AssignmentRules" USING gin (properties);
+    CREATE INDEX cdim_graph_SyncSource_idx ON cdim_graph."SyncSource" USING gin (properties);
+    CREATE INDEX cdim_graph_QuarantinedDevice_idx ON cdim_graph."QuarantinedDevice" USING gin (properties);
 
     SELECT * FROM cypher('cdim_graph', \$\$ CREATE (a: NotDetectedDevice) \$\$) AS (a agtype);
 
     SELECT * FROM cypher('cdim_graph', \$\$ CREATE (a: ResourceGroups {id: "00000000-0000-7000-8000-000000000000", name: "default", description: "default group", createdAt: "$current_datetime_iso8601", updatedAt: "$current_datetime_iso8601"}) \$\$) AS (a agtype);
+
+    CREATE TABLE IF NOT EXISTS public.configuration_manager_outbox (
+        id text PRIMARY KEY,
+        pubsub_name text NOT NULL,
+        topic text NOT NULL,
+        event_type text NOT NULL,
+        payload jsonb NOT NULL,
+        created_at timestamptz NOT NULL DEFAULT now(),
+        attempts integer NOT NULL DEFAULT 0,
+        next_attempt_at timestamptz NOT NULL DEFAULT now(),
+        delivered_at timestamptz,
+        last_error text NOT NULL DEFAULT ''
+    );
+
+    CREATE TABLE IF NOT EXISTS public.configuration_manager_changes (
+        seq bigserial PRIMARY KEY,
+        id text NOT NULL,
+        type text NOT NULL,
+        subject text NOT NULL,
+        data jsonb NOT NULL,
+        created_at timestamptz NOT NULL DEFAULT now()
+    );
+    CREATE TABLE IF NOT EXISTS public.configuration_manager_webhooks (
+        id text PRIMARY KEY,
+        url text NOT NULL,
+        event_types jsonb NOT NULL,
+        secret text NOT NULL,
+        created_at timestamptz NOT NULL DEFAULT now()
+    );
+    CREATE TABLE IF NOT EXISTS public.configuration_manager_webhook_deliveries (
+        id text PRIMARY KEY,
+        webhook_id text NOT NULL REFERENCES public.configuration_manager_webhooks (id) ON DELETE CASCADE,
+        event_id text NOT NULL,
+        event_type text NOT NULL,
+        payload jsonb NOT NULL,
+        status text NOT NULL DEFAULT 'pending',
+        attempts integer NOT NULL DEFAULT 0,
+        next_attempt_at timestamptz NOT NULL DEFAULT now(),
+        last_attempt_at timestamptz,
+        last_status_code integer,
+        last_error text,
+        delivered_at timestamptz,
+        created_at timestamptz NOT NULL DEFAULT now(),
+        UNIQUE (webhook_id, event_id)
+    );
+    CREATE INDEX IF NOT EXISTS configuration_manager_webhook_deliveries_pending
+        ON public.configuration_manager_webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+
+    CREATE TABLE IF NOT EXISTS public.configuration_manager_sync_jobs (
+        id text PRIMARY KEY,
+        status text NOT NULL DEFAULT 'queued',
+        parameters jsonb NOT NULL,
+        content bytea,
+        processed integer NOT NULL DEFAULT 0,
+        attempts integer NOT NULL DEFAULT 0,
+        status_code integer,
+        result jsonb,
+        created_at timestamptz NOT NULL DEFAULT now(),
+        started_at timestamptz,
+        heartbeat_at timestamptz,
+        finished_at timestamptz
+    );
+    CREATE INDEX IF NOT EXISTS configuration_manager_sync_jobs_unfinished
+        ON public.configuration_manager_sync_jobs (created_at) WHERE status IN ('queued', 'running');
 EOSQL
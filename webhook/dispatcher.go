@@ -0,0 +1,53 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/publisher"
+)
+
+// Dispatcher is a publisher.Publisher queueing the deliveries of each event to the webhooks subscribing to its type.
+// Combined with the backend of the outbox relay by publisher.MultiPublisher, the webhooks receive the same events as the backend.
+// The deliveries are made by the Worker; an event dispatched again is not delivered twice to the same webhook.
+type Dispatcher struct {
+	db *sql.DB
+}
+
+// NewDispatcher creates a Dispatcher queueing the deliveries through db,
+// the connection pool shared with the other background workers, which is not closed by the Dispatcher.
+func NewDispatcher(db *sql.DB) *Dispatcher {
+	return &Dispatcher{db: db}
+}
+
+// Publish queues the deliveries of the message to the subscribing webhooks.
+func (d *Dispatcher) Publish(ctx context.Context, message publisher.Message) error {
+	webhooks, err := List(d.db)
+	if err != nil {
+		return err
+	}
+	enqueued, err := enqueueDeliveries(d.db, webhooks, message.ID, message.Type, message.Payload)
+	if err != nil {
+		return err
+	}
+	if enqueued > 0 {
+		common.Log.Debug(fmt.Sprintf("webhook deliveries queued [id : %s, type : %s, webhooks : %d]", message.ID, message.Type, enqueued))
+	}
+	return nil
+}
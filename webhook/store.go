@@ -0,0 +1,387 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package webhook
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/project-cdim/configuration-manager/common"
+)
+
+const sqlInsertWebhook string = `
+INSERT INTO public.configuration_manager_webhooks (id, url, event_types, secret)
+VALUES ($1, $2, $3, $4)
+RETURNING created_at
+`
+
+const sqlSelectWebhooks string = `
+SELECT id, url, event_types, secret, created_at
+FROM public.configuration_manager_webhooks
+ORDER BY created_at, id
+`
+
+const sqlSelectWebhook string = `
+SELECT id, url, event_types, secret, created_at
+FROM public.configuration_manager_webhooks
+WHERE id = $1
+`
+
+const sqlDeleteWebhook string = `
+DELETE FROM public.configuration_manager_webhooks WHERE id = $1
+`
+
+// The same event is delivered once per webhook, even if it is dispatched again after a failed publication to the other backend.
+const sqlInsertDelivery string = `
+INSERT INTO public.configuration_manager_webhook_deliveries (id, webhook_id, event_id, event_type, payload)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (webhook_id, event_id) DO NOTHING
+`
+
+const sqlSelectPendingDeliveries string = `
+SELECT d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.attempts, w.url, w.secret
+FROM public.configuration_manager_webhook_deliveries d
+JOIN public.configuration_manager_webhooks w ON w.id = d.webhook_id
+WHERE d.status = 'pending' AND d.next_attempt_at <= now()
+ORDER BY d.created_at, d.id
+LIMIT $1
+FOR UPDATE OF d SKIP LOCKED
+`
+
+const sqlUpdateDeliveryDelivered string = `
+UPDATE public.configuration_manager_webhook_deliveries
+SET status = 'delivered', attempts = attempts + 1, last_attempt_at = now(), last_status_code = $2, last_error = NULL, delivered_at = now()
+WHERE id = $1
+`
+
+const sqlUpdateDeliveryFailed string = `
+UPDATE public.configuration_manager_webhook_deliveries
+SET status = $2, attempts = attempts + 1, last_attempt_at = now(), last_status_code = $3, last_error = $4, next_attempt_at = $5
+WHERE id = $1
+`
+
+const sqlSelectDeliveryColumns string = `
+SELECT id, webhook_id, event_id, event_type, status, attempts, next_attempt_at, last_attempt_at, last_status_code, last_error, delivered_at, created_at
+FROM public.configuration_manager_webhook_deliveries
+`
+
+const sqlSelectDeliveriesOfWebhook string = sqlSelectDeliveryColumns + `WHERE webhook_id = $1 AND ($2 = '' OR status = $2)
+ORDER BY created_at DESC, id DESC
+LIMIT $3
+`
+
+const sqlSelectDeadLetters string = sqlSelectDeliveryColumns + `WHERE status = 'deadLetter'
+ORDER BY created_at DESC, id DESC
+LIMIT $1
+`
+
+// Statuses of a delivery
+const (
+	DeliveryStatusPending    = "pending"    // Waiting for the first attempt or a retry
+	DeliveryStatusDelivered  = "delivered"  // Accepted by the receiver
+	DeliveryStatusDeadLetter = "deadLetter" // Given up after the maximum number of attempts
+)
+
+// Executor is a database handle on which the webhooks are read or written, satisfied by both *sql.DB and *sql.Tx.
+type Executor interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+// Delivery is the state of the delivery of an event to a webhook.
+type Delivery struct {
+	ID             string
+	WebhookID      string
+	EventID        string
+	EventType      string
+	Status         string
+	Attempts       int
+	NextAttemptAt  string
+	LastAttemptAt  string
+	LastStatusCode int
+	LastError      string
+	DeliveredAt    string
+	CreatedAt      string
+}
+
+// ToObject converts the delivery into a map for the response. The attributes of the attempts not made yet are omitted.
+func (d *Delivery) ToObject() map[string]any {
+	res := map[string]any{
+		"id":        d.ID,
+		"webhookID": d.WebhookID,
+		"eventID":   d.EventID,
+		"eventType": d.EventType,
+		"status":    d.Status,
+		"attempts":  d.Attempts,
+		"createdAt": d.CreatedAt,
+	}
+	if d.Status == DeliveryStatusPending {
+		res["nextAttemptAt"] = d.NextAttemptAt
+	}
+	if len(d.LastAttemptAt) > 0 {
+		res["lastAttemptAt"] = d.LastAttemptAt
+	}
+	if d.LastStatusCode != 0 {
+		res["lastStatusCode"] = d.LastStatusCode
+	}
+	if len(d.LastError) > 0 {
+		res["lastError"] = d.LastError
+	}
+	if len(d.DeliveredAt) > 0 {
+		res["deliveredAt"] = d.DeliveredAt
+	}
+	return res
+}
+
+// pendingDelivery is a delivery due for an attempt, with the destination of the webhook.
+type pendingDelivery struct {
+	ID        string
+	WebhookID string
+	EventID   string
+	EventType string
+	Payload   []byte
+	Attempts  int
+	URL       string
+	Secret    string
+}
+
+// formatTime formats the time in ISO 8601 in UTC.
+func formatTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05Z07:00")
+}
+
+// Create registers the webhook with a new ID, and returns the registered webhook.
+func Create(executor Executor, webhook Webhook) (Webhook, error) {
+	id, _ := uuid.NewV7()
+	webhook.ID = id.String()
+	eventTypes, err := json.Marshal(webhook.EventTypes)
+	if err != nil {
+		return webhook, err
+	}
+
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s, param3: %s", sqlInsertWebhook, webhook.ID, webhook.URL, eventTypes))
+	rows, err := executor.Query(sqlInsertWebhook, webhook.ID, webhook.URL, eventTypes, webhook.Secret)
+	if err != nil {
+		common.Log.Error(err.Error())
+		return webhook, err
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		var createdAt time.Time
+		if err := rows.Scan(&createdAt); err != nil {
+			common.Log.Error(err.Error())
+			return webhook, err
+		}
+		webhook.CreatedAt = formatTime(createdAt)
+	}
+	if err := rows.Err(); err != nil {
+		common.Log.Error(err.Error())
+		return webhook, err
+	}
+	return webhook, nil
+}
+
+// List returns all the registered webhooks in the order of their registration.
+func List(executor Executor) ([]Webhook, error) {
+	common.Log.Debug(fmt.Sprintf("query: %s", sqlSelectWebhooks))
+	return queryWebhooks(executor, sqlSelectWebhooks)
+}
+
+// Find returns the webhook of id. The second return value is false if the webhook does not exist.
+func Find(executor Executor, id string) (Webhook, bool, error) {
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s", sqlSelectWebhook, id))
+	webhooks, err := queryWebhooks(executor, sqlSelectWebhook, id)
+	if err != nil || len(webhooks) == 0 {
+		return Webhook{}, false, err
+	}
+	return webhooks[0], true, nil
+}
+
+// Delete deletes the webhook of id together with its deliveries. It returns false if the webhook does not exist.
+func Delete(executor Executor, id string) (bool, error) {
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s", sqlDeleteWebhook, id))
+	result, err := executor.Exec(sqlDeleteWebhook, id)
+	if err != nil {
+		common.Log.Error(err.Error())
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		common.Log.Error(err.Error())
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// queryWebhooks runs the query selecting webhooks and scans the result.
+func queryWebhooks(executor Executor, query string, args ...any) ([]Webhook, error) {
+	rows, err := executor.Query(query, args...)
+	if err != nil {
+		common.Log.Error(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := []Webhook{}
+	for rows.Next() {
+		var webhook Webhook
+		var eventTypes []byte
+		var createdAt time.Time
+		if err := rows.Scan(&webhook.ID, &webhook.URL, &eventTypes, &webhook.Secret, &createdAt); err != nil {
+			common.Log.Error(err.Error())
+			return nil, err
+		}
+		if err := json.Unmarshal(eventTypes, &webhook.EventTypes); err != nil {
+			common.Log.Error(fmt.Sprintf("webhook event types unmarshal error [id : %s] : %s", webhook.ID, err.Error()))
+			return nil, err
+		}
+		webhook.CreatedAt = formatTime(createdAt)
+		webhooks = append(webhooks, webhook)
+	}
+	if err := rows.Err(); err != nil {
+		common.Log.Error(err.Error())
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// enqueueDeliveries adds a pending delivery of the event to each of webhooks subscribing to its type, and returns the number of the added deliveries.
+func enqueueDeliveries(executor Executor, webhooks []Webhook, eventID string, eventType string, payload []byte) (int, error) {
+	enqueued := 0
+	for _, webhook := range webhooks {
+		if !webhook.Matches(eventType) {
+			continue
+		}
+		id, _ := uuid.NewV7()
+		common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s, param3: %s, param4: %s", sqlInsertDelivery, id.String(), webhook.ID, eventID, eventType))
+		if _, err := executor.Exec(sqlInsertDelivery, id.String(), webhook.ID, eventID, eventType, payload); err != nil {
+			common.Log.Error(err.Error())
+			return enqueued, err
+		}
+		enqueued++
+	}
+	return enqueued, nil
+}
+
+// fetchPendingDeliveries locks and returns up to limit deliveries due for an attempt, skipping those locked by another worker.
+func fetchPendingDeliveries(tx *sql.Tx, limit int) ([]pendingDelivery, error) {
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %d", sqlSelectPendingDeliveries, limit))
+	rows, err := tx.Query(sqlSelectPendingDeliveries, limit)
+	if err != nil {
+		common.Log.Error(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []pendingDelivery{}
+	for rows.Next() {
+		var delivery pendingDelivery
+		if err := rows.Scan(&delivery.ID, &delivery.WebhookID, &delivery.EventID, &delivery.EventType, &delivery.Payload, &delivery.Attempts, &delivery.URL, &delivery.Secret); err != nil {
+			common.Log.Error(err.Error())
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if err := rows.Err(); err != nil {
+		common.Log.Error(err.Error())
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// markDelivered records the successful attempt of the delivery of id.
+func markDelivered(tx *sql.Tx, id string, statusCode int) error {
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %d", sqlUpdateDeliveryDelivered, id, statusCode))
+	if _, err := tx.Exec(sqlUpdateDeliveryDelivered, id, statusCode); err != nil {
+		common.Log.Error(err.Error())
+		return err
+	}
+	return nil
+}
+
+// markFailed records the failed attempt of the delivery of id.
+// If deadLetter is true, the delivery is given up and moved to the dead-letter list; otherwise it is retried at nextAttemptAt.
+// statusCode is 0 if no response was received.
+func markFailed(tx *sql.Tx, id string, deadLetter bool, statusCode int, lastError string, nextAttemptAt time.Time) error {
+	status := DeliveryStatusPending
+	if deadLetter {
+		status = DeliveryStatusDeadLetter
+	}
+	code := sql.NullInt64{Int64: int64(statusCode), Valid: statusCode != 0}
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s, param3: %d, param4: %s", sqlUpdateDeliveryFailed, id, status, statusCode, lastError))
+	if _, err := tx.Exec(sqlUpdateDeliveryFailed, id, status, code, lastError, nextAttemptAt); err != nil {
+		common.Log.Error(err.Error())
+		return err
+	}
+	return nil
+}
+
+// ListDeliveries returns up to limit deliveries of the webhook of webhookID, newest first.
+// If status is not empty, only the deliveries in the status are returned.
+func ListDeliveries(executor Executor, webhookID string, status string, limit int) ([]Delivery, error) {
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %s, param2: %s, param3: %d", sqlSelectDeliveriesOfWebhook, webhookID, status, limit))
+	return queryDeliveries(executor, sqlSelectDeliveriesOfWebhook, webhookID, status, limit)
+}
+
+// ListDeadLetters returns up to limit deliveries given up over all the webhooks, newest first.
+func ListDeadLetters(executor Executor, limit int) ([]Delivery, error) {
+	common.Log.Debug(fmt.Sprintf("query: %s, param1: %d", sqlSelectDeadLetters, limit))
+	return queryDeliveries(executor, sqlSelectDeadLetters, limit)
+}
+
+// queryDeliveries runs the query selecting deliveries and scans the result.
+func queryDeliveries(executor Executor, query string, args ...any) ([]Delivery, error) {
+	rows, err := executor.Query(query, args...)
+	if err != nil {
+		common.Log.Error(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []Delivery{}
+	for rows.Next() {
+		var delivery Delivery
+		var nextAttemptAt, createdAt time.Time
+		var lastAttemptAt, deliveredAt sql.NullTime
+		var lastStatusCode sql.NullInt64
+		var lastError sql.NullString
+		if err := rows.Scan(&delivery.ID, &delivery.WebhookID, &delivery.EventID, &delivery.EventType, &delivery.Status, &delivery.Attempts,
+			&nextAttemptAt, &lastAttemptAt, &lastStatusCode, &lastError, &deliveredAt, &createdAt); err != nil {
+			common.Log.Error(err.Error())
+			return nil, err
+		}
+		delivery.NextAttemptAt = formatTime(nextAttemptAt)
+		delivery.CreatedAt = formatTime(createdAt)
+		if lastAttemptAt.Valid {
+			delivery.LastAttemptAt = formatTime(lastAttemptAt.Time)
+		}
+		if deliveredAt.Valid {
+			delivery.DeliveredAt = formatTime(deliveredAt.Time)
+		}
+		delivery.LastStatusCode = int(lastStatusCode.Int64)
+		delivery.LastError = lastError.String
+		deliveries = append(deliveries, delivery)
+	}
+	if err := rows.Err(); err != nil {
+		common.Log.Error(err.Error())
+		return nil, err
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,89 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package webhook
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDelivery_ToObject(t *testing.T) {
+	tests := []struct {
+		name     string
+		delivery Delivery
+		want     map[string]any
+	}{
+		{
+			name: "Normal case: Pending delivery not attempted yet",
+			delivery: Delivery{ID: "delivery001", WebhookID: "hook001", EventID: "event001", EventType: "resource.created", Status: "pending",
+				NextAttemptAt: "2025-01-01T00:00:00Z", CreatedAt: "2025-01-01T00:00:00Z"},
+			want: map[string]any{"id": "delivery001", "webhookID": "hook001", "eventID": "event001", "eventType": "resource.created", "status": "pending",
+				"attempts": 0, "nextAttemptAt": "2025-01-01T00:00:00Z", "createdAt": "2025-01-01T00:00:00Z"},
+		},
+		{
+			name: "Normal case: Delivery in the dead-letter list",
+			delivery: Delivery{ID: "delivery001", WebhookID: "hook001", EventID: "event001", EventType: "resource.created", Status: "deadLetter", Attempts: 10,
+				NextAttemptAt: "2025-01-01T01:00:00Z", LastAttemptAt: "2025-01-01T00:50:00Z", LastStatusCode: 503, LastError: "webhook returned 503 Service Unavailable",
+				CreatedAt: "2025-01-01T00:00:00Z"},
+			want: map[string]any{"id": "delivery001", "webhookID": "hook001", "eventID": "event001", "eventType": "resource.created", "status": "deadLetter",
+				"attempts": 10, "lastAttemptAt": "2025-01-01T00:50:00Z", "lastStatusCode": 503, "lastError": "webhook returned 503 Service Unavailable",
+				"createdAt": "2025-01-01T00:00:00Z"},
+		},
+		{
+			name: "Normal case: Delivered delivery",
+			delivery: Delivery{ID: "delivery001", WebhookID: "hook001", EventID: "event001", EventType: "resource.created", Status: "delivered", Attempts: 1,
+				NextAttemptAt: "2025-01-01T00:00:00Z", LastAttemptAt: "2025-01-01T00:00:01Z", LastStatusCode: 200, DeliveredAt: "2025-01-01T00:00:01Z",
+				CreatedAt: "2025-01-01T00:00:00Z"},
+			want: map[string]any{"id": "delivery001", "webhookID": "hook001", "eventID": "event001", "eventType": "resource.created", "status": "delivered",
+				"attempts": 1, "lastAttemptAt": "2025-01-01T00:00:01Z", "lastStatusCode": 200, "deliveredAt": "2025-01-01T00:00:01Z",
+				"createdAt": "2025-01-01T00:00:00Z"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.delivery.ToObject(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ToObject() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	t.Skip("not test")
+}
+
+func TestList(t *testing.T) {
+	t.Skip("not test")
+}
+
+func TestFind(t *testing.T) {
+	t.Skip("not test")
+}
+
+func TestDelete(t *testing.T) {
+	t.Skip("not test")
+}
+
+func TestListDeliveries(t *testing.T) {
+	t.Skip("not test")
+}
+
+func TestListDeadLetters(t *testing.T) {
+	t.Skip("not test")
+}
+
+func Test_enqueueDeliveries(t *testing.T) {
+	t.Skip("not test")
+}
@@ -0,0 +1,138 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// Webhook is a subscription delivering the events whose types match EventTypes to URL.
+// An empty EventTypes matches all events. An element ending with ".*" matches the events of a category, e.g. "resource.*".
+// The body of each delivery is signed with Secret.
+type Webhook struct {
+	ID         string
+	URL        string
+	EventTypes []string
+	Secret     string
+	CreatedAt  string
+}
+
+// ValidateProperty validates the properties of the request to register a webhook and returns the webhook to register.
+// url is required and must be an absolute HTTP(S) URL. eventTypes and secret are optional.
+// If secret is not specified, a random secret is generated.
+func ValidateProperty(properties map[string]any) (Webhook, error) {
+	webhook := Webhook{EventTypes: []string{}}
+
+	callbackURL, ok := properties["url"].(string)
+	if !ok {
+		return webhook, fmt.Errorf("url is required")
+	}
+	parsed, err := url.Parse(callbackURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || len(parsed.Host) == 0 {
+		return webhook, fmt.Errorf("url is invalid: %q", callbackURL)
+	}
+	webhook.URL = callbackURL
+
+	if value, exists := properties["eventTypes"]; exists {
+		eventTypes, ok := value.([]any)
+		if !ok {
+			return webhook, fmt.Errorf("eventTypes must be an array of strings")
+		}
+		for _, eventType := range eventTypes {
+			str, ok := eventType.(string)
+			if !ok || len(strings.TrimSpace(str)) == 0 {
+				return webhook, fmt.Errorf("eventTypes must be an array of strings")
+			}
+			if !slices.Contains(webhook.EventTypes, str) {
+				webhook.EventTypes = append(webhook.EventTypes, str)
+			}
+		}
+	}
+
+	if value, exists := properties["secret"]; exists {
+		secret, ok := value.(string)
+		if !ok || len(secret) == 0 {
+			return webhook, fmt.Errorf("secret must be a non-empty string")
+		}
+		webhook.Secret = secret
+	} else {
+		webhook.Secret, err = generateSecret()
+		if err != nil {
+			return webhook, err
+		}
+	}
+
+	return webhook, nil
+}
+
+// Matches reports whether the webhook subscribes to the events of eventType.
+func (w *Webhook) Matches(eventType string) bool {
+	if len(w.EventTypes) == 0 {
+		return true
+	}
+	for _, pattern := range w.EventTypes {
+		if pattern == "*" || pattern == eventType {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok && strings.HasPrefix(eventType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ToObject converts the webhook into a map for the response. The secret is not included.
+func (w *Webhook) ToObject() map[string]any {
+	return map[string]any{
+		"id":         w.ID,
+		"url":        w.URL,
+		"eventTypes": w.EventTypes,
+		"createdAt":  w.CreatedAt,
+	}
+}
+
+// ToObjectWithSecret converts the webhook into a map including the secret. It is used only for the response of the registration.
+func (w *Webhook) ToObjectWithSecret() map[string]any {
+	res := w.ToObject()
+	res["secret"] = w.Secret
+	return res
+}
+
+// generateSecret generates a random secret of 32 bytes in hexadecimal.
+func generateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// Sign returns the signature of a delivery, which is the HMAC-SHA256 of "<timestamp>.<body>" with secret in hexadecimal.
+// The receiver recomputes it from the X-Webhook-Timestamp header and the body, and compares it with the X-Webhook-Signature header
+// after removing the "sha256=" prefix. Including the timestamp lets the receiver reject replayed deliveries.
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
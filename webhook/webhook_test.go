@@ -0,0 +1,142 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package webhook
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateProperty(t *testing.T) {
+	tests := []struct {
+		name       string
+		properties map[string]any
+		want       Webhook
+		wantErr    bool
+	}{
+		{
+			name:       "Normal case: All the properties are specified",
+			properties: map[string]any{"url": "https://example.com/hook", "eventTypes": []any{"resource.created", "node.*", "resource.created"}, "secret": "s3cr3t"},
+			want:       Webhook{URL: "https://example.com/hook", EventTypes: []string{"resource.created", "node.*"}, Secret: "s3cr3t"},
+			wantErr:    false,
+		},
+		{
+			name:       "Normal case: Only url is specified",
+			properties: map[string]any{"url": "http://localhost:8000/hook"},
+			want:       Webhook{URL: "http://localhost:8000/hook", EventTypes: []string{}},
+			wantErr:    false,
+		},
+		{
+			name:       "Error case: url is not specified",
+			properties: map[string]any{"eventTypes": []any{}},
+			wantErr:    true,
+		},
+		{
+			name:       "Error case: url is not an HTTP URL",
+			properties: map[string]any{"url": "ftp://example.com/hook"},
+			wantErr:    true,
+		},
+		{
+			name:       "Error case: url is relative",
+			properties: map[string]any{"url": "/hook"},
+			wantErr:    true,
+		},
+		{
+			name:       "Error case: eventTypes is not an array",
+			properties: map[string]any{"url": "http://localhost/hook", "eventTypes": "resource.created"},
+			wantErr:    true,
+		},
+		{
+			name:       "Error case: eventTypes has an empty string",
+			properties: map[string]any{"url": "http://localhost/hook", "eventTypes": []any{" "}},
+			wantErr:    true,
+		},
+		{
+			name:       "Error case: secret is empty",
+			properties: map[string]any{"url": "http://localhost/hook", "secret": ""},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateProperty(tt.properties)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateProperty() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(tt.want.Secret) == 0 {
+				// A random secret is generated
+				if len(got.Secret) != 64 {
+					t.Errorf("ValidateProperty() secret = %v, want a generated secret", got.Secret)
+				}
+				got.Secret = ""
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateProperty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhook_Matches(t *testing.T) {
+	tests := []struct {
+		name       string
+		eventTypes []string
+		eventType  string
+		want       bool
+	}{
+		{name: "Normal case: No filter matches all the events", eventTypes: []string{}, eventType: "node.composed", want: true},
+		{name: "Normal case: Exact match", eventTypes: []string{"resource.created"}, eventType: "resource.created", want: true},
+		{name: "Normal case: Category wildcard", eventTypes: []string{"resource.*"}, eventType: "resource.notDetected", want: true},
+		{name: "Normal case: Wildcard for all the events", eventTypes: []string{"*"}, eventType: "cxlswitch.connected", want: true},
+		{name: "Normal case: Another category does not match", eventTypes: []string{"resource.*"}, eventType: "resourceGroup.created", want: false},
+		{name: "Normal case: Another type does not match", eventTypes: []string{"resource.created"}, eventType: "configuration_manager.hwsync.completed", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Webhook{EventTypes: tt.eventTypes}
+			if got := w.Matches(tt.eventType); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhook_ToObject(t *testing.T) {
+	w := &Webhook{ID: "hook001", URL: "http://localhost/hook", EventTypes: []string{"node.*"}, Secret: "s3cr3t", CreatedAt: "2025-01-01T00:00:00Z"}
+	want := map[string]any{"id": "hook001", "url": "http://localhost/hook", "eventTypes": []string{"node.*"}, "createdAt": "2025-01-01T00:00:00Z"}
+	if got := w.ToObject(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ToObject() = %v, want %v", got, want)
+	}
+	want["secret"] = "s3cr3t"
+	if got := w.ToObjectWithSecret(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ToObjectWithSecret() = %v, want %v", got, want)
+	}
+}
+
+func TestSign(t *testing.T) {
+	// Computed with: printf '1700000000.{"id":"event001"}' | openssl dgst -sha256 -hmac s3cr3t
+	want := "9f2bc683b539120f8bade3d39965c266386a4b31edf33a2f10d2327545fa14c8"
+	got := Sign("s3cr3t", "1700000000", []byte(`{"id":"event001"}`))
+	if got != want {
+		t.Errorf("Sign() = %v, want %v", got, want)
+	}
+	if Sign("other", "1700000000", []byte(`{"id":"event001"}`)) == got {
+		t.Errorf("Sign() does not depend on the secret")
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/project-cdim/configuration-manager/common"
+	"github.com/project-cdim/configuration-manager/publisher"
+)
+
+const (
+	defaultWorkerInterval  = 1 * time.Second  // Interval to poll the pending deliveries
+	defaultWorkerBatchSize = 50               // Maximum number of deliveries attempted in a poll
+	defaultMaxAttempts     = 10               // Number of attempts after which a delivery is moved to the dead-letter list
+	defaultBaseBackoff     = 1 * time.Second  // Delay before the first retry
+	defaultMaxBackoff      = 10 * time.Minute // Upper limit of the delay between retries
+	defaultDeliveryTimeout = 10 * time.Second // Timeout of an attempt
+	maxErrorBodyLength     = 512              // Maximum length of the response body recorded as the error of an attempt
+)
+
+// Headers of a delivery, in addition to the CloudEvents attributes in the binary content mode
+const (
+	HeaderWebhookID  = "X-Webhook-ID"        // ID of the webhook
+	HeaderDeliveryID = "X-Webhook-Delivery"  // ID of the delivery, the same across its retries
+	HeaderTimestamp  = "X-Webhook-Timestamp" // Time of the attempt in Unix seconds
+	HeaderSignature  = "X-Webhook-Signature" // "sha256=" followed by the result of Sign
+)
+
+// Worker delivers the pending deliveries to the webhooks.
+// A failed delivery is retried with an exponential backoff, and moved to the dead-letter list after maxAttempts attempts.
+type Worker struct {
+	db          *sql.DB
+	client      *http.Client
+	interval    time.Duration
+	batchSize   int
+	maxAttempts int
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+	now         func() time.Time
+}
+
+// NewWorker creates a Worker polling the pending deliveries through db,
+// the connection pool shared with the other background workers, which is not closed by the Worker.
+func NewWorker(db *sql.DB) Worker {
+	return Worker{
+		db:          db,
+		client:      &http.Client{Timeout: defaultDeliveryTimeout},
+		interval:    defaultWorkerInterval,
+		batchSize:   defaultWorkerBatchSize,
+		maxAttempts: defaultMaxAttempts,
+		baseBackoff: defaultBaseBackoff,
+		maxBackoff:  defaultMaxBackoff,
+		now:         time.Now,
+	}
+}
+
+// Run polls the pending deliveries and delivers them until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		if _, err := w.deliverOnce(ctx); err != nil {
+			common.Log.Warn(fmt.Sprintf("webhook worker error : %s", err.Error()))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// deliverOnce attempts the deliveries due in a transaction and returns the number of the successful ones.
+func (w *Worker) deliverOnce(ctx context.Context) (int, error) {
+	tx, err := w.db.Begin()
+	if err != nil {
+		common.Log.Error(err.Error())
+		return 0, err
+	}
+	// The rollback after the commit does nothing
+	defer tx.Rollback()
+
+	deliveries, err := fetchPendingDeliveries(tx, w.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, delivery := range deliveries {
+		statusCode, err := w.deliver(ctx, delivery)
+		if err != nil {
+			attempts := delivery.Attempts + 1
+			deadLetter := attempts >= w.maxAttempts
+			if deadLetter {
+				common.Log.Error(fmt.Sprintf("webhook delivery moved to the dead-letter list [id : %s, webhook : %s, event : %s, attempts : %d] : %s", delivery.ID, delivery.WebhookID, delivery.EventID, attempts, err.Error()))
+			} else {
+				common.Log.Warn(fmt.Sprintf("webhook delivery error [id : %s, webhook : %s, event : %s, attempts : %d] : %s", delivery.ID, delivery.WebhookID, delivery.EventID, attempts, err.Error()))
+			}
+			if err := markFailed(tx, delivery.ID, deadLetter, statusCode, err.Error(), w.now().Add(w.backoff(attempts))); err != nil {
+				return 0, err
+			}
+			continue
+		}
+		if err := markDelivered(tx, delivery.ID, statusCode); err != nil {
+			return 0, err
+		}
+		delivered++
+	}
+
+	if err := tx.Commit(); err != nil {
+		common.Log.Error(err.Error())
+		return 0, err
+	}
+	return delivered, nil
+}
+
+// deliver POSTs the payload of the delivery to the webhook, signed with its secret.
+// It returns the status code of the response, or 0 if no response was received. A response other than 2xx is treated as an error.
+func (w *Worker) deliver(ctx context.Context, delivery pendingDelivery) (int, error) {
+	req, err := w.newRequest(ctx, delivery)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyLength))
+	// Read the rest of the body so that the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if len(body) > 0 {
+			return resp.StatusCode, fmt.Errorf("webhook returned %s: %s", resp.Status, string(body))
+		}
+		return resp.StatusCode, fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// newRequest creates the request of the delivery.
+// The event is sent as a CloudEvent in the binary content mode, with the headers identifying the delivery and its signature.
+func (w *Worker) newRequest(ctx context.Context, delivery pendingDelivery) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return nil, err
+	}
+	timestamp := strconv.FormatInt(w.now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("ce-specversion", "1.0")
+	req.Header.Set("ce-id", delivery.EventID)
+	req.Header.Set("ce-type", delivery.EventType)
+	req.Header.Set("ce-source", publisher.CloudEventSource)
+	req.Header.Set(HeaderWebhookID, delivery.WebhookID)
+	req.Header.Set(HeaderDeliveryID, delivery.ID)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, "sha256="+Sign(delivery.Secret, timestamp, delivery.Payload))
+	return req, nil
+}
+
+// backoff returns the delay before the next attempt after the delivery has failed attempts times.
+// The delay doubles on every failure, starting from baseBackoff and capped at maxBackoff.
+func (w *Worker) backoff(attempts int) time.Duration {
+	delay := w.baseBackoff
+	for i := 1; i < attempts && delay < w.maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, w.maxBackoff)
+}
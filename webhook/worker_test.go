@@ -0,0 +1,128 @@
+// Copyright (C) 2025 NEC Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewWorker(t *testing.T) {
+	db := &sql.DB{}
+	w := NewWorker(db)
+	if w.db != db {
+		t.Errorf("NewWorker() db = %v, want %v", w.db, db)
+	}
+	if w.interval != time.Second || w.batchSize != 50 || w.maxAttempts != 10 || w.baseBackoff != time.Second || w.maxBackoff != 10*time.Minute {
+		t.Errorf("NewWorker() = %+v", w)
+	}
+}
+
+func TestWorker_backoff(t *testing.T) {
+	w := NewWorker(nil)
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: 1 * time.Second},
+		{attempts: 2, want: 2 * time.Second},
+		{attempts: 5, want: 16 * time.Second},
+		{attempts: 10, want: 512 * time.Second},
+		{attempts: 11, want: 10 * time.Minute},
+		{attempts: 100, want: 10 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := w.backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestWorker_deliver(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         int
+		wantStatusCode int
+		wantErr        bool
+	}{
+		{name: "Normal case: The receiver accepts the delivery", status: http.StatusNoContent, wantStatusCode: http.StatusNoContent, wantErr: false},
+		{name: "Error case: The receiver returns an error", status: http.StatusServiceUnavailable, wantStatusCode: http.StatusServiceUnavailable, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotHeader http.Header
+			var gotBody []byte
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				gotHeader = r.Header.Clone()
+				gotBody, _ = io.ReadAll(r.Body)
+				rw.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			w := NewWorker(nil)
+			w.now = func() time.Time { return time.Unix(1700000000, 0) }
+			delivery := pendingDelivery{ID: "delivery001", WebhookID: "hook001", EventID: "event001", EventType: "resource.created",
+				Payload: []byte(`{"id":"event001"}`), URL: server.URL, Secret: "s3cr3t"}
+
+			statusCode, err := w.deliver(context.Background(), delivery)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("deliver() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if statusCode != tt.wantStatusCode {
+				t.Errorf("deliver() statusCode = %v, want %v", statusCode, tt.wantStatusCode)
+			}
+			if string(gotBody) != `{"id":"event001"}` {
+				t.Errorf("deliver() body = %v", string(gotBody))
+			}
+			wantHeader := map[string]string{
+				"Content-Type":        "application/json",
+				"Ce-Specversion":      "1.0",
+				"Ce-Id":               "event001",
+				"Ce-Type":             "resource.created",
+				"Ce-Source":           "configuration-manager",
+				"X-Webhook-Id":        "hook001",
+				"X-Webhook-Delivery":  "delivery001",
+				"X-Webhook-Timestamp": "1700000000",
+				"X-Webhook-Signature": "sha256=9f2bc683b539120f8bade3d39965c266386a4b31edf33a2f10d2327545fa14c8",
+			}
+			for key, want := range wantHeader {
+				if got := gotHeader.Get(key); got != want {
+					t.Errorf("deliver() header %s = %v, want %v", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestWorker_deliver_unreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	w := NewWorker(nil)
+	statusCode, err := w.deliver(context.Background(), pendingDelivery{ID: "delivery001", URL: url, Payload: []byte(`{}`)})
+	if err == nil || statusCode != 0 {
+		t.Errorf("deliver() = %v, %v, want 0 and an error", statusCode, err)
+	}
+}
+
+func TestWorker_deliverOnce(t *testing.T) {
+	t.Skip("not test")
+}